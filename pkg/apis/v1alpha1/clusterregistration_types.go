@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TaintEffect describes how controllers treat a tainted cluster.
+type TaintEffect string
+
+const (
+	// TaintEffectNoSchedule means that no new work is placed onto the
+	// cluster by controllers not tolerating the taint.
+	TaintEffectNoSchedule TaintEffect = "NoSchedule"
+
+	// TaintEffectNoEngage means that controllers not tolerating the taint
+	// do not engage the cluster.
+	TaintEffectNoEngage TaintEffect = "NoEngage"
+)
+
+// Taint marks a cluster such that controllers not tolerating it avoid the
+// cluster.
+type Taint struct {
+	// Key is the taint key.
+	// +required
+	Key string `json:"key"`
+
+	// Value is the taint value.
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// Effect is the effect of the taint.
+	// +kubebuilder:validation:Enum=NoSchedule;NoEngage
+	// +required
+	Effect TaintEffect `json:"effect"`
+}
+
+// SecretKeyReference references a key of a secret in the namespace of the
+// ClusterRegistration.
+type SecretKeyReference struct {
+	// Name is the name of the secret.
+	// +required
+	Name string `json:"name"`
+
+	// Key is the key of the kubeconfig in the secret. Defaults to
+	// "kubeconfig".
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// ClusterRegistrationSpec is the desired state of a ClusterRegistration.
+type ClusterRegistrationSpec struct {
+	// Endpoint overrides the API server URL of the kubeconfig.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// SecretRef references the secret holding the kubeconfig of the
+	// cluster.
+	// +required
+	SecretRef SecretKeyReference `json:"secretRef"`
+
+	// Taints are the taints of the cluster.
+	// +optional
+	Taints []Taint `json:"taints,omitempty"`
+}
+
+const (
+	// ConditionTypeEngaged is true if the cluster is engaged by the
+	// provider.
+	ConditionTypeEngaged = "Engaged"
+)
+
+// ClusterRegistrationStatus is the observed state of a ClusterRegistration.
+type ClusterRegistrationStatus struct {
+	// ObservedGeneration is the generation last processed by the provider.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the engagement of the cluster.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Endpoint",type=string,JSONPath=`.spec.endpoint`
+// +kubebuilder:printcolumn:name="Engaged",type=string,JSONPath=`.status.conditions[?(@.type=="Engaged")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ClusterRegistration registers a cluster with the registration provider.
+// Its labels and annotations are the metadata of the cluster.
+type ClusterRegistration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterRegistrationSpec   `json:"spec,omitempty"`
+	Status ClusterRegistrationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterRegistrationList contains a list of ClusterRegistration.
+type ClusterRegistrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterRegistration `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterRegistration{}, &ClusterRegistrationList{})
+}
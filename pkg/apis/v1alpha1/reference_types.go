@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ClusterObjectReference references an object in a cluster of the fleet.
+// It can be embedded into APIs that point at objects in other clusters.
+type ClusterObjectReference struct {
+	// Cluster is the name of the cluster as known to the provider. The
+	// empty name refers to the local cluster.
+	// +optional
+	Cluster string `json:"cluster,omitempty"`
+
+	// APIVersion is the API version of the referenced object.
+	// +required
+	APIVersion string `json:"apiVersion"`
+
+	// Kind is the kind of the referenced object.
+	// +required
+	Kind string `json:"kind"`
+
+	// Namespace is the namespace of the referenced object. Empty for
+	// cluster-scoped objects.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name is the name of the referenced object.
+	// +required
+	Name string `json:"name"`
+}
+
+// GroupVersionKind returns the GroupVersionKind of the referenced object.
+func (r ClusterObjectReference) GroupVersionKind() schema.GroupVersionKind {
+	return schema.FromAPIVersionAndKind(r.APIVersion, r.Kind)
+}
+
+// NamespacedName returns the namespace and name of the referenced object.
+func (r ClusterObjectReference) NamespacedName() types.NamespacedName {
+	return types.NamespacedName{Namespace: r.Namespace, Name: r.Name}
+}
+
+// String returns the reference in the form "cluster://<cluster>/<kind>.<group>/<namespace>/<name>".
+func (r ClusterObjectReference) String() string {
+	gk := r.GroupVersionKind().GroupKind().String()
+	return "cluster://" + r.Cluster + "/" + gk + "/" + r.NamespacedName().String()
+}
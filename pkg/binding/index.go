@@ -0,0 +1,270 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package binding maintains which spoke clusters are targeted by hub
+// objects, based on the ClusterSelector of the hub objects and the metadata
+// of the engaged clusters.
+package binding
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	"sigs.k8s.io/multicluster-runtime/pkg/selector"
+)
+
+// Options are the options for the Index.
+type Options struct {
+	// ResyncInterval is the interval in which the metadata of the engaged
+	// clusters is read again, to pick up label changes that do not lead to
+	// a re-engagement. Zero disables the resync; use Refresh instead.
+	ResyncInterval time.Duration
+
+	// OnChange is called with the hub objects whose set of bound clusters
+	// changed. It is called without holding locks of the Index.
+	OnChange func(keys []types.NamespacedName)
+}
+
+var _ mcmanager.Runnable = &Index{}
+
+// Index maintains the bindings between hub objects and the engaged clusters
+// selected by them. Add it to the manager with Manager.Add, and call Set
+// and Delete from the reconciler of the hub objects.
+type Index struct {
+	mgr  mcmanager.Manager
+	opts Options
+	log  logr.Logger
+
+	lock      sync.RWMutex
+	selectors map[types.NamespacedName]*selector.Selector
+	clusters  map[string]multicluster.Metadata
+	bindings  map[types.NamespacedName]sets.Set[string]
+	byCluster map[string]sets.Set[types.NamespacedName]
+}
+
+// New returns a new Index.
+func New(mgr mcmanager.Manager, opts Options) *Index {
+	return &Index{
+		mgr:       mgr,
+		opts:      opts,
+		log:       log.Log.WithName("binding-index"),
+		selectors: map[types.NamespacedName]*selector.Selector{},
+		clusters:  map[string]multicluster.Metadata{},
+		bindings:  map[types.NamespacedName]sets.Set[string]{},
+		byCluster: map[string]sets.Set[types.NamespacedName]{},
+	}
+}
+
+// Set adds or updates the hub object with the given key and selector. A nil
+// selector selects all clusters.
+func (idx *Index) Set(key types.NamespacedName, sel *selector.ClusterSelector) error {
+	compiled, err := sel.Compile()
+	if err != nil {
+		return err
+	}
+
+	idx.lock.Lock()
+	idx.selectors[key] = compiled
+	clusters := sets.New[string]()
+	for name, md := range idx.clusters {
+		if compiled.Matches(name, md) {
+			clusters.Insert(name)
+		}
+	}
+	changed := idx.bind(key, clusters)
+	idx.lock.Unlock()
+
+	if changed {
+		idx.notify([]types.NamespacedName{key})
+	}
+	return nil
+}
+
+// Delete removes the hub object with the given key.
+func (idx *Index) Delete(key types.NamespacedName) {
+	idx.lock.Lock()
+	_, known := idx.selectors[key]
+	delete(idx.selectors, key)
+	changed := idx.bind(key, nil)
+	idx.lock.Unlock()
+
+	if known && changed {
+		idx.notify([]types.NamespacedName{key})
+	}
+}
+
+// ClustersFor returns the sorted names of the clusters bound to the hub
+// object with the given key.
+func (idx *Index) ClustersFor(key types.NamespacedName) []string {
+	idx.lock.RLock()
+	defer idx.lock.RUnlock()
+	return sets.List(idx.bindings[key])
+}
+
+// ObjectsFor returns the sorted keys of the hub objects bound to the given
+// cluster.
+func (idx *Index) ObjectsFor(clusterName string) []types.NamespacedName {
+	idx.lock.RLock()
+	defer idx.lock.RUnlock()
+	keys := idx.byCluster[clusterName].UnsortedList()
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	return keys
+}
+
+// RequestsFor returns reconcile requests for the hub objects bound to the
+// given cluster. It is meant for handlers mapping events of a cluster to
+// hub objects.
+func (idx *Index) RequestsFor(clusterName string) []reconcile.Request {
+	keys := idx.ObjectsFor(clusterName)
+	reqs := make([]reconcile.Request, 0, len(keys))
+	for _, key := range keys {
+		reqs = append(reqs, reconcile.Request{NamespacedName: key})
+	}
+	return reqs
+}
+
+// Engage adds the cluster to the index, and removes it again when ctx is
+// done.
+func (idx *Index) Engage(ctx context.Context, name string, _ cluster.Cluster) error {
+	if err := idx.Refresh(ctx, name); err != nil {
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		idx.lock.Lock()
+		delete(idx.clusters, name)
+		changed := idx.rebind(name)
+		idx.lock.Unlock()
+		idx.notify(changed)
+	}()
+	return nil
+}
+
+// Refresh reads the metadata of the engaged cluster again, and updates its
+// bindings.
+func (idx *Index) Refresh(ctx context.Context, clusterName string) error {
+	md, err := idx.mgr.GetClusterMetadata(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+
+	idx.lock.Lock()
+	idx.clusters[clusterName] = md
+	changed := idx.rebind(clusterName)
+	idx.lock.Unlock()
+
+	idx.notify(changed)
+	return nil
+}
+
+// Start resyncs the cluster metadata if configured, and blocks until ctx is
+// done.
+func (idx *Index) Start(ctx context.Context) error {
+	if idx.opts.ResyncInterval <= 0 {
+		<-ctx.Done()
+		return nil
+	}
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		idx.lock.RLock()
+		names := make([]string, 0, len(idx.clusters))
+		for name := range idx.clusters {
+			names = append(names, name)
+		}
+		idx.lock.RUnlock()
+
+		for _, name := range names {
+			if err := idx.Refresh(ctx, name); err != nil {
+				idx.log.Error(err, "Failed to refresh cluster metadata", "cluster", multicluster.EscapeClusterName(name))
+			}
+		}
+	}, idx.opts.ResyncInterval)
+	return nil
+}
+
+// rebind recomputes the bindings of all hub objects to the given cluster,
+// and returns the keys of the changed hub objects. The lock must be held.
+func (idx *Index) rebind(clusterName string) []types.NamespacedName {
+	md, engaged := idx.clusters[clusterName]
+	var changed []types.NamespacedName
+	for key, sel := range idx.selectors {
+		bound := idx.bindings[key].Has(clusterName)
+		selected := engaged && sel.Matches(clusterName, md)
+		if bound == selected {
+			continue
+		}
+		clusters := idx.bindings[key].Clone()
+		if clusters == nil {
+			clusters = sets.New[string]()
+		}
+		if selected {
+			clusters.Insert(clusterName)
+		} else {
+			clusters.Delete(clusterName)
+		}
+		idx.bind(key, clusters)
+		changed = append(changed, key)
+	}
+	return changed
+}
+
+// bind sets the clusters bound to the hub object, and returns whether they
+// changed. The lock must be held.
+func (idx *Index) bind(key types.NamespacedName, clusters sets.Set[string]) bool {
+	old := idx.bindings[key]
+	if old.Equal(clusters) {
+		return false
+	}
+	for name := range old {
+		if !clusters.Has(name) {
+			idx.byCluster[name].Delete(key)
+			if idx.byCluster[name].Len() == 0 {
+				delete(idx.byCluster, name)
+			}
+		}
+	}
+	for name := range clusters {
+		if _, ok := idx.byCluster[name]; !ok {
+			idx.byCluster[name] = sets.New[types.NamespacedName]()
+		}
+		idx.byCluster[name].Insert(key)
+	}
+	if clusters.Len() == 0 {
+		delete(idx.bindings, key)
+	} else {
+		idx.bindings[key] = clusters
+	}
+	return true
+}
+
+func (idx *Index) notify(keys []types.NamespacedName) {
+	if len(keys) > 0 && idx.opts.OnChange != nil {
+		idx.opts.OnChange(keys)
+	}
+}
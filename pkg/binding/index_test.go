@@ -0,0 +1,97 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binding
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/fake"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	"sigs.k8s.io/multicluster-runtime/pkg/selector"
+)
+
+var _ = Describe("Index", func() {
+	var (
+		mgr     *fake.Manager
+		idx     *Index
+		changes []types.NamespacedName
+	)
+	prod := types.NamespacedName{Namespace: "default", Name: "prod"}
+	all := types.NamespacedName{Namespace: "default", Name: "all"}
+
+	BeforeEach(func() {
+		mgr = fake.NewManagerBuilder().
+			WithCluster("a").
+			WithClusterMetadata("a", multicluster.Metadata{Labels: map[string]string{"env": "prod"}}).
+			WithCluster("b").
+			WithClusterMetadata("b", multicluster.Metadata{Labels: map[string]string{"env": "dev"}}).
+			Build()
+		changes = nil
+		idx = New(mgr, Options{OnChange: func(keys []types.NamespacedName) { changes = append(changes, keys...) }})
+	})
+
+	It("binds hub objects to the selected clusters", func(ctx context.Context) {
+		Expect(idx.Set(prod, &selector.ClusterSelector{Expression: "env=prod"})).To(Succeed())
+		Expect(idx.Set(all, nil)).To(Succeed())
+		Expect(idx.ClustersFor(prod)).To(BeEmpty())
+
+		Expect(idx.Engage(ctx, "a", mgr.FakeCluster("a"))).To(Succeed())
+		Expect(idx.Engage(ctx, "b", mgr.FakeCluster("b"))).To(Succeed())
+
+		Expect(idx.ClustersFor(prod)).To(Equal([]string{"a"}))
+		Expect(idx.ClustersFor(all)).To(Equal([]string{"a", "b"}))
+		Expect(idx.ObjectsFor("a")).To(Equal([]types.NamespacedName{all, prod}))
+		Expect(idx.RequestsFor("b")).To(Equal([]reconcile.Request{{NamespacedName: all}}))
+		Expect(changes).To(ConsistOf(prod, all, all))
+	})
+
+	It("unbinds disengaged clusters", func(ctx context.Context) {
+		Expect(idx.Set(all, nil)).To(Succeed())
+		clusterCtx, cancel := context.WithCancel(ctx)
+		Expect(idx.Engage(clusterCtx, "a", mgr.FakeCluster("a"))).To(Succeed())
+		Expect(idx.ClustersFor(all)).To(Equal([]string{"a"}))
+
+		cancel()
+		Eventually(func() []string { return idx.ClustersFor(all) }).Should(BeEmpty())
+		Expect(idx.ObjectsFor("a")).To(BeEmpty())
+	})
+
+	It("updates bindings on label changes", func(ctx context.Context) {
+		Expect(idx.Set(prod, &selector.ClusterSelector{Expression: "env=prod"})).To(Succeed())
+		Expect(idx.Engage(ctx, "b", mgr.FakeCluster("b"))).To(Succeed())
+		Expect(idx.ClustersFor(prod)).To(BeEmpty())
+
+		mgr.FakeProvider().Add("b", mgr.FakeCluster("b"), multicluster.Metadata{Labels: map[string]string{"env": "prod"}})
+		Expect(idx.Refresh(ctx, "b")).To(Succeed())
+		Expect(idx.ClustersFor(prod)).To(Equal([]string{"b"}))
+	})
+
+	It("unbinds deleted hub objects", func(ctx context.Context) {
+		Expect(idx.Engage(ctx, "a", mgr.FakeCluster("a"))).To(Succeed())
+		Expect(idx.Set(prod, &selector.ClusterSelector{Expression: "env=prod"})).To(Succeed())
+		idx.Delete(prod)
+		Expect(idx.ClustersFor(prod)).To(BeEmpty())
+		Expect(idx.ObjectsFor("a")).To(BeEmpty())
+	})
+})
@@ -17,6 +17,7 @@ limitations under the License.
 package builder
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
@@ -25,7 +26,9 @@ import (
 	"github.com/go-logr/logr"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 
@@ -38,10 +41,17 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	mccluster "sigs.k8s.io/multicluster-runtime/pkg/cluster"
+	"sigs.k8s.io/multicluster-runtime/pkg/clustergroup"
 	mccontroller "sigs.k8s.io/multicluster-runtime/pkg/controller"
 	mchandler "sigs.k8s.io/multicluster-runtime/pkg/handler"
 	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	mcmetrics "sigs.k8s.io/multicluster-runtime/pkg/metrics"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	mcpredicate "sigs.k8s.io/multicluster-runtime/pkg/predicate"
 	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+	"sigs.k8s.io/multicluster-runtime/pkg/selector"
+	"sigs.k8s.io/multicluster-runtime/pkg/sharding"
 	mcsource "sigs.k8s.io/multicluster-runtime/pkg/source"
 )
 
@@ -63,18 +73,36 @@ type Builder = TypedBuilder[mcreconcile.Request]
 // that is passed to the workqueue and then to the Reconciler.
 // The workqueue de-duplicates identical requests.
 type TypedBuilder[request mcreconcile.ClusterAware[request]] struct {
-	forInput         ForInput
-	ownsInput        []OwnsInput
-	rawSources       []source.TypedSource[request]
-	watchesInput     []WatchesInput[request]
-	mgr              mcmanager.Manager
-	globalPredicates []predicate.Predicate
-	ctrl             mccontroller.TypedController[request]
-	ctrlOptions      controller.TypedOptions[request]
-	name             string
-	newController    func(name string, mgr mcmanager.Manager, options controller.TypedOptions[request]) (mccontroller.TypedController[request], error)
+	forInput          ForInput
+	ownsInput         []OwnsInput
+	rawSources        []source.TypedSource[request]
+	watchesInput      []WatchesInput[request]
+	mgr               mcmanager.Manager
+	globalPredicates  []predicate.Predicate
+	clusterPredicates []mcpredicate.ClusterPredicate
+	ctrl              mccontroller.TypedController[request]
+	ctrlOptions       controller.TypedOptions[request]
+	name              string
+	newController     func(name string, mgr mcmanager.Manager, options controller.TypedOptions[request]) (mccontroller.TypedController[request], error)
 
 	enableClusterNotFoundWrapper *bool
+	enableClusterDeduplication   bool
+	clusterSelector              *selector.ClusterSelector
+	clusterGroups                *clustergroup.Groups
+	clusterGroup                 string
+	missingKindPolicy            mcsource.MissingKindPolicy
+	resync                       *mcsource.ResyncOptions
+	tolerations                  []multicluster.Toleration
+	sharding                     sharding.Config
+	minClusterVersion            string
+	clusterHealth                *mcreconcile.ClusterHealth
+	deliveryGuarantee            mccontroller.DeliveryGuarantee
+	queueMetrics                 *mccontroller.ClusterQueueMetricsOptions
+	deliveryStore                mccontroller.DeliveryStore[request]
+	enableProvenance             bool
+	provenance                   *mccontroller.ProvenanceRecorder[request]
+	enableInitialSync            bool
+	initialSync                  *mccontroller.InitialSyncDetector[request]
 }
 
 // ControllerManagedBy returns a new controller builder that will be started by the provided Manager.
@@ -242,7 +270,7 @@ func (blder *TypedBuilder[request]) WatchesRawSource(src source.TypedSource[requ
 // WithEventFilter sets the event filters, to filter which create/update/delete/generic events eventually
 // trigger reconciliations. For example, filtering on whether the resource version has changed.
 // Given predicate is added for all watched objects and thus must be able to deal with the type
-// of all watched objects.
+// of all watched objects. Use WithClusterEventFilter for filters that depend on the cluster.
 //
 // Defaults to the empty list.
 func (blder *TypedBuilder[request]) WithEventFilter(p predicate.Predicate) *TypedBuilder[request] {
@@ -250,6 +278,17 @@ func (blder *TypedBuilder[request]) WithEventFilter(p predicate.Predicate) *Type
 	return blder
 }
 
+// WithClusterEventFilter sets event filters that receive the name of the
+// cluster of the event, to filter events differently per cluster, see the
+// combinators of the predicate package. Like the filters of
+// WithEventFilter, the predicate is applied to all watched objects.
+//
+// Defaults to the empty list.
+func (blder *TypedBuilder[request]) WithClusterEventFilter(p mcpredicate.ClusterPredicate) *TypedBuilder[request] {
+	blder.clusterPredicates = append(blder.clusterPredicates, p)
+	return blder
+}
+
 // WithOptions overrides the controller options used in doController. Defaults to empty.
 func (blder *TypedBuilder[request]) WithOptions(options controller.TypedOptions[request]) *TypedBuilder[request] {
 	blder.ctrlOptions = options
@@ -271,6 +310,138 @@ func (blder *TypedBuilder[request]) WithClusterNotFoundWrapper(enabled bool) *Ty
 	return blder
 }
 
+// WithClusterDeduplication enables or disables collapsing of requests that
+// only differ in their cluster name into a single request. The reconciler then
+// receives requests without a cluster name, and the set of clusters that
+// triggered the request is available through context.ClustersFrom. This is
+// useful for fleet-scoped reconcilers whose work is keyed by a hub object.
+// Defaults to false.
+//
+// Note: this replaces the controller's queue and hence does not use the
+// priority queue, unless a custom NewQueue is passed via WithOptions.
+func (blder *TypedBuilder[request]) WithClusterDeduplication(enabled bool) *TypedBuilder[request] {
+	blder.enableClusterDeduplication = enabled
+	return blder
+}
+
+// WithDeliveryGuarantee sets what happens to requests of a cluster that is
+// disengaged before they were reconciled successfully. With
+// DeliveryAtLeastOnce, they are re-enqueued when the cluster is engaged
+// again. The pending requests are kept in memory, or in store if it is not
+// nil, for up to mccontroller.DefaultDeliveryRetention after their cluster
+// was disengaged. Defaults to DeliveryAtMostOnce.
+//
+// DeliveryAtLeastOnce cannot be combined with WithClusterDeduplication.
+func (blder *TypedBuilder[request]) WithDeliveryGuarantee(guarantee mccontroller.DeliveryGuarantee, store mccontroller.DeliveryStore[request]) *TypedBuilder[request] {
+	blder.deliveryGuarantee = guarantee
+	blder.deliveryStore = store
+	return blder
+}
+
+// WithClusterQueueMetrics reports the adds, depth and retries of the queue
+// of the controller per cluster, labeled with the provider and shard of the
+// cluster as returned by opts, and the noisiest clusters. With cluster
+// deduplication, requests are reported without their cluster.
+func (blder *TypedBuilder[request]) WithClusterQueueMetrics(opts mccontroller.ClusterQueueMetricsOptions) *TypedBuilder[request] {
+	blder.queueMetrics = &opts
+	return blder
+}
+
+// WithProvenance enables or disables recording the event that enqueued a
+// request. The reconciler then finds the cluster, kind, object and event
+// type that triggered it through context.ProvenanceFrom. Requests of raw
+// sources and requeued requests carry no provenance. Defaults to false.
+func (blder *TypedBuilder[request]) WithProvenance(enabled bool) *TypedBuilder[request] {
+	blder.enableProvenance = enabled
+	return blder
+}
+
+// WithInitialSyncDetection enables or disables detecting requests that
+// were triggered only by the objects listed when their cluster was engaged.
+// The reconciler then finds out with context.IsInitialSync, e.g. to
+// suppress notifications during the mass engagement of new clusters.
+// Defaults to false.
+func (blder *TypedBuilder[request]) WithInitialSyncDetection(enabled bool) *TypedBuilder[request] {
+	blder.enableInitialSync = enabled
+	return blder
+}
+
+// WithTolerations lets the controller watch clusters with the given taints.
+// Clusters with NoEngage taints that are not tolerated are skipped.
+func (blder *TypedBuilder[request]) WithTolerations(tolerations ...multicluster.Toleration) *TypedBuilder[request] {
+	blder.tolerations = append(blder.tolerations, tolerations...)
+	return blder
+}
+
+// WithSharding restricts the provider clusters the controller watches to
+// those assigned to the shard of this replica by the strategy of cfg, e.g.
+// sharding.LabelAffinity to keep clusters of the same region on the same
+// shard. The local cluster is not affected.
+func (blder *TypedBuilder[request]) WithSharding(cfg sharding.Config) *TypedBuilder[request] {
+	blder.sharding = cfg
+	return blder
+}
+
+// WithClusterHealth feeds the outcomes of the reconciliations into the
+// circuit breakers of health, e.g. to scale requeue delays with
+// ClusterHealth.RequeueAfter. If health is nil,
+// mcreconcile.DefaultClusterHealth is used, which backs
+// mcreconcile.RequeueAfterForCluster.
+func (blder *TypedBuilder[request]) WithClusterHealth(health *mcreconcile.ClusterHealth) *TypedBuilder[request] {
+	if health == nil {
+		health = mcreconcile.DefaultClusterHealth
+	}
+	blder.clusterHealth = health
+	return blder
+}
+
+// WithMinimumClusterVersion lets the controller skip provider clusters
+// running a Kubernetes version older than the given one, e.g. "1.29",
+// instead of failing at runtime on fields or kinds they do not support.
+// Skipped clusters are logged and counted in the
+// multicluster_clusters_skipped_total metric.
+func (blder *TypedBuilder[request]) WithMinimumClusterVersion(minVersion string) *TypedBuilder[request] {
+	blder.minClusterVersion = minVersion
+	return blder
+}
+
+// WithClusterSelector restricts the provider clusters the controller watches
+// to those selected by the given ClusterSelector, evaluated against the
+// metadata supplied by the provider when a cluster is engaged. The local
+// cluster is not affected.
+func (blder *TypedBuilder[request]) WithClusterSelector(sel *selector.ClusterSelector) *TypedBuilder[request] {
+	blder.clusterSelector = sel
+	return blder
+}
+
+// WithClusterGroup restricts the provider clusters the controller watches
+// to the members of the named group of groups. It is combined with
+// WithClusterSelector. The group must be defined when clusters are
+// engaged, otherwise their engagement fails. The local cluster is not
+// affected.
+func (blder *TypedBuilder[request]) WithClusterGroup(groups *clustergroup.Groups, name string) *TypedBuilder[request] {
+	blder.clusterGroups = groups
+	blder.clusterGroup = name
+	return blder
+}
+
+// WithPeriodicResync enqueues all objects of the For() type of a cluster
+// whenever a resync is due according to opts, independent of the resync of
+// the informers, so that the controller converges periodically even without
+// events. It applies to the same clusters as For().
+func (blder *TypedBuilder[request]) WithPeriodicResync(opts mcsource.ResyncOptions) *TypedBuilder[request] {
+	blder.resync = &opts
+	return blder
+}
+
+// WithMissingKindPolicy sets what happens when a watched kind is not served
+// by an engaged provider cluster, e.g. because a CRD is not installed there.
+// By default, the engagement of such clusters fails.
+func (blder *TypedBuilder[request]) WithMissingKindPolicy(policy mcsource.MissingKindPolicy) *TypedBuilder[request] {
+	blder.missingKindPolicy = policy
+	return blder
+}
+
 // Named sets the name of the controller to the given name. The name shows up
 // in metrics, among other things, and thus should be a prometheus compatible name
 // (underscores and alphanumeric characters only).
@@ -306,6 +477,9 @@ func (blder *TypedBuilder[request]) Build(r reconcile.TypedReconciler[request])
 	if blder.forInput.err != nil {
 		return nil, blder.forInput.err
 	}
+	if err := blder.validateGVKs(); err != nil {
+		return nil, err
+	}
 
 	// Set the ControllerManagedBy
 	if err := blder.doController(r); err != nil {
@@ -320,10 +494,54 @@ func (blder *TypedBuilder[request]) Build(r reconcile.TypedReconciler[request])
 	return blder.ctrl, nil
 }
 
+// validateGVKs checks that unstructured and metadata-only objects passed to
+// For, Owns and Watches carry their GroupVersionKind, which cannot be derived
+// from the scheme.
+func (blder *TypedBuilder[request]) validateGVKs() error {
+	check := func(method string, obj client.Object) error {
+		if obj == nil || !isSchemaless(obj) {
+			return nil
+		}
+		if obj.GetObjectKind().GroupVersionKind().Empty() {
+			return fmt.Errorf("%T passed to %s() must have its GroupVersionKind set", obj, method)
+		}
+		return nil
+	}
+	if err := check("For", blder.forInput.object); err != nil {
+		return err
+	}
+	for _, own := range blder.ownsInput {
+		if err := check("Owns", own.object); err != nil {
+			return err
+		}
+	}
+	for _, w := range blder.watchesInput {
+		if err := check("Watches", w.obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isSchemaless returns whether the kind of obj is carried by the object
+// instead of its Go type.
+func isSchemaless(obj client.Object) bool {
+	switch obj.(type) {
+	case runtime.Unstructured, *metav1.PartialObjectMetadata:
+		return true
+	}
+	return false
+}
+
 func (blder *TypedBuilder[request]) project(proj objectProjection) func(cluster.Cluster, client.Object) (client.Object, error) {
 	return func(cl cluster.Cluster, obj client.Object) (client.Object, error) {
 		switch proj {
 		case projectAsNormal:
+			if isSchemaless(obj) {
+				// every cluster gets its own copy, the caches must not share
+				// the same object.
+				return obj.DeepCopyObject().(client.Object), nil
+			}
 			return obj, nil
 		case projectAsMetadata:
 			metaObj := &metav1.PartialObjectMetadata{}
@@ -352,9 +570,10 @@ func (blder *TypedBuilder[request]) doWatch() error {
 		allPredicates := append([]predicate.Predicate(nil), blder.globalPredicates...)
 		allPredicates = append(allPredicates, blder.forInput.predicates...)
 
+		hdler = blder.recordProvenance(blder.forInput.object, mcpredicate.FilterHandler(hdler, blder.clusterPredicates...))
 		src := mcsource.TypedKind[client.Object, request](blder.forInput.object, hdler, allPredicates...).
 			WithProjection(blder.project(blder.forInput.objectProjection))
-		if ptr.Deref(blder.forInput.engageWithLocalCluster, blder.mgr.GetProvider() == nil) {
+		if blder.engageWithLocalCluster(blder.forInput.engageWithLocalCluster) {
 			src, err := src.ForCluster("", blder.mgr.GetLocalManager())
 			if err != nil {
 				return err
@@ -363,11 +582,14 @@ func (blder *TypedBuilder[request]) doWatch() error {
 				return err
 			}
 		}
-		if ptr.Deref(blder.forInput.engageWithProviderClusters, blder.mgr.GetProvider() != nil) {
-			if err := blder.ctrl.MultiClusterWatch(src); err != nil {
+		if blder.engageWithProviderClusters(blder.forInput.engageWithProviderClusters) {
+			if err := blder.multiClusterWatch(blder.forInput.object, src); err != nil {
 				return err
 			}
 		}
+		if err := blder.doResync(); err != nil {
+			return err
+		}
 	}
 
 	// Watches the managed types
@@ -391,9 +613,9 @@ func (blder *TypedBuilder[request]) doWatch() error {
 		}
 		allPredicates := append([]predicate.Predicate(nil), blder.globalPredicates...)
 		allPredicates = append(allPredicates, own.predicates...)
-		src := mcsource.TypedKind[client.Object, request](own.object, hdler, allPredicates...).
+		src := mcsource.TypedKind[client.Object, request](own.object, blder.recordProvenance(own.object, mcpredicate.FilterHandler(hdler, blder.clusterPredicates...)), allPredicates...).
 			WithProjection(blder.project(own.objectProjection))
-		if ptr.Deref(own.engageWithLocalCluster, blder.mgr.GetProvider() == nil) {
+		if blder.engageWithLocalCluster(own.engageWithLocalCluster) {
 			src, err := src.ForCluster("", blder.mgr.GetLocalManager())
 			if err != nil {
 				return err
@@ -402,8 +624,8 @@ func (blder *TypedBuilder[request]) doWatch() error {
 				return err
 			}
 		}
-		if ptr.Deref(own.engageWithProviderClusters, blder.mgr.GetProvider() != nil) {
-			if err := blder.ctrl.MultiClusterWatch(src); err != nil {
+		if blder.engageWithProviderClusters(own.engageWithProviderClusters) {
+			if err := blder.multiClusterWatch(own.object, src); err != nil {
 				return err
 			}
 		}
@@ -416,8 +638,8 @@ func (blder *TypedBuilder[request]) doWatch() error {
 	for _, w := range blder.watchesInput {
 		allPredicates := append([]predicate.Predicate(nil), blder.globalPredicates...)
 		allPredicates = append(allPredicates, w.predicates...)
-		src := mcsource.TypedKind[client.Object, request](w.obj, w.handler, allPredicates...).WithProjection(blder.project(w.objectProjection))
-		if ptr.Deref(w.engageWithLocalCluster, blder.mgr.GetProvider() == nil) {
+		src := mcsource.TypedKind[client.Object, request](w.obj, blder.recordProvenance(w.obj, mcpredicate.FilterHandler(w.handler, blder.clusterPredicates...)), allPredicates...).WithProjection(blder.project(w.objectProjection))
+		if blder.engageWithLocalCluster(w.engageWithLocalCluster) {
 			src, err := src.ForCluster("", blder.mgr.GetLocalManager())
 			if err != nil {
 				return err
@@ -426,8 +648,8 @@ func (blder *TypedBuilder[request]) doWatch() error {
 				return err
 			}
 		}
-		if ptr.Deref(w.engageWithProviderClusters, blder.mgr.GetProvider() != nil) {
-			if err := blder.ctrl.MultiClusterWatch(src); err != nil {
+		if blder.engageWithProviderClusters(w.engageWithProviderClusters) {
+			if err := blder.multiClusterWatch(w.obj, src); err != nil {
 				return err
 			}
 		}
@@ -440,6 +662,129 @@ func (blder *TypedBuilder[request]) doWatch() error {
 	return nil
 }
 
+// recordProvenance wraps the handler of the watch of obj to record the
+// provenance of its requests, if enabled with WithProvenance, and whether
+// they belong to the initial sync, if enabled with
+// WithInitialSyncDetection.
+func (blder *TypedBuilder[request]) recordProvenance(obj client.Object, h mchandler.TypedEventHandlerFunc[client.Object, request]) mchandler.TypedEventHandlerFunc[client.Object, request] {
+	if blder.initialSync != nil {
+		h = blder.initialSync.Handler(h)
+	}
+	if blder.provenance == nil {
+		return h
+	}
+	gvk, err := apiutil.GVKForObject(obj, blder.mgr.GetLocalManager().GetScheme())
+	if err != nil {
+		// the kind is unknown to the scheme, record the rest.
+		gvk = schema.GroupVersionKind{}
+	}
+	return blder.provenance.Handler(gvk, h)
+}
+
+// engageWithLocalCluster returns whether a watch engages the local
+// cluster. In single-cluster mode, all watches do.
+func (blder *TypedBuilder[request]) engageWithLocalCluster(engage *bool) bool {
+	return blder.mgr.SingleCluster() || ptr.Deref(engage, blder.mgr.GetProvider() == nil)
+}
+
+// engageWithProviderClusters returns whether a watch engages the provider
+// clusters. In single-cluster mode, none does.
+func (blder *TypedBuilder[request]) engageWithProviderClusters(engage *bool) bool {
+	return !blder.mgr.SingleCluster() && ptr.Deref(engage, blder.mgr.GetProvider() != nil)
+}
+
+// multiClusterWatch watches src of obj in the provider clusters selected by
+// the cluster selector and cluster group, skipping clusters with NoEngage taints that are not
+// tolerated, clusters of other shards and clusters below the minimum version.
+func (blder *TypedBuilder[request]) multiClusterWatch(obj client.Object, src mcsource.TypedSource[client.Object, request]) error {
+	src = mcsource.WithDiscoveryGate(src, obj, blder.missingKindPolicy)
+	var sel *selector.Selector
+	if blder.clusterSelector != nil {
+		var err error
+		if sel, err = blder.clusterSelector.Compile(); err != nil {
+			return err
+		}
+	}
+	if err := blder.sharding.Validate(); err != nil {
+		return err
+	}
+	var minVersion *version.Version
+	if blder.minClusterVersion != "" {
+		var err error
+		if minVersion, err = version.ParseGeneric(blder.minClusterVersion); err != nil {
+			return fmt.Errorf("invalid minimum cluster version: %w", err)
+		}
+	}
+	// the metadata is looked up only if a filter needs it. NoEngage taints
+	// are supplied by metadata providers only.
+	_, taints := blder.mgr.GetProvider().(multicluster.MetadataProvider)
+	if sel == nil && !taints && !blder.sharding.Enabled() && minVersion == nil && blder.clusterGroups == nil {
+		return blder.ctrl.MultiClusterWatch(src)
+	}
+	src = mcsource.WithClusterFilter[client.Object, request](src, func(ctx context.Context, name string, _ cluster.Cluster) (bool, error) {
+		md, err := blder.mgr.GetClusterMetadata(ctx, name)
+		if err != nil {
+			return false, err
+		}
+		if taint, ok := multicluster.FindUntoleratedTaint(md.Taints, blder.tolerations, multicluster.TaintEffectNoEngage); ok {
+			blder.mgr.GetLogger().Info("Skipping tainted cluster", "cluster", multicluster.EscapeClusterName(name), "taint", taint.Key)
+			return false, nil
+		}
+		if !blder.sharding.Owns(name, md) {
+			return false, nil
+		}
+		if minVersion != nil {
+			info, err := blder.mgr.GetClusterVersion(ctx, name)
+			if err != nil {
+				return false, err
+			}
+			v, err := mccluster.ParseVersion(info)
+			if err != nil {
+				return false, err
+			}
+			if v.LessThan(minVersion) {
+				blder.mgr.GetLogger().Info("Skipping cluster below minimum version", "cluster", multicluster.EscapeClusterName(name), "version", v.String(), "minVersion", minVersion.String())
+				mcmetrics.ClustersSkipped.WithLabelValues(mcmetrics.ClusterLabel(name), "VersionTooOld").Inc()
+				return false, nil
+			}
+		}
+		if blder.clusterGroups != nil {
+			if ok, err := blder.clusterGroups.Matches(blder.clusterGroup, name, md); err != nil || !ok {
+				return false, err
+			}
+		}
+		return sel.Matches(name, md), nil
+	})
+	return blder.ctrl.MultiClusterWatch(src)
+}
+
+// doResync watches the periodic resync of the For() type, if configured.
+func (blder *TypedBuilder[request]) doResync() error {
+	if blder.resync == nil {
+		return nil
+	}
+	if blder.resync.Schedule == nil {
+		return errors.New("periodic resync must have a schedule")
+	}
+	src, ok := mcsource.Resync(blder.forInput.object, *blder.resync).(mcsource.TypedSource[client.Object, request])
+	if !ok {
+		return fmt.Errorf("WithPeriodicResync() can only be used with mcreconcile.Request, got %T", *new(request))
+	}
+	if blder.engageWithLocalCluster(blder.forInput.engageWithLocalCluster) {
+		src, err := src.ForCluster("", blder.mgr.GetLocalManager())
+		if err != nil {
+			return err
+		}
+		if err := blder.ctrl.Watch(src); err != nil {
+			return err
+		}
+	}
+	if blder.engageWithProviderClusters(blder.forInput.engageWithProviderClusters) {
+		return blder.multiClusterWatch(blder.forInput.object, src)
+	}
+	return nil
+}
+
 func (blder *TypedBuilder[request]) getControllerName(gvk schema.GroupVersionKind, hasGVK bool) (string, error) {
 	if blder.name != "" {
 		return blder.name, nil
@@ -461,11 +806,57 @@ func (blder *TypedBuilder[request]) doController(r reconcile.TypedReconciler[req
 		ctrlOptions.Reconciler = r
 	}
 
+	// track the health of the clusters if enabled with WithClusterHealth.
+	if blder.clusterHealth != nil {
+		ctrlOptions.Reconciler = mcreconcile.NewClusterHealthWrapper(blder.clusterHealth, ctrlOptions.Reconciler)
+	}
+
 	// the ClusterNotFound wrapper is enabled by default, but can be disabled with WithClusterNotFoundWrapper(false).
 	if ptr.Deref(blder.enableClusterNotFoundWrapper, true) {
 		ctrlOptions.Reconciler = mcreconcile.NewClusterNotFoundWrapper(ctrlOptions.Reconciler)
 	}
 
+	// report the queue per cluster if enabled with WithClusterQueueMetrics.
+	if blder.queueMetrics != nil {
+		ctrlOptions.NewQueue = mccontroller.NewClusterQueueMetrics[request](*blder.queueMetrics).NewQueue(ctrlOptions.NewQueue)
+	}
+
+	// remember requests across cluster flaps if enabled with WithDeliveryGuarantee.
+	switch blder.deliveryGuarantee {
+	case "", mccontroller.DeliveryAtMostOnce:
+	case mccontroller.DeliveryAtLeastOnce:
+		if blder.enableClusterDeduplication {
+			return errors.New("delivery guarantee AtLeastOnce cannot be combined with cluster deduplication")
+		}
+		tracker := mccontroller.NewDeliveryTracker(blder.deliveryStore)
+		ctrlOptions.Reconciler = tracker.Reconciler(ctrlOptions.Reconciler)
+		ctrlOptions.NewQueue = tracker.NewQueue(ctrlOptions.NewQueue)
+		if err := blder.mgr.Add(tracker); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown delivery guarantee %q", blder.deliveryGuarantee)
+	}
+
+	// collapse requests across clusters if enabled with WithClusterDeduplication(true).
+	if blder.enableClusterDeduplication {
+		dedup := mccontroller.NewClusterDeduplicator[request]()
+		ctrlOptions.Reconciler = dedup.Reconciler(ctrlOptions.Reconciler)
+		ctrlOptions.NewQueue = dedup.NewQueue(ctrlOptions.NewQueue)
+	}
+
+	// pass the event that enqueued a request if enabled with WithProvenance(true).
+	if blder.enableProvenance {
+		blder.provenance = mccontroller.NewProvenanceRecorder[request]()
+		ctrlOptions.Reconciler = blder.provenance.Reconciler(ctrlOptions.Reconciler)
+	}
+
+	// mark requests of the initial sync if enabled with WithInitialSyncDetection(true).
+	if blder.enableInitialSync {
+		blder.initialSync = mccontroller.NewInitialSyncDetector[request]()
+		ctrlOptions.Reconciler = blder.initialSync.Reconciler(ctrlOptions.Reconciler)
+	}
+
 	// Retrieve the GVK from the object we're reconciling
 	// to pre-populate logger information, and to optionally generate a default name.
 	var gvk schema.GroupVersionKind
@@ -27,6 +27,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -154,6 +155,45 @@ var _ = Describe("application", func() {
 			Expect(instance).To(BeNil())
 		})
 
+		It("should return an error when an unstructured object has no GVK", func() {
+			By("creating a controller manager")
+			m, err := mcmanager.New(cfg, nil, mcmanager.Options{})
+			Expect(err).NotTo(HaveOccurred())
+
+			instance, err := ControllerManagedBy(m).
+				Named("my_unstructured_controller").
+				Watches(&unstructured.Unstructured{}, mchandler.EnqueueRequestForObject).
+				Build(noop)
+			Expect(err).To(MatchError(ContainSubstring("must have its GroupVersionKind set")))
+			Expect(instance).To(BeNil())
+		})
+
+		It("should allow unstructured and metadata-only objects with a GVK", func() {
+			By("creating a controller manager")
+			m, err := mcmanager.New(cfg, nil, mcmanager.Options{})
+			Expect(err).NotTo(HaveOccurred())
+
+			deploy := &unstructured.Unstructured{}
+			deploy.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("Deployment"))
+			rs := &metav1.PartialObjectMetadata{}
+			rs.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("ReplicaSet"))
+
+			instance, err := ControllerManagedBy(m).
+				Named("my_schemaless_controller").
+				For(deploy).
+				Owns(rs).
+				Build(noop)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance).NotTo(BeNil())
+
+			By("projecting a copy of the unstructured object per cluster")
+			blder := ControllerManagedBy(m).For(deploy)
+			obj, err := blder.project(projectAsNormal)(nil, deploy)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(obj).To(Equal(deploy))
+			Expect(obj).NotTo(BeIdenticalTo(deploy))
+		})
+
 		It("should allow creating a controllerw without calling For", func() {
 			By("creating a controller manager")
 			m, err := mcmanager.New(cfg, nil, mcmanager.Options{})
@@ -18,22 +18,27 @@ package builder
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+
+	mcwebhook "sigs.k8s.io/multicluster-runtime/pkg/webhook"
 )
 
 // WebhookBuilder builds a Webhook.
@@ -48,6 +53,14 @@ type WebhookBuilder struct {
 	config              *rest.Config
 	recoverPanic        *bool
 	logConstructor      func(base logr.Logger, req *admission.Request) logr.Logger
+	encodings           mcwebhook.EncodingOptions
+	limits              mcwebhook.LimitOptions
+	auditSink           mcwebhook.AuditSink
+	sideEffects         []mcwebhook.SideEffect
+	patchLimit          *mcwebhook.PatchOptions
+	ruleCompiler        mcwebhook.RuleCompiler
+	rules               []mcwebhook.ValidationRule
+	denialRecorder      record.EventRecorder
 	err                 error
 }
 
@@ -96,6 +109,96 @@ func (blder *WebhookBuilder) RecoverPanic(recoverPanic bool) *WebhookBuilder {
 	return blder
 }
 
+// WithProtobuf lets the defaulting and validating webhooks accept protobuf
+// encoded AdmissionReview requests, and respond with protobuf if requested.
+func (blder *WebhookBuilder) WithProtobuf() *WebhookBuilder {
+	blder.encodings.Protobuf = true
+	return blder
+}
+
+// WithCompression lets the defaulting and validating webhooks accept gzip
+// encoded requests, and gzip responses of at least minSize bytes if the
+// client accepts it. A minSize of zero uses
+// mcwebhook.DefaultMinCompressSize.
+func (blder *WebhookBuilder) WithCompression(minSize int) *WebhookBuilder {
+	blder.encodings.Compression = true
+	blder.encodings.MinCompressSize = minSize
+	return blder
+}
+
+// WithTimeout sets the deadline of requests in the defaulting and
+// validating webhooks, independent of the timeoutSeconds of the webhook
+// configuration. Requests exceeding it are rejected with code 503 Service
+// Unavailable, and handled according to the failurePolicy of the webhook
+// configuration unless WithDenyOnLimits is set.
+func (blder *WebhookBuilder) WithTimeout(timeout time.Duration) *WebhookBuilder {
+	blder.limits.Timeout = timeout
+	return blder
+}
+
+// WithMaxConcurrent limits the number of requests each of the defaulting
+// and validating webhooks serves at the same time. Further requests are
+// rejected with code 429 Too Many Requests, and handled according to the
+// failurePolicy of the webhook configuration unless WithDenyOnLimits is
+// set.
+func (blder *WebhookBuilder) WithMaxConcurrent(n int) *WebhookBuilder {
+	blder.limits.MaxConcurrent = n
+	return blder
+}
+
+// WithDenyOnLimits denies the requests rejected by WithTimeout and
+// WithMaxConcurrent, regardless of the failurePolicy of the webhook
+// configuration.
+func (blder *WebhookBuilder) WithDenyOnLimits() *WebhookBuilder {
+	blder.limits.Deny = true
+	return blder
+}
+
+// WithAuditSink records sanitized admission decisions of the defaulting and
+// validating webhooks into sink, e.g. one created with
+// mcwebhook.NewWriterAuditSink.
+func (blder *WebhookBuilder) WithAuditSink(sink mcwebhook.AuditSink) *WebhookBuilder {
+	blder.auditSink = sink
+	return blder
+}
+
+// WithSideEffects declares side effects of the defaulting and validating
+// webhooks. They are called after a request was allowed, and skipped for
+// dry-run requests. Defaulters and validators can check for dry-run
+// themselves with mcwebhook.IsDryRun.
+func (blder *WebhookBuilder) WithSideEffects(effects ...mcwebhook.SideEffect) *WebhookBuilder {
+	blder.sideEffects = append(blder.sideEffects, effects...)
+	return blder
+}
+
+// WithPatchLimit caps the size of the JSON patch generated by the defaulting
+// webhook at maxBytes. Larger patches are replaced by a patch of the changed
+// top-level fields, or rejected with a clear error, depending on fallback.
+// A maxBytes of zero uses mcwebhook.DefaultMaxPatchBytes.
+func (blder *WebhookBuilder) WithPatchLimit(maxBytes int, fallback mcwebhook.PatchFallback) *WebhookBuilder {
+	blder.patchLimit = &mcwebhook.PatchOptions{MaxPatchBytes: maxBytes, Fallback: fallback}
+	return blder
+}
+
+// WithValidationRules registers validation rules evaluated with the
+// object, oldObject and request variables, alongside or instead of a
+// CustomValidator. The rules are compiled with compiler on Complete, as CEL
+// expressions if compiler is nil, and a request is denied if any rule
+// evaluates to false.
+func (blder *WebhookBuilder) WithValidationRules(compiler mcwebhook.RuleCompiler, rules ...mcwebhook.ValidationRule) *WebhookBuilder {
+	blder.ruleCompiler = compiler
+	blder.rules = append(blder.rules, rules...)
+	return blder
+}
+
+// WithDenialEvents emits a Warning event with recorder on the involved object,
+// or its namespace, when the validating webhook denies a request. This gives
+// users feedback beyond the error message of the client.
+func (blder *WebhookBuilder) WithDenialEvents(recorder record.EventRecorder) *WebhookBuilder {
+	blder.denialRecorder = recorder
+	return blder
+}
+
 // WithCustomPath overrides the webhook's default path by the customPath
 func (blder *WebhookBuilder) WithCustomPath(customPath string) *WebhookBuilder {
 	blder.customPath = customPath
@@ -183,19 +286,32 @@ func (blder *WebhookBuilder) registerDefaultingWebhook() error {
 			path = generatedCustomPath
 		}
 
+		if blder.patchLimit != nil {
+			mwh = mcwebhook.WithPatchLimit(mwh, *blder.patchLimit)
+		}
+		mwh = mcwebhook.WithSideEffects(mwh, blder.sideEffects...)
+		if blder.auditSink != nil {
+			mwh = mcwebhook.WithAudit(mwh, path, blder.auditSink)
+		}
+
 		// Checking if the path is already registered.
 		// If so, just skip it.
 		if !blder.isAlreadyHandled(path) {
 			log.Info("Registering a mutating webhook",
 				"GVK", blder.gvk,
 				"path", path)
-			blder.mgr.GetWebhookServer().Register(path, mwh)
+			blder.mgr.GetWebhookServer().Register(path, blder.wrapHandler(mwh))
 		}
 	}
 
 	return nil
 }
 
+// wrapHandler applies the encodings and limits to a webhook handler.
+func (blder *WebhookBuilder) wrapHandler(h http.Handler) http.Handler {
+	return mcwebhook.WithEncodings(mcwebhook.WithLimits(h, blder.limits), blder.encodings)
+}
+
 func (blder *WebhookBuilder) getDefaultingWebhook() *admission.Webhook {
 	if defaulter := blder.customDefaulter; defaulter != nil {
 		w := admission.WithCustomDefaulter(blder.mgr.GetScheme(), blder.apiType, defaulter, blder.customDefaulterOpts...)
@@ -209,7 +325,10 @@ func (blder *WebhookBuilder) getDefaultingWebhook() *admission.Webhook {
 
 // registerValidatingWebhook registers a validating webhook if necessary.
 func (blder *WebhookBuilder) registerValidatingWebhook() error {
-	vwh := blder.getValidatingWebhook()
+	vwh, err := blder.getValidatingWebhook()
+	if err != nil {
+		return err
+	}
 	if vwh != nil {
 		vwh.LogConstructor = blder.logConstructor
 		path := generateValidatePath(blder.gvk)
@@ -221,28 +340,43 @@ func (blder *WebhookBuilder) registerValidatingWebhook() error {
 			path = generatedCustomPath
 		}
 
+		vwh = mcwebhook.WithSideEffects(vwh, blder.sideEffects...)
+		if blder.denialRecorder != nil {
+			vwh = mcwebhook.WithDenialEvents(vwh, blder.denialRecorder)
+		}
+		if blder.auditSink != nil {
+			vwh = mcwebhook.WithAudit(vwh, path, blder.auditSink)
+		}
+
 		// Checking if the path is already registered.
 		// If so, just skip it.
 		if !blder.isAlreadyHandled(path) {
 			log.Info("Registering a validating webhook",
 				"GVK", blder.gvk,
 				"path", path)
-			blder.mgr.GetWebhookServer().Register(path, vwh)
+			blder.mgr.GetWebhookServer().Register(path, blder.wrapHandler(vwh))
 		}
 	}
 
 	return nil
 }
 
-func (blder *WebhookBuilder) getValidatingWebhook() *admission.Webhook {
+func (blder *WebhookBuilder) getValidatingWebhook() (*admission.Webhook, error) {
+	var w *admission.Webhook
 	if validator := blder.customValidator; validator != nil {
-		w := admission.WithCustomValidator(blder.mgr.GetScheme(), blder.apiType, validator)
-		if blder.recoverPanic != nil {
-			w = w.WithRecoverPanic(*blder.recoverPanic)
+		w = admission.WithCustomValidator(blder.mgr.GetScheme(), blder.apiType, validator)
+	}
+	if len(blder.rules) > 0 {
+		rules, err := mcwebhook.CompileRules(blder.ruleCompiler, blder.rules...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile validation rules for %s: %w", blder.gvk, err)
 		}
-		return w
+		w = mcwebhook.WithValidationRules(w, rules)
 	}
-	return nil
+	if w != nil && blder.recoverPanic != nil {
+		w = w.WithRecoverPanic(*blder.recoverPanic)
+	}
+	return w, nil
 }
 
 func (blder *WebhookBuilder) registerConversionWebhook() error {
@@ -25,7 +25,8 @@ type EngageOptions struct {
 
 // WithEngageWithLocalCluster configures whether the controller should engage
 // with the local cluster of the manager (empty string). This defaults to false
-// if a cluster provider is configured, and to true otherwise.
+// if a cluster provider is configured, and to true otherwise. In
+// single-cluster mode, controllers always engage the local cluster.
 func WithEngageWithLocalCluster(engage bool) EngageOptions {
 	return EngageOptions{
 		engageWithLocalCluster: &engage,
@@ -34,7 +35,8 @@ func WithEngageWithLocalCluster(engage bool) EngageOptions {
 
 // WithEngageWithProviderClusters configured whether the controller should engage
 // with the provider clusters of the manager. This defaults to true if a
-// cluster provider is set, and has no effect otherwise.
+// cluster provider is set, and has no effect otherwise, e.g. in single-cluster
+// mode.
 func WithEngageWithProviderClusters(engage bool) EngageOptions {
 	return EngageOptions{
 		engageWithProviderClusters: &engage,
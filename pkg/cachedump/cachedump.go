@@ -0,0 +1,295 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cachedump exports the cache contents of the engaged clusters to a
+// tarball, to diagnose what a controller thought the world looked like
+// during an incident.
+package cachedump
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+
+	mcerrors "sigs.k8s.io/multicluster-runtime/pkg/errors"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+)
+
+// Format is the encoding of the dumped objects.
+type Format string
+
+const (
+	// FormatYAML encodes every object as YAML. This is the default.
+	FormatYAML Format = "yaml"
+
+	// FormatJSON encodes every object as JSON.
+	FormatJSON Format = "json"
+)
+
+// ErrorsFile is the file of the tarball listing the clusters and kinds that
+// could not be dumped.
+const ErrorsFile = "errors.txt"
+
+// RedactedValue replaces redacted values.
+const RedactedValue = "REDACTED"
+
+// RedactFunc redacts sensitive fields of an object in place before it is
+// dumped.
+type RedactFunc func(clusterName string, obj *unstructured.Unstructured)
+
+// RedactSecrets replaces the values of Secrets, and drops their last
+// applied configuration, which holds the values as well.
+func RedactSecrets(_ string, obj *unstructured.Unstructured) {
+	if obj.GroupVersionKind().GroupKind() != (schema.GroupKind{Kind: "Secret"}) {
+		return
+	}
+	for _, field := range []string{"data", "stringData"} {
+		values, ok, _ := unstructured.NestedMap(obj.Object, field)
+		if !ok {
+			continue
+		}
+		for k := range values {
+			values[k] = RedactedValue
+		}
+		_ = unstructured.SetNestedMap(obj.Object, values, field)
+	}
+	if annotations := obj.GetAnnotations(); annotations != nil {
+		delete(annotations, "kubectl.kubernetes.io/last-applied-configuration")
+		obj.SetAnnotations(annotations)
+	}
+}
+
+// Options are the options of a Dumper.
+type Options struct {
+	// Kinds are the kinds to dump. Kinds registered in the scheme of a
+	// cluster are read from its typed cache, i.e. what the controllers see.
+	// Listing a kind without informer starts one.
+	Kinds []schema.GroupVersionKind
+
+	// Format is the encoding of the objects. Defaults to FormatYAML.
+	Format Format
+
+	// Redact redacts the objects before they are dumped. Defaults to
+	// RedactSecrets.
+	Redact RedactFunc
+}
+
+var _ mcmanager.Runnable = &Dumper{}
+
+// Dumper dumps the cache contents of the engaged clusters. Add it to the
+// manager with Manager.Add, and serve it on a debug endpoint, e.g.
+//
+//	mgr.AddMetricsServerExtraHandler("/debug/fleet-cache", dumper)
+type Dumper struct {
+	opts Options
+
+	lock     sync.RWMutex
+	clusters map[string]cluster.Cluster
+}
+
+// New returns a new Dumper.
+func New(opts Options) (*Dumper, error) {
+	if len(opts.Kinds) == 0 {
+		return nil, errors.New("at least one kind must be set")
+	}
+	switch opts.Format {
+	case "":
+		opts.Format = FormatYAML
+	case FormatYAML, FormatJSON:
+	default:
+		return nil, fmt.Errorf("unknown format %q", opts.Format)
+	}
+	if opts.Redact == nil {
+		opts.Redact = RedactSecrets
+	}
+	return &Dumper{opts: opts, clusters: map[string]cluster.Cluster{}}, nil
+}
+
+// Engage dumps the cluster until ctx is done.
+func (d *Dumper) Engage(ctx context.Context, name string, cl cluster.Cluster) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.clusters[name] = cl
+
+	go func() {
+		<-ctx.Done()
+		d.lock.Lock()
+		defer d.lock.Unlock()
+		if d.clusters[name] == cl {
+			delete(d.clusters, name)
+		}
+	}()
+	return nil
+}
+
+// Start blocks until ctx is done.
+func (d *Dumper) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+// Dump writes a gzipped tarball of the cached objects of all engaged
+// clusters to w, one file per object at
+// <cluster>/<group>/<version>/<kind>/[<namespace>/]<name>.<format>, the
+// core group being named "core". Kinds that cannot be listed in a cluster
+// are listed in ErrorsFile and returned as aggregate error, the others are
+// dumped nevertheless.
+func (d *Dumper) Dump(ctx context.Context, w io.Writer) error {
+	d.lock.RLock()
+	names := make([]string, 0, len(d.clusters))
+	clusters := make(map[string]cluster.Cluster, len(d.clusters))
+	for name, cl := range d.clusters {
+		names = append(names, name)
+		clusters[name] = cl
+	}
+	d.lock.RUnlock()
+	sort.Strings(names)
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	now := time.Now()
+
+	var errs []error
+	for _, name := range names {
+		for _, gvk := range d.opts.Kinds {
+			objs, err := list(ctx, clusters[name], gvk)
+			if err != nil {
+				errs = append(errs, mcerrors.New(name, "list "+gvk.String(), err))
+				continue
+			}
+			for _, obj := range objs {
+				d.opts.Redact(name, obj)
+				data, err := d.encode(obj)
+				if err != nil {
+					errs = append(errs, mcerrors.New(name, "encode "+gvk.String(), err))
+					continue
+				}
+				if err := writeFile(tw, d.path(name, gvk, obj), data, now); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	dumpErr := mcerrors.NewAggregate(errs...)
+	if dumpErr != nil {
+		var b strings.Builder
+		for _, err := range errs {
+			fmt.Fprintln(&b, err)
+		}
+		if err := writeFile(tw, ErrorsFile, []byte(b.String()), now); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	return dumpErr
+}
+
+// ServeHTTP serves the tarball of Dump for download.
+func (d *Dumper) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=fleet-cache-%s.tar.gz", time.Now().UTC().Format("20060102T150405Z")))
+	if err := d.Dump(req.Context(), w); err != nil {
+		log.FromContext(req.Context()).WithName("cachedump").Error(err, "Incomplete cache dump")
+	}
+}
+
+// list returns the cached objects of the kind in the cluster, read through
+// the typed cache if the kind is registered in the scheme of the cluster.
+func list(ctx context.Context, cl cluster.Cluster, gvk schema.GroupVersionKind) ([]*unstructured.Unstructured, error) {
+	listGVK := gvk.GroupVersion().WithKind(gvk.Kind + "List")
+	var l client.ObjectList
+	if o, err := cl.GetScheme().New(listGVK); err == nil {
+		if typed, ok := o.(client.ObjectList); ok {
+			l = typed
+		}
+	}
+	if l == nil {
+		u := &unstructured.UnstructuredList{}
+		u.SetGroupVersionKind(listGVK)
+		l = u
+	}
+	if err := cl.GetCache().List(ctx, l); err != nil {
+		return nil, err
+	}
+
+	items, err := meta.ExtractList(l)
+	if err != nil {
+		return nil, err
+	}
+	objs := make([]*unstructured.Unstructured, 0, len(items))
+	for _, item := range items {
+		u, ok := item.(*unstructured.Unstructured)
+		if !ok {
+			content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(item)
+			if err != nil {
+				return nil, err
+			}
+			u = &unstructured.Unstructured{Object: content}
+		}
+		u.SetGroupVersionKind(gvk)
+		objs = append(objs, u)
+	}
+	return objs, nil
+}
+
+func (d *Dumper) encode(obj *unstructured.Unstructured) ([]byte, error) {
+	if d.opts.Format == FormatJSON {
+		return json.MarshalIndent(obj.Object, "", "  ")
+	}
+	return yaml.Marshal(obj.Object)
+}
+
+func (d *Dumper) path(clusterName string, gvk schema.GroupVersionKind, obj *unstructured.Unstructured) string {
+	group := gvk.Group
+	if group == "" {
+		group = "core"
+	}
+	return path.Join(clusterName, group, gvk.Version, gvk.Kind, obj.GetNamespace(), obj.GetName()+"."+string(d.opts.Format))
+}
+
+func writeFile(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(data)), ModTime: modTime}); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
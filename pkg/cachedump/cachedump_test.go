@@ -0,0 +1,196 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cachedump
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/fake"
+)
+
+var _ = Describe("Dumper", func() {
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+		mgr    *fake.Manager
+	)
+	configMaps := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+	secrets := schema.GroupVersionKind{Version: "v1", Kind: "Secret"}
+
+	untar := func(data []byte) map[string][]byte {
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		Expect(err).NotTo(HaveOccurred())
+		tr := tar.NewReader(gz)
+		files := map[string][]byte{}
+		for {
+			hdr, err := tr.Next()
+			if errors.Is(err, io.EOF) {
+				return files
+			}
+			Expect(err).NotTo(HaveOccurred())
+			files[hdr.Name], err = io.ReadAll(tr)
+			Expect(err).NotTo(HaveOccurred())
+		}
+	}
+	engage := func(d *Dumper) {
+		for _, name := range []string{"one", "two"} {
+			cl, err := mgr.GetCluster(ctx, name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(d.Engage(ctx, name, cl)).To(Succeed())
+		}
+	}
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		DeferCleanup(func() { cancel() })
+		mgr = fake.NewManagerBuilder().
+			WithCluster("one",
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "a"}, Data: map[string]string{"k": "v"}},
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "s", Annotations: map[string]string{
+					"kubectl.kubernetes.io/last-applied-configuration": `{"data":{"password":"aHVudGVyMg=="}}`,
+				}}, Data: map[string][]byte{"password": []byte("hunter2")}},
+			).
+			WithCluster("two", &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "b"}}).
+			Build()
+	})
+
+	It("dumps the cached objects of every engaged cluster and redacts Secrets", func() {
+		d, err := New(Options{Kinds: []schema.GroupVersionKind{configMaps, secrets}})
+		Expect(err).NotTo(HaveOccurred())
+		engage(d)
+
+		var buf bytes.Buffer
+		Expect(d.Dump(ctx, &buf)).To(Succeed())
+		files := untar(buf.Bytes())
+		Expect(files).To(HaveLen(3))
+		Expect(files).To(HaveKey("one/core/v1/ConfigMap/default/a.yaml"))
+		Expect(files).To(HaveKey("two/core/v1/ConfigMap/kube-system/b.yaml"))
+
+		cm := &corev1.ConfigMap{}
+		Expect(yaml.Unmarshal(files["one/core/v1/ConfigMap/default/a.yaml"], cm)).To(Succeed())
+		Expect(cm.Kind).To(Equal("ConfigMap"))
+		Expect(cm.Data).To(Equal(map[string]string{"k": "v"}))
+
+		secret := &corev1.Secret{}
+		Expect(yaml.Unmarshal(files["one/core/v1/Secret/default/s.yaml"], secret)).To(Succeed())
+		Expect(secret.StringData).To(BeEmpty())
+		Expect(secret.Annotations).NotTo(HaveKey("kubectl.kubernetes.io/last-applied-configuration"))
+		Expect(string(files["one/core/v1/Secret/default/s.yaml"])).NotTo(ContainSubstring("aHVudGVyMg=="))
+		Expect(string(files["one/core/v1/Secret/default/s.yaml"])).To(ContainSubstring("password: " + RedactedValue))
+	})
+
+	It("encodes the objects as JSON with a custom redaction", func() {
+		d, err := New(Options{
+			Kinds:  []schema.GroupVersionKind{configMaps},
+			Format: FormatJSON,
+			Redact: func(clusterName string, obj *unstructured.Unstructured) {
+				obj.SetLabels(map[string]string{"cluster": clusterName})
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		engage(d)
+
+		var buf bytes.Buffer
+		Expect(d.Dump(ctx, &buf)).To(Succeed())
+		files := untar(buf.Bytes())
+		cm := &corev1.ConfigMap{}
+		Expect(json.Unmarshal(files["two/core/v1/ConfigMap/kube-system/b.json"], cm)).To(Succeed())
+		Expect(cm.Labels).To(Equal(map[string]string{"cluster": "two"}))
+	})
+
+	It("records the kinds that could not be listed and dumps the others", func() {
+		mgr = fake.NewManagerBuilder().
+			WithCluster("one", &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "a"}}).
+			WithCluster("two").
+			WithInterceptorFuncs("two", interceptor.Funcs{
+				List: func(context.Context, client.WithWatch, client.ObjectList, ...client.ListOption) error {
+					return errors.New("boom")
+				},
+			}).
+			Build()
+		d, err := New(Options{Kinds: []schema.GroupVersionKind{configMaps}})
+		Expect(err).NotTo(HaveOccurred())
+		engage(d)
+
+		var buf bytes.Buffer
+		err = d.Dump(ctx, &buf)
+		Expect(err).To(MatchError(ContainSubstring("boom")))
+		files := untar(buf.Bytes())
+		Expect(files).To(HaveKey("one/core/v1/ConfigMap/default/a.yaml"))
+		Expect(string(files[ErrorsFile])).To(ContainSubstring("two"))
+	})
+
+	It("forgets disengaged clusters", func() {
+		d, err := New(Options{Kinds: []schema.GroupVersionKind{configMaps}})
+		Expect(err).NotTo(HaveOccurred())
+		clusterCtx, clusterCancel := context.WithCancel(ctx)
+		cl, err := mgr.GetCluster(ctx, "one")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(d.Engage(clusterCtx, "one", cl)).To(Succeed())
+		clusterCancel()
+
+		Eventually(func() map[string][]byte {
+			var buf bytes.Buffer
+			Expect(d.Dump(ctx, &buf)).To(Succeed())
+			return untar(buf.Bytes())
+		}).Should(BeEmpty())
+	})
+
+	It("serves the tarball over HTTP", func() {
+		d, err := New(Options{Kinds: []schema.GroupVersionKind{configMaps}})
+		Expect(err).NotTo(HaveOccurred())
+		engage(d)
+
+		srv := httptest.NewServer(d)
+		defer srv.Close()
+		resp, err := http.Get(srv.URL)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.Header.Get("Content-Type")).To(Equal("application/gzip"))
+		Expect(resp.Header.Get("Content-Disposition")).To(HavePrefix("attachment; filename=fleet-cache-"))
+		data, err := io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(untar(data)).To(HaveLen(2))
+	})
+
+	It("rejects invalid options", func() {
+		_, err := New(Options{})
+		Expect(err).To(HaveOccurred())
+		_, err = New(Options{Kinds: []schema.GroupVersionKind{configMaps}, Format: "xml"})
+		Expect(err).To(HaveOccurred())
+	})
+})
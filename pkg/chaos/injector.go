@@ -0,0 +1,199 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package chaos injects connectivity faults into the clients of individual
+// clusters, so that resilience paths like retries, requeues and
+// re-engagement can be tested deterministically.
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"syscall"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Fault is a kind of fault injected into requests to a cluster.
+type Fault string
+
+const (
+	// FaultConnectionReset fails requests with a connection reset error.
+	FaultConnectionReset Fault = "ConnectionReset"
+	// FaultTooManyRequests fails requests with HTTP status 429.
+	FaultTooManyRequests Fault = "TooManyRequests"
+	// FaultUnreachable fails requests and provider lookups of the cluster
+	// until the fault is cleared.
+	FaultUnreachable Fault = "Unreachable"
+)
+
+// ErrUnreachable is returned for requests to clusters marked unreachable.
+var ErrUnreachable = errors.New("cluster is unreachable")
+
+// Injector holds the faults to inject per cluster. The zero value is not
+// usable, use NewInjector instead.
+type Injector struct {
+	lock     sync.Mutex
+	clusters map[string]*faults
+}
+
+type faults struct {
+	latency     time.Duration
+	unreachable bool
+	next        []Fault
+	retryAfter  time.Duration
+	watches     map[io.Closer]struct{}
+}
+
+// NewInjector returns an injector without faults.
+func NewInjector() *Injector {
+	return &Injector{clusters: map[string]*faults{}}
+}
+
+func (i *Injector) get(cluster string) *faults {
+	f, ok := i.clusters[cluster]
+	if !ok {
+		f = &faults{watches: map[io.Closer]struct{}{}}
+		i.clusters[cluster] = f
+	}
+	return f
+}
+
+// SetLatency delays every request to the cluster by d. A zero duration
+// removes the latency.
+func (i *Injector) SetLatency(cluster string, d time.Duration) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.get(cluster).latency = d
+}
+
+// SetUnreachable marks the cluster as unreachable or reachable again.
+// Marking a cluster unreachable drops its open watches.
+func (i *Injector) SetUnreachable(cluster string, unreachable bool) {
+	i.lock.Lock()
+	f := i.get(cluster)
+	f.unreachable = unreachable
+	i.lock.Unlock()
+
+	if unreachable {
+		i.DropWatches(cluster)
+	}
+}
+
+// FailNext fails the next n requests to the cluster with the given fault.
+// Faults queued by multiple calls are injected in order.
+func (i *Injector) FailNext(cluster string, n int, fault Fault) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	f := i.get(cluster)
+	for range n {
+		f.next = append(f.next, fault)
+	}
+}
+
+// SetRetryAfter sets the Retry-After duration of injected 429 responses.
+func (i *Injector) SetRetryAfter(cluster string, d time.Duration) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.get(cluster).retryAfter = d
+}
+
+// DropWatches closes all open watch streams of the cluster. Clients observe
+// this like a dropped connection and have to re-establish the watch.
+func (i *Injector) DropWatches(cluster string) {
+	i.lock.Lock()
+	f := i.get(cluster)
+	watches := f.watches
+	f.watches = map[io.Closer]struct{}{}
+	i.lock.Unlock()
+
+	for w := range watches {
+		_ = w.Close()
+	}
+}
+
+// Reset removes all faults of the cluster.
+func (i *Injector) Reset(cluster string) {
+	i.DropWatches(cluster)
+
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	delete(i.clusters, cluster)
+}
+
+// Unreachable returns whether the cluster is marked unreachable.
+func (i *Injector) Unreachable(cluster string) bool {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	return i.get(cluster).unreachable
+}
+
+// inject waits for the latency of the cluster and returns the fault to
+// inject into the next request, if any.
+func (i *Injector) inject(ctx context.Context, cluster string) (Fault, time.Duration, error) {
+	i.lock.Lock()
+	f := i.get(cluster)
+	latency, retryAfter := f.latency, f.retryAfter
+	var fault Fault
+	switch {
+	case f.unreachable:
+		fault = FaultUnreachable
+	case len(f.next) > 0:
+		fault, f.next = f.next[0], f.next[1:]
+	}
+	i.lock.Unlock()
+
+	if latency > 0 {
+		t := time.NewTimer(latency)
+		defer t.Stop()
+		select {
+		case <-ctx.Done():
+			return "", 0, ctx.Err()
+		case <-t.C:
+		}
+	}
+	return fault, retryAfter, nil
+}
+
+// faultErr returns the client error for the given fault, or nil.
+func faultErr(cluster string, gr schema.GroupResource, fault Fault, retryAfter time.Duration) error {
+	switch fault {
+	case FaultConnectionReset:
+		return fmt.Errorf("cluster %q: %w", cluster, syscall.ECONNRESET)
+	case FaultTooManyRequests:
+		return apierrors.NewTooManyRequests(fmt.Sprintf("injected fault for %s in cluster %q", gr, cluster), int(retryAfter.Seconds()))
+	case FaultUnreachable:
+		return fmt.Errorf("cluster %q: %w", cluster, ErrUnreachable)
+	}
+	return nil
+}
+
+func (i *Injector) trackWatch(cluster string, w io.Closer) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.get(cluster).watches[w] = struct{}{}
+}
+
+func (i *Injector) untrackWatch(cluster string, w io.Closer) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	delete(i.get(cluster).watches, w)
+}
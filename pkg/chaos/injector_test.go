@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaos
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Injector", func() {
+	var (
+		ctx      context.Context
+		injector *Injector
+		c        client.WithWatch
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		injector = NewInjector()
+		c = fake.NewClientBuilder().
+			WithObjects(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "a"}}).
+			WithInterceptorFuncs(injector.InterceptorFuncs("one")).
+			Build()
+	})
+
+	get := func() error {
+		return c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "a"}, &corev1.ConfigMap{})
+	}
+
+	It("fails the next requests in order", func() {
+		injector.FailNext("one", 1, FaultTooManyRequests)
+		injector.FailNext("one", 1, FaultConnectionReset)
+		injector.FailNext("two", 1, FaultConnectionReset)
+
+		Expect(apierrors.IsTooManyRequests(get())).To(BeTrue())
+		Expect(errors.Is(get(), syscall.ECONNRESET)).To(BeTrue())
+		Expect(get()).To(Succeed())
+	})
+
+	It("fails requests while the cluster is unreachable", func() {
+		injector.SetUnreachable("one", true)
+		Expect(errors.Is(get(), ErrUnreachable)).To(BeTrue())
+		Expect(errors.Is(get(), ErrUnreachable)).To(BeTrue())
+
+		injector.SetUnreachable("one", false)
+		Expect(get()).To(Succeed())
+	})
+
+	It("delays requests", func() {
+		injector.SetLatency("one", 50*time.Millisecond)
+		start := time.Now()
+		Expect(get()).To(Succeed())
+		Expect(time.Since(start)).To(BeNumerically(">=", 50*time.Millisecond))
+	})
+
+	It("drops watches", func() {
+		w, err := c.Watch(ctx, &corev1.ConfigMapList{})
+		Expect(err).NotTo(HaveOccurred())
+
+		injector.DropWatches("one")
+		Eventually(w.ResultChan()).Should(BeClosed())
+	})
+
+	It("injects 429 responses into a transport", func() {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer srv.Close()
+
+		injector.FailNext("one", 1, FaultTooManyRequests)
+		injector.SetRetryAfter("one", 2*time.Second)
+		hc := &http.Client{Transport: injector.RoundTripper("one", http.DefaultTransport)}
+
+		resp, err := hc.Get(srv.URL)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusTooManyRequests))
+		Expect(resp.Header.Get("Retry-After")).To(Equal("2"))
+		Expect(resp.Body.Close()).To(Succeed())
+
+		resp, err = hc.Get(srv.URL)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(resp.Body.Close()).To(Succeed())
+	})
+})
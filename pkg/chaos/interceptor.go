@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaos
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// InterceptorFuncs returns interceptor functions that inject the faults of
+// the given cluster into a fake client.
+func (i *Injector) InterceptorFuncs(cluster string) interceptor.Funcs {
+	check := func(ctx context.Context, c client.WithWatch, obj runtime.Object) error {
+		fault, retryAfter, err := i.inject(ctx, cluster)
+		if err != nil {
+			return err
+		}
+		return faultErr(cluster, groupResource(c, obj), fault, retryAfter)
+	}
+	return interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if err := check(ctx, c, obj); err != nil {
+				return err
+			}
+			return c.Get(ctx, key, obj, opts...)
+		},
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if err := check(ctx, c, list); err != nil {
+				return err
+			}
+			return c.List(ctx, list, opts...)
+		},
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if err := check(ctx, c, obj); err != nil {
+				return err
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			if err := check(ctx, c, obj); err != nil {
+				return err
+			}
+			return c.Update(ctx, obj, opts...)
+		},
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			if err := check(ctx, c, obj); err != nil {
+				return err
+			}
+			return c.Patch(ctx, obj, patch, opts...)
+		},
+		Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+			if err := check(ctx, c, obj); err != nil {
+				return err
+			}
+			return c.Delete(ctx, obj, opts...)
+		},
+		DeleteAllOf: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteAllOfOption) error {
+			if err := check(ctx, c, obj); err != nil {
+				return err
+			}
+			return c.DeleteAllOf(ctx, obj, opts...)
+		},
+		Watch: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) (watch.Interface, error) {
+			if err := check(ctx, c, list); err != nil {
+				return nil, err
+			}
+			w, err := c.Watch(ctx, list, opts...)
+			if err != nil {
+				return nil, err
+			}
+			tw := &trackedWatch{Interface: w}
+			tw.untrack = func() { i.untrackWatch(cluster, tw) }
+			i.trackWatch(cluster, tw)
+			return tw, nil
+		},
+	}
+}
+
+func groupResource(c client.Client, obj runtime.Object) schema.GroupResource {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return schema.GroupResource{}
+	}
+	return schema.GroupResource{Group: gvk.Group, Resource: strings.TrimSuffix(gvk.Kind, "List")}
+}
+
+// trackedWatch is a watch that can be stopped by the injector to drop the
+// stream.
+type trackedWatch struct {
+	watch.Interface
+	once    sync.Once
+	untrack func()
+}
+
+func (w *trackedWatch) Stop() {
+	w.once.Do(w.untrack)
+	w.Interface.Stop()
+}
+
+func (w *trackedWatch) Close() error {
+	w.Stop()
+	return nil
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaos
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+var _ multicluster.Provider = &Provider{}
+
+// Provider decorates a provider such that lookups of clusters marked
+// unreachable fail.
+type Provider struct {
+	multicluster.Provider
+	injector *Injector
+}
+
+// WrapProvider returns a provider that fails lookups of clusters marked
+// unreachable in the injector.
+func WrapProvider(p multicluster.Provider, injector *Injector) *Provider {
+	return &Provider{Provider: p, injector: injector}
+}
+
+// Get returns the cluster with the given name, or an error wrapping
+// ErrUnreachable if the cluster is marked unreachable.
+func (p *Provider) Get(ctx context.Context, clusterName string) (cluster.Cluster, error) {
+	if p.injector.Unreachable(clusterName) {
+		return nil, fmt.Errorf("cluster %q: %w", clusterName, ErrUnreachable)
+	}
+	return p.Provider.Get(ctx, clusterName)
+}
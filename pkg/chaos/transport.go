@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaos
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+)
+
+// WrapConfig returns a copy of cfg whose transport injects the faults of
+// the given cluster.
+func (i *Injector) WrapConfig(cluster string, cfg *rest.Config) *rest.Config {
+	cfg = rest.CopyConfig(cfg)
+	cfg.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return i.RoundTripper(cluster, rt)
+	})
+	return cfg
+}
+
+// RoundTripper wraps rt to inject the faults of the given cluster.
+func (i *Injector) RoundTripper(cluster string, rt http.RoundTripper) http.RoundTripper {
+	return &roundTripper{injector: i, cluster: cluster, delegate: rt}
+}
+
+type roundTripper struct {
+	injector *Injector
+	cluster  string
+	delegate http.RoundTripper
+}
+
+func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	fault, retryAfter, err := rt.injector.inject(req.Context(), rt.cluster)
+	if err != nil {
+		return nil, err
+	}
+	if fault == FaultTooManyRequests {
+		status := apierrors.NewTooManyRequests("injected fault in cluster "+strconv.Quote(rt.cluster), int(retryAfter.Seconds())).Status()
+		body, err := json.Marshal(&status)
+		if err != nil {
+			return nil, err
+		}
+		header := http.Header{"Content-Type": []string{"application/json"}}
+		if retryAfter > 0 {
+			header.Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())))
+		}
+		return &http.Response{
+			Status:     http.StatusText(http.StatusTooManyRequests),
+			StatusCode: http.StatusTooManyRequests,
+			Header:     header,
+			Body:       io.NopCloser(bytes.NewReader(body)),
+			Request:    req,
+		}, nil
+	}
+	if err := faultErr(rt.cluster, schema.GroupResource{}, fault, retryAfter); err != nil {
+		return nil, err
+	}
+
+	resp, err := rt.delegate.RoundTrip(req)
+	if err != nil || !isWatch(req) {
+		return resp, err
+	}
+	body := &watchBody{ReadCloser: resp.Body}
+	body.untrack = func() { rt.injector.untrackWatch(rt.cluster, body) }
+	rt.injector.trackWatch(rt.cluster, body)
+	resp.Body = body
+	return resp, nil
+}
+
+func isWatch(req *http.Request) bool {
+	w := req.URL.Query().Get("watch")
+	return w == "true" || w == "1"
+}
+
+// watchBody is the body of a watch response that can be closed by the
+// injector to drop the stream.
+type watchBody struct {
+	io.ReadCloser
+	once    sync.Once
+	untrack func()
+}
+
+func (b *watchBody) Close() error {
+	b.once.Do(b.untrack)
+	return b.ReadCloser.Close()
+}
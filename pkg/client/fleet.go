@@ -0,0 +1,263 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/clustergroup"
+	mcerrors "sigs.k8s.io/multicluster-runtime/pkg/errors"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+)
+
+var _ mcmanager.Runnable = &Fleet{}
+
+// Fleet reads objects across all engaged clusters. Add it to the manager
+// with Manager.Add.
+type Fleet struct {
+	lock     sync.RWMutex
+	clusters map[string]cluster.Cluster
+}
+
+// NewFleet returns a new Fleet.
+func NewFleet() *Fleet {
+	return &Fleet{clusters: map[string]cluster.Cluster{}}
+}
+
+// Engage adds the cluster to the fleet until ctx is done.
+func (f *Fleet) Engage(ctx context.Context, name string, cl cluster.Cluster) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.clusters[name] = cl
+	go func() {
+		<-ctx.Done()
+		f.lock.Lock()
+		defer f.lock.Unlock()
+		if f.clusters[name] == cl {
+			delete(f.clusters, name)
+		}
+	}()
+	return nil
+}
+
+// Start blocks until ctx is done.
+func (f *Fleet) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+// Clusters returns the sorted names of the engaged clusters.
+func (f *Fleet) Clusters() []string {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	names := make([]string, 0, len(f.clusters))
+	for name := range f.clusters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ClusterObject is an object of a cluster in a fleet-wide listing.
+type ClusterObject struct {
+	// Cluster is the name of the cluster of the object.
+	Cluster string
+
+	// Object is the object.
+	Object client.Object
+}
+
+// FleetPage is a page of a fleet-wide listing.
+type FleetPage struct {
+	// Items are the objects of the page, grouped by cluster in the order
+	// of the cluster names.
+	Items []ClusterObject
+
+	// Continue is passed with client.Continue to get the next page. It is
+	// empty on the last page.
+	Continue string
+
+	// ClusterContinue are the continue tokens of the clusters that are not
+	// exhausted yet. An empty token means the cluster was not listed yet.
+	ClusterContinue map[string]string
+
+	// ResourceVersions are the resource versions of the lists of the
+	// clusters listed for this page.
+	ResourceVersions map[string]string
+}
+
+// ListAll lists the kind of list in all engaged clusters, reading from the
+// API servers. list is only used as a prototype and is not filled.
+//
+// Without client.Limit, all objects are returned at once. With a limit, at
+// most limit objects are returned per page, shared evenly by the clusters
+// not exhausted yet, so that every page interleaves the clusters. Pass the
+// Continue of the page with client.Continue to get the next one. The
+// clusters of a listing are fixed on its first page: clusters engaged later
+// are not listed, clusters disengaged in between are skipped.
+func (f *Fleet) ListAll(ctx context.Context, list client.ObjectList, opts ...client.ListOption) (*FleetPage, error) {
+	return f.list(ctx, func() ([]string, error) { return f.Clusters(), nil }, list, opts...)
+}
+
+// ListGroup is like ListAll, listing only the engaged clusters that are
+// members of the named group of groups when the listing starts.
+func (f *Fleet) ListGroup(ctx context.Context, groups *clustergroup.Groups, name string, list client.ObjectList, opts ...client.ListOption) (*FleetPage, error) {
+	return f.list(ctx, func() ([]string, error) { return groups.Members(name) }, list, opts...)
+}
+
+// list implements ListAll for the clusters returned by clusters on the
+// first page.
+func (f *Fleet) list(ctx context.Context, clusters func() ([]string, error), list client.ObjectList, opts ...client.ListOption) (*FleetPage, error) {
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+
+	pending := map[string]string{}
+	if listOpts.Continue == "" {
+		names, err := clusters()
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			pending[name] = ""
+		}
+	} else {
+		var err error
+		if pending, err = decodeContinue(listOpts.Continue); err != nil {
+			return nil, err
+		}
+	}
+	names := make([]string, 0, len(pending))
+	for name := range pending {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	page := &FleetPage{ResourceVersions: map[string]string{}}
+	for i, name := range names {
+		f.lock.RLock()
+		cl, ok := f.clusters[name]
+		f.lock.RUnlock()
+		if !ok {
+			delete(pending, name)
+			continue
+		}
+
+		clusterOpts := *listOpts
+		clusterOpts.Continue = pending[name]
+		if listOpts.Limit > 0 {
+			remaining := listOpts.Limit - int64(len(page.Items))
+			if remaining <= 0 {
+				break
+			}
+			// share the remaining limit with the clusters still to come.
+			clusters := int64(len(names) - i)
+			clusterOpts.Limit = (remaining + clusters - 1) / clusters
+		}
+		for {
+			l, ok := list.DeepCopyObject().(client.ObjectList)
+			if !ok {
+				return nil, fmt.Errorf("cannot copy list %T", list)
+			}
+			if err := cl.GetAPIReader().List(ctx, l, &clusterOpts); err != nil {
+				return nil, mcerrors.New(name, "list", err)
+			}
+			objs, err := extractList(l)
+			if err != nil {
+				return nil, mcerrors.New(name, "list", err)
+			}
+			for _, obj := range objs {
+				page.Items = append(page.Items, ClusterObject{Cluster: name, Object: obj})
+			}
+			if _, ok := page.ResourceVersions[name]; !ok {
+				page.ResourceVersions[name] = l.GetResourceVersion()
+			}
+			clusterOpts.Continue = l.GetContinue()
+			if clusterOpts.Continue == "" || listOpts.Limit > 0 {
+				break
+			}
+		}
+		if clusterOpts.Continue == "" {
+			delete(pending, name)
+		} else {
+			pending[name] = clusterOpts.Continue
+		}
+	}
+
+	page.ClusterContinue = pending
+	if len(pending) > 0 {
+		token, err := encodeContinue(pending)
+		if err != nil {
+			return nil, err
+		}
+		page.Continue = token
+	}
+	return page, nil
+}
+
+func extractList(list client.ObjectList) ([]client.Object, error) {
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+	objs := make([]client.Object, 0, len(items))
+	for _, item := range items {
+		obj, ok := item.(client.Object)
+		if !ok {
+			return nil, fmt.Errorf("unexpected list item %T", item)
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+type fleetContinue struct {
+	Clusters map[string]string `json:"clusters"`
+}
+
+func encodeContinue(pending map[string]string) (string, error) {
+	data, err := json.Marshal(fleetContinue{Clusters: pending})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeContinue(token string) (map[string]string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fleet continue token: %w", err)
+	}
+	var c fleetContinue
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("invalid fleet continue token: %w", err)
+	}
+	if c.Clusters == nil {
+		return nil, errors.New("invalid fleet continue token: no clusters")
+	}
+	return c.Clusters, nil
+}
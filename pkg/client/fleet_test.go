@@ -0,0 +1,199 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/clustergroup"
+	"sigs.k8s.io/multicluster-runtime/pkg/fake"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	"sigs.k8s.io/multicluster-runtime/pkg/selector"
+)
+
+// paginate serves lists in pages like an API server, the continue token
+// being the index of the next item.
+var paginate = interceptor.Funcs{
+	List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+		listOpts := &client.ListOptions{}
+		listOpts.ApplyOptions(opts)
+		if err := c.List(ctx, list); err != nil {
+			return err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return err
+		}
+		start := 0
+		if listOpts.Continue != "" {
+			if start, err = strconv.Atoi(listOpts.Continue); err != nil {
+				return err
+			}
+		}
+		end := len(items)
+		if listOpts.Limit > 0 && start+int(listOpts.Limit) < end {
+			end = start + int(listOpts.Limit)
+			list.SetContinue(strconv.Itoa(end))
+		}
+		return meta.SetList(list, items[start:end])
+	},
+}
+
+var _ = Describe("Fleet", func() {
+	ctx := context.Background()
+	configMaps := func(n int) []client.Object {
+		objs := make([]client.Object, 0, n)
+		for i := range n {
+			objs = append(objs, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: fmt.Sprintf("cm-%d", i)}})
+		}
+		return objs
+	}
+
+	var (
+		fleet  *Fleet
+		groups *clustergroup.Groups
+	)
+
+	BeforeEach(func() {
+		mgr := fake.NewManagerBuilder().
+			WithCluster("one", configMaps(5)...).WithInterceptorFuncs("one", paginate).
+			WithCluster("two", configMaps(2)...).WithInterceptorFuncs("two", paginate).
+			WithClusterMetadata("two", multicluster.Metadata{Labels: map[string]string{"env": "prod"}}).
+			Build()
+		fleet = NewFleet()
+		groups = clustergroup.New(mgr, clustergroup.Options{})
+		Expect(groups.Define("prod", &selector.ClusterSelector{Expression: "env=prod"})).To(Succeed())
+		ctx, cancel := context.WithCancel(ctx)
+		DeferCleanup(cancel)
+		for _, name := range []string{"one", "two"} {
+			Expect(fleet.Engage(ctx, name, mgr.FakeCluster(name))).To(Succeed())
+			Expect(groups.Engage(ctx, name, mgr.FakeCluster(name))).To(Succeed())
+		}
+	})
+
+	clusters := func(page *FleetPage) []string {
+		var names []string
+		for _, item := range page.Items {
+			names = append(names, item.Cluster)
+		}
+		return names
+	}
+
+	It("lists all clusters at once without limit", func() {
+		page, err := fleet.ListAll(ctx, &corev1.ConfigMapList{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(page.Items).To(HaveLen(7))
+		Expect(page.Continue).To(BeEmpty())
+	})
+
+	It("interleaves the clusters in pages", func() {
+		page, err := fleet.ListAll(ctx, &corev1.ConfigMapList{}, client.Limit(4))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clusters(page)).To(Equal([]string{"one", "one", "two", "two"}))
+		Expect(page.ClusterContinue).To(Equal(map[string]string{"one": "2"}))
+		Expect(page.Continue).NotTo(BeEmpty())
+
+		page, err = fleet.ListAll(ctx, &corev1.ConfigMapList{}, client.Limit(4), client.Continue(page.Continue))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clusters(page)).To(Equal([]string{"one", "one", "one"}))
+		Expect(page.Items[0].Object.GetName()).To(Equal("cm-2"))
+		Expect(page.Continue).To(BeEmpty())
+		Expect(page.ClusterContinue).To(BeEmpty())
+	})
+
+	It("lists the members of a cluster group", func() {
+		page, err := fleet.ListGroup(ctx, groups, "prod", &corev1.ConfigMapList{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clusters(page)).To(Equal([]string{"two", "two"}))
+
+		_, err = fleet.ListGroup(ctx, groups, "staging", &corev1.ConfigMapList{})
+		Expect(err).To(MatchError(clustergroup.ErrGroupNotFound))
+	})
+
+	It("rejects invalid continue tokens", func() {
+		_, err := fleet.ListAll(ctx, &corev1.ConfigMapList{}, client.Continue("garbage!"))
+		Expect(err).To(MatchError(ContainSubstring("invalid fleet continue token")))
+	})
+
+	Describe("Snapshot", func() {
+		// churn updates cm-0 after each of the first n lists.
+		churn := func(n int) interceptor.Funcs {
+			return interceptor.Funcs{
+				List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+					if err := c.List(ctx, list, opts...); err != nil {
+						return err
+					}
+					if n == 0 {
+						return nil
+					}
+					n--
+					cm := &corev1.ConfigMap{}
+					if err := c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "cm-0"}, cm); err != nil {
+						return err
+					}
+					cm.Data = map[string]string{"n": strconv.Itoa(n)}
+					return c.Update(ctx, cm)
+				},
+			}
+		}
+
+		snapshot := func(n, retries int) *FleetSnapshot {
+			mgr := fake.NewManagerBuilder().
+				WithCluster("one", configMaps(3)...).
+				WithCluster("two", configMaps(2)...).WithInterceptorFuncs("two", churn(n)).
+				Build()
+			fleet := NewFleet()
+			ctx, cancel := context.WithCancel(ctx)
+			DeferCleanup(cancel)
+			Expect(fleet.Engage(ctx, "one", mgr.FakeCluster("one"))).To(Succeed())
+			Expect(fleet.Engage(ctx, "two", mgr.FakeCluster("two"))).To(Succeed())
+
+			s, err := fleet.Snapshot(ctx, &corev1.ConfigMapList{}, retries)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(s.Items).To(HaveLen(5))
+			Expect(s.ResourceVersions).To(HaveKey("one"))
+			Expect(s.ResourceVersions).To(HaveKey("two"))
+			return s
+		}
+
+		It("is consistent without churn", func() {
+			Expect(snapshot(0, 0).Consistent()).To(BeTrue())
+		})
+
+		It("reports clusters that changed while listed", func() {
+			s := snapshot(2, 0)
+			Expect(s.Consistent()).To(BeFalse())
+			Expect(s.Churned).To(Equal([]string{"two"}))
+		})
+
+		It("retries clusters that changed while listed", func() {
+			Expect(snapshot(2, 2).Consistent()).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,171 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package client provides multi-cluster client helpers.
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	mcerrors "sigs.k8s.io/multicluster-runtime/pkg/errors"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+)
+
+// Identity is the identity a controller uses for requests to a cluster.
+type Identity struct {
+	// Impersonate is the user and groups to impersonate. If the user name
+	// is empty, no impersonation takes place.
+	Impersonate rest.ImpersonationConfig
+
+	// UserAgent overrides the user agent of the requests.
+	UserAgent string
+
+	// FieldManager is the field manager of writes. It is only applied to
+	// requests that do not set a field manager themselves.
+	FieldManager string
+}
+
+// IdentityFunc returns the identity of the named controller for requests to
+// a cluster. The context of the caller is passed, so that the identity can be
+// derived from request-scoped values like the tenant of a hub object.
+type IdentityFunc func(ctx context.Context, controllerName, clusterName string) (Identity, error)
+
+// DefaultIdentity returns an identity without impersonation that sets the
+// user agent to "<controller>/<cluster>" and the field manager to the
+// controller name.
+func DefaultIdentity(_ context.Context, controllerName, clusterName string) (Identity, error) {
+	return Identity{
+		UserAgent:    rest.DefaultKubernetesUserAgent() + " " + controllerName + "/" + clusterName,
+		FieldManager: controllerName,
+	}, nil
+}
+
+// NewWithIdentity returns a client for the cluster that sends requests with
+// the given identity. Reads are served from the cache of the cluster, which
+// is shared by all identities.
+func NewWithIdentity(cl cluster.Cluster, id Identity) (client.Client, error) {
+	cfg := rest.CopyConfig(cl.GetConfig())
+	if id.Impersonate.UserName != "" {
+		cfg.Impersonate = id.Impersonate
+	}
+	if id.UserAgent != "" {
+		cfg.UserAgent = id.UserAgent
+	}
+
+	c, err := client.New(cfg, client.Options{
+		Scheme: cl.GetScheme(),
+		Mapper: cl.GetRESTMapper(),
+		Cache:  &client.CacheOptions{Reader: cl.GetCache()},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+	if id.FieldManager != "" {
+		c = client.WithFieldOwner(c, id.FieldManager)
+	}
+	return c, nil
+}
+
+// IdentityClients hands out clients with the identity of a controller per
+// cluster. Clients are created on first use and reused as long as the
+// cluster and the identity do not change.
+type IdentityClients struct {
+	mgr            mcmanager.Manager
+	controllerName string
+	identity       IdentityFunc
+
+	lock    sync.Mutex
+	clients map[string]identityClients
+}
+
+type identityClients struct {
+	cluster cluster.Cluster
+	clients map[string]client.Client
+}
+
+// NewIdentityClients returns clients for the named controller with the
+// identity returned by fn. If fn is nil, DefaultIdentity is used.
+func NewIdentityClients(mgr mcmanager.Manager, controllerName string, fn IdentityFunc) *IdentityClients {
+	if fn == nil {
+		fn = DefaultIdentity
+	}
+	return &IdentityClients{
+		mgr:            mgr,
+		controllerName: controllerName,
+		identity:       fn,
+		clients:        map[string]identityClients{},
+	}
+}
+
+// Get returns a client for the given cluster with the identity of the
+// controller.
+func (c *IdentityClients) Get(ctx context.Context, clusterName string) (client.Client, error) {
+	cl, err := c.mgr.GetCluster(ctx, clusterName)
+	if err != nil {
+		return nil, mcerrors.New(clusterName, "get cluster", err)
+	}
+	id, err := c.identity(ctx, c.controllerName, clusterName)
+	if err != nil {
+		return nil, mcerrors.New(clusterName, "get identity", err)
+	}
+	key := id.key()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	entry, ok := c.clients[clusterName]
+	if !ok || entry.cluster != cl {
+		// the cluster is new or was re-engaged.
+		entry = identityClients{cluster: cl, clients: map[string]client.Client{}}
+		c.clients[clusterName] = entry
+	}
+	if cli, ok := entry.clients[key]; ok {
+		return cli, nil
+	}
+	cli, err := NewWithIdentity(cl, id)
+	if err != nil {
+		return nil, mcerrors.New(clusterName, "create client", err)
+	}
+	entry.clients[key] = cli
+	return cli, nil
+}
+
+func (id Identity) key() string {
+	groups := append([]string(nil), id.Impersonate.Groups...)
+	sort.Strings(groups)
+	extra := make([]string, 0, len(id.Impersonate.Extra))
+	for k, v := range id.Impersonate.Extra {
+		extra = append(extra, k+"="+strings.Join(v, ","))
+	}
+	sort.Strings(extra)
+	return strings.Join([]string{
+		id.Impersonate.UserName,
+		id.Impersonate.UID,
+		strings.Join(groups, ","),
+		strings.Join(extra, ";"),
+		id.UserAgent,
+		id.FieldManager,
+	}, "\x00")
+}
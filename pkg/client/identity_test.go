@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/fake"
+)
+
+type tenantKey struct{}
+
+var _ = Describe("IdentityClients", func() {
+	It("reuses clients per cluster and identity", func() {
+		mgr := fake.NewManagerBuilder().WithCluster("one").WithCluster("two").Build()
+		clients := NewIdentityClients(mgr, "ctrl", func(ctx context.Context, controllerName, clusterName string) (Identity, error) {
+			tenant, _ := ctx.Value(tenantKey{}).(string)
+			return Identity{
+				Impersonate:  rest.ImpersonationConfig{UserName: tenant, Groups: []string{"tenants"}},
+				FieldManager: controllerName + "@" + clusterName,
+			}, nil
+		})
+
+		ctxA := context.WithValue(context.Background(), tenantKey{}, "a")
+		ctxB := context.WithValue(context.Background(), tenantKey{}, "b")
+
+		a1, err := clients.Get(ctxA, "one")
+		Expect(err).NotTo(HaveOccurred())
+		a2, err := clients.Get(ctxA, "one")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(a2).To(BeIdenticalTo(a1))
+
+		b, err := clients.Get(ctxB, "one")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).NotTo(BeIdenticalTo(a1))
+
+		other, err := clients.Get(ctxA, "two")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(other).NotTo(BeIdenticalTo(a1))
+
+		_, err = clients.Get(ctxA, "three")
+		Expect(err).To(HaveOccurred())
+	})
+})
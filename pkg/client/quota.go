@@ -0,0 +1,189 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// QuotaExceededError is returned by clients of NewQuotaGuard for creations
+// that a ResourceQuota of the namespace would reject. Reconcilers should
+// requeue after RetryAfter instead of returning the error, so that full
+// namespaces across the fleet are not retried in tight loops:
+//
+//	var quotaErr *QuotaExceededError
+//	if errors.As(err, &quotaErr) {
+//		return reconcile.Result{RequeueAfter: quotaErr.RetryAfter}, nil
+//	}
+type QuotaExceededError struct {
+	// Cluster is the name of the cluster.
+	Cluster string
+
+	// Namespace is the namespace of the quota.
+	Namespace string
+
+	// Quota is the name of the exceeded ResourceQuota. It is empty if the
+	// API server rejected the creation.
+	Quota string
+
+	// Resource is the exceeded resource, e.g. "count/deployments.apps".
+	Resource corev1.ResourceName
+
+	// Used and Hard are the usage and limit of the resource.
+	Used, Hard resource.Quantity
+
+	// RetryAfter is the time after which the creation should be retried.
+	RetryAfter time.Duration
+
+	// Err is the error of the API server, if it rejected the creation.
+	Err error
+}
+
+func (e *QuotaExceededError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("quota exceeded in namespace %q of cluster %q: %v", e.Namespace, e.Cluster, e.Err)
+	}
+	return fmt.Sprintf("quota %q exceeded in namespace %q of cluster %q: %s used %s of %s", e.Quota, e.Namespace, e.Cluster, e.Resource, e.Used.String(), e.Hard.String())
+}
+
+func (e *QuotaExceededError) Unwrap() error {
+	return e.Err
+}
+
+// IsQuotaExceeded returns whether err is or wraps a QuotaExceededError.
+func IsQuotaExceeded(err error) bool {
+	var quotaErr *QuotaExceededError
+	return errors.As(err, &quotaErr)
+}
+
+// QuotaGuardOptions configure NewQuotaGuard.
+type QuotaGuardOptions struct {
+	// Reader reads the ResourceQuotas. Defaults to the guarded client, i.e.
+	// usually the cache of the cluster.
+	Reader client.Reader
+
+	// RetryAfter is the retry guidance of the returned errors. Defaults to
+	// one minute.
+	RetryAfter time.Duration
+}
+
+// NewQuotaGuard returns a client for the cluster with the given name that
+// checks the object count quotas of the ResourceQuotas in the namespace
+// before creating namespaced objects, and returns a QuotaExceededError
+// instead of sending a creation that would be rejected. Creations rejected
+// by the API server for exceeded quotas are reported as QuotaExceededError
+// as well.
+//
+// Object counts are checked for "count/<resource>.<group>" and the legacy
+// core names like "configmaps". Compute resources are left to the API
+// server.
+func NewQuotaGuard(c client.Client, clusterName string, opts QuotaGuardOptions) client.Client {
+	if opts.Reader == nil {
+		opts.Reader = c
+	}
+	if opts.RetryAfter <= 0 {
+		opts.RetryAfter = time.Minute
+	}
+	return &quotaClient{Client: c, cluster: clusterName, opts: opts}
+}
+
+type quotaClient struct {
+	client.Client
+	cluster string
+	opts    QuotaGuardOptions
+}
+
+// legacyCountResources are the core resources quotas count without the
+// "count/" prefix.
+var legacyCountResources = map[string]bool{
+	"pods":                   true,
+	"services":               true,
+	"secrets":                true,
+	"configmaps":             true,
+	"persistentvolumeclaims": true,
+	"replicationcontrollers": true,
+	"resourcequotas":         true,
+}
+
+func (c *quotaClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := c.check(ctx, obj); err != nil {
+		return err
+	}
+	err := c.Client.Create(ctx, obj, opts...)
+	if apierrors.IsForbidden(err) && strings.Contains(err.Error(), "exceeded quota") {
+		return &QuotaExceededError{Cluster: c.cluster, Namespace: obj.GetNamespace(), RetryAfter: c.opts.RetryAfter, Err: err}
+	}
+	return err
+}
+
+// check returns a QuotaExceededError if creating obj exceeds an object
+// count quota of its namespace.
+func (c *quotaClient) check(ctx context.Context, obj client.Object) error {
+	if obj.GetNamespace() == "" {
+		return nil
+	}
+	gvk, err := c.GroupVersionKindFor(obj)
+	if err != nil {
+		return err
+	}
+	mapping, err := c.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return err
+	}
+	names := []corev1.ResourceName{corev1.ResourceName("count/" + mapping.Resource.GroupResource().String())}
+	if mapping.Resource.Group == "" && legacyCountResources[mapping.Resource.Resource] {
+		names = append(names, corev1.ResourceName(mapping.Resource.Resource))
+	}
+
+	var quotas corev1.ResourceQuotaList
+	if err := c.opts.Reader.List(ctx, &quotas, client.InNamespace(obj.GetNamespace())); err != nil {
+		return fmt.Errorf("failed to list resource quotas: %w", err)
+	}
+	for _, q := range quotas.Items {
+		for _, name := range names {
+			hard, ok := q.Status.Hard[name]
+			if !ok {
+				continue
+			}
+			used := q.Status.Used[name]
+			next := used.DeepCopy()
+			next.Add(resource.MustParse("1"))
+			if next.Cmp(hard) > 0 {
+				return &QuotaExceededError{
+					Cluster:    c.cluster,
+					Namespace:  obj.GetNamespace(),
+					Quota:      q.Name,
+					Resource:   name,
+					Used:       used,
+					Hard:       hard,
+					RetryAfter: c.opts.RetryAfter,
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var _ = Describe("NewQuotaGuard", func() {
+	mapper := testrestmapper.TestOnlyStaticRESTMapper(scheme.Scheme)
+	quota := func(name string, hard, used corev1.ResourceList) *corev1.ResourceQuota {
+		return &corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "full", Name: name},
+			Status:     corev1.ResourceQuotaStatus{Hard: hard, Used: used},
+		}
+	}
+
+	It("rejects creations exceeding object count quotas", func(ctx context.Context) {
+		c := fakeclient.NewClientBuilder().WithRESTMapper(mapper).WithObjects(
+			quota("objects", corev1.ResourceList{
+				"configmaps":             resource.MustParse("2"),
+				"count/deployments.apps": resource.MustParse("5"),
+			}, corev1.ResourceList{
+				"configmaps":             resource.MustParse("2"),
+				"count/deployments.apps": resource.MustParse("4"),
+			}),
+		).Build()
+		guarded := NewQuotaGuard(c, "spoke", QuotaGuardOptions{RetryAfter: 5 * time.Minute})
+
+		err := guarded.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "full", Name: "a"}})
+		var quotaErr *QuotaExceededError
+		Expect(errors.As(err, &quotaErr)).To(BeTrue())
+		Expect(quotaErr.Cluster).To(Equal("spoke"))
+		Expect(quotaErr.Quota).To(Equal("objects"))
+		Expect(quotaErr.Resource).To(Equal(corev1.ResourceName("configmaps")))
+		Expect(quotaErr.RetryAfter).To(Equal(5 * time.Minute))
+		Expect(err).To(MatchError(ContainSubstring(`quota "objects" exceeded in namespace "full" of cluster "spoke"`)))
+		Expect(apierrors.IsNotFound(c.Get(ctx, client.ObjectKey{Namespace: "full", Name: "a"}, &corev1.ConfigMap{}))).To(BeTrue(), "not sent")
+
+		Expect(guarded.Create(ctx, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "full", Name: "a"}})).To(Succeed())
+		Expect(guarded.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "a"}})).To(Succeed())
+	})
+
+	It("reports creations rejected by the API server", func(ctx context.Context) {
+		c := fakeclient.NewClientBuilder().WithRESTMapper(mapper).WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(context.Context, client.WithWatch, client.Object, ...client.CreateOption) error {
+				return apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "a", errors.New("exceeded quota: compute, requested: cpu=1, used: cpu=4, limited: cpu=4"))
+			},
+		}).Build()
+		err := NewQuotaGuard(c, "spoke", QuotaGuardOptions{}).Create(ctx, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "full", Name: "a"}})
+		Expect(IsQuotaExceeded(err)).To(BeTrue())
+		Expect(apierrors.IsForbidden(err)).To(BeTrue())
+		var quotaErr *QuotaExceededError
+		Expect(errors.As(err, &quotaErr)).To(BeTrue())
+		Expect(quotaErr.RetryAfter).To(Equal(time.Minute))
+	})
+})
@@ -0,0 +1,148 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	mcerrors "sigs.k8s.io/multicluster-runtime/pkg/errors"
+)
+
+// FleetSnapshot is a best-effort consistent listing of the fleet.
+type FleetSnapshot struct {
+	// Items are the objects of all clusters, grouped by cluster in the
+	// order of the cluster names.
+	Items []ClusterObject
+
+	// ResourceVersions are the resource versions of the lists of the
+	// clusters at snapshot time.
+	ResourceVersions map[string]string
+
+	// Churned are the sorted names of the clusters whose objects still
+	// changed while they were listed after all retries. Their items are
+	// from the last listing.
+	Churned []string
+}
+
+// Consistent returns whether no cluster churned during the snapshot.
+func (s *FleetSnapshot) Consistent() bool {
+	return len(s.Churned) == 0
+}
+
+// Snapshot lists the kind of list in all engaged clusters, like ListAll
+// without a limit, and lists every cluster again to detect objects that
+// changed in between. Changed clusters are listed again up to retries times
+// until two listings match, and are reported as churned otherwise. This
+// does not make the snapshot atomic across clusters, but lets fleet-wide
+// invariant checks skip or retry data that was in flux.
+func (f *Fleet) Snapshot(ctx context.Context, list client.ObjectList, retries int, opts ...client.ListOption) (*FleetSnapshot, error) {
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	listOpts.Limit, listOpts.Continue = 0, ""
+
+	snapshot := &FleetSnapshot{ResourceVersions: map[string]string{}}
+	var errs []error
+	for _, name := range f.Clusters() {
+		f.lock.RLock()
+		cl, ok := f.clusters[name]
+		f.lock.RUnlock()
+		if !ok {
+			continue
+		}
+
+		objs, rv, err := listCluster(ctx, cl, list, listOpts)
+		if err != nil {
+			errs = append(errs, mcerrors.New(name, "list", err))
+			continue
+		}
+		churned := true
+		for attempt := 0; attempt <= retries; attempt++ {
+			again, againRV, err := listCluster(ctx, cl, list, listOpts)
+			if err != nil {
+				errs = append(errs, mcerrors.New(name, "list", err))
+				break
+			}
+			if sameVersions(objs, again) {
+				churned = false
+				break
+			}
+			objs, rv = again, againRV
+		}
+		if churned {
+			snapshot.Churned = append(snapshot.Churned, name)
+		}
+		snapshot.ResourceVersions[name] = rv
+		for _, obj := range objs {
+			snapshot.Items = append(snapshot.Items, ClusterObject{Cluster: name, Object: obj})
+		}
+	}
+	sort.Strings(snapshot.Churned)
+	return snapshot, mcerrors.NewAggregate(errs...)
+}
+
+// listCluster lists all pages of the cluster, returning the resource
+// version of the first page.
+func listCluster(ctx context.Context, cl cluster.Cluster, list client.ObjectList, opts *client.ListOptions) ([]client.Object, string, error) {
+	pageOpts := *opts
+	var objs []client.Object
+	var rv string
+	for {
+		l, ok := list.DeepCopyObject().(client.ObjectList)
+		if !ok {
+			return nil, "", fmt.Errorf("cannot copy list %T", list)
+		}
+		if err := cl.GetAPIReader().List(ctx, l, &pageOpts); err != nil {
+			return nil, "", err
+		}
+		items, err := extractList(l)
+		if err != nil {
+			return nil, "", err
+		}
+		objs = append(objs, items...)
+		if rv == "" {
+			rv = l.GetResourceVersion()
+		}
+		if pageOpts.Continue = l.GetContinue(); pageOpts.Continue == "" {
+			return objs, rv, nil
+		}
+	}
+}
+
+// sameVersions returns whether both listings contain the same objects with
+// the same resource versions.
+func sameVersions(a, b []client.Object) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	versions := make(map[types.NamespacedName]string, len(a))
+	for _, obj := range a {
+		versions[client.ObjectKeyFromObject(obj)] = obj.GetResourceVersion()
+	}
+	for _, obj := range b {
+		if rv, ok := versions[client.ObjectKeyFromObject(obj)]; !ok || rv != obj.GetResourceVersion() {
+			return false
+		}
+	}
+	return true
+}
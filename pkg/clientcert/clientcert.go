@@ -0,0 +1,165 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package clientcert sources the client certificates of clusters from
+// Secrets of the hub cluster, e.g. issued by cert-manager, and switches the
+// engaged clusters to rotated certificates without disengaging them.
+package clientcert
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	mccluster "sigs.k8s.io/multicluster-runtime/pkg/cluster"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+// ClusterAnnotation is set on a Secret to the name of the cluster whose
+// client certificate it holds. With cert-manager, set it through the
+// secretTemplate of the Certificate.
+const ClusterAnnotation = "multicluster.x-k8s.io/cluster"
+
+// Options are the options for the Rotator.
+type Options struct {
+	// Namespace is the namespace of the hub cluster holding the Secrets.
+	Namespace string
+
+	// UseCA also replaces the CA of the cluster config with the ca.crt of
+	// the Secret, if present.
+	UseCA bool
+}
+
+// Rotator keeps the client certificates of the engaged clusters in sync
+// with Secrets of type kubernetes.io/tls in the hub cluster. The clusters
+// must be created with mccluster.NewUpdatable, which is the default of
+// most providers, so that their watches survive the rotation.
+type Rotator struct {
+	mgr    mcmanager.Manager
+	opts   Options
+	log    logr.Logger
+	client client.Client
+	reader client.Reader
+}
+
+// New returns a new Rotator watching the Secrets through the local manager.
+func New(mgr mcmanager.Manager, opts Options) (*Rotator, error) {
+	if opts.Namespace == "" {
+		return nil, errors.New("namespace is required")
+	}
+	localMgr := mgr.GetLocalManager()
+	r := &Rotator{
+		mgr:    mgr,
+		opts:   opts,
+		log:    log.Log.WithName("clientcert-rotator"),
+		client: localMgr.GetClient(),
+		reader: localMgr.GetAPIReader(),
+	}
+	if err := builder.ControllerManagedBy(localMgr).
+		Named("clientcert-rotator").
+		For(&corev1.Secret{}, builder.WithPredicates(predicate.NewPredicateFuncs(r.isCertificate))).
+		Complete(r); err != nil {
+		return nil, fmt.Errorf("failed to create controller: %w", err)
+	}
+	return r, nil
+}
+
+func (r *Rotator) isCertificate(obj client.Object) bool {
+	_, ok := obj.GetAnnotations()[ClusterAnnotation]
+	return ok && obj.GetNamespace() == r.opts.Namespace
+}
+
+// Config returns a copy of cfg with the client certificate of the cluster
+// with the given name. Call it from the NewCluster function of a provider,
+// so that clusters are engaged with the current certificate.
+func (r *Rotator) Config(ctx context.Context, clusterName string, cfg *rest.Config) (*rest.Config, error) {
+	secrets := &corev1.SecretList{}
+	if err := r.reader.List(ctx, secrets, client.InNamespace(r.opts.Namespace)); err != nil {
+		return nil, err
+	}
+	for i := range secrets.Items {
+		if s := &secrets.Items[i]; s.Annotations[ClusterAnnotation] == clusterName {
+			return r.withCertificate(cfg, s)
+		}
+	}
+	return nil, fmt.Errorf("no client certificate secret for cluster %q in namespace %q", clusterName, r.opts.Namespace)
+}
+
+// Reconcile switches the cluster of the Secret to its certificate, if the
+// cluster is engaged and uses a different one.
+func (r *Rotator) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	secret := &corev1.Secret{}
+	if err := r.client.Get(ctx, req.NamespacedName, secret); apierrors.IsNotFound(err) {
+		return reconcile.Result{}, nil
+	} else if err != nil {
+		return reconcile.Result{}, err
+	}
+	clusterName := secret.Annotations[ClusterAnnotation]
+	log := r.log.WithValues("cluster", multicluster.EscapeClusterName(clusterName), "secret", req.NamespacedName)
+
+	cl, err := r.mgr.GetCluster(ctx, clusterName)
+	if errors.Is(err, multicluster.ErrClusterNotFound) {
+		return reconcile.Result{}, nil
+	} else if err != nil {
+		return reconcile.Result{}, err
+	}
+	current := cl.GetConfig()
+	if bytes.Equal(current.CertData, secret.Data[corev1.TLSCertKey]) && bytes.Equal(current.KeyData, secret.Data[corev1.TLSPrivateKeyKey]) {
+		return reconcile.Result{}, nil
+	}
+	cfg, err := r.withCertificate(current, secret)
+	if err != nil {
+		log.Error(err, "Invalid client certificate secret")
+		return reconcile.Result{}, nil
+	}
+	if err := r.mgr.UpdateCluster(ctx, clusterName, cfg); err != nil {
+		if errors.Is(err, mccluster.ErrUpdateNotSupported) {
+			log.Error(err, "Cannot rotate client certificate, the cluster was not created with mccluster.NewUpdatable")
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	log.Info("Rotated client certificate")
+	return reconcile.Result{}, nil
+}
+
+func (r *Rotator) withCertificate(cfg *rest.Config, secret *corev1.Secret) (*rest.Config, error) {
+	cert, key := secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey]
+	if len(cert) == 0 || len(key) == 0 {
+		return nil, fmt.Errorf("secret %s/%s has no %s or %s", secret.Namespace, secret.Name, corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+	}
+	cfg = rest.CopyConfig(cfg)
+	cfg.CertData, cfg.KeyData = cert, key
+	cfg.CertFile, cfg.KeyFile = "", ""
+	if ca := secret.Data["ca.crt"]; r.opts.UseCA && len(ca) > 0 {
+		cfg.CAData, cfg.CAFile = ca, ""
+	}
+	return cfg, nil
+}
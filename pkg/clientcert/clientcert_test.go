@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clientcert
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/fake"
+)
+
+type updatableCluster struct {
+	*fake.Cluster
+	cfg *rest.Config
+}
+
+func (c *updatableCluster) GetConfig() *rest.Config { return c.cfg }
+
+func (c *updatableCluster) UpdateConfig(cfg *rest.Config) error {
+	c.cfg = cfg
+	return nil
+}
+
+var _ = Describe("Rotator", func() {
+	ctx := context.Background()
+	secret := func(cert string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "certs", Name: "edge-client", Annotations: map[string]string{ClusterAnnotation: "edge"}},
+			Type:       corev1.SecretTypeTLS,
+			Data: map[string][]byte{
+				corev1.TLSCertKey:       []byte(cert),
+				corev1.TLSPrivateKeyKey: []byte("key"),
+				"ca.crt":                []byte("ca"),
+			},
+		}
+	}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "certs", Name: "edge-client"}}
+
+	It("switches engaged clusters to the rotated certificate", func() {
+		mgr := fake.NewManagerBuilder().WithCluster("", secret("cert-1")).Build()
+		r, err := New(mgr, Options{Namespace: "certs", UseCA: true})
+		Expect(err).NotTo(HaveOccurred())
+
+		cfg, err := r.Config(ctx, "edge", &rest.Config{Host: "https://edge:6443", TLSClientConfig: rest.TLSClientConfig{CertFile: "/old.crt"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.CertData).To(Equal([]byte("cert-1")))
+		Expect(cfg.CertFile).To(BeEmpty())
+		Expect(cfg.CAData).To(Equal([]byte("ca")))
+		_, err = r.Config(ctx, "other", &rest.Config{})
+		Expect(err).To(MatchError(ContainSubstring("no client certificate secret")))
+
+		cl := &updatableCluster{Cluster: fake.NewManagerBuilder().WithCluster("edge").Build().FakeCluster("edge"), cfg: cfg}
+		h, err := mgr.EngageCluster(ctx, "edge", cl)
+		Expect(err).NotTo(HaveOccurred())
+		defer h.Stop()
+		Eventually(h.Ready()).Should(BeClosed())
+
+		Expect(r.Reconcile(ctx, req)).To(Equal(reconcile.Result{}))
+		Expect(cl.cfg).To(BeIdenticalTo(cfg))
+
+		rotated := secret("cert-2")
+		local := mgr.GetLocalManager().GetClient()
+		Expect(local.Update(ctx, rotated)).To(Succeed())
+		Expect(r.Reconcile(ctx, req)).To(Equal(reconcile.Result{}))
+		Expect(cl.cfg.CertData).To(Equal([]byte("cert-2")))
+		Expect(cl.cfg.Host).To(Equal("https://edge:6443"))
+	})
+
+	It("ignores clusters that are not engaged", func() {
+		mgr := fake.NewManagerBuilder().WithCluster("", secret("cert-1")).Build()
+		r, err := New(mgr, Options{Namespace: "certs"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Reconcile(ctx, req)).To(Equal(reconcile.Result{}))
+	})
+})
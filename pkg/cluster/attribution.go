@@ -0,0 +1,123 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	mccontext "sigs.k8s.io/multicluster-runtime/pkg/context"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+// AttributionOptions configure how requests to the clusters are attributed
+// to the hub components sending them, e.g. in the audit logs and API
+// Priority and Fairness metrics of the spoke API servers.
+type AttributionOptions struct {
+	// Component is the name of the hub component, e.g. "fleet-manager".
+	// Defaults to the command name of rest.DefaultKubernetesUserAgent.
+	Component string
+
+	// Version is the version of the component. Defaults to the version of
+	// rest.DefaultKubernetesUserAgent.
+	Version string
+
+	// CorrelationHeader is the header carrying the correlation ID of a
+	// request, e.g. "X-Correlation-ID". If empty, no correlation ID is
+	// sent.
+	CorrelationHeader string
+
+	// CorrelationID returns the correlation ID of a request from its
+	// context, or the empty string if it has none. Defaults to the reconcile
+	// ID of the controller-runtime reconcile the request is sent from.
+	CorrelationID func(ctx context.Context) string
+}
+
+// WithAttribution returns a copy of cfg whose requests carry the user agent
+// "<component>/<version>/<cluster>/<controller>", and the correlation
+// header if configured. The controller is taken from the context of the
+// request, see context.WithController, and omitted outside of reconciles,
+// e.g. for the watches of the cache. The cluster name is escaped with
+// multicluster.EscapeClusterName.
+func WithAttribution(cfg *rest.Config, clusterName string, opts AttributionOptions) *rest.Config {
+	cfg = rest.CopyConfig(cfg)
+	if opts.Component == "" || opts.Version == "" {
+		// "<command>/<version> (<os>/<arch>) kubernetes/<commit>"
+		command, _, _ := strings.Cut(rest.DefaultKubernetesUserAgent(), " ")
+		component, version, _ := strings.Cut(command, "/")
+		if opts.Component == "" {
+			opts.Component = component
+		}
+		if opts.Version == "" {
+			opts.Version = version
+		}
+	}
+	if opts.CorrelationID == nil {
+		opts.CorrelationID = func(ctx context.Context) string {
+			return string(controller.ReconcileIDFromContext(ctx))
+		}
+	}
+	userAgent := opts.Component + "/" + opts.Version + "/" + multicluster.EscapeClusterName(clusterName)
+	cfg.UserAgent = userAgent
+
+	wrap := cfg.WrapTransport
+	cfg.WrapTransport = func(rt http.RoundTripper) http.RoundTripper {
+		if wrap != nil {
+			rt = wrap(rt)
+		}
+		return &attributionTransport{rt: rt, userAgent: userAgent, opts: opts}
+	}
+	return cfg
+}
+
+// attributionTransport adds the controller to the user agent, and the
+// correlation header to the requests.
+type attributionTransport struct {
+	rt        http.RoundTripper
+	userAgent string
+	opts      AttributionOptions
+}
+
+func (t *attributionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	controllerName, hasController := mccontext.ControllerFrom(ctx)
+	var id string
+	if t.opts.CorrelationHeader != "" {
+		id = t.opts.CorrelationID(ctx)
+	}
+	if !hasController && id == "" {
+		return t.rt.RoundTrip(req)
+	}
+	req = req.Clone(ctx)
+	if hasController {
+		req.Header.Set("User-Agent", t.userAgent+"/"+controllerName)
+	}
+	if id != "" {
+		req.Header.Set(t.opts.CorrelationHeader, id)
+	}
+	return t.rt.RoundTrip(req)
+}
+
+// WrappedRoundTripper implements utilnet.RoundTripperWrapper.
+func (t *attributionTransport) WrappedRoundTripper() http.RoundTripper {
+	return t.rt
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/client-go/rest"
+
+	mccontext "sigs.k8s.io/multicluster-runtime/pkg/context"
+)
+
+var _ = Describe("WithAttribution", func() {
+	It("attributes the requests to the component, cluster and controller", func(ctx context.Context) {
+		headers := make(chan http.Header, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			headers <- req.Header
+			_, _ = w.Write([]byte(`{"gitVersion":"v1.30.0"}`))
+		}))
+		defer server.Close()
+
+		base := &rest.Config{Host: server.URL}
+		cfg := WithAttribution(base, "Prod", AttributionOptions{
+			Component:         "fleet-manager",
+			Version:           "v1.2.3",
+			CorrelationHeader: "X-Correlation-ID",
+			CorrelationID: func(ctx context.Context) string {
+				id, _ := ctx.Value(correlationKey{}).(string)
+				return id
+			},
+		})
+		Expect(base.UserAgent).To(BeEmpty())
+		hc, err := rest.HTTPClientFor(cfg)
+		Expect(err).NotTo(HaveOccurred())
+
+		get := func(ctx context.Context) http.Header {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/version", nil)
+			Expect(err).NotTo(HaveOccurred())
+			resp, err := hc.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			_ = resp.Body.Close()
+			var h http.Header
+			Expect(headers).To(Receive(&h))
+			return h
+		}
+
+		h := get(ctx)
+		Expect(h.Get("User-Agent")).To(Equal("fleet-manager/v1.2.3/X50rod"))
+		Expect(h.Values("X-Correlation-ID")).To(BeEmpty())
+
+		h = get(context.WithValue(mccontext.WithController(ctx, "rollout"), correlationKey{}, "abc"))
+		Expect(h.Get("User-Agent")).To(Equal("fleet-manager/v1.2.3/X50rod/rollout"))
+		Expect(h.Get("X-Correlation-ID")).To(Equal("abc"))
+	})
+
+	It("defaults to the user agent of client-go", func() {
+		cfg := WithAttribution(&rest.Config{}, "prod", AttributionOptions{})
+		Expect(rest.DefaultKubernetesUserAgent()).To(HavePrefix(cfg.UserAgent[:len(cfg.UserAgent)-len("/prod")] + " "))
+	})
+})
+
+type correlationKey struct{}
@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/version"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+)
+
+// CapabilityProbe checks whether a cluster has a capability, e.g. whether
+// metrics-server is installed.
+type CapabilityProbe struct {
+	// Name is the name of the capability. It must be a valid label name,
+	// e.g. "metrics-server".
+	Name string
+
+	// Probe returns whether the cluster has the capability.
+	Probe func(ctx context.Context, cl cluster.Cluster) (bool, error)
+}
+
+// KindProbe returns a probe checking whether the cluster serves the kind,
+// e.g. metrics.k8s.io/v1beta1 PodMetrics for metrics-server.
+func KindProbe(name string, gvk schema.GroupVersionKind) CapabilityProbe {
+	return CapabilityProbe{Name: name, Probe: func(_ context.Context, cl cluster.Cluster) (bool, error) {
+		_, err := cl.GetRESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+		if meta.IsNoMatchError(err) {
+			return false, nil
+		}
+		return err == nil, err
+	}}
+}
+
+// ObjectsProbe returns a probe checking whether objects of the list's kind
+// exist in the cluster, e.g. StorageClasses. The API server is asked for a
+// single object.
+func ObjectsProbe(name string, list client.ObjectList) CapabilityProbe {
+	return CapabilityProbe{Name: name, Probe: func(ctx context.Context, cl cluster.Cluster) (bool, error) {
+		list := list.DeepCopyObject().(client.ObjectList)
+		if err := cl.GetAPIReader().List(ctx, list, client.Limit(1)); err != nil {
+			if meta.IsNoMatchError(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		return meta.LenList(list) > 0, nil
+	}}
+}
+
+// MinimumVersionProbe returns a probe checking whether the Kubernetes
+// version of the cluster is at least minVersion, e.g. "1.22" for
+// server-side apply.
+func MinimumVersionProbe(name, minVersion string) CapabilityProbe {
+	minimum, err := version.ParseGeneric(minVersion)
+	return CapabilityProbe{Name: name, Probe: func(_ context.Context, cl cluster.Cluster) (bool, error) {
+		if err != nil {
+			return false, fmt.Errorf("invalid minimum version: %w", err)
+		}
+		info, err := ServerVersion(cl)
+		if err != nil {
+			return false, err
+		}
+		v, err := ParseVersion(info)
+		if err != nil {
+			return false, err
+		}
+		return v.AtLeast(minimum), nil
+	}}
+}
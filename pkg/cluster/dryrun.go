@@ -0,0 +1,151 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	apimachineryversion "k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+)
+
+// NewDryRun returns a cluster that behaves like cl, except that all writes
+// of its client are sent to the API server as server-side dry-run and are
+// logged to log. Reads, caches and watches are not affected. It lets a new
+// controller version run against production clusters without mutating
+// them.
+func NewDryRun(cl cluster.Cluster, log logr.Logger) cluster.Cluster {
+	if IsDryRun(cl) {
+		return cl
+	}
+	return &dryRunCluster{
+		Cluster: cl,
+		client:  &loggingClient{Client: client.NewDryRunClient(cl.GetClient()), log: log},
+	}
+}
+
+// IsDryRun returns whether the cluster was created by NewDryRun.
+func IsDryRun(cl cluster.Cluster) bool {
+	_, ok := cl.(*dryRunCluster)
+	return ok
+}
+
+type dryRunCluster struct {
+	cluster.Cluster
+	client client.Client
+}
+
+var _ Updatable = &dryRunCluster{}
+
+func (c *dryRunCluster) GetClient() client.Client {
+	return c.client
+}
+
+// UpdateConfig updates the wrapped cluster.
+func (c *dryRunCluster) UpdateConfig(cfg *rest.Config) error {
+	return UpdateConfig(c.Cluster, cfg)
+}
+
+// ServerVersion returns the version of the wrapped cluster.
+func (c *dryRunCluster) ServerVersion() (*apimachineryversion.Info, error) {
+	return ServerVersion(c.Cluster)
+}
+
+// loggingClient logs the writes of a dry-run client.
+type loggingClient struct {
+	client.Client
+	log logr.Logger
+}
+
+func (c *loggingClient) logWrite(verb string, obj runtime.Object, keysAndValues ...any) {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if gvk, err := c.GroupVersionKindFor(obj); err == nil {
+		kind = gvk.Kind
+	}
+	if o, ok := obj.(client.Object); ok {
+		keysAndValues = append(keysAndValues, "namespace", o.GetNamespace(), "name", o.GetName())
+	}
+	c.log.Info("Dry-run "+verb, append([]any{"kind", kind}, keysAndValues...)...)
+}
+
+func (c *loggingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	c.logWrite("create", obj, "object", obj)
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *loggingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	c.logWrite("update", obj, "object", obj)
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *loggingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	c.logWrite("patch", obj, patchValues(obj, patch)...)
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *loggingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	c.logWrite("delete", obj)
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func (c *loggingClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	c.logWrite("delete collection", obj)
+	return c.Client.DeleteAllOf(ctx, obj, opts...)
+}
+
+func (c *loggingClient) Status() client.SubResourceWriter {
+	return c.SubResource("status")
+}
+
+func (c *loggingClient) SubResource(subResource string) client.SubResourceClient {
+	return &loggingSubResourceClient{SubResourceClient: c.Client.SubResource(subResource), client: c, subResource: subResource}
+}
+
+type loggingSubResourceClient struct {
+	client.SubResourceClient
+	client      *loggingClient
+	subResource string
+}
+
+func (c *loggingSubResourceClient) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	c.client.logWrite("create", obj, "subresource", c.subResource, "object", subResource)
+	return c.SubResourceClient.Create(ctx, obj, subResource, opts...)
+}
+
+func (c *loggingSubResourceClient) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	c.client.logWrite("update", obj, "subresource", c.subResource, "object", obj)
+	return c.SubResourceClient.Update(ctx, obj, opts...)
+}
+
+func (c *loggingSubResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	c.client.logWrite("patch", obj, append([]any{"subresource", c.subResource}, patchValues(obj, patch)...)...)
+	return c.SubResourceClient.Patch(ctx, obj, patch, opts...)
+}
+
+func patchValues(obj client.Object, patch client.Patch) []any {
+	data, err := patch.Data(obj)
+	if err != nil {
+		return []any{"patchType", patch.Type(), "patchError", err.Error()}
+	}
+	return []any{"patchType", patch.Type(), "patch", string(data)}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+)
+
+// LiveFallbackOptions are the options of WithLiveFallback.
+type LiveFallbackOptions struct {
+	// Window is the time after the creation of the cluster client during
+	// which cache misses fall back to live reads. Defaults to two minutes.
+	Window time.Duration
+
+	// QPS is the maximum queries per second of the live reads. Defaults
+	// to 5.
+	QPS float32
+
+	// Burst is the maximum burst of the live reads. Defaults to 10.
+	Burst int
+}
+
+// WithLiveFallback is a cluster option that makes Get of the cluster client
+// fall back to a rate limited live read when the object is not found in the
+// cache, during the window after the cluster was created. This smooths the
+// time after engagement in which caches are still warming and reconcilers
+// see spurious NotFound errors. It has no effect on live clusters.
+func WithLiveFallback(opts LiveFallbackOptions) cluster.Option {
+	if opts.Window <= 0 {
+		opts.Window = 2 * time.Minute
+	}
+	return func(o *cluster.Options) {
+		newClient := o.NewClient
+		if newClient == nil {
+			newClient = client.New
+		}
+		o.NewClient = func(cfg *rest.Config, clientOpts client.Options) (client.Client, error) {
+			c, err := newClient(cfg, clientOpts)
+			if err != nil {
+				return nil, err
+			}
+			if clientOpts.Cache == nil || clientOpts.Cache.Reader == nil {
+				return c, nil
+			}
+			if _, ok := clientOpts.Cache.Reader.(*liveCache); ok {
+				return c, nil
+			}
+			live, err := client.New(LiveOptions{QPS: opts.QPS, Burst: opts.Burst}.rateLimited(cfg), client.Options{
+				HTTPClient: clientOpts.HTTPClient,
+				Scheme:     clientOpts.Scheme,
+				Mapper:     clientOpts.Mapper,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to create live fallback client: %w", err)
+			}
+			return &fallbackClient{Client: c, live: live, until: time.Now().Add(opts.Window)}, nil
+		}
+	}
+}
+
+// fallbackClient is a client whose Get falls back to live reads on cache
+// misses until a deadline.
+type fallbackClient struct {
+	client.Client
+	live  client.Reader
+	until time.Time
+}
+
+// Get gets the object from the cache, and from the API server if it is not
+// found in the cache during the fallback window.
+func (c *fallbackClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	err := c.Client.Get(ctx, key, obj, opts...)
+	if !apierrors.IsNotFound(err) || time.Now().After(c.until) {
+		return err
+	}
+	return c.live.Get(ctx, key, obj, opts...)
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+)
+
+var _ = Describe("WithLiveFallback", func() {
+	var (
+		server *httptest.Server
+		reads  atomic.Int32
+	)
+
+	BeforeEach(func() {
+		reads.Store(0)
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			reads.Add(1)
+			if req.URL.Path != "/api/v1/namespaces/default/configmaps/warming" {
+				http.NotFound(w, req)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"namespace":"default","name":"warming"},"data":{"k":"live"}}`))
+		}))
+		DeferCleanup(server.Close)
+	})
+
+	// newClient returns the client of WithLiveFallback wrapping an empty
+	// cached client.
+	newClient := func(opts LiveFallbackOptions) client.Client {
+		o := &cluster.Options{NewClient: func(*rest.Config, client.Options) (client.Client, error) {
+			return clientfake.NewClientBuilder().Build(), nil
+		}}
+		WithLiveFallback(opts)(o)
+		c, err := o.NewClient(&rest.Config{Host: server.URL}, client.Options{
+			Scheme: scheme.Scheme,
+			Mapper: testrestmapper.TestOnlyStaticRESTMapper(scheme.Scheme),
+			Cache:  &client.CacheOptions{Reader: clientfake.NewClientBuilder().Build()},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return c
+	}
+
+	It("reads objects missing in the cache live while the cache warms up", func(ctx context.Context) {
+		c := newClient(LiveFallbackOptions{})
+		cm := &corev1.ConfigMap{}
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "warming"}, cm)).To(Succeed())
+		Expect(cm.Data).To(HaveKeyWithValue("k", "live"))
+
+		err := c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "missing"}, &corev1.ConfigMap{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		Expect(reads.Load()).To(BeEquivalentTo(2))
+	})
+
+	It("stops falling back after the window", func(ctx context.Context) {
+		c := newClient(LiveFallbackOptions{Window: time.Nanosecond})
+		time.Sleep(time.Millisecond)
+		err := c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "warming"}, &corev1.ConfigMap{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		Expect(reads.Load()).To(BeZero())
+	})
+
+	It("does not wrap live clusters", func() {
+		cl, err := NewLive(&rest.Config{Host: server.URL}, LiveOptions{}, WithLiveFallback(LiveFallbackOptions{}))
+		Expect(err).NotTo(HaveOccurred())
+		_, ok := cl.GetClient().(*fallbackClient)
+		Expect(ok).To(BeFalse())
+	})
+})
@@ -0,0 +1,157 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+)
+
+// KonnectivityOptions configure the tunnel through a konnectivity server
+// (apiserver-network-proxy) running in HTTP CONNECT mode. The konnectivity
+// agents in the clusters keep reverse tunnels to the server, through which
+// the connections to their API servers are dialed.
+type KonnectivityOptions struct {
+	// Address is the host:port of the konnectivity server.
+	Address string
+
+	// UDSName is the path of the unix socket of the konnectivity server. If
+	// set, Address is ignored and the connection is not encrypted.
+	UDSName string
+
+	// CAFile is the path of a PEM encoded bundle of the CAs the certificate
+	// of the server is verified with. If empty, the system roots are used.
+	CAFile string
+
+	// CertFile and KeyFile are the paths of the client certificate and key
+	// the manager authenticates with at the server. The files are read on
+	// every dial, so that rotated certificates are picked up.
+	CertFile string
+	KeyFile  string
+
+	// ServerName is the name the certificate of the server is verified
+	// against. Defaults to the host of Address.
+	ServerName string
+}
+
+// KonnectivityDialer returns a DialFunc opening a tunnel through the
+// konnectivity server of opts for every connection. Use it as the Dial of
+// ProxyOptions.
+func KonnectivityDialer(opts KonnectivityOptions) DialFunc {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		conn, err := opts.dialServer(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to dial konnectivity server: %w", err)
+		}
+		tunnel, err := connect(ctx, conn, address)
+		if err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("failed to open konnectivity tunnel to %s: %w", address, err)
+		}
+		return tunnel, nil
+	}
+}
+
+// dialServer dials the konnectivity server.
+func (o KonnectivityOptions) dialServer(ctx context.Context) (net.Conn, error) {
+	var d net.Dialer
+	if o.UDSName != "" {
+		return d.DialContext(ctx, "unix", o.UDSName)
+	}
+	if o.Address == "" {
+		return nil, errors.New("no konnectivity server address")
+	}
+	cfg, err := o.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	td := tls.Dialer{NetDialer: &d, Config: cfg}
+	return td.DialContext(ctx, "tcp", o.Address)
+}
+
+// tlsConfig returns the TLS config of the connections to the server.
+func (o KonnectivityOptions) tlsConfig() (*tls.Config, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12, ServerName: o.ServerName}
+	if cfg.ServerName == "" {
+		host, _, err := net.SplitHostPort(o.Address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid konnectivity server address: %w", err)
+		}
+		cfg.ServerName = host
+	}
+	if o.CAFile != "" {
+		data, err := os.ReadFile(o.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no valid CA certificates in %s", o.CAFile)
+		}
+	}
+	if o.CertFile != "" || o.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(o.CertFile, o.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
+// connect asks the server on conn to tunnel to address.
+func connect(ctx context.Context, conn net.Conn, address string) (net.Conn, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+		defer func() { _ = conn.SetDeadline(time.Time{}) }()
+	}
+	if _, err := fmt.Fprintf(conn, "CONNECT %s HTTP/1.1\r\nHost: %s\r\n\r\n", address, address); err != nil {
+		return nil, err
+	}
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, &http.Request{Method: http.MethodConnect})
+	if err != nil {
+		return nil, err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response %q", resp.Status)
+	}
+	if br.Buffered() > 0 {
+		return &bufferedConn{Conn: conn, r: br}, nil
+	}
+	return conn, nil
+}
+
+// bufferedConn reads the bytes the server sent along with its response
+// before reading from the connection.
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/client-go/rest"
+)
+
+var _ = Describe("KonnectivityDialer", func() {
+	// serve runs a konnectivity server in HTTP CONNECT mode on a unix
+	// socket, answering tunnel requests with status.
+	serve := func(status int) (string, chan string) {
+		path := filepath.Join(GinkgoT().TempDir(), "konnectivity.sock")
+		l, err := net.Listen("unix", path)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(l.Close)
+		targets := make(chan string, 1)
+		go func() {
+			for {
+				conn, err := l.Accept()
+				if err != nil {
+					return
+				}
+				go func() {
+					defer conn.Close()
+					req, err := http.ReadRequest(bufio.NewReader(conn))
+					if err != nil || req.Method != http.MethodConnect {
+						return
+					}
+					targets <- req.Host
+					if status != http.StatusOK {
+						_, _ = io.WriteString(conn, "HTTP/1.1 503 Service Unavailable\r\n\r\n")
+						return
+					}
+					backend, err := net.Dial("tcp", req.Host)
+					if err != nil {
+						return
+					}
+					defer backend.Close()
+					_, _ = io.WriteString(conn, "HTTP/1.1 200 Connection established\r\n\r\n")
+					go func() { _, _ = io.Copy(backend, conn) }()
+					_, _ = io.Copy(conn, backend)
+				}()
+			}
+		}()
+		return path, targets
+	}
+
+	It("tunnels the requests of a cluster through the server", func() {
+		apiserver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			_, _ = w.Write([]byte(`{"gitVersion":"v1.30.0"}`))
+		}))
+		defer apiserver.Close()
+		path, targets := serve(http.StatusOK)
+
+		cfg := WrapConfigForProxy(&rest.Config{Host: apiserver.URL}, "spoke", func(string) (ProxyOptions, bool) {
+			return ProxyOptions{Dial: KonnectivityDialer(KonnectivityOptions{UDSName: path})}, true
+		})
+		hc, err := rest.HTTPClientFor(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		resp, err := hc.Get(apiserver.URL + "/version")
+		Expect(err).NotTo(HaveOccurred())
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(body)).To(ContainSubstring("v1.30.0"))
+		Expect(targets).To(Receive(Equal(apiserver.Listener.Addr().String())))
+	})
+
+	It("fails when the server refuses the tunnel", func() {
+		path, _ := serve(http.StatusServiceUnavailable)
+		_, err := KonnectivityDialer(KonnectivityOptions{UDSName: path})(context.Background(), "tcp", "spoke:6443")
+		Expect(err).To(MatchError(ContainSubstring(`unexpected response "503 Service Unavailable"`)))
+	})
+
+	It("requires a server address", func() {
+		_, err := KonnectivityDialer(KonnectivityOptions{})(context.Background(), "tcp", "spoke:6443")
+		Expect(err).To(MatchError(ContainSubstring("no konnectivity server address")))
+	})
+})
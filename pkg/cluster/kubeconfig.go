@@ -0,0 +1,83 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	// register the OIDC auth-provider used by kubeconfigs of many clusters.
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc"
+)
+
+// ErrExecNotAllowed is returned for kubeconfigs with an exec credential
+// plugin whose command is not allowed.
+var ErrExecNotAllowed = errors.New("exec credential plugin not allowed")
+
+// KubeconfigOptions restricts the authentication of kubeconfigs read from
+// untrusted sources like secrets.
+type KubeconfigOptions struct {
+	// ExecAllowList are the commands of exec credential plugins that may be
+	// executed in the manager pod, e.g. "aws" or "/usr/local/bin/gke-gcloud-auth-plugin".
+	// They are compared verbatim with the command of the kubeconfig. If
+	// empty, kubeconfigs with exec credential plugins are rejected.
+	ExecAllowList []string
+}
+
+// RESTConfigFromKubeconfig returns the config of the current context of the
+// kubeconfig. Embedded certificates and tokens, OIDC auth-providers and
+// allowed exec credential plugins are supported. Plugins never run
+// interactively.
+func RESTConfigFromKubeconfig(data []byte, opts KubeconfigOptions) (*rest.Config, error) {
+	kc, err := clientcmd.Load(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+	if err := checkExec(kc, opts); err != nil {
+		return nil, err
+	}
+	cfg, err := clientcmd.NewDefaultClientConfig(*kc, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+	if cfg.ExecProvider != nil {
+		cfg.ExecProvider.InteractiveMode = clientcmdapi.NeverExecInteractiveMode
+	}
+	return cfg, nil
+}
+
+// checkExec returns an error if the user of the current context uses an
+// exec credential plugin that is not allowed.
+func checkExec(kc *clientcmdapi.Config, opts KubeconfigOptions) error {
+	kctx, ok := kc.Contexts[kc.CurrentContext]
+	if !ok {
+		return nil
+	}
+	auth, ok := kc.AuthInfos[kctx.AuthInfo]
+	if !ok || auth.Exec == nil {
+		return nil
+	}
+	if !slices.Contains(opts.ExecAllowList, auth.Exec.Command) {
+		return fmt.Errorf("%w: %q", ErrExecNotAllowed, auth.Exec.Command)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/client-go/rest"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+var _ = Describe("RESTConfigFromKubeconfig", func() {
+	kubeconfig := func(user string) []byte {
+		return []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: c
+  cluster:
+    server: https://cluster.example.com
+contexts:
+- name: c
+  context:
+    cluster: c
+    user: u
+current-context: c
+users:
+- name: u
+  user:
+` + user)
+	}
+	exec := kubeconfig(`    exec:
+      apiVersion: client.authentication.k8s.io/v1
+      command: aws
+      args: ["eks", "get-token", "--cluster-name", "c"]
+      interactiveMode: IfAvailable
+`)
+
+	It("reads embedded tokens", func() {
+		cfg, err := RESTConfigFromKubeconfig(kubeconfig("    token: secret\n"), KubeconfigOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Host).To(Equal("https://cluster.example.com"))
+		Expect(cfg.BearerToken).To(Equal("secret"))
+	})
+
+	It("rejects exec plugins that are not allowed", func() {
+		_, err := RESTConfigFromKubeconfig(exec, KubeconfigOptions{})
+		Expect(err).To(MatchError(ErrExecNotAllowed))
+		_, err = RESTConfigFromKubeconfig(exec, KubeconfigOptions{ExecAllowList: []string{"/usr/bin/aws"}})
+		Expect(err).To(MatchError(ErrExecNotAllowed))
+	})
+
+	It("runs allowed exec plugins non-interactively", func() {
+		cfg, err := RESTConfigFromKubeconfig(exec, KubeconfigOptions{ExecAllowList: []string{"aws"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.ExecProvider).NotTo(BeNil())
+		Expect(cfg.ExecProvider.Command).To(Equal("aws"))
+		Expect(cfg.ExecProvider.InteractiveMode).To(Equal(clientcmdapi.NeverExecInteractiveMode))
+	})
+
+	It("supports the OIDC auth-provider", func() {
+		cfg, err := RESTConfigFromKubeconfig(kubeconfig(`    auth-provider:
+      name: oidc
+      config:
+        idp-issuer-url: https://issuer.example.com
+        client-id: fleet
+        id-token: token
+`), KubeconfigOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.AuthProvider.Name).To(Equal("oidc"))
+		_, err = rest.TransportFor(cfg)
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cluster provides cluster implementations for multi-cluster
+// providers.
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+)
+
+// ErrCacheDisabled is returned by the cache of a live cluster when an
+// informer is requested.
+var ErrCacheDisabled = errors.New("cache is disabled for live cluster")
+
+// LiveOptions are options for live clusters.
+type LiveOptions struct {
+	// QPS is the maximum queries per second to the cluster. Defaults to 5.
+	QPS float32
+
+	// Burst is the maximum burst of queries to the cluster. Defaults to 10.
+	Burst int
+}
+
+// NewLive returns a cluster that does not hold informer caches. Reads of
+// its client and cache go directly to the API server, and all requests are
+// rate limited by opts. Watches on a live cluster are skipped by
+// multi-cluster sources, and field indexes are ignored.
+//
+// Live clusters are meant for very large fleets where holding caches for
+// every cluster is prohibitive, but occasional direct reads are fine.
+// Providers can engage some clusters as live clusters through their cluster
+// constructor hooks.
+func NewLive(cfg *rest.Config, opts LiveOptions, clusterOpts ...cluster.Option) (cluster.Cluster, error) {
+	return cluster.New(opts.rateLimited(cfg), append(clusterOpts, WithLive(opts))...)
+}
+
+// WithLive is a cluster option that replaces the cache of the cluster with
+// rate limited live reads, like NewLive. Unlike NewLive, writes are not
+// rate limited. It can be passed to providers that accept cluster options
+// to engage all their clusters as live clusters.
+func WithLive(opts LiveOptions) cluster.Option {
+	return func(o *cluster.Options) {
+		o.NewCache = func(cfg *rest.Config, cacheOpts cache.Options) (cache.Cache, error) {
+			return newLiveCache(opts.rateLimited(cfg), cacheOpts)
+		}
+	}
+}
+
+func (opts LiveOptions) rateLimited(cfg *rest.Config) *rest.Config {
+	cfg = rest.CopyConfig(cfg)
+	cfg.QPS = opts.QPS
+	if cfg.QPS == 0 {
+		cfg.QPS = 5
+	}
+	cfg.Burst = opts.Burst
+	if cfg.Burst == 0 {
+		cfg.Burst = 10
+	}
+	cfg.RateLimiter = nil
+	return cfg
+}
+
+// IsLive returns whether the cluster was created by NewLive or with WithLive.
+func IsLive(cl cluster.Cluster) bool {
+	_, ok := cl.GetCache().(*liveCache)
+	return ok
+}
+
+// liveCache is a cache.Cache that reads from the API server.
+type liveCache struct {
+	client.Reader
+}
+
+var _ cache.Cache = &liveCache{}
+
+func newLiveCache(cfg *rest.Config, opts cache.Options) (cache.Cache, error) {
+	c, err := client.New(cfg, client.Options{
+		HTTPClient: opts.HTTPClient,
+		Scheme:     opts.Scheme,
+		Mapper:     opts.Mapper,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create live client: %w", err)
+	}
+	return &liveCache{Reader: c}, nil
+}
+
+func (c *liveCache) GetInformer(_ context.Context, obj client.Object, _ ...cache.InformerGetOption) (cache.Informer, error) {
+	return nil, fmt.Errorf("cannot get informer for %T: %w", obj, ErrCacheDisabled)
+}
+
+func (c *liveCache) GetInformerForKind(_ context.Context, gvk schema.GroupVersionKind, _ ...cache.InformerGetOption) (cache.Informer, error) {
+	return nil, fmt.Errorf("cannot get informer for %s: %w", gvk, ErrCacheDisabled)
+}
+
+func (c *liveCache) RemoveInformer(context.Context, client.Object) error {
+	return nil
+}
+
+func (c *liveCache) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+func (c *liveCache) WaitForCacheSync(context.Context) bool {
+	return true
+}
+
+// IndexField is a no-op, the API server does not know about client-side
+// indexes.
+func (c *liveCache) IndexField(context.Context, client.Object, string, client.IndexerFunc) error {
+	return nil
+}
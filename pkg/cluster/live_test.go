@@ -0,0 +1,58 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+)
+
+var _ = Describe("NewLive", func() {
+	cfg := &rest.Config{Host: "https://127.0.0.1:1"}
+
+	It("creates a cluster without informers", func(ctx context.Context) {
+		cl, err := NewLive(cfg, LiveOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(IsLive(cl)).To(BeTrue())
+
+		_, err = cl.GetCache().GetInformer(ctx, &corev1.ConfigMap{})
+		Expect(err).To(MatchError(ErrCacheDisabled))
+		Expect(cl.GetCache().WaitForCacheSync(ctx)).To(BeTrue())
+		Expect(cl.GetFieldIndexer().IndexField(ctx, &corev1.ConfigMap{}, "data", func(client.Object) []string { return nil })).To(Succeed())
+	})
+
+	It("rate limits requests", func() {
+		cl, err := NewLive(cfg, LiveOptions{QPS: 1, Burst: 2})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cl.GetConfig().QPS).To(Equal(float32(1)))
+		Expect(cl.GetConfig().Burst).To(Equal(2))
+	})
+
+	It("does not report regular clusters as live", func() {
+		cl, err := cluster.New(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(IsLive(cl)).To(BeFalse())
+	})
+})
@@ -0,0 +1,187 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	mcmetrics "sigs.k8s.io/multicluster-runtime/pkg/metrics"
+)
+
+// Reasons of the reloads of REST mappers, see
+// mcmetrics.ClusterRESTMapperReloads.
+const (
+	RESTMapperReloadExpired = "Expired"
+	RESTMapperReloadMiss    = "Miss"
+	RESTMapperReloadReset   = "Reset"
+)
+
+// RESTMapperOptions control when the REST mapper of a cluster drops all
+// its mappings and reloads them from the API server. Between reloads, the
+// dynamic REST mapper of controller-runtime discovers unknown groups
+// lazily.
+type RESTMapperOptions struct {
+	// MaxAge is the age after which the mappings are reloaded on the next
+	// lookup, picking up changed and removed kinds. Useful for clusters
+	// whose CRDs churn. Zero keeps the mappings until a lookup misses.
+	MaxAge time.Duration
+
+	// MissReloadInterval is the minimum time between reloads after lookups
+	// of unknown kinds. Within the interval, lookups of unknown kinds fail
+	// without a reload, which protects stable clusters from repeated
+	// discovery when kinds they do not serve are looked up. Zero reloads
+	// on every miss.
+	MissReloadInterval time.Duration
+}
+
+// WithRESTMapperRefresh is a cluster option that reloads the REST mapper
+// of the cluster with the given name according to opts. The reloads are
+// counted in the multicluster_cluster_rest_mapper_reloads_total metric.
+func WithRESTMapperRefresh(clusterName string, opts RESTMapperOptions) cluster.Option {
+	return func(o *cluster.Options) {
+		newMapper := o.MapperProvider
+		if newMapper == nil {
+			newMapper = apiutil.NewDynamicRESTMapper
+		}
+		o.MapperProvider = func(cfg *rest.Config, httpClient *http.Client) (meta.RESTMapper, error) {
+			m, err := newMapper(cfg, httpClient)
+			if err != nil {
+				return nil, err
+			}
+			return &refreshingMapper{
+				newMapper: func() (meta.RESTMapper, error) { return newMapper(cfg, httpClient) },
+				opts:      opts,
+				label:     mcmetrics.ClusterLabel(clusterName),
+				now:       time.Now,
+				mapper:    m,
+				loaded:    time.Now(),
+			}, nil
+		}
+	}
+}
+
+var _ meta.ResettableRESTMapper = &refreshingMapper{}
+
+// refreshingMapper replaces its mapper with a new one according to its
+// options.
+type refreshingMapper struct {
+	newMapper func() (meta.RESTMapper, error)
+	opts      RESTMapperOptions
+	label     string
+	now       func() time.Time
+
+	lock   sync.RWMutex
+	mapper meta.RESTMapper
+	loaded time.Time
+}
+
+// current returns the mapper, reloading it if it expired.
+func (m *refreshingMapper) current() meta.RESTMapper {
+	m.lock.RLock()
+	mapper, loaded := m.mapper, m.loaded
+	m.lock.RUnlock()
+	if m.opts.MaxAge > 0 && m.now().Sub(loaded) > m.opts.MaxAge {
+		if reloaded, ok := m.reload(loaded, RESTMapperReloadExpired); ok {
+			return reloaded
+		}
+	}
+	return mapper
+}
+
+// reload replaces the mapper loaded at the given time, unless it was
+// replaced concurrently. It returns the new mapper, or false if creating
+// it failed.
+func (m *refreshingMapper) reload(loaded time.Time, reason string) (meta.RESTMapper, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if !m.loaded.Equal(loaded) {
+		return m.mapper, true
+	}
+	mapper, err := m.newMapper()
+	if err != nil {
+		return nil, false
+	}
+	m.mapper, m.loaded = mapper, m.now()
+	mcmetrics.ClusterRESTMapperReloads.WithLabelValues(m.label, reason).Inc()
+	return mapper, true
+}
+
+// lookup calls fn with the mapper, and again with a reloaded one if the
+// lookup missed and the miss reload interval passed.
+func lookup[T any](m *refreshingMapper, fn func(meta.RESTMapper) (T, error)) (T, error) {
+	mapper := m.current()
+	v, err := fn(mapper)
+	if err == nil || !meta.IsNoMatchError(err) {
+		return v, err
+	}
+	m.lock.RLock()
+	loaded := m.loaded
+	m.lock.RUnlock()
+	if m.now().Sub(loaded) < m.opts.MissReloadInterval {
+		return v, err
+	}
+	reloaded, ok := m.reload(loaded, RESTMapperReloadMiss)
+	if !ok {
+		return v, err
+	}
+	return fn(reloaded)
+}
+
+func (m *refreshingMapper) KindFor(resource schema.GroupVersionResource) (schema.GroupVersionKind, error) {
+	return lookup(m, func(r meta.RESTMapper) (schema.GroupVersionKind, error) { return r.KindFor(resource) })
+}
+
+func (m *refreshingMapper) KindsFor(resource schema.GroupVersionResource) ([]schema.GroupVersionKind, error) {
+	return lookup(m, func(r meta.RESTMapper) ([]schema.GroupVersionKind, error) { return r.KindsFor(resource) })
+}
+
+func (m *refreshingMapper) ResourceFor(input schema.GroupVersionResource) (schema.GroupVersionResource, error) {
+	return lookup(m, func(r meta.RESTMapper) (schema.GroupVersionResource, error) { return r.ResourceFor(input) })
+}
+
+func (m *refreshingMapper) ResourcesFor(input schema.GroupVersionResource) ([]schema.GroupVersionResource, error) {
+	return lookup(m, func(r meta.RESTMapper) ([]schema.GroupVersionResource, error) { return r.ResourcesFor(input) })
+}
+
+func (m *refreshingMapper) RESTMapping(gk schema.GroupKind, versions ...string) (*meta.RESTMapping, error) {
+	return lookup(m, func(r meta.RESTMapper) (*meta.RESTMapping, error) { return r.RESTMapping(gk, versions...) })
+}
+
+func (m *refreshingMapper) RESTMappings(gk schema.GroupKind, versions ...string) ([]*meta.RESTMapping, error) {
+	return lookup(m, func(r meta.RESTMapper) ([]*meta.RESTMapping, error) { return r.RESTMappings(gk, versions...) })
+}
+
+func (m *refreshingMapper) ResourceSingularizer(resource string) (string, error) {
+	return lookup(m, func(r meta.RESTMapper) (string, error) { return r.ResourceSingularizer(resource) })
+}
+
+// Reset reloads the mapper, e.g. when a client observed a missing kind.
+func (m *refreshingMapper) Reset() {
+	m.lock.RLock()
+	loaded := m.loaded
+	m.lock.RUnlock()
+	m.reload(loaded, RESTMapperReloadReset)
+}
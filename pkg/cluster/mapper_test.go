@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	mcmetrics "sigs.k8s.io/multicluster-runtime/pkg/metrics"
+)
+
+var _ = Describe("WithRESTMapperRefresh", func() {
+	widget := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+
+	var (
+		served  []schema.GroupVersionKind
+		created int
+		now     time.Time
+	)
+
+	newMapper := func(name string, opts RESTMapperOptions) *refreshingMapper {
+		served, created, now = nil, 0, time.Now()
+		o := &cluster.Options{MapperProvider: func(*rest.Config, *http.Client) (meta.RESTMapper, error) {
+			created++
+			m := meta.NewDefaultRESTMapper(nil)
+			for _, gvk := range served {
+				m.Add(gvk, meta.RESTScopeNamespace)
+			}
+			return m, nil
+		}}
+		WithRESTMapperRefresh(name, opts)(o)
+		m, err := o.MapperProvider(&rest.Config{}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		rm := m.(*refreshingMapper)
+		rm.now = func() time.Time { return now }
+		rm.loaded = now
+		return rm
+	}
+	reloads := func(name, reason string) float64 {
+		return testutil.ToFloat64(mcmetrics.ClusterRESTMapperReloads.WithLabelValues(name, reason))
+	}
+
+	It("reloads on misses", func() {
+		m := newMapper("miss", RESTMapperOptions{})
+		served = []schema.GroupVersionKind{widget}
+		mapping, err := m.RESTMapping(widget.GroupKind(), widget.Version)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mapping.GroupVersionKind).To(Equal(widget))
+		Expect(created).To(Equal(2))
+		Expect(reloads("miss", RESTMapperReloadMiss)).To(Equal(1.0))
+
+		_, err = m.RESTMapping(widget.GroupKind(), widget.Version)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created).To(Equal(2), "hits do not reload")
+	})
+
+	It("limits the reloads on misses", func() {
+		m := newMapper("lazy", RESTMapperOptions{MissReloadInterval: time.Minute})
+		served = []schema.GroupVersionKind{widget}
+		_, err := m.RESTMapping(widget.GroupKind(), widget.Version)
+		Expect(meta.IsNoMatchError(err)).To(BeTrue())
+		Expect(created).To(Equal(1))
+
+		now = now.Add(2 * time.Minute)
+		_, err = m.RESTMapping(widget.GroupKind(), widget.Version)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reloads("lazy", RESTMapperReloadMiss)).To(Equal(1.0))
+	})
+
+	It("reloads expired mappings", func() {
+		m := newMapper("aggressive", RESTMapperOptions{MaxAge: time.Minute, MissReloadInterval: time.Hour})
+		served = []schema.GroupVersionKind{widget}
+		_, err := m.KindFor(schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"})
+		Expect(meta.IsNoMatchError(err)).To(BeTrue())
+
+		now = now.Add(2 * time.Minute)
+		gvk, err := m.KindFor(schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gvk).To(Equal(widget))
+		Expect(reloads("aggressive", RESTMapperReloadExpired)).To(Equal(1.0))
+	})
+})
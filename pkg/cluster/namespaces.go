@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+// NamespacesFunc returns the namespaces the cache of a cluster is
+// restricted to, determined per cluster from its name and metadata, e.g.
+// the tenant namespace of a spoke. Nil or empty means all namespaces.
+type NamespacesFunc func(clusterName string, md multicluster.Metadata) []string
+
+// NamespacesFromMetadata returns a NamespacesFunc reading a comma-separated
+// list of namespaces from the label or, if not set, the annotation with the
+// given key.
+func NamespacesFromMetadata(key string) NamespacesFunc {
+	return func(_ string, md multicluster.Metadata) []string {
+		value, ok := md.Labels[key]
+		if !ok {
+			value = md.Annotations[key]
+		}
+		var namespaces []string
+		for _, ns := range strings.Split(value, ",") {
+			if ns = strings.TrimSpace(ns); ns != "" {
+				namespaces = append(namespaces, ns)
+			}
+		}
+		slices.Sort(namespaces)
+		return slices.Compact(namespaces)
+	}
+}
+
+// WithCacheNamespaces restricts the cache of the cluster to the namespaces,
+// overriding the namespaces of options applied before. Without namespaces,
+// it does nothing.
+func WithCacheNamespaces(namespaces ...string) cluster.Option {
+	return func(o *cluster.Options) {
+		if len(namespaces) == 0 {
+			return
+		}
+		o.Cache.DefaultNamespaces = make(map[string]cache.Config, len(namespaces))
+		for _, ns := range namespaces {
+			o.Cache.DefaultNamespaces[ns] = cache.Config{}
+		}
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+var _ = Describe("Cache namespaces", func() {
+	It("reads the namespaces from the label or annotation", func() {
+		fn := NamespacesFromMetadata("example.com/namespaces")
+		Expect(fn("spoke", multicluster.Metadata{
+			Labels:      map[string]string{"example.com/namespaces": "tenant-b, tenant-a,,tenant-b"},
+			Annotations: map[string]string{"example.com/namespaces": "ignored"},
+		})).To(Equal([]string{"tenant-a", "tenant-b"}))
+		Expect(fn("spoke", multicluster.Metadata{
+			Annotations: map[string]string{"example.com/namespaces": "tenant-c"},
+		})).To(Equal([]string{"tenant-c"}))
+		Expect(fn("spoke", multicluster.Metadata{})).To(BeEmpty())
+	})
+
+	It("restricts the cache to the namespaces", func() {
+		opts := &cluster.Options{}
+		opts.Cache.DefaultNamespaces = map[string]cache.Config{"default": {}}
+		WithCacheNamespaces()(opts)
+		Expect(opts.Cache.DefaultNamespaces).To(HaveKey("default"))
+
+		WithCacheNamespaces("tenant-a", "tenant-b")(opts)
+		Expect(opts.Cache.DefaultNamespaces).To(Equal(map[string]cache.Config{"tenant-a": {}, "tenant-b": {}}))
+	})
+})
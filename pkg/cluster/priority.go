@@ -0,0 +1,141 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/transport"
+
+	mccontext "sigs.k8s.io/multicluster-runtime/pkg/context"
+)
+
+// PriorityOptions configure how the low-priority requests to a cluster are
+// marked, such that a FlowSchema of API Priority and Fairness on the API
+// server of the cluster can assign them to a low priority level, and
+// interactive users are not starved by fleet controllers.
+//
+// FlowSchemas match users, groups and service accounts, so Impersonate is
+// needed for them to see a difference. The identity of the clusters must be
+// allowed to impersonate the user.
+type PriorityOptions struct {
+	// Impersonate is the user and groups low-priority requests are sent
+	// as. If the user name is empty, no impersonation takes place.
+	Impersonate rest.ImpersonationConfig
+
+	// Header is a header set to "low" on low-priority requests, e.g. for
+	// proxies and audit policies. If empty, no header is set.
+	Header string
+
+	// IsLowPriority classifies the requests. Defaults to DefaultLowPriority.
+	IsLowPriority func(req *http.Request) bool
+}
+
+// PriorityFunc returns the priority options of the cluster with the given
+// name, or false if its requests are not classified.
+type PriorityFunc func(clusterName string) (PriorityOptions, bool)
+
+// DefaultLowPriority classifies requests as low priority if their context
+// is marked with context.WithLowPriority or context.WithInitialSync, or if
+// they are paginated LISTs sent outside of reconciles, i.e. the warm-up and
+// relists of informers.
+func DefaultLowPriority(req *http.Request) bool {
+	ctx := req.Context()
+	if mccontext.IsLowPriority(ctx) || mccontext.IsInitialSync(ctx) {
+		return true
+	}
+	if _, ok := mccontext.ControllerFrom(ctx); ok || req.Method != http.MethodGet {
+		return false
+	}
+	q := req.URL.Query()
+	if w := q.Get("watch"); w == "true" || w == "1" {
+		return false
+	}
+	return q.Has("limit")
+}
+
+// WrapConfigForPriority returns a copy of cfg marking the low-priority
+// requests to the cluster with the given name, if priority returns options
+// for it.
+func WrapConfigForPriority(cfg *rest.Config, clusterName string, priority PriorityFunc) *rest.Config {
+	if priority == nil {
+		return cfg
+	}
+	opts, ok := priority(clusterName)
+	if !ok || (opts.Impersonate.UserName == "" && opts.Header == "") {
+		return cfg
+	}
+	if opts.IsLowPriority == nil {
+		opts.IsLowPriority = DefaultLowPriority
+	}
+	cfg = rest.CopyConfig(cfg)
+	wrap := cfg.WrapTransport
+	cfg.WrapTransport = func(rt http.RoundTripper) http.RoundTripper {
+		if wrap != nil {
+			rt = wrap(rt)
+		}
+		return &priorityTransport{rt: rt, opts: opts}
+	}
+	return cfg
+}
+
+// priorityTransport marks low-priority requests.
+type priorityTransport struct {
+	rt   http.RoundTripper
+	opts PriorityOptions
+}
+
+func (t *priorityTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.opts.IsLowPriority(req) {
+		return t.rt.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	if t.opts.Header != "" {
+		req.Header.Set(t.opts.Header, "low")
+	}
+	if imp := t.opts.Impersonate; imp.UserName != "" {
+		// replace an impersonation of the config.
+		for k := range req.Header {
+			if strings.HasPrefix(k, transport.ImpersonateUserExtraHeaderPrefix) {
+				req.Header.Del(k)
+			}
+		}
+		req.Header.Set(transport.ImpersonateUserHeader, imp.UserName)
+		req.Header.Del(transport.ImpersonateUIDHeader)
+		if imp.UID != "" {
+			req.Header.Set(transport.ImpersonateUIDHeader, imp.UID)
+		}
+		req.Header.Del(transport.ImpersonateGroupHeader)
+		for _, g := range imp.Groups {
+			req.Header.Add(transport.ImpersonateGroupHeader, g)
+		}
+		for k, vs := range imp.Extra {
+			for _, v := range vs {
+				req.Header.Add(transport.ImpersonateUserExtraHeaderPrefix+url.PathEscape(k), v)
+			}
+		}
+	}
+	return t.rt.RoundTrip(req)
+}
+
+// WrappedRoundTripper implements utilnet.RoundTripperWrapper.
+func (t *priorityTransport) WrappedRoundTripper() http.RoundTripper {
+	return t.rt
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/client-go/rest"
+
+	mccontext "sigs.k8s.io/multicluster-runtime/pkg/context"
+)
+
+var _ = Describe("WrapConfigForPriority", func() {
+	It("marks low-priority requests", func(ctx context.Context) {
+		headers := make(chan http.Header, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			headers <- req.Header
+			_, _ = w.Write([]byte(`{}`))
+		}))
+		defer server.Close()
+
+		base := &rest.Config{Host: server.URL, Impersonate: rest.ImpersonationConfig{UserName: "tenant", Groups: []string{"tenants"}}}
+		Expect(WrapConfigForPriority(base, "spoke", func(string) (PriorityOptions, bool) { return PriorityOptions{}, false })).To(BeIdenticalTo(base))
+		cfg := WrapConfigForPriority(base, "spoke", func(string) (PriorityOptions, bool) {
+			return PriorityOptions{
+				Impersonate: rest.ImpersonationConfig{UserName: "fleet-background", Groups: []string{"fleet:low-priority"}},
+				Header:      "X-Fleet-Priority",
+			}, true
+		})
+		hc, err := rest.HTTPClientFor(cfg)
+		Expect(err).NotTo(HaveOccurred())
+
+		get := func(ctx context.Context, path string) http.Header {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+path, nil)
+			Expect(err).NotTo(HaveOccurred())
+			resp, err := hc.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			_ = resp.Body.Close()
+			var h http.Header
+			Expect(headers).To(Receive(&h))
+			return h
+		}
+
+		By("listing for a cache")
+		h := get(ctx, "/api/v1/configmaps?limit=500&resourceVersion=0")
+		Expect(h.Get("X-Fleet-Priority")).To(Equal("low"))
+		Expect(h.Get("Impersonate-User")).To(Equal("fleet-background"))
+		Expect(h.Values("Impersonate-Group")).To(Equal([]string{"fleet:low-priority"}))
+
+		By("watching for a cache")
+		h = get(ctx, "/api/v1/configmaps?watch=true&limit=500")
+		Expect(h.Values("X-Fleet-Priority")).To(BeEmpty())
+		Expect(h.Get("Impersonate-User")).To(Equal("tenant"))
+
+		By("listing in a reconcile")
+		h = get(mccontext.WithController(ctx, "rollout"), "/api/v1/configmaps?limit=500")
+		Expect(h.Values("X-Fleet-Priority")).To(BeEmpty())
+		Expect(h.Values("Impersonate-Group")).To(Equal([]string{"tenants"}))
+
+		By("marking a reconcile as non-urgent")
+		h = get(mccontext.WithLowPriority(mccontext.WithController(ctx, "rollout")), "/api/v1/namespaces/default/configmaps/a")
+		Expect(h.Get("X-Fleet-Priority")).To(Equal("low"))
+		Expect(h.Get("Impersonate-User")).To(Equal("fleet-background"))
+	})
+})
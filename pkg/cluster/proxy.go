@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+
+	"k8s.io/client-go/rest"
+)
+
+// DialFunc dials a connection to the API server of a cluster.
+type DialFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+// ProxyOptions configure how the API server of a cluster is reached.
+type ProxyOptions struct {
+	// URL is the URL of an HTTP CONNECT proxy with scheme http or https, or
+	// of a SOCKS5 proxy with scheme socks5.
+	URL *url.URL
+
+	// Dial dials the connections to the API server, or to the proxy if URL
+	// is set, e.g. through a konnectivity tunnel.
+	Dial DialFunc
+}
+
+// ProxyFunc returns the proxy options of the cluster with the given name,
+// or false if the cluster is reached directly. Providers call it when
+// building the config of a cluster.
+type ProxyFunc func(clusterName string) (ProxyOptions, bool)
+
+// StaticProxy returns a ProxyFunc reaching all clusters through the proxy at
+// u.
+func StaticProxy(u *url.URL) ProxyFunc {
+	return func(string) (ProxyOptions, bool) {
+		return ProxyOptions{URL: u}, true
+	}
+}
+
+// ParseProxyURL parses the URL of a proxy, rejecting unsupported schemes.
+func ParseProxyURL(s string) (*url.URL, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q, expected http, https or socks5", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("proxy URL %q has no host", s)
+	}
+	return u, nil
+}
+
+// WrapConfigForProxy returns a copy of cfg connecting through the proxy of
+// the cluster with the given name, if proxy returns one. The clients and
+// informers of clusters built from the returned config use the proxy.
+func WrapConfigForProxy(cfg *rest.Config, clusterName string, proxy ProxyFunc) *rest.Config {
+	if proxy == nil {
+		return cfg
+	}
+	opts, ok := proxy(clusterName)
+	if !ok {
+		return cfg
+	}
+	cfg = rest.CopyConfig(cfg)
+	if opts.URL != nil {
+		cfg.Proxy = http.ProxyURL(opts.URL)
+	}
+	if opts.Dial != nil {
+		cfg.Dial = opts.Dial
+	}
+	return cfg
+}
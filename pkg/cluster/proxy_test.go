@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/client-go/rest"
+)
+
+var _ = Describe("WrapConfigForProxy", func() {
+	It("sends the requests of proxied clusters through the proxy", func() {
+		hosts := make(chan string, 1)
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			hosts <- req.URL.Host
+			_, _ = w.Write([]byte(`{"gitVersion":"v1.30.0"}`))
+		}))
+		defer proxy.Close()
+		u, err := ParseProxyURL(proxy.URL)
+		Expect(err).NotTo(HaveOccurred())
+
+		base := &rest.Config{Host: "http://spoke.invalid"}
+		Expect(WrapConfigForProxy(base, "direct", func(string) (ProxyOptions, bool) { return ProxyOptions{}, false })).To(BeIdenticalTo(base))
+
+		cfg := WrapConfigForProxy(base, "spoke", StaticProxy(u))
+		Expect(base.Proxy).To(BeNil())
+		hc, err := rest.HTTPClientFor(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		resp, err := hc.Get("http://spoke.invalid/version")
+		Expect(err).NotTo(HaveOccurred())
+		_ = resp.Body.Close()
+		Expect(hosts).To(Receive(Equal("spoke.invalid")))
+	})
+
+	It("dials through the given dialer", func() {
+		dialed := make(chan string, 1)
+		dial := func(ctx context.Context, network, address string) (net.Conn, error) {
+			dialed <- address
+			return nil, &net.OpError{Op: "dial", Net: network, Err: context.Canceled}
+		}
+		cfg := WrapConfigForProxy(&rest.Config{Host: "http://spoke.invalid:6443"}, "spoke", func(string) (ProxyOptions, bool) {
+			return ProxyOptions{Dial: dial}, true
+		})
+		hc, err := rest.HTTPClientFor(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = hc.Get("http://spoke.invalid:6443/version")
+		Expect(err).To(HaveOccurred())
+		Expect(dialed).To(Receive(Equal("spoke.invalid:6443")))
+	})
+
+	It("rejects unsupported proxy URLs", func() {
+		_, err := ParseProxyURL("ftp://proxy:21")
+		Expect(err).To(MatchError(ContainSubstring("unsupported proxy scheme")))
+		_, err = ParseProxyURL("socks5://")
+		Expect(err).To(MatchError(ContainSubstring("no host")))
+		u, err := ParseProxyURL("socks5://proxy:1080")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(u).To(Equal(&url.URL{Scheme: "socks5", Host: "proxy:1080"}))
+	})
+})
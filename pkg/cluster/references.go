@@ -0,0 +1,292 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+)
+
+// InformerReferences are the references to an informer of the cache of a
+// cluster.
+type InformerReferences struct {
+	// GroupVersionKind is the kind of the informer.
+	GroupVersionKind schema.GroupVersionKind `json:"groupVersionKind"`
+
+	// Type is the Go type of the informed objects. Typed, unstructured and
+	// metadata-only informers of the same kind are distinct.
+	Type string `json:"type"`
+
+	// References is the number of contexts the informer was requested with
+	// that are not done yet.
+	References int `json:"references"`
+
+	// Pinned is whether the informer serves reads or indexes of the cache,
+	// or was requested with a context that is never done. Pinned informers
+	// are never removed automatically.
+	Pinned bool `json:"pinned"`
+}
+
+// WithInformerReferences is a cluster option that counts the references to
+// the informers of the cache, and removes informers that are no longer
+// referenced, e.g. when a controller is removed at runtime or a dynamic
+// watch stops, freeing their memory.
+//
+// Every GetInformer or GetInformerForKind call references the informer
+// until the context passed to it is done, e.g. until the source of a
+// controller stops. Informers serving reads or indexes of the cache are
+// pinned, as are informers requested with a context that is never done.
+// The references can be read with GetInformerReferences. It has no effect
+// on live clusters.
+func WithInformerReferences() cluster.Option {
+	return func(o *cluster.Options) {
+		newCache := o.NewCache
+		if newCache == nil {
+			newCache = cache.New
+		}
+		o.NewCache = func(cfg *rest.Config, opts cache.Options) (cache.Cache, error) {
+			c, err := newCache(cfg, opts)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := c.(*liveCache); ok {
+				return c, nil
+			}
+			return &refCountingCache{
+				Cache:     c,
+				scheme:    opts.Scheme,
+				informers: map[informerKey]*informerRefs{},
+			}, nil
+		}
+	}
+}
+
+// GetInformerReferences returns the references to the informers of the
+// cache of the cluster, sorted by kind. It returns false if the cluster was
+// not created with WithInformerReferences.
+func GetInformerReferences(cl cluster.Cluster) ([]InformerReferences, bool) {
+	c, ok := cl.GetCache().(*refCountingCache)
+	if !ok {
+		return nil, false
+	}
+	return c.references(), true
+}
+
+// refCountingCache is a cache.Cache that removes informers that are no
+// longer referenced.
+type refCountingCache struct {
+	cache.Cache
+	scheme *runtime.Scheme
+
+	lock      sync.Mutex
+	informers map[informerKey]*informerRefs
+}
+
+type informerRefs struct {
+	obj    client.Object
+	count  int
+	pinned bool
+}
+
+func (c *refCountingCache) GetInformer(ctx context.Context, obj client.Object, opts ...cache.InformerGetOption) (cache.Informer, error) {
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return nil, err
+	}
+	key := keyFor(gvk, obj)
+	// reference the informer before getting it, such that it is not
+	// removed by a concurrent release in between.
+	c.acquire(ctx, key, obj)
+	inf, err := c.Cache.GetInformer(ctx, obj, opts...)
+	if err != nil {
+		c.release(key)
+		return nil, err
+	}
+	return inf, nil
+}
+
+func (c *refCountingCache) GetInformerForKind(ctx context.Context, gvk schema.GroupVersionKind, opts ...cache.InformerGetOption) (cache.Informer, error) {
+	obj, err := c.scheme.New(gvk)
+	if err != nil {
+		return nil, err
+	}
+	cobj, ok := obj.(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a client.Object", obj)
+	}
+	key := keyFor(gvk, cobj)
+	c.acquire(ctx, key, cobj)
+	inf, err := c.Cache.GetInformerForKind(ctx, gvk, opts...)
+	if err != nil {
+		c.release(key)
+		return nil, err
+	}
+	return inf, nil
+}
+
+func (c *refCountingCache) RemoveInformer(ctx context.Context, obj client.Object) error {
+	if err := c.Cache.RemoveInformer(ctx, obj); err != nil {
+		return err
+	}
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.informers, keyFor(gvk, obj))
+	return nil
+}
+
+func (c *refCountingCache) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	c.pin(obj)
+	return c.Cache.Get(ctx, key, obj, opts...)
+}
+
+func (c *refCountingCache) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if obj, err := c.itemOf(list); err == nil {
+		c.pin(obj)
+	}
+	return c.Cache.List(ctx, list, opts...)
+}
+
+func (c *refCountingCache) IndexField(ctx context.Context, obj client.Object, field string, extractValue client.IndexerFunc) error {
+	c.pin(obj)
+	return c.Cache.IndexField(ctx, obj, field, extractValue)
+}
+
+// acquire references the informer until ctx is done.
+func (c *refCountingCache) acquire(ctx context.Context, key informerKey, obj client.Object) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	refs, ok := c.informers[key]
+	if !ok {
+		refs = &informerRefs{obj: obj}
+		c.informers[key] = refs
+	}
+	if ctx.Done() == nil {
+		refs.pinned = true
+		return
+	}
+	refs.count++
+	go func() {
+		<-ctx.Done()
+		c.release(key)
+	}()
+}
+
+// release drops a reference to the informer, and removes it if it was the
+// last one and the informer is not pinned.
+func (c *refCountingCache) release(key informerKey) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	refs, ok := c.informers[key]
+	if !ok {
+		// removed explicitly.
+		return
+	}
+	if refs.count > 0 {
+		refs.count--
+	}
+	if refs.count > 0 || refs.pinned {
+		return
+	}
+	delete(c.informers, key)
+	// the lock is held, such that a concurrent acquire gets a new informer.
+	_ = c.Cache.RemoveInformer(context.Background(), refs.obj)
+}
+
+// pin keeps the informer of obj forever.
+func (c *refCountingCache) pin(obj client.Object) {
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return
+	}
+	key := keyFor(gvk, obj)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	refs, ok := c.informers[key]
+	if !ok {
+		refs = &informerRefs{obj: obj}
+		c.informers[key] = refs
+	}
+	refs.pinned = true
+}
+
+// itemOf returns an item of the list, which is of the type the informer
+// serving the list informs.
+func (c *refCountingCache) itemOf(list client.ObjectList) (client.Object, error) {
+	gvk, err := apiutil.GVKForObject(list, c.scheme)
+	if err != nil {
+		return nil, err
+	}
+	gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+	switch list.(type) {
+	case *unstructured.UnstructuredList:
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(gvk)
+		return u, nil
+	case *metav1.PartialObjectMetadataList:
+		m := &metav1.PartialObjectMetadata{}
+		m.SetGroupVersionKind(gvk)
+		return m, nil
+	}
+	obj, err := c.scheme.New(gvk)
+	if err != nil {
+		return nil, err
+	}
+	cobj, ok := obj.(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a client.Object", obj)
+	}
+	return cobj, nil
+}
+
+func (c *refCountingCache) references() []InformerReferences {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	refs := make([]InformerReferences, 0, len(c.informers))
+	for key, r := range c.informers {
+		refs = append(refs, InformerReferences{
+			GroupVersionKind: key.gvk,
+			Type:             key.typ,
+			References:       r.count,
+			Pinned:           r.pinned,
+		})
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].GroupVersionKind != refs[j].GroupVersionKind {
+			return refs[i].GroupVersionKind.String() < refs[j].GroupVersionKind.String()
+		}
+		return refs[i].Type < refs[j].Type
+	})
+	return refs
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+)
+
+type removalCache struct {
+	informertest.FakeInformers
+
+	lock    sync.Mutex
+	removed []string
+}
+
+func (c *removalCache) RemoveInformer(_ context.Context, obj client.Object) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.removed = append(c.removed, obj.GetObjectKind().GroupVersionKind().Kind)
+	return nil
+}
+
+func (c *removalCache) Removed() []string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return append([]string(nil), c.removed...)
+}
+
+func (c *removalCache) List(context.Context, client.ObjectList, ...client.ListOption) error {
+	return nil
+}
+
+var _ = Describe("WithInformerReferences", func() {
+	cfg := &rest.Config{Host: "https://127.0.0.1:1"}
+
+	It("removes informers that are no longer referenced", func(ctx context.Context) {
+		fake := &removalCache{}
+		cl, err := cluster.New(cfg, func(o *cluster.Options) {
+			o.NewCache = func(*rest.Config, cache.Options) (cache.Cache, error) {
+				return fake, nil
+			}
+		}, WithCacheAccounting(), WithInformerReferences())
+		Expect(err).NotTo(HaveOccurred())
+
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+		ctxA, stopA := context.WithCancel(ctx)
+		ctxB, stopB := context.WithCancel(ctx)
+		_, err = cl.GetCache().GetInformer(ctxA, u)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = cl.GetCache().GetInformer(ctxB, u)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = cl.GetCache().GetInformer(ctxA, &corev1.ConfigMap{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cl.GetCache().List(ctx, &corev1.ConfigMapList{})).To(Succeed())
+
+		refs, ok := GetInformerReferences(cl)
+		Expect(ok).To(BeTrue())
+		Expect(refs).To(Equal([]InformerReferences{
+			{GroupVersionKind: corev1.SchemeGroupVersion.WithKind("ConfigMap"), Type: "*v1.ConfigMap", References: 1, Pinned: true},
+			{GroupVersionKind: corev1.SchemeGroupVersion.WithKind("Secret"), Type: "*unstructured.Unstructured", References: 2},
+		}))
+		stats, ok, err := GetCacheStats(ctx, cl)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(stats.Informers).To(Equal(2))
+
+		stopA()
+		Eventually(func() []InformerReferences {
+			refs, _ := GetInformerReferences(cl)
+			return refs
+		}).Should(HaveExactElements(
+			InformerReferences{GroupVersionKind: corev1.SchemeGroupVersion.WithKind("ConfigMap"), Type: "*v1.ConfigMap", Pinned: true},
+			InformerReferences{GroupVersionKind: corev1.SchemeGroupVersion.WithKind("Secret"), Type: "*unstructured.Unstructured", References: 1},
+		))
+		Expect(fake.Removed()).To(BeEmpty(), "read informers are kept")
+
+		stopB()
+		Eventually(fake.Removed).Should(Equal([]string{"Secret"}))
+		refs, _ = GetInformerReferences(cl)
+		Expect(refs).To(HaveLen(1))
+		stats, _, err = GetCacheStats(ctx, cl)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stats.Informers).To(Equal(1), "removed informers are no longer accounted")
+	})
+
+	It("does not count references of live clusters", func() {
+		cl, err := NewLive(cfg, LiveOptions{}, WithInformerReferences())
+		Expect(err).NotTo(HaveOccurred())
+		_, ok := GetInformerReferences(cl)
+		Expect(ok).To(BeFalse())
+	})
+})
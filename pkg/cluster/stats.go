@@ -0,0 +1,201 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+)
+
+// CacheStats are statistics of the cache of a cluster.
+type CacheStats struct {
+	// Informers is the number of informers.
+	Informers int
+
+	// Objects is the number of cached objects per kind.
+	Objects map[schema.GroupVersionKind]int
+
+	// ApproximateBytes is the approximate serialized size of the cached
+	// objects. The in-memory size is typically a small multiple of it.
+	ApproximateBytes int64
+}
+
+// WithCacheAccounting is a cluster option that keeps track of the informers
+// of the cache, such that statistics can be read with GetCacheStats. It has
+// no effect on live clusters.
+func WithCacheAccounting() cluster.Option {
+	return func(o *cluster.Options) {
+		newCache := o.NewCache
+		if newCache == nil {
+			newCache = cache.New
+		}
+		o.NewCache = func(cfg *rest.Config, opts cache.Options) (cache.Cache, error) {
+			c, err := newCache(cfg, opts)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := c.(*liveCache); ok {
+				return c, nil
+			}
+			if rc, ok := c.(*refCountingCache); ok {
+				// account below the reference counting, such that removed
+				// informers are no longer accounted, and reading the
+				// statistics does not reference them.
+				rc.Cache = &accountingCache{Cache: rc.Cache, scheme: opts.Scheme, informers: map[informerKey]client.Object{}}
+				return rc, nil
+			}
+			return &accountingCache{Cache: c, scheme: opts.Scheme, informers: map[informerKey]client.Object{}}, nil
+		}
+	}
+}
+
+// GetCacheStats returns the statistics of the cache of the cluster. It
+// returns false if the cluster was not created with WithCacheAccounting.
+func GetCacheStats(ctx context.Context, cl cluster.Cluster) (CacheStats, bool, error) {
+	var c *accountingCache
+	switch cc := cl.GetCache().(type) {
+	case *accountingCache:
+		c = cc
+	case *refCountingCache:
+		c, _ = cc.Cache.(*accountingCache)
+	}
+	if c == nil {
+		return CacheStats{}, false, nil
+	}
+	stats, err := c.stats(ctx)
+	return stats, true, err
+}
+
+// informerKey identifies an informer. Typed, unstructured and metadata-only
+// informers of the same kind are distinct.
+type informerKey struct {
+	gvk schema.GroupVersionKind
+	typ string
+}
+
+func keyFor(gvk schema.GroupVersionKind, obj runtime.Object) informerKey {
+	return informerKey{gvk: gvk, typ: fmt.Sprintf("%T", obj)}
+}
+
+// accountingCache is a cache.Cache that records its informers.
+type accountingCache struct {
+	cache.Cache
+	scheme *runtime.Scheme
+
+	lock      sync.Mutex
+	informers map[informerKey]client.Object
+}
+
+func (c *accountingCache) GetInformer(ctx context.Context, obj client.Object, opts ...cache.InformerGetOption) (cache.Informer, error) {
+	inf, err := c.Cache.GetInformer(ctx, obj, opts...)
+	if err != nil {
+		return nil, err
+	}
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return nil, err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.informers[keyFor(gvk, obj)] = obj
+	return inf, nil
+}
+
+func (c *accountingCache) GetInformerForKind(ctx context.Context, gvk schema.GroupVersionKind, opts ...cache.InformerGetOption) (cache.Informer, error) {
+	inf, err := c.Cache.GetInformerForKind(ctx, gvk, opts...)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := c.scheme.New(gvk)
+	if err != nil {
+		return nil, err
+	}
+	cobj, ok := obj.(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a client.Object", obj)
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.informers[keyFor(gvk, cobj)] = cobj
+	return inf, nil
+}
+
+func (c *accountingCache) RemoveInformer(ctx context.Context, obj client.Object) error {
+	if err := c.Cache.RemoveInformer(ctx, obj); err != nil {
+		return err
+	}
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.informers, keyFor(gvk, obj))
+	return nil
+}
+
+func (c *accountingCache) stats(ctx context.Context) (CacheStats, error) {
+	c.lock.Lock()
+	informers := make(map[informerKey]client.Object, len(c.informers))
+	for k, obj := range c.informers {
+		informers[k] = obj
+	}
+	c.lock.Unlock()
+
+	stats := CacheStats{Informers: len(informers), Objects: map[schema.GroupVersionKind]int{}}
+	for k, obj := range informers {
+		inf, err := c.Cache.GetInformer(ctx, obj, cache.BlockUntilSynced(false))
+		if err != nil {
+			return CacheStats{}, fmt.Errorf("failed to get informer for %s: %w", k.gvk, err)
+		}
+		s, ok := inf.(interface{ GetStore() toolscache.Store })
+		if !ok || s.GetStore() == nil {
+			continue
+		}
+		items := s.GetStore().List()
+		stats.Objects[k.gvk] += len(items)
+		for _, item := range items {
+			stats.ApproximateBytes += approximateSize(item)
+		}
+	}
+	return stats, nil
+}
+
+// approximateSize returns the protobuf size of built-in objects, and the
+// JSON size of others.
+func approximateSize(obj interface{}) int64 {
+	if s, ok := obj.(interface{ Size() int }); ok {
+		return int64(s.Size())
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return 0
+	}
+	return int64(len(data))
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+)
+
+type storeInformer struct {
+	cache.Informer
+	store toolscache.Store
+}
+
+func (i *storeInformer) GetStore() toolscache.Store {
+	return i.store
+}
+
+type storeCache struct {
+	informertest.FakeInformers
+	store toolscache.Store
+}
+
+func (c *storeCache) GetInformer(context.Context, client.Object, ...cache.InformerGetOption) (cache.Informer, error) {
+	return &storeInformer{store: c.store}, nil
+}
+
+var _ = Describe("WithCacheAccounting", func() {
+	cfg := &rest.Config{Host: "https://127.0.0.1:1"}
+
+	It("reports the cached objects", func(ctx context.Context) {
+		store := toolscache.NewStore(toolscache.MetaNamespaceKeyFunc)
+		Expect(store.Add(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "a"}, Data: map[string]string{"k": "v"}})).To(Succeed())
+		Expect(store.Add(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "b"}})).To(Succeed())
+
+		cl, err := cluster.New(cfg, func(o *cluster.Options) {
+			o.NewCache = func(*rest.Config, cache.Options) (cache.Cache, error) {
+				return &storeCache{store: store}, nil
+			}
+		}, WithCacheAccounting())
+		Expect(err).NotTo(HaveOccurred())
+
+		stats, ok, err := GetCacheStats(ctx, cl)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(stats.Informers).To(BeZero())
+
+		_, err = cl.GetCache().GetInformer(ctx, &corev1.ConfigMap{})
+		Expect(err).NotTo(HaveOccurred())
+		stats, _, err = GetCacheStats(ctx, cl)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stats.Informers).To(Equal(1))
+		Expect(stats.Objects).To(HaveKeyWithValue(corev1.SchemeGroupVersion.WithKind("ConfigMap"), 2))
+		Expect(stats.ApproximateBytes).To(BeNumerically(">", 0))
+
+		Expect(cl.GetCache().RemoveInformer(ctx, &corev1.ConfigMap{})).To(Succeed())
+		stats, _, err = GetCacheStats(ctx, cl)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stats.Informers).To(BeZero())
+	})
+
+	It("does not account live clusters", func(ctx context.Context) {
+		cl, err := NewLive(cfg, LiveOptions{}, WithCacheAccounting())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(IsLive(cl)).To(BeTrue())
+		_, ok, err := GetCacheStats(ctx, cl)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+	})
+})
@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	mcmetrics "sigs.k8s.io/multicluster-runtime/pkg/metrics"
+)
+
+// ProjectFunc trims the fields of a cached object that the controllers do
+// not read, in place.
+type ProjectFunc func(obj client.Object) error
+
+// TrimOptions configure which fields are stripped from cached objects.
+type TrimOptions struct {
+	// KeepManagedFields keeps the managed fields of the objects.
+	KeepManagedFields bool
+
+	// KeepLastApplied keeps the last applied configuration annotation of
+	// kubectl.
+	KeepLastApplied bool
+
+	// Projections trim the objects of the given kinds further.
+	Projections map[schema.GroupVersionKind]ProjectFunc
+}
+
+// WithTrimmedObjects is a cluster option that strips the managed fields and
+// the last applied configuration annotation from all cached objects, and
+// applies the projections of their kinds, reducing the memory of the caches
+// at fleet scale. The stripped bytes are counted by the
+// multicluster_cache_trimmed_bytes_total metric; projections are not
+// accounted for. Objects with a transform of their own in the ByObject
+// cache options are not trimmed. It has no effect on live clusters.
+func WithTrimmedObjects(opts TrimOptions) cluster.Option {
+	return func(o *cluster.Options) {
+		newCache := o.NewCache
+		if newCache == nil {
+			newCache = cache.New
+		}
+		o.NewCache = func(cfg *rest.Config, cacheOpts cache.Options) (cache.Cache, error) {
+			next := cacheOpts.DefaultTransform
+			trim := TrimTransform(cacheOpts.Scheme, opts)
+			cacheOpts.DefaultTransform = func(i interface{}) (interface{}, error) {
+				i, err := trim(i)
+				if err != nil || next == nil {
+					return i, err
+				}
+				return next(i)
+			}
+			return newCache(cfg, cacheOpts)
+		}
+	}
+}
+
+// TrimTransform returns the cache transform of WithTrimmedObjects, e.g. to
+// use it in the ByObject cache options. The scheme resolves the kinds of
+// typed objects for the projections and the metrics.
+func TrimTransform(scheme *runtime.Scheme, opts TrimOptions) toolscache.TransformFunc {
+	return func(i interface{}) (interface{}, error) {
+		obj, ok := i.(client.Object)
+		if !ok {
+			return i, nil
+		}
+		gvk := obj.GetObjectKind().GroupVersionKind()
+		if gvk.Empty() && scheme != nil {
+			gvk, _ = apiutil.GVKForObject(obj, scheme)
+		}
+
+		var trimmed int
+		if !opts.KeepManagedFields {
+			for _, mf := range obj.GetManagedFields() {
+				trimmed += len(mf.Manager) + len(mf.Operation) + len(mf.APIVersion) + len(mf.Subresource)
+				if mf.FieldsV1 != nil {
+					trimmed += len(mf.FieldsV1.Raw)
+				}
+			}
+			obj.SetManagedFields(nil)
+		}
+		if annotations := obj.GetAnnotations(); !opts.KeepLastApplied && annotations != nil {
+			if v, ok := annotations[corev1.LastAppliedConfigAnnotation]; ok {
+				trimmed += len(v)
+				delete(annotations, corev1.LastAppliedConfigAnnotation)
+				obj.SetAnnotations(annotations)
+			}
+		}
+		if trimmed > 0 {
+			mcmetrics.CacheTrimmedBytes.WithLabelValues(gvk.String()).Add(float64(trimmed))
+		}
+
+		if project, ok := opts.Projections[gvk]; ok {
+			if err := project(obj); err != nil {
+				return nil, err
+			}
+		}
+		return obj, nil
+	}
+}
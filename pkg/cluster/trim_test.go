@@ -0,0 +1,116 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	mcmetrics "sigs.k8s.io/multicluster-runtime/pkg/metrics"
+)
+
+var _ = Describe("Trimmed objects", func() {
+	secret := func() *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "s",
+			Annotations: map[string]string{
+				corev1.LastAppliedConfigAnnotation: `{"data":{}}`,
+				"keep":                             "me",
+			},
+			ManagedFields: []metav1.ManagedFieldsEntry{{
+				Manager:   "kubectl",
+				Operation: metav1.ManagedFieldsOperationApply,
+				FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:data":{}}`)},
+			}},
+		}, Data: map[string][]byte{"k": []byte("v")}}
+	}
+
+	It("strips managed fields and last applied configurations, and counts the bytes", func() {
+		counter := mcmetrics.CacheTrimmedBytes.WithLabelValues("/v1, Kind=Secret")
+		before := testutil.ToFloat64(counter)
+
+		out, err := TrimTransform(scheme.Scheme, TrimOptions{})(secret())
+		Expect(err).NotTo(HaveOccurred())
+		s := out.(*corev1.Secret)
+		Expect(s.ManagedFields).To(BeNil())
+		Expect(s.Annotations).To(Equal(map[string]string{"keep": "me"}))
+		Expect(s.Data).To(HaveKey("k"))
+		Expect(testutil.ToFloat64(counter) - before).To(BeNumerically("==", len("kubectl")+len("Apply")+len(`{"f:data":{}}`)+len(`{"data":{}}`)))
+
+		out, err = TrimTransform(scheme.Scheme, TrimOptions{KeepManagedFields: true, KeepLastApplied: true})(secret())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(Equal(secret()))
+
+		tombstone := toolscache.DeletedFinalStateUnknown{Key: "default/s"}
+		Expect(TrimTransform(scheme.Scheme, TrimOptions{})(tombstone)).To(Equal(tombstone))
+	})
+
+	It("projects the objects of the configured kinds", func() {
+		transform := TrimTransform(scheme.Scheme, TrimOptions{Projections: map[schema.GroupVersionKind]ProjectFunc{
+			corev1.SchemeGroupVersion.WithKind("Secret"): func(obj client.Object) error {
+				obj.(*corev1.Secret).Data = nil
+				return nil
+			},
+			corev1.SchemeGroupVersion.WithKind("ConfigMap"): func(client.Object) error {
+				return errors.New("boom")
+			},
+		}})
+
+		out, err := transform(secret())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out.(*corev1.Secret).Data).To(BeNil())
+		_, err = transform(&corev1.ConfigMap{})
+		Expect(err).To(MatchError("boom"))
+	})
+
+	It("chains the transform with the default transform of the cache", func() {
+		var transform toolscache.TransformFunc
+		opts := &cluster.Options{
+			Cache: cache.Options{DefaultTransform: func(i interface{}) (interface{}, error) {
+				i.(client.Object).SetLabels(map[string]string{"transformed": "true"})
+				return i, nil
+			}},
+			NewCache: func(_ *rest.Config, opts cache.Options) (cache.Cache, error) {
+				transform = opts.DefaultTransform
+				return nil, nil
+			},
+		}
+		WithTrimmedObjects(TrimOptions{})(opts)
+		_, err := opts.NewCache(&rest.Config{}, cache.Options{Scheme: scheme.Scheme, DefaultTransform: opts.Cache.DefaultTransform})
+		Expect(err).NotTo(HaveOccurred())
+
+		out, err := transform(secret())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out.(*corev1.Secret).ManagedFields).To(BeNil())
+		Expect(out.(*corev1.Secret).Labels).To(HaveKeyWithValue("transformed", "true"))
+	})
+})
@@ -0,0 +1,162 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"k8s.io/client-go/rest"
+)
+
+// ErrCertificateNotPinned is returned for connections to a cluster whose
+// certificate chain contains none of the pinned certificates.
+var ErrCertificateNotPinned = errors.New("certificate chain does not contain a pinned certificate")
+
+// TrustOptions override the trust of the connections to a cluster, whatever
+// the kubeconfig of the provider carries.
+type TrustOptions struct {
+	// CAData is a PEM encoded bundle of the CAs to trust instead of the CA
+	// of the kubeconfig.
+	CAData []byte
+
+	// CAFile is the path of a PEM encoded bundle of the CAs to trust, read
+	// when the cluster config is built. Appended to CAData.
+	CAFile string
+
+	// PinnedSHA256 are the hex encoded SHA-256 fingerprints of certificates
+	// of which at least one must be in the verified certificate chain of
+	// the API server, e.g. the serving certificate or an intermediate CA.
+	// Colons are ignored.
+	PinnedSHA256 []string
+}
+
+// TrustFunc returns the trust of the cluster with the given name, or false
+// if the trust of the kubeconfig is used. Providers call it when building
+// the config of a cluster.
+type TrustFunc func(clusterName string) (TrustOptions, bool)
+
+// ParseFingerprint parses a hex encoded SHA-256 fingerprint, with or
+// without colons.
+func ParseFingerprint(s string) ([sha256.Size]byte, error) {
+	var fp [sha256.Size]byte
+	b, err := hex.DecodeString(strings.ReplaceAll(s, ":", ""))
+	if err != nil {
+		return fp, fmt.Errorf("invalid fingerprint %q: %w", s, err)
+	}
+	if len(b) != sha256.Size {
+		return fp, fmt.Errorf("invalid fingerprint %q: expected %d bytes, got %d", s, sha256.Size, len(b))
+	}
+	copy(fp[:], b)
+	return fp, nil
+}
+
+// WrapConfigForTrust returns a copy of cfg trusting the CAs and requiring
+// the pinned certificates of the cluster with the given name, if trust
+// returns them. Connections are then verified even if the kubeconfig skips
+// verification, and plain HTTP requests are rejected.
+func WrapConfigForTrust(cfg *rest.Config, clusterName string, trust TrustFunc) (*rest.Config, error) {
+	if trust == nil {
+		return cfg, nil
+	}
+	opts, ok := trust(clusterName)
+	if !ok {
+		return cfg, nil
+	}
+
+	cfg = rest.CopyConfig(cfg)
+	caData := opts.CAData
+	if opts.CAFile != "" {
+		data, err := os.ReadFile(opts.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		caData = append(append([]byte(nil), caData...), data...)
+	}
+	if len(caData) > 0 {
+		if !x509.NewCertPool().AppendCertsFromPEM(caData) {
+			return nil, fmt.Errorf("no valid CA certificates for cluster %q", clusterName)
+		}
+		cfg.CAData, cfg.CAFile = caData, ""
+	}
+	cfg.Insecure = false
+
+	pins := make(map[[sha256.Size]byte]bool, len(opts.PinnedSHA256))
+	for _, s := range opts.PinnedSHA256 {
+		fp, err := ParseFingerprint(s)
+		if err != nil {
+			return nil, err
+		}
+		pins[fp] = true
+	}
+	// wrap the base transport first, before wrappers added so far.
+	wrap := cfg.WrapTransport
+	cfg.WrapTransport = func(rt http.RoundTripper) http.RoundTripper {
+		rt = newPinnedTransport(rt, pins)
+		if wrap != nil {
+			rt = wrap(rt)
+		}
+		return rt
+	}
+	return cfg, nil
+}
+
+// newPinnedTransport returns a transport verifying the pins on every TLS
+// connection of rt, which must be the base transport of the config.
+func newPinnedTransport(rt http.RoundTripper, pins map[[sha256.Size]byte]bool) http.RoundTripper {
+	t, ok := rt.(*http.Transport)
+	if !ok {
+		return roundTripperFunc(func(*http.Request) (*http.Response, error) {
+			return nil, fmt.Errorf("cannot enforce cluster trust on transport %T", rt)
+		})
+	}
+	t = t.Clone()
+	if t.TLSClientConfig == nil {
+		t.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	if len(pins) > 0 {
+		t.TLSClientConfig.VerifyConnection = func(cs tls.ConnectionState) error {
+			for _, chain := range cs.VerifiedChains {
+				for _, cert := range chain {
+					if pins[sha256.Sum256(cert.Raw)] {
+						return nil
+					}
+				}
+			}
+			return ErrCertificateNotPinned
+		}
+	}
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Scheme != "https" {
+			return nil, fmt.Errorf("refusing %s request to %s with enforced cluster trust", req.URL.Scheme, req.URL.Host)
+		}
+		return t.RoundTrip(req)
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
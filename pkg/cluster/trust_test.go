@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/client-go/rest"
+)
+
+var _ = Describe("WrapConfigForTrust", func() {
+	var (
+		server *httptest.Server
+		caData []byte
+		pin    string
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{}`))
+		}))
+		DeferCleanup(server.Close)
+		caData = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+		sum := sha256.Sum256(server.Certificate().Raw)
+		pin = hex.EncodeToString(sum[:])
+	})
+
+	get := func(cfg *rest.Config, url string) error {
+		hc, err := rest.HTTPClientFor(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		resp, err := hc.Get(url)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	trust := func(opts TrustOptions) TrustFunc {
+		return func(name string) (TrustOptions, bool) { return opts, name == "spoke" }
+	}
+
+	It("trusts the CA and pinned certificate instead of the kubeconfig", func() {
+		base := &rest.Config{Host: server.URL, TLSClientConfig: rest.TLSClientConfig{Insecure: true}}
+		cfg, err := WrapConfigForTrust(base, "other", trust(TrustOptions{}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg).To(BeIdenticalTo(base))
+
+		cfg, err = WrapConfigForTrust(base, "spoke", trust(TrustOptions{CAData: caData, PinnedSHA256: []string{pin}}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Insecure).To(BeFalse())
+		Expect(base.Insecure).To(BeTrue())
+		Expect(get(cfg, server.URL+"/version")).To(Succeed())
+	})
+
+	It("rejects connections without a pinned certificate", func() {
+		other := sha256.Sum256([]byte("other"))
+		cfg, err := WrapConfigForTrust(&rest.Config{Host: server.URL}, "spoke", trust(TrustOptions{
+			CAData:       caData,
+			PinnedSHA256: []string{hex.EncodeToString(other[:])},
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(get(cfg, server.URL+"/version")).To(MatchError(ContainSubstring(ErrCertificateNotPinned.Error())))
+	})
+
+	It("rejects plain HTTP requests", func() {
+		cfg, err := WrapConfigForTrust(&rest.Config{Host: "http://spoke.invalid"}, "spoke", trust(TrustOptions{PinnedSHA256: []string{pin}}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(get(cfg, "http://spoke.invalid/version")).To(MatchError(ContainSubstring("refusing http request")))
+	})
+
+	It("rejects invalid fingerprints and CAs", func() {
+		_, err := WrapConfigForTrust(&rest.Config{}, "spoke", trust(TrustOptions{PinnedSHA256: []string{"ab:cd"}}))
+		Expect(err).To(MatchError(ContainSubstring("expected 32 bytes")))
+		_, err = WrapConfigForTrust(&rest.Config{}, "spoke", trust(TrustOptions{CAData: []byte("garbage")}))
+		Expect(err).To(MatchError(ContainSubstring("no valid CA certificates")))
+	})
+})
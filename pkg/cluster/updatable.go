@@ -0,0 +1,174 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/connrotation"
+
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+)
+
+// ErrUpdateNotSupported is returned when the config of a cluster that was
+// not created by NewUpdatable is updated.
+var ErrUpdateNotSupported = errors.New("cluster does not support config updates")
+
+// Updatable is implemented by clusters whose endpoint and credentials can
+// be updated while they are running.
+type Updatable interface {
+	// UpdateConfig switches the cluster to the host and credentials of cfg.
+	UpdateConfig(cfg *rest.Config) error
+}
+
+// NewUpdatable returns a cluster whose endpoint and credentials can be
+// updated with UpdateConfig, keeping its caches, indexes and watches. All
+// requests of the cluster go through a transport that is swapped on
+// update. Open connections are closed afterwards, so that watches are
+// re-established with the new config and resume from their last resource
+// version instead of starting over.
+//
+// Changes of other parts of the config, like QPS, are not applied.
+func NewUpdatable(cfg *rest.Config, opts ...cluster.Option) (cluster.Cluster, error) {
+	t, err := newSwappableTransport(cfg)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, func(o *cluster.Options) {
+		o.HTTPClient = &http.Client{Transport: t, Timeout: cfg.Timeout}
+	})
+	cl, err := cluster.New(cfg, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &updatableCluster{Cluster: cl, transport: t}, nil
+}
+
+// UpdateConfig updates the endpoint and credentials of the cluster, or
+// returns ErrUpdateNotSupported if the cluster was not created with
+// NewUpdatable.
+func UpdateConfig(cl cluster.Cluster, cfg *rest.Config) error {
+	if u, ok := cl.(Updatable); ok {
+		return u.UpdateConfig(cfg)
+	}
+	return ErrUpdateNotSupported
+}
+
+type updatableCluster struct {
+	cluster.Cluster
+	transport *swappableTransport
+}
+
+var _ Updatable = &updatableCluster{}
+
+// GetConfig returns the current config of the cluster.
+func (c *updatableCluster) GetConfig() *rest.Config {
+	return c.transport.config()
+}
+
+// UpdateConfig implements Updatable.
+func (c *updatableCluster) UpdateConfig(cfg *rest.Config) error {
+	return c.transport.swap(cfg)
+}
+
+// swappableTransport sends requests through the transport of the current
+// config, rewriting the URL of the requests if the host changed.
+type swappableTransport struct {
+	origin *url.URL
+
+	lock    sync.RWMutex
+	current *configTransport
+}
+
+type configTransport struct {
+	cfg    *rest.Config
+	host   *url.URL
+	rt     http.RoundTripper
+	dialer *connrotation.Dialer
+}
+
+func newSwappableTransport(cfg *rest.Config) (*swappableTransport, error) {
+	current, err := newConfigTransport(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &swappableTransport{origin: current.host, current: current}, nil
+}
+
+func newConfigTransport(cfg *rest.Config) (*configTransport, error) {
+	cfg = rest.CopyConfig(cfg)
+	host, _, err := rest.DefaultServerUrlFor(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid host %q: %w", cfg.Host, err)
+	}
+	dial := cfg.Dial
+	if dial == nil {
+		dial = (&net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}).DialContext
+	}
+	dialer := connrotation.NewDialer(dial)
+	transportCfg := rest.CopyConfig(cfg)
+	transportCfg.Dial = dialer.DialContext
+	rt, err := rest.TransportFor(transportCfg)
+	if err != nil {
+		return nil, err
+	}
+	return &configTransport{cfg: cfg, host: host, rt: rt, dialer: dialer}, nil
+}
+
+func (t *swappableTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.lock.RLock()
+	current := t.current
+	t.lock.RUnlock()
+
+	if current.host.Scheme != t.origin.Scheme || current.host.Host != t.origin.Host || current.host.Path != t.origin.Path {
+		req = req.Clone(req.Context())
+		req.URL.Scheme = current.host.Scheme
+		req.URL.Host = current.host.Host
+		req.URL.Path = strings.TrimSuffix(current.host.Path, "/") + strings.TrimPrefix(req.URL.Path, strings.TrimSuffix(t.origin.Path, "/"))
+		req.Host = ""
+	}
+	return current.rt.RoundTrip(req)
+}
+
+func (t *swappableTransport) swap(cfg *rest.Config) error {
+	next, err := newConfigTransport(cfg)
+	if err != nil {
+		return err
+	}
+	t.lock.Lock()
+	prev := t.current
+	t.current = next
+	t.lock.Unlock()
+
+	// break open watches, they reconnect through the new transport.
+	prev.dialer.CloseAll()
+	return nil
+}
+
+func (t *swappableTransport) config() *rest.Config {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return rest.CopyConfig(t.current.cfg)
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+)
+
+var _ = Describe("NewUpdatable", func() {
+	var servers []*httptest.Server
+	var hits map[string][]string
+
+	serve := func(name string) *httptest.Server {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hits[name] = append(hits[name], r.URL.Path+" "+r.Header.Get("Authorization"))
+		}))
+		servers = append(servers, s)
+		return s
+	}
+
+	BeforeEach(func() {
+		hits = map[string][]string{}
+	})
+	AfterEach(func() {
+		for _, s := range servers {
+			s.Close()
+		}
+		servers = nil
+	})
+
+	It("sends requests to the updated host with the updated credentials", func() {
+		a, b := serve("a"), serve("b")
+		cl, err := NewUpdatable(&rest.Config{Host: a.URL, BearerToken: "old"})
+		Expect(err).NotTo(HaveOccurred())
+
+		get := func() {
+			resp, err := cl.GetHTTPClient().Get(a.URL + "/api/v1/namespaces")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Body.Close()).To(Succeed())
+		}
+		get()
+		Expect(hits["a"]).To(Equal([]string{"/api/v1/namespaces Bearer old"}))
+
+		Expect(UpdateConfig(cl, &rest.Config{Host: b.URL + "/proxy", BearerToken: "new"})).To(Succeed())
+		get()
+		Expect(hits["a"]).To(HaveLen(1))
+		Expect(hits["b"]).To(Equal([]string{"/proxy/api/v1/namespaces Bearer new"}))
+		Expect(cl.GetConfig().Host).To(Equal(b.URL + "/proxy"))
+	})
+
+	It("does not update other clusters", func() {
+		cl, err := cluster.New(&rest.Config{Host: serve("a").URL})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(UpdateConfig(cl, &rest.Config{})).To(MatchError(ErrUpdateNotSupported))
+	})
+})
@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/version"
+	apimachineryversion "k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery"
+
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+)
+
+// versioned is implemented by clusters that know their server version
+// without asking the API server, e.g. fake clusters.
+type versioned interface {
+	ServerVersion() (*apimachineryversion.Info, error)
+}
+
+// ServerVersion returns the Kubernetes version of the API server of the
+// cluster.
+func ServerVersion(cl cluster.Cluster) (*apimachineryversion.Info, error) {
+	if v, ok := cl.(versioned); ok {
+		return v.ServerVersion()
+	}
+	var dc *discovery.DiscoveryClient
+	var err error
+	if hc := cl.GetHTTPClient(); hc != nil {
+		dc, err = discovery.NewDiscoveryClientForConfigAndClient(cl.GetConfig(), hc)
+	} else {
+		dc, err = discovery.NewDiscoveryClientForConfig(cl.GetConfig())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return dc.ServerVersion()
+}
+
+// ParseVersion parses the git version of the version info, ignoring vendor
+// suffixes like "-eks-1234".
+func ParseVersion(info *apimachineryversion.Info) (*version.Version, error) {
+	if info == nil {
+		return nil, fmt.Errorf("no version info")
+	}
+	v, err := version.ParseGeneric(info.GitVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse version %q: %w", info.GitVersion, err)
+	}
+	return v, nil
+}
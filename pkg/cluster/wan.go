@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/rest"
+)
+
+// WANOptions tune the requests to a cluster behind a flaky wide area
+// network link, such that a network blip does not turn into a storm of full
+// relists across the fleet.
+type WANOptions struct {
+	// KeepAlive is the TCP keep-alive period, to detect dead connections
+	// early. Defaults to 15 seconds.
+	KeepAlive time.Duration
+
+	// RelistJitter is the maximum random delay of list requests after a
+	// connection failure, spreading the relists of all informers. Defaults
+	// to 5 seconds.
+	RelistJitter time.Duration
+
+	// RecoveryWindow is how long after a connection failure list requests
+	// are jittered and served from the watch cache of the API server.
+	// Defaults to one minute.
+	RecoveryWindow time.Duration
+}
+
+func (o *WANOptions) complete() {
+	if o.KeepAlive == 0 {
+		o.KeepAlive = 15 * time.Second
+	}
+	if o.RelistJitter == 0 {
+		o.RelistJitter = 5 * time.Second
+	}
+	if o.RecoveryWindow == 0 {
+		o.RecoveryWindow = time.Minute
+	}
+}
+
+// WrapConfigForWAN returns a copy of cfg tuned for flaky links:
+//
+//   - watches always request bookmarks, so that informers can resume from a
+//     recent resourceVersion after a disconnect instead of relisting,
+//   - after a connection failure, lists are delayed by a random jitter and
+//     lists without resourceVersion are served from the watch cache of the
+//     API server instead of etcd,
+//   - dead connections are detected by TCP keep-alives.
+//
+// Pass the returned config to the cluster constructor of a provider.
+func WrapConfigForWAN(cfg *rest.Config, opts WANOptions) *rest.Config {
+	opts.complete()
+	cfg = rest.CopyConfig(cfg)
+	if cfg.Dial == nil {
+		cfg.Dial = (&net.Dialer{Timeout: 30 * time.Second, KeepAlive: opts.KeepAlive}).DialContext
+	}
+	cfg.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &wanRoundTripper{opts: opts, delegate: rt}
+	})
+	return cfg
+}
+
+type wanRoundTripper struct {
+	opts     WANOptions
+	delegate http.RoundTripper
+
+	lock     sync.Mutex
+	failedAt time.Time
+}
+
+func (rt *wanRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	q := req.URL.Query()
+	switch {
+	case req.Method != http.MethodGet:
+	case q.Get("watch") == "true" || q.Get("watch") == "1":
+		if !q.Has("allowWatchBookmarks") {
+			q.Set("allowWatchBookmarks", "true")
+			req = withQuery(req, q)
+		}
+	case (q.Has("limit") || q.Has("resourceVersion")) && rt.recovering():
+		if rt.opts.RelistJitter > 0 {
+			t := time.NewTimer(rand.N(rt.opts.RelistJitter)) //nolint:gosec // jitter does not need a secure random source.
+			select {
+			case <-t.C:
+			case <-req.Context().Done():
+				t.Stop()
+				return nil, req.Context().Err()
+			}
+		}
+		if q.Get("resourceVersion") == "" && q.Get("continue") == "" {
+			q.Set("resourceVersion", "0")
+			q.Del("resourceVersionMatch")
+			req = withQuery(req, q)
+		}
+	}
+
+	resp, err := rt.delegate.RoundTrip(req)
+	if err != nil {
+		rt.failed()
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		rt.failed()
+	}
+	return resp, nil
+}
+
+func (rt *wanRoundTripper) failed() {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+	rt.failedAt = time.Now()
+}
+
+func (rt *wanRoundTripper) recovering() bool {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+	return !rt.failedAt.IsZero() && time.Since(rt.failedAt) < rt.opts.RecoveryWindow
+}
+
+func withQuery(req *http.Request, q url.Values) *http.Request {
+	req = req.Clone(req.Context())
+	req.URL.RawQuery = q.Encode()
+	return req
+}
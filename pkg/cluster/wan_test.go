@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/client-go/rest"
+)
+
+type recordingTransport struct {
+	queries []string
+	fail    bool
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.queries = append(t.queries, req.URL.RawQuery)
+	if t.fail {
+		t.fail = false
+		return nil, errors.New("connection reset by peer")
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("")), Request: req}, nil
+}
+
+var _ = Describe("WrapConfigForWAN", func() {
+	var (
+		delegate *recordingTransport
+		rt       http.RoundTripper
+	)
+
+	BeforeEach(func() {
+		delegate = &recordingTransport{}
+		cfg := WrapConfigForWAN(&rest.Config{Host: "https://127.0.0.1:1"}, WANOptions{RelistJitter: time.Millisecond})
+		Expect(cfg.Dial).NotTo(BeNil())
+		rt = cfg.WrapTransport(delegate)
+	})
+
+	get := func(query string) error {
+		req, err := http.NewRequest(http.MethodGet, "https://127.0.0.1:1/api/v1/configmaps?"+query, nil)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = rt.RoundTrip(req)
+		return err
+	}
+
+	It("requests bookmarks for watches", func() {
+		Expect(get("watch=true&resourceVersion=5")).To(Succeed())
+		Expect(delegate.queries).To(ConsistOf(ContainSubstring("allowWatchBookmarks=true")))
+	})
+
+	It("serves lists from the watch cache after a connection failure", func() {
+		Expect(get("limit=500")).To(Succeed())
+		Expect(delegate.queries[0]).To(Equal("limit=500"))
+
+		delegate.fail = true
+		Expect(get("limit=500")).NotTo(Succeed())
+
+		Expect(get("limit=500")).To(Succeed())
+		Expect(delegate.queries[2]).To(Equal("limit=500&resourceVersion=0"))
+
+		Expect(get("limit=500&resourceVersion=42")).To(Succeed())
+		Expect(delegate.queries[3]).To(Equal("limit=500&resourceVersion=42"))
+	})
+})
@@ -0,0 +1,189 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package clustergroup maintains named groups of engaged clusters, defined
+// by a ClusterSelector, that builders, fleet clients and the propagation
+// engine reference by name. A group like "prod-eu" is thus defined once,
+// and its membership is maintained centrally as clusters are engaged,
+// disengaged or relabelled.
+package clustergroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/binding"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	"sigs.k8s.io/multicluster-runtime/pkg/selector"
+)
+
+// ErrGroupNotFound is returned for groups that are not defined.
+var ErrGroupNotFound = errors.New("cluster group not found")
+
+// Options are the options for Groups.
+type Options struct {
+	// ResyncInterval is the interval in which the metadata of the engaged
+	// clusters is read again, to pick up label changes that do not lead to
+	// a re-engagement. Zero disables the resync.
+	ResyncInterval time.Duration
+
+	// OnChange is called with the names of the groups whose members
+	// changed. It is called without holding locks of Groups.
+	OnChange func(groups []string)
+}
+
+var _ mcmanager.Runnable = &Groups{}
+
+// Groups maintains the members of named cluster groups among the engaged
+// clusters. Add it to the manager with Manager.Add.
+type Groups struct {
+	mgr   mcmanager.Manager
+	index *binding.Index
+
+	lock      sync.RWMutex
+	selectors map[string]*selector.Selector
+}
+
+// New returns a new Groups.
+func New(mgr mcmanager.Manager, opts Options) *Groups {
+	var onChange func(keys []types.NamespacedName)
+	if opts.OnChange != nil {
+		onChange = func(keys []types.NamespacedName) {
+			names := make([]string, 0, len(keys))
+			for _, key := range keys {
+				names = append(names, key.Name)
+			}
+			opts.OnChange(names)
+		}
+	}
+	return &Groups{
+		mgr:       mgr,
+		index:     binding.New(mgr, binding.Options{ResyncInterval: opts.ResyncInterval, OnChange: onChange}),
+		selectors: map[string]*selector.Selector{},
+	}
+}
+
+// Define adds or replaces the group with the given name. A nil selector
+// selects all clusters.
+func (g *Groups) Define(name string, sel *selector.ClusterSelector) error {
+	if name == "" {
+		return errors.New("cluster group name must not be empty")
+	}
+	compiled, err := sel.Compile()
+	if err != nil {
+		return fmt.Errorf("invalid selector of cluster group %q: %w", name, err)
+	}
+	g.lock.Lock()
+	g.selectors[name] = compiled
+	g.lock.Unlock()
+	return g.index.Set(key(name), sel)
+}
+
+// Remove removes the group with the given name.
+func (g *Groups) Remove(name string) {
+	g.lock.Lock()
+	delete(g.selectors, name)
+	g.lock.Unlock()
+	g.index.Delete(key(name))
+}
+
+// Names returns the sorted names of the defined groups.
+func (g *Groups) Names() []string {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	names := make([]string, 0, len(g.selectors))
+	for name := range g.selectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Members returns the sorted names of the engaged clusters in the group.
+func (g *Groups) Members(name string) ([]string, error) {
+	if _, err := g.selector(name); err != nil {
+		return nil, err
+	}
+	return g.index.ClustersFor(key(name)), nil
+}
+
+// GroupsOf returns the sorted names of the groups the engaged cluster is a
+// member of.
+func (g *Groups) GroupsOf(clusterName string) []string {
+	keys := g.index.ObjectsFor(clusterName)
+	names := make([]string, 0, len(keys))
+	for _, k := range keys {
+		names = append(names, k.Name)
+	}
+	return names
+}
+
+// Matches returns whether the cluster with the given name and metadata
+// belongs to the group. Unlike Members, it does not depend on the cluster
+// being engaged with Groups yet, and thus can be used by components
+// filtering clusters while they are engaged.
+func (g *Groups) Matches(name, clusterName string, md multicluster.Metadata) (bool, error) {
+	sel, err := g.selector(name)
+	if err != nil {
+		return false, err
+	}
+	return sel.Matches(clusterName, md), nil
+}
+
+// MatchesCluster is like Matches, looking up the metadata of the cluster
+// through the manager.
+func (g *Groups) MatchesCluster(ctx context.Context, name, clusterName string) (bool, error) {
+	sel, err := g.selector(name)
+	if err != nil {
+		return false, err
+	}
+	return sel.MatchesCluster(ctx, g.mgr, clusterName)
+}
+
+// Engage adds the cluster to the groups selecting it, and removes it again
+// when ctx is done.
+func (g *Groups) Engage(ctx context.Context, name string, cl cluster.Cluster) error {
+	return g.index.Engage(ctx, name, cl)
+}
+
+// Start resyncs the cluster metadata if configured, and blocks until ctx is
+// done.
+func (g *Groups) Start(ctx context.Context) error {
+	return g.index.Start(ctx)
+}
+
+func (g *Groups) selector(name string) (*selector.Selector, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	sel, ok := g.selectors[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrGroupNotFound, name)
+	}
+	return sel, nil
+}
+
+func key(name string) types.NamespacedName {
+	return types.NamespacedName{Name: name}
+}
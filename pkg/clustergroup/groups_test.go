@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustergroup
+
+import (
+	"context"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/fake"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	"sigs.k8s.io/multicluster-runtime/pkg/selector"
+)
+
+var _ = Describe("Groups", func() {
+	var (
+		mgr     *fake.Manager
+		groups  *Groups
+		lock    sync.Mutex
+		changed []string
+	)
+
+	BeforeEach(func() {
+		changed = nil
+		mgr = fake.NewManagerBuilder().
+			WithCluster("eu-1").WithClusterMetadata("eu-1", multicluster.Metadata{Labels: map[string]string{"env": "prod", "region": "eu"}}).
+			WithCluster("us-1").WithClusterMetadata("us-1", multicluster.Metadata{Labels: map[string]string{"env": "prod", "region": "us"}}).
+			WithCluster("dev").
+			Build()
+		groups = New(mgr, Options{OnChange: func(names []string) {
+			lock.Lock()
+			defer lock.Unlock()
+			changed = append(changed, names...)
+		}})
+	})
+
+	engage := func(ctx context.Context, names ...string) {
+		for _, name := range names {
+			Expect(groups.Engage(ctx, name, mgr.FakeCluster(name))).To(Succeed())
+		}
+	}
+
+	It("maintains the members of the groups", func(ctx SpecContext) {
+		Expect(groups.Define("prod", &selector.ClusterSelector{Expression: "env=prod"})).To(Succeed())
+		Expect(groups.Define("prod-eu", &selector.ClusterSelector{Expression: "env=prod,region=eu"})).To(Succeed())
+		Expect(groups.Names()).To(Equal([]string{"prod", "prod-eu"}))
+
+		clusterCtx, cancel := context.WithCancel(ctx)
+		engage(ctx, "us-1", "dev")
+		engage(clusterCtx, "eu-1")
+		Expect(groups.Members("prod")).To(Equal([]string{"eu-1", "us-1"}))
+		Expect(groups.Members("prod-eu")).To(Equal([]string{"eu-1"}))
+		Expect(groups.GroupsOf("eu-1")).To(Equal([]string{"prod", "prod-eu"}))
+		Expect(groups.GroupsOf("dev")).To(BeEmpty())
+
+		cancel()
+		Eventually(func() ([]string, error) { return groups.Members("prod-eu") }).Should(BeEmpty())
+		Expect(groups.Members("prod")).To(Equal([]string{"us-1"}))
+
+		lock.Lock()
+		defer lock.Unlock()
+		Expect(changed).To(ContainElements("prod", "prod-eu"))
+	})
+
+	It("updates the members when a group is redefined or removed", func(ctx SpecContext) {
+		engage(ctx, "eu-1", "us-1", "dev")
+		Expect(groups.Define("g", &selector.ClusterSelector{Names: []string{"dev"}})).To(Succeed())
+		Expect(groups.Members("g")).To(Equal([]string{"dev"}))
+
+		Expect(groups.Define("g", &selector.ClusterSelector{Expression: "region=us"})).To(Succeed())
+		Expect(groups.Members("g")).To(Equal([]string{"us-1"}))
+
+		groups.Remove("g")
+		_, err := groups.Members("g")
+		Expect(err).To(MatchError(ErrGroupNotFound))
+		Expect(groups.GroupsOf("us-1")).To(BeEmpty())
+	})
+
+	It("matches clusters not engaged yet", func(ctx SpecContext) {
+		Expect(groups.Define("prod-eu", &selector.ClusterSelector{Expression: "env=prod,region=eu"})).To(Succeed())
+		Expect(groups.MatchesCluster(ctx, "prod-eu", "eu-1")).To(BeTrue())
+		Expect(groups.MatchesCluster(ctx, "prod-eu", "us-1")).To(BeFalse())
+		_, err := groups.Matches("staging", "eu-1", multicluster.Metadata{})
+		Expect(err).To(MatchError(ErrGroupNotFound))
+	})
+
+	It("rejects invalid groups", func() {
+		Expect(groups.Define("", nil)).NotTo(Succeed())
+		Expect(groups.Define("bad", &selector.ClusterSelector{Expression: "env in ("})).NotTo(Succeed())
+		Expect(groups.Names()).To(BeEmpty())
+	})
+})
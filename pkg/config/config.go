@@ -0,0 +1,752 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package config provides a versioned configuration file type for the
+// provider and fleet options of a multi-cluster binary.
+package config
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/yaml"
+
+	mccluster "sigs.k8s.io/multicluster-runtime/pkg/cluster"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/selector"
+)
+
+// GroupVersion is the API group and version of the configuration file.
+var GroupVersion = schema.GroupVersion{Group: "config.multicluster.x-k8s.io", Version: "v1alpha1"}
+
+// Kind is the kind of the configuration file.
+const Kind = "FleetConfiguration"
+
+// Provider names known to the configuration.
+const (
+	ProviderClusterAPI          = "cluster-api"
+	ProviderClusterRegistration = "cluster-registration"
+	ProviderDNS                 = "dns"
+	ProviderKind                = "kind"
+	ProviderNamespace           = "namespace"
+	ProviderNone                = "none"
+	ProviderSingle              = "single"
+)
+
+// FleetConfiguration configures the provider and the fleet of a
+// multi-cluster binary.
+type FleetConfiguration struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Provider selects and configures the cluster provider.
+	Provider ProviderConfiguration `json:"provider"`
+
+	// Engagement limits which clusters are engaged.
+	// +optional
+	Engagement EngagementConfiguration `json:"engagement,omitempty"`
+
+	// Cache tunes the caches of the engaged clusters.
+	// +optional
+	Cache CacheConfiguration `json:"cache,omitempty"`
+
+	// Shutdown tunes the shutdown of the manager.
+	// +optional
+	Shutdown ShutdownConfiguration `json:"shutdown,omitempty"`
+
+	// Membership publishes the engaged clusters of the manager to a
+	// ConfigMap of the host cluster.
+	// +optional
+	Membership *MembershipConfiguration `json:"membership,omitempty"`
+
+	// DryRunClusters are the names of the clusters all writes to are sent
+	// as server-side dry-run, e.g. to canary a new controller version
+	// against production clusters.
+	// +optional
+	DryRunClusters []string `json:"dryRunClusters,omitempty"`
+}
+
+// ProviderConfiguration selects a provider by name. Only the section
+// matching the name may be set. The kind and Cluster-API providers live in
+// modules of their own; register their Factory with the options package to
+// use them. Providers without a section here can be registered the same way.
+type ProviderConfiguration struct {
+	// Name is the name of the provider, e.g. "cluster-api" or "dns".
+	// The provider "none" runs the manager in single-cluster mode against the
+	// local cluster only, see mcmanager.WithSingleCluster.
+	Name string `json:"name"`
+
+	// RequireLeadership runs the provider only on the replica elected
+	// leader, when it is run with mcmanager.AddProvider. Other replicas
+	// engage no clusters, but keep serving webhooks and metrics.
+	// +optional
+	RequireLeadership bool `json:"requireLeadership,omitempty"`
+
+	// ClusterAPI configures the Cluster-API provider.
+	// +optional
+	ClusterAPI *ClusterAPIConfiguration `json:"clusterAPI,omitempty"`
+
+	// Kind configures the kind provider.
+	// +optional
+	Kind *KindConfiguration `json:"kind,omitempty"`
+
+	// DNS configures the DNS provider.
+	// +optional
+	DNS *DNSConfiguration `json:"dns,omitempty"`
+
+	// Auth restricts the authentication of kubeconfigs read from secrets.
+	// +optional
+	Auth *AuthConfiguration `json:"auth,omitempty"`
+
+	// Proxy configures the proxies through which clusters are reached.
+	// +optional
+	Proxy *ProxyConfiguration `json:"proxy,omitempty"`
+
+	// Trust overrides the CAs of the kubeconfigs and pins the certificates
+	// of the clusters.
+	// +optional
+	Trust *TrustConfiguration `json:"trust,omitempty"`
+}
+
+// TrustConfiguration overrides the trust of the connections to clusters.
+type TrustConfiguration struct {
+	// Default is the trust of all clusters not listed in Clusters.
+	// +optional
+	Default *ClusterTrustConfiguration `json:"default,omitempty"`
+
+	// Clusters are the trust of individual clusters by cluster name.
+	// +optional
+	Clusters map[string]ClusterTrustConfiguration `json:"clusters,omitempty"`
+}
+
+// ClusterTrustConfiguration is the trust of a cluster.
+type ClusterTrustConfiguration struct {
+	// CAFile is the path of a PEM encoded bundle of the CAs to trust
+	// instead of the CA of the kubeconfig.
+	// +optional
+	CAFile string `json:"caFile,omitempty"`
+
+	// PinnedSHA256 are the hex encoded SHA-256 fingerprints of which at
+	// least one certificate of the chain of the API server must match.
+	// +optional
+	PinnedSHA256 []string `json:"pinnedSHA256,omitempty"`
+}
+
+// ProxyConfiguration configures the proxies through which clusters are
+// reached. URLs have the scheme http or https for HTTP CONNECT proxies, or
+// socks5 for SOCKS5 proxies.
+type ProxyConfiguration struct {
+	// URL is the proxy of all clusters not listed in Clusters.
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// Clusters are the proxies of individual clusters by cluster name. An
+	// empty URL reaches the cluster directly.
+	// +optional
+	Clusters map[string]string `json:"clusters,omitempty"`
+
+	// Konnectivity tunnels the connections to the clusters, or to their
+	// proxies, through a konnectivity server.
+	// +optional
+	Konnectivity *KonnectivityConfiguration `json:"konnectivity,omitempty"`
+}
+
+// KonnectivityConfiguration configures the tunnels through a konnectivity
+// server (apiserver-network-proxy) in HTTP CONNECT mode, to which the
+// konnectivity agents of the clusters connect.
+type KonnectivityConfiguration struct {
+	// Address is the host:port of the konnectivity server, connected to
+	// with TLS.
+	// +optional
+	Address string `json:"address,omitempty"`
+
+	// UDSName is the path of the unix socket of the konnectivity server.
+	// Exactly one of Address and UDSName must be set.
+	// +optional
+	UDSName string `json:"udsName,omitempty"`
+
+	// CAFile is the path of the CA bundle verifying the server.
+	// +optional
+	CAFile string `json:"caFile,omitempty"`
+
+	// CertFile and KeyFile are the paths of the client certificate and key.
+	// +optional
+	CertFile string `json:"certFile,omitempty"`
+	// +optional
+	KeyFile string `json:"keyFile,omitempty"`
+
+	// Clusters restricts the tunnels to the clusters with these names. If
+	// empty, all clusters are tunneled.
+	// +optional
+	Clusters []string `json:"clusters,omitempty"`
+}
+
+// AuthConfiguration restricts the authentication of kubeconfigs read from
+// secrets.
+type AuthConfiguration struct {
+	// ExecAllowList are the commands of exec credential plugins that may
+	// run in the manager pod, compared verbatim with the kubeconfigs. If
+	// empty, kubeconfigs with exec credential plugins are rejected.
+	// +optional
+	ExecAllowList []string `json:"execAllowList,omitempty"`
+}
+
+// ClusterAPIConfiguration configures the Cluster-API provider.
+type ClusterAPIConfiguration struct {
+	// Namespaces restricts the watched Cluster objects to these namespaces.
+	// If empty, all namespaces are watched.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Selector selects the Cluster objects by label.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// KindConfiguration configures the kind provider.
+type KindConfiguration struct {
+	// Prefix restricts the engaged kind clusters to those with the given
+	// name prefix. Defaults to "fleet-".
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// DNSConfiguration configures the DNS provider, discovering the clusters
+// from the SRV records of _<service>._<proto>.<domain>.
+type DNSConfiguration struct {
+	// Domain is the domain of the SRV records.
+	Domain string `json:"domain"`
+
+	// Service is the service of the SRV records. Defaults to "kubernetes".
+	// +optional
+	Service string `json:"service,omitempty"`
+
+	// Proto is the protocol of the SRV records. Defaults to "tcp".
+	// +optional
+	Proto string `json:"proto,omitempty"`
+
+	// Interval is the interval in which the records are looked up.
+	// Defaults to 30 seconds.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// TokenFile is the template of the path of the bearer token file of a
+	// cluster, e.g. "/var/run/fleet/{{.Name}}/token".
+	// +optional
+	TokenFile string `json:"tokenFile,omitempty"`
+
+	// CertDir is the template of the path of the directory with tls.crt,
+	// tls.key and ca.crt of a cluster.
+	// +optional
+	CertDir string `json:"certDir,omitempty"`
+
+	// CAFile is the template of the path of the CA bundle of a cluster.
+	// +optional
+	CAFile string `json:"caFile,omitempty"`
+}
+
+// ShutdownConfiguration tunes the shutdown of the manager.
+type ShutdownConfiguration struct {
+	// FlushTimeout is the time the shutdown hooks get to persist progress
+	// after all clusters were disengaged, before the reconcilers are
+	// stopped. Defaults to 10 seconds.
+	// +optional
+	FlushTimeout *metav1.Duration `json:"flushTimeout,omitempty"`
+}
+
+// MembershipConfiguration configures publishing the engaged clusters.
+type MembershipConfiguration struct {
+	// Namespace is the namespace of the ConfigMap.
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Interval is the interval in which the health of the clusters is
+	// refreshed. Defaults to 30 seconds.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// EngagementConfiguration limits which clusters are engaged, and how fast.
+type EngagementConfiguration struct {
+	// ClusterSelector selects the engaged clusters.
+	// +optional
+	ClusterSelector *selector.ClusterSelector `json:"clusterSelector,omitempty"`
+
+	// MaxClusters is the maximum number of engaged clusters. Zero means
+	// unlimited.
+	// +optional
+	MaxClusters int `json:"maxClusters,omitempty"`
+
+	// Parallelism is the maximum number of clusters whose caches sync at the
+	// same time after engagement. Zero means unlimited.
+	// +optional
+	Parallelism int `json:"parallelism,omitempty"`
+
+	// Stagger is the minimum time between the start of two engagements.
+	// +optional
+	Stagger *metav1.Duration `json:"stagger,omitempty"`
+}
+
+// CacheConfiguration tunes the caches of the engaged clusters.
+type CacheConfiguration struct {
+	// SyncPeriod is the minimum frequency at which watched objects are
+	// reconciled again.
+	// +optional
+	SyncPeriod *metav1.Duration `json:"syncPeriod,omitempty"`
+
+	// Namespaces restricts the caches to these namespaces. If empty, all
+	// namespaces are cached.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// NamespacesKey restricts the cache of each cluster to the
+	// comma-separated namespaces in the label or annotation of the cluster
+	// with this key, e.g. its tenant namespace. It overrides Namespaces for
+	// clusters that have the label or annotation.
+	// +optional
+	NamespacesKey string `json:"namespacesKey,omitempty"`
+
+	// KeepManagedFields keeps the managed fields and last applied
+	// configuration annotations of cached objects. By default, they are
+	// stripped to reduce the memory of the caches.
+	// +optional
+	KeepManagedFields bool `json:"keepManagedFields,omitempty"`
+
+	// LiveFallbackWindow is the time after the engagement of a cluster
+	// during which objects not found in its cache are read from its API
+	// server, rate limited, while the cache warms up. Disabled if unset.
+	// +optional
+	LiveFallbackWindow *metav1.Duration `json:"liveFallbackWindow,omitempty"`
+
+	// Live disables the caches. Clusters are read directly from their API
+	// servers and are not watched.
+	// +optional
+	Live *LiveConfiguration `json:"live,omitempty"`
+
+	// RESTMapper controls when the REST mappers of the clusters reload
+	// their mappings.
+	// +optional
+	RESTMapper *RESTMapperConfiguration `json:"restMapper,omitempty"`
+}
+
+// RESTMapperConfiguration controls when the REST mappers of the clusters
+// reload their mappings.
+type RESTMapperConfiguration struct {
+	// Default is the policy of all clusters not listed in Clusters.
+	// +optional
+	Default *ClusterRESTMapperConfiguration `json:"default,omitempty"`
+
+	// Clusters are the policies of individual clusters by cluster name,
+	// e.g. to reload aggressively where CRDs churn.
+	// +optional
+	Clusters map[string]ClusterRESTMapperConfiguration `json:"clusters,omitempty"`
+}
+
+// ClusterRESTMapperConfiguration is the REST mapper policy of a cluster.
+type ClusterRESTMapperConfiguration struct {
+	// MaxAge is the age after which the mappings are reloaded. If unset,
+	// they are kept until a lookup misses.
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+
+	// MissReloadInterval is the minimum time between reloads after lookups
+	// of unknown kinds. If unset, every miss reloads.
+	// +optional
+	MissReloadInterval *metav1.Duration `json:"missReloadInterval,omitempty"`
+}
+
+// LiveConfiguration configures the rate limits of live reads.
+type LiveConfiguration struct {
+	// QPS is the maximum queries per second to a cluster. Defaults to 5.
+	// +optional
+	QPS float32 `json:"qps,omitempty"`
+
+	// Burst is the maximum burst of queries to a cluster. Defaults to 10.
+	// +optional
+	Burst int `json:"burst,omitempty"`
+}
+
+// Load reads the configuration file at path. Unknown fields are rejected.
+// The result is neither completed nor validated.
+func Load(path string) (*FleetConfiguration, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fleet configuration: %w", err)
+	}
+	cfg := &FleetConfiguration{}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse fleet configuration %q: %w", path, err)
+	}
+	if gvk := cfg.GroupVersionKind(); gvk != GroupVersion.WithKind(Kind) {
+		return nil, fmt.Errorf("unsupported fleet configuration %q, expected %s", gvk, GroupVersion.WithKind(Kind))
+	}
+	return cfg, nil
+}
+
+// Complete sets defaults for unset fields.
+func (c *FleetConfiguration) Complete() {
+	c.APIVersion = GroupVersion.String()
+	c.Kind = Kind
+
+	switch c.Provider.Name {
+	case ProviderKind:
+		if c.Provider.Kind == nil {
+			c.Provider.Kind = &KindConfiguration{}
+		}
+		if c.Provider.Kind.Prefix == "" {
+			c.Provider.Kind.Prefix = "fleet-"
+		}
+	case ProviderClusterAPI:
+		if c.Provider.ClusterAPI == nil {
+			c.Provider.ClusterAPI = &ClusterAPIConfiguration{}
+		}
+	}
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c *FleetConfiguration) Validate() error {
+	var errs field.ErrorList
+
+	p := field.NewPath("provider")
+	if c.Provider.Name == "" {
+		errs = append(errs, field.Required(p.Child("name"), "provider name is required"))
+	}
+	for _, section := range []struct {
+		provider, name string
+		set            bool
+	}{
+		{ProviderClusterAPI, "clusterAPI", c.Provider.ClusterAPI != nil},
+		{ProviderKind, "kind", c.Provider.Kind != nil},
+		{ProviderDNS, "dns", c.Provider.DNS != nil},
+	} {
+		if section.set && section.provider != c.Provider.Name {
+			errs = append(errs, field.Forbidden(p.Child(section.name), fmt.Sprintf("must not be set for provider %q", c.Provider.Name)))
+		}
+	}
+
+	if ls := c.Provider.ClusterAPI; ls != nil && ls.Selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(ls.Selector); err != nil {
+			errs = append(errs, field.Invalid(p.Child("clusterAPI", "selector"), ls.Selector, err.Error()))
+		}
+	}
+
+	if c.Provider.Name == ProviderDNS && (c.Provider.DNS == nil || c.Provider.DNS.Domain == "") {
+		errs = append(errs, field.Required(p.Child("dns", "domain"), "domain is required"))
+	}
+	if d := c.Provider.DNS; d != nil && d.Interval != nil && d.Interval.Duration <= 0 {
+		errs = append(errs, field.Invalid(p.Child("dns", "interval"), d.Interval.Duration.String(), "must be positive"))
+	}
+
+	if px := c.Provider.Proxy; px != nil {
+		if px.URL != "" {
+			if _, err := mccluster.ParseProxyURL(px.URL); err != nil {
+				errs = append(errs, field.Invalid(p.Child("proxy", "url"), px.URL, err.Error()))
+			}
+		}
+		for name, u := range px.Clusters {
+			if u == "" {
+				continue
+			}
+			if _, err := mccluster.ParseProxyURL(u); err != nil {
+				errs = append(errs, field.Invalid(p.Child("proxy", "clusters").Key(name), u, err.Error()))
+			}
+		}
+		if k := px.Konnectivity; k != nil {
+			if (k.Address == "") == (k.UDSName == "") {
+				errs = append(errs, field.Invalid(p.Child("proxy", "konnectivity"), k.Address, "exactly one of address and udsName must be set"))
+			}
+			if (k.CertFile == "") != (k.KeyFile == "") {
+				errs = append(errs, field.Invalid(p.Child("proxy", "konnectivity", "certFile"), k.CertFile, "certFile and keyFile must be set together"))
+			}
+		}
+	}
+
+	if t := c.Provider.Trust; t != nil {
+		validateTrust := func(ct ClusterTrustConfiguration, path *field.Path) {
+			for i, s := range ct.PinnedSHA256 {
+				if _, err := mccluster.ParseFingerprint(s); err != nil {
+					errs = append(errs, field.Invalid(path.Child("pinnedSHA256").Index(i), s, err.Error()))
+				}
+			}
+		}
+		if t.Default != nil {
+			validateTrust(*t.Default, p.Child("trust", "default"))
+		}
+		for name, ct := range t.Clusters {
+			validateTrust(ct, p.Child("trust", "clusters").Key(name))
+		}
+	}
+
+	if a := c.Provider.Auth; a != nil {
+		for i, cmd := range a.ExecAllowList {
+			if cmd == "" {
+				errs = append(errs, field.Required(p.Child("auth", "execAllowList").Index(i), "command must not be empty"))
+			}
+		}
+	}
+
+	e := field.NewPath("engagement")
+	if sel := c.Engagement.ClusterSelector; sel != nil {
+		if _, err := sel.Compile(); err != nil {
+			errs = append(errs, field.Invalid(e.Child("clusterSelector"), sel, err.Error()))
+		}
+	}
+	if c.Engagement.MaxClusters < 0 {
+		errs = append(errs, field.Invalid(e.Child("maxClusters"), c.Engagement.MaxClusters, "must not be negative"))
+	}
+	if c.Engagement.Parallelism < 0 {
+		errs = append(errs, field.Invalid(e.Child("parallelism"), c.Engagement.Parallelism, "must not be negative"))
+	}
+	if d := c.Engagement.Stagger; d != nil && d.Duration < 0 {
+		errs = append(errs, field.Invalid(e.Child("stagger"), d.Duration.String(), "must not be negative"))
+	}
+
+	if d := c.Shutdown.FlushTimeout; d != nil && d.Duration < 0 {
+		errs = append(errs, field.Invalid(field.NewPath("shutdown", "flushTimeout"), d.Duration.String(), "must not be negative"))
+	}
+
+	if mc := c.Membership; mc != nil {
+		m := field.NewPath("membership")
+		if mc.Namespace == "" {
+			errs = append(errs, field.Required(m.Child("namespace"), "must be set"))
+		}
+		if mc.Name == "" {
+			errs = append(errs, field.Required(m.Child("name"), "must be set"))
+		}
+		if d := mc.Interval; d != nil && d.Duration <= 0 {
+			errs = append(errs, field.Invalid(m.Child("interval"), d.Duration.String(), "must be positive"))
+		}
+	}
+
+	if d := c.Cache.SyncPeriod; d != nil && d.Duration <= 0 {
+		errs = append(errs, field.Invalid(field.NewPath("cache", "syncPeriod"), d.Duration.String(), "must be positive"))
+	}
+	if w := c.Cache.LiveFallbackWindow; w != nil && w.Duration <= 0 {
+		errs = append(errs, field.Invalid(field.NewPath("cache", "liveFallbackWindow"), w.Duration.String(), "must be positive"))
+	}
+	if rm := c.Cache.RESTMapper; rm != nil {
+		validateRESTMapper := func(cm ClusterRESTMapperConfiguration, path *field.Path) {
+			if d := cm.MaxAge; d != nil && d.Duration <= 0 {
+				errs = append(errs, field.Invalid(path.Child("maxAge"), d.Duration.String(), "must be positive"))
+			}
+			if d := cm.MissReloadInterval; d != nil && d.Duration < 0 {
+				errs = append(errs, field.Invalid(path.Child("missReloadInterval"), d.Duration.String(), "must not be negative"))
+			}
+		}
+		if rm.Default != nil {
+			validateRESTMapper(*rm.Default, field.NewPath("cache", "restMapper", "default"))
+		}
+		for name, cm := range rm.Clusters {
+			validateRESTMapper(cm, field.NewPath("cache", "restMapper", "clusters").Key(name))
+		}
+	}
+	if l := c.Cache.Live; l != nil {
+		if l.QPS < 0 {
+			errs = append(errs, field.Invalid(field.NewPath("cache", "live", "qps"), l.QPS, "must not be negative"))
+		}
+		if l.Burst < 0 {
+			errs = append(errs, field.Invalid(field.NewPath("cache", "live", "burst"), l.Burst, "must not be negative"))
+		}
+	}
+
+	return errs.ToAggregate()
+}
+
+// ClusterOptions returns the options applying the cache configuration to
+// the clusters created by the provider.
+func (c *FleetConfiguration) ClusterOptions() []cluster.Option {
+	if l := c.Cache.Live; l != nil {
+		return []cluster.Option{mccluster.WithLive(mccluster.LiveOptions{QPS: l.QPS, Burst: l.Burst})}
+	}
+	opts := []cluster.Option{func(o *cluster.Options) {
+		if c.Cache.SyncPeriod != nil {
+			o.Cache.SyncPeriod = ptr.To(c.Cache.SyncPeriod.Duration)
+		}
+		if len(c.Cache.Namespaces) > 0 {
+			o.Cache.DefaultNamespaces = map[string]cache.Config{}
+			for _, ns := range c.Cache.Namespaces {
+				o.Cache.DefaultNamespaces[ns] = cache.Config{}
+			}
+		}
+	}}
+	if !c.Cache.KeepManagedFields {
+		opts = append(opts, mccluster.WithTrimmedObjects(mccluster.TrimOptions{}))
+	}
+	if w := c.Cache.LiveFallbackWindow; w != nil {
+		opts = append(opts, mccluster.WithLiveFallback(mccluster.LiveFallbackOptions{Window: w.Duration}))
+	}
+	return opts
+}
+
+// NamespacesFunc returns the namespaces per cluster configured by
+// NamespacesKey, or nil.
+func (c *FleetConfiguration) NamespacesFunc() mccluster.NamespacesFunc {
+	if c.Cache.NamespacesKey == "" {
+		return nil
+	}
+	return mccluster.NamespacesFromMetadata(c.Cache.NamespacesKey)
+}
+
+// KubeconfigOptions returns the options restricting the authentication of
+// kubeconfigs read by the provider.
+func (c *FleetConfiguration) KubeconfigOptions() mccluster.KubeconfigOptions {
+	if c.Provider.Auth == nil {
+		return mccluster.KubeconfigOptions{}
+	}
+	return mccluster.KubeconfigOptions{ExecAllowList: c.Provider.Auth.ExecAllowList}
+}
+
+// ProxyFunc returns the proxies of the clusters, or nil if no proxy is
+// configured. The configuration must be valid.
+func (c *FleetConfiguration) ProxyFunc() mccluster.ProxyFunc {
+	px := c.Provider.Proxy
+	if px == nil {
+		return nil
+	}
+	var dial mccluster.DialFunc
+	if k := px.Konnectivity; k != nil {
+		dial = mccluster.KonnectivityDialer(mccluster.KonnectivityOptions{
+			Address:  k.Address,
+			UDSName:  k.UDSName,
+			CAFile:   k.CAFile,
+			CertFile: k.CertFile,
+			KeyFile:  k.KeyFile,
+		})
+	}
+	return func(clusterName string) (mccluster.ProxyOptions, bool) {
+		var opts mccluster.ProxyOptions
+		if dial != nil && (len(px.Konnectivity.Clusters) == 0 || slices.Contains(px.Konnectivity.Clusters, clusterName)) {
+			opts.Dial = dial
+		}
+		s, ok := px.Clusters[clusterName]
+		if !ok {
+			s = px.URL
+		}
+		if s != "" {
+			u, err := mccluster.ParseProxyURL(s)
+			if err != nil {
+				return mccluster.ProxyOptions{}, false
+			}
+			opts.URL = u
+		}
+		return opts, opts.URL != nil || opts.Dial != nil
+	}
+}
+
+// TrustFunc returns the trust of the clusters, or nil if no trust is
+// configured.
+func (c *FleetConfiguration) TrustFunc() mccluster.TrustFunc {
+	t := c.Provider.Trust
+	if t == nil {
+		return nil
+	}
+	return func(clusterName string) (mccluster.TrustOptions, bool) {
+		ct, ok := t.Clusters[clusterName]
+		if !ok {
+			if t.Default == nil {
+				return mccluster.TrustOptions{}, false
+			}
+			ct = *t.Default
+		}
+		return mccluster.TrustOptions{CAFile: ct.CAFile, PinnedSHA256: ct.PinnedSHA256}, true
+	}
+}
+
+// ProviderOptions returns the options to run the provider with
+// mcmanager.AddProvider.
+func (c *FleetConfiguration) ProviderOptions() []mcmanager.ProviderOption {
+	if c.Provider.RequireLeadership {
+		return []mcmanager.ProviderOption{mcmanager.RequireLeadership()}
+	}
+	return nil
+}
+
+// ManagerOptions returns the options applying the engagement, shutdown and
+// membership configuration to the multi-cluster manager. The REST mapper
+// policies are applied through the cluster constructor of the manager,
+// unless a constructor was configured with mcmanager.WithNewCluster
+// before. Clusters are then created with mcmanager.DefaultNewCluster,
+// also by providers defaulting to another constructor.
+func (c *FleetConfiguration) ManagerOptions() []mcmanager.Option {
+	var stagger time.Duration
+	if c.Engagement.Stagger != nil {
+		stagger = c.Engagement.Stagger.Duration
+	}
+	opts := []mcmanager.Option{mcmanager.WithEngagementParallelism(c.Engagement.Parallelism, stagger)}
+	if c.Shutdown.FlushTimeout != nil {
+		opts = append(opts, mcmanager.WithShutdownFlushTimeout(c.Shutdown.FlushTimeout.Duration))
+	}
+	if mc := c.Membership; mc != nil {
+		mo := mcmanager.MembershipOptions{ConfigMap: types.NamespacedName{Namespace: mc.Namespace, Name: mc.Name}}
+		if mc.Interval != nil {
+			mo.Interval = mc.Interval.Duration
+		}
+		opts = append(opts, mcmanager.WithMembership(mo))
+	}
+	if len(c.DryRunClusters) > 0 {
+		opts = append(opts, mcmanager.WithDryRun(c.DryRunClusters...))
+	}
+	if c.Provider.Name == ProviderNone {
+		opts = append(opts, mcmanager.WithSingleCluster())
+	}
+	if rm := c.Cache.RESTMapper; rm != nil {
+		newCluster := mcmanager.WithNewCluster(func(name string, cfg *rest.Config, clusterOpts ...cluster.Option) (cluster.Cluster, error) {
+			if mo, ok := c.restMapperOptions(name); ok {
+				clusterOpts = append(slices.Clip(clusterOpts), mccluster.WithRESTMapperRefresh(name, mo))
+			}
+			return mcmanager.DefaultNewCluster(name, cfg, clusterOpts...)
+		})
+		// a cluster constructor configured by the user takes precedence.
+		opts = append(opts, func(o *mcmanager.MultiClusterOptions) {
+			if o.NewCluster == nil {
+				newCluster(o)
+			}
+		})
+	}
+	return opts
+}
+
+// restMapperOptions returns the REST mapper policy of the cluster, if any.
+func (c *FleetConfiguration) restMapperOptions(clusterName string) (mccluster.RESTMapperOptions, bool) {
+	rm := c.Cache.RESTMapper
+	cm, ok := rm.Clusters[clusterName]
+	if !ok {
+		if rm.Default == nil {
+			return mccluster.RESTMapperOptions{}, false
+		}
+		cm = *rm.Default
+	}
+	var mo mccluster.RESTMapperOptions
+	if cm.MaxAge != nil {
+		mo.MaxAge = cm.MaxAge.Duration
+	}
+	if cm.MissReloadInterval != nil {
+		mo.MissReloadInterval = cm.MissReloadInterval.Duration
+	}
+	return mo, true
+}
@@ -0,0 +1,265 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	mccluster "sigs.k8s.io/multicluster-runtime/pkg/cluster"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+)
+
+var _ = Describe("FleetConfiguration", func() {
+	write := func(content string) string {
+		path := filepath.Join(GinkgoT().TempDir(), "config.yaml")
+		Expect(os.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+		return path
+	}
+
+	It("loads, completes and validates a configuration", func() {
+		cfg, err := Load(write(`apiVersion: config.multicluster.x-k8s.io/v1alpha1
+kind: FleetConfiguration
+provider:
+  name: cluster-api
+  clusterAPI:
+    namespaces: [fleet]
+engagement:
+  maxClusters: 10
+  clusterSelector:
+    expression: env=prod
+cache:
+  syncPeriod: 5m
+  namespaces: [default]
+`))
+		Expect(err).NotTo(HaveOccurred())
+		cfg.Complete()
+		Expect(cfg.Validate()).To(Succeed())
+		Expect(cfg.Provider.ClusterAPI.Namespaces).To(Equal([]string{"fleet"}))
+
+		opts := &cluster.Options{}
+		for _, o := range cfg.ClusterOptions() {
+			o(opts)
+		}
+		Expect(*opts.Cache.SyncPeriod).To(Equal(5 * time.Minute))
+		Expect(opts.Cache.DefaultNamespaces).To(HaveKey("default"))
+		Expect(opts.NewCache).NotTo(BeNil(), "managed fields are stripped by default")
+
+		cfg.Cache.KeepManagedFields = true
+		opts = &cluster.Options{}
+		for _, o := range cfg.ClusterOptions() {
+			o(opts)
+		}
+		Expect(opts.NewCache).To(BeNil())
+		Expect(opts.NewClient).To(BeNil())
+
+		cfg.Cache.LiveFallbackWindow = &metav1.Duration{Duration: time.Minute}
+		opts = &cluster.Options{}
+		for _, o := range cfg.ClusterOptions() {
+			o(opts)
+		}
+		Expect(opts.NewClient).NotTo(BeNil(), "cache misses fall back to live reads")
+
+		cfg.Cache.LiveFallbackWindow.Duration = 0
+		Expect(cfg.Validate()).To(MatchError(ContainSubstring("cache.liveFallbackWindow")))
+	})
+
+	It("disables the caches for live reads", func() {
+		cfg, err := Load(write(`apiVersion: config.multicluster.x-k8s.io/v1alpha1
+kind: FleetConfiguration
+provider:
+  name: kind
+cache:
+  live:
+    qps: 2
+`))
+		Expect(err).NotTo(HaveOccurred())
+		cfg.Complete()
+		Expect(cfg.Validate()).To(Succeed())
+
+		opts := &cluster.Options{}
+		for _, o := range cfg.ClusterOptions() {
+			o(opts)
+		}
+		Expect(opts.NewCache).NotTo(BeNil())
+
+		cfg.Cache.Live.Burst = -1
+		Expect(cfg.Validate()).To(MatchError(ContainSubstring("cache.live.burst")))
+	})
+
+	It("reaches clusters through their proxies", func() {
+		cfg, err := Load(write(`apiVersion: config.multicluster.x-k8s.io/v1alpha1
+kind: FleetConfiguration
+provider:
+  name: kind
+  proxy:
+    url: http://proxy:3128
+    clusters:
+      edge: socks5://tunnel:1080
+      local: ""
+`))
+		Expect(err).NotTo(HaveOccurred())
+		cfg.Complete()
+		Expect(cfg.Validate()).To(Succeed())
+
+		proxy := cfg.ProxyFunc()
+		opts, ok := proxy("edge")
+		Expect(ok).To(BeTrue())
+		Expect(opts.URL.String()).To(Equal("socks5://tunnel:1080"))
+		opts, ok = proxy("other")
+		Expect(ok).To(BeTrue())
+		Expect(opts.URL.String()).To(Equal("http://proxy:3128"))
+		_, ok = proxy("local")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("tunnels clusters through konnectivity", func() {
+		cfg, err := Load(write(`apiVersion: config.multicluster.x-k8s.io/v1alpha1
+kind: FleetConfiguration
+provider:
+  name: kind
+  proxy:
+    konnectivity:
+      udsName: /etc/konnectivity/konnectivity-server.socket
+      clusters: [spoke]
+`))
+		Expect(err).NotTo(HaveOccurred())
+		cfg.Complete()
+		Expect(cfg.Validate()).To(Succeed())
+
+		proxy := cfg.ProxyFunc()
+		opts, ok := proxy("spoke")
+		Expect(ok).To(BeTrue())
+		Expect(opts.Dial).NotTo(BeNil())
+		Expect(opts.URL).To(BeNil())
+		_, ok = proxy("other")
+		Expect(ok).To(BeFalse())
+
+		cfg.Provider.Proxy.Konnectivity.Address = "konnectivity:8131"
+		Expect(cfg.Validate()).To(MatchError(ContainSubstring("exactly one of address and udsName")))
+	})
+
+	It("runs the manager in single-cluster mode without provider", func() {
+		cfg, err := Load(write(`apiVersion: config.multicluster.x-k8s.io/v1alpha1
+kind: FleetConfiguration
+provider:
+  name: none
+`))
+		Expect(err).NotTo(HaveOccurred())
+		cfg.Complete()
+		Expect(cfg.Validate()).To(Succeed())
+
+		opts := &mcmanager.MultiClusterOptions{}
+		for _, o := range cfg.ManagerOptions() {
+			o(opts)
+		}
+		Expect(opts.SingleCluster).To(BeTrue())
+	})
+
+	It("applies the REST mapper policies per cluster", func() {
+		cfg, err := Load(write(`apiVersion: config.multicluster.x-k8s.io/v1alpha1
+kind: FleetConfiguration
+provider:
+  name: kind
+cache:
+  restMapper:
+    default:
+      missReloadInterval: 10m
+    clusters:
+      crd-churn:
+        maxAge: 1m
+`))
+		Expect(err).NotTo(HaveOccurred())
+		cfg.Complete()
+		Expect(cfg.Validate()).To(Succeed())
+
+		opts := &mcmanager.MultiClusterOptions{}
+		for _, o := range cfg.ManagerOptions() {
+			o(opts)
+		}
+		Expect(opts.NewCluster).NotTo(BeNil())
+
+		custom := 0
+		opts = &mcmanager.MultiClusterOptions{}
+		mcmanager.WithNewCluster(func(string, *rest.Config, ...cluster.Option) (cluster.Cluster, error) {
+			custom++
+			return nil, nil
+		})(opts)
+		for _, o := range cfg.ManagerOptions() {
+			o(opts)
+		}
+		_, err = opts.NewCluster("crd-churn", &rest.Config{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(custom).To(Equal(1), "a user constructor is kept")
+
+		mo, ok := cfg.restMapperOptions("crd-churn")
+		Expect(ok).To(BeTrue())
+		Expect(mo).To(Equal(mccluster.RESTMapperOptions{MaxAge: time.Minute}))
+		mo, ok = cfg.restMapperOptions("stable")
+		Expect(ok).To(BeTrue())
+		Expect(mo).To(Equal(mccluster.RESTMapperOptions{MissReloadInterval: 10 * time.Minute}))
+
+		cfg.Cache.RESTMapper.Clusters["crd-churn"] = ClusterRESTMapperConfiguration{MaxAge: &metav1.Duration{}}
+		Expect(cfg.Validate()).To(MatchError(ContainSubstring("cache.restMapper.clusters[crd-churn].maxAge")))
+	})
+
+	It("rejects unknown fields and versions", func() {
+		_, err := Load(write("apiVersion: config.multicluster.x-k8s.io/v1alpha1\nkind: FleetConfiguration\nprovider:\n  nme: kind\n"))
+		Expect(err).To(HaveOccurred())
+
+		_, err = Load(write("apiVersion: config.multicluster.x-k8s.io/v2\nkind: FleetConfiguration\n"))
+		Expect(err).To(MatchError(ContainSubstring("unsupported fleet configuration")))
+	})
+
+	It("rejects invalid configurations", func() {
+		cfg := &FleetConfiguration{
+			Provider: ProviderConfiguration{
+				Name:       "kind",
+				ClusterAPI: &ClusterAPIConfiguration{},
+				Auth:       &AuthConfiguration{ExecAllowList: []string{""}},
+				Proxy:      &ProxyConfiguration{Clusters: map[string]string{"edge": "ftp://proxy"}},
+				Trust:      &TrustConfiguration{Default: &ClusterTrustConfiguration{PinnedSHA256: []string{"zz"}}},
+			},
+			Engagement: EngagementConfiguration{MaxClusters: -1, Parallelism: -1},
+			Shutdown:   ShutdownConfiguration{FlushTimeout: &metav1.Duration{Duration: -time.Second}},
+			Membership: &MembershipConfiguration{Namespace: "default"},
+		}
+		cfg.Complete()
+		err := cfg.Validate()
+		Expect(err).To(MatchError(ContainSubstring("provider.clusterAPI")))
+		Expect(err).To(MatchError(ContainSubstring("engagement.maxClusters")))
+		Expect(err).To(MatchError(ContainSubstring("engagement.parallelism")))
+		Expect(err).To(MatchError(ContainSubstring("provider.auth.execAllowList[0]")))
+		Expect(err).To(MatchError(ContainSubstring("shutdown.flushTimeout")))
+		Expect(err).To(MatchError(ContainSubstring("membership.name")))
+		Expect(err).To(MatchError(ContainSubstring("provider.proxy.clusters[edge]")))
+		Expect(err).To(MatchError(ContainSubstring("provider.trust.default.pinnedSHA256[0]")))
+
+		Expect((&FleetConfiguration{}).Validate()).To(MatchError(ContainSubstring("provider.name")))
+		Expect((&FleetConfiguration{Provider: ProviderConfiguration{Name: ProviderDNS}}).Validate()).To(MatchError(ContainSubstring("provider.dns.domain")))
+	})
+})
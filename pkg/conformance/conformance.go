@@ -0,0 +1,307 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conformance provides a Ginkgo suite validating that a Provider
+// implementation has the semantics the manager and its components rely on.
+//
+// Provider authors register the suite in their Ginkgo test suite with a
+// Harness driving the clusters known to their provider:
+//
+//	var _ = conformance.DescribeProvider("my-provider", conformance.Harness{
+//		New:           newProvider,
+//		AddCluster:    addCluster,
+//		RemoveCluster: removeCluster,
+//	})
+package conformance
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/fake"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+// Provider is a provider under test. It is run like by AddProvider.
+type Provider interface {
+	multicluster.Provider
+	mcmanager.ProviderRunner
+}
+
+// Harness drives the provider under test.
+type Harness struct {
+	// New returns a new provider that knows no clusters. Required.
+	New func(ctx context.Context) (Provider, error)
+
+	// AddCluster makes a cluster with the given name available to the
+	// providers, e.g. by creating a secret or a DNS record. Required.
+	AddCluster func(ctx context.Context, name string) error
+
+	// RemoveCluster makes the cluster with the given name unavailable to
+	// the providers. Required.
+	RemoveCluster func(ctx context.Context, name string) error
+
+	// RenameCluster renames a cluster available to the providers. The
+	// rename specs are skipped if nil.
+	RenameCluster func(ctx context.Context, oldName, newName string) error
+
+	// Timeout is the time the provider may take to react to a change of
+	// its clusters. Defaults to 10 seconds.
+	Timeout time.Duration
+}
+
+// DescribeProvider registers the conformance specs of the provider with
+// Ginkgo. It is meant to be called at the top level of a test file.
+func DescribeProvider(name string, h Harness) bool {
+	if h.Timeout <= 0 {
+		h.Timeout = 10 * time.Second
+	}
+	return Describe("Provider conformance: "+name, func() {
+		var (
+			p       Provider
+			mgr     mcmanager.Manager
+			engaged *recorder
+		)
+
+		BeforeEach(func(ctx SpecContext) {
+			var err error
+			p, err = h.New(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			// the manager runs against the provider under test, so that it
+			// calls back into the provider while engaging its clusters.
+			mgr, err = mcmanager.WithMultiCluster(fake.NewManagerBuilder().Build().GetLocalManager(), p)
+			Expect(err).NotTo(HaveOccurred())
+			engaged = &recorder{mgr: mgr}
+			Expect(mgr.Add(engaged)).To(Succeed())
+		})
+
+		run := func(ctx context.Context) (stop func()) {
+			runCtx, cancel := context.WithCancel(ctx)
+			done := make(chan error, 1)
+			go func() { done <- p.Run(runCtx, mgr) }()
+			return func() {
+				cancel()
+				var err error
+				Eventually(done, h.Timeout).Should(Receive(&err), "Run must return when its context is done")
+				if err != nil {
+					Expect(err).To(MatchError(context.Canceled))
+				}
+			}
+		}
+		notFound := func(ctx context.Context, clusterName string) func() error {
+			return func() error {
+				_, err := p.Get(ctx, clusterName)
+				return err
+			}
+		}
+
+		It("is usable before it is started", func(ctx SpecContext) {
+			_, err := p.Get(ctx, "conformance-unknown")
+			Expect(err).To(MatchError(multicluster.ErrClusterNotFound))
+			Expect(p.IndexField(ctx, &corev1.ConfigMap{}, "conformance", func(client.Object) []string { return nil })).To(Succeed())
+		})
+
+		It("engages added clusters and serves them with Get", func(ctx SpecContext) {
+			Expect(h.AddCluster(ctx, "conformance-a")).To(Succeed())
+			defer run(ctx)()
+
+			Eventually(engaged.names, h.Timeout).Should(ConsistOf("conformance-a"))
+			Expect(h.AddCluster(ctx, "conformance-b")).To(Succeed())
+			Eventually(engaged.names, h.Timeout).Should(ConsistOf("conformance-a", "conformance-b"))
+
+			cl, err := p.Get(ctx, "conformance-b")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cl).To(BeIdenticalTo(engaged.cluster("conformance-b")), "Get must return the engaged cluster")
+		})
+
+		It("engages every cluster once", func(ctx SpecContext) {
+			Expect(h.AddCluster(ctx, "conformance-a")).To(Succeed())
+			defer run(ctx)()
+
+			Eventually(engaged.names, h.Timeout).Should(ConsistOf("conformance-a"))
+			first, err := p.Get(ctx, "conformance-a")
+			Expect(err).NotTo(HaveOccurred())
+			Consistently(func() int { return engaged.count("conformance-a") }, h.Timeout/4).Should(Equal(1))
+			Expect(engaged.context("conformance-a").Err()).NotTo(HaveOccurred())
+			Expect(p.Get(ctx, "conformance-a")).To(BeIdenticalTo(first))
+		})
+
+		It("disengages removed clusters", func(ctx SpecContext) {
+			Expect(h.AddCluster(ctx, "conformance-a")).To(Succeed())
+			Expect(h.AddCluster(ctx, "conformance-b")).To(Succeed())
+			defer run(ctx)()
+			Eventually(engaged.names, h.Timeout).Should(ConsistOf("conformance-a", "conformance-b"))
+
+			Expect(h.RemoveCluster(ctx, "conformance-a")).To(Succeed())
+			Eventually(engaged.context("conformance-a").Done(), h.Timeout).Should(BeClosed(), "the engagement context must be cancelled")
+			Eventually(notFound(ctx, "conformance-a"), h.Timeout).Should(MatchError(multicluster.ErrClusterNotFound))
+			Expect(engaged.context("conformance-b").Err()).NotTo(HaveOccurred())
+		})
+
+		It("handles renamed clusters", func(ctx SpecContext) {
+			if h.RenameCluster == nil {
+				Skip("the provider does not support renames")
+			}
+			Expect(h.AddCluster(ctx, "conformance-a")).To(Succeed())
+			defer run(ctx)()
+			Eventually(engaged.names, h.Timeout).Should(ConsistOf("conformance-a"))
+
+			Expect(h.RenameCluster(ctx, "conformance-a", "conformance-renamed")).To(Succeed())
+			Eventually(engaged.context("conformance-a").Done(), h.Timeout).Should(BeClosed())
+			Eventually(func() context.Context { return engaged.context("conformance-renamed") }, h.Timeout).ShouldNot(BeNil())
+			Expect(engaged.context("conformance-renamed").Err()).NotTo(HaveOccurred())
+			Eventually(notFound(ctx, "conformance-a"), h.Timeout).Should(MatchError(multicluster.ErrClusterNotFound))
+			Expect(p.Get(ctx, "conformance-renamed")).NotTo(BeNil())
+		})
+
+		It("serves concurrent Gets while clusters change", func(ctx SpecContext) {
+			Expect(h.AddCluster(ctx, "conformance-a")).To(Succeed())
+			defer run(ctx)()
+			Eventually(engaged.names, h.Timeout).Should(ContainElement("conformance-a"))
+
+			getCtx, stopGets := context.WithCancel(ctx)
+			var wg sync.WaitGroup
+			errs := make(chan error, 16)
+			for range cap(errs) {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					defer GinkgoRecover()
+					for getCtx.Err() == nil {
+						for _, name := range []string{"conformance-a", "conformance-b"} {
+							cl, err := p.Get(ctx, name)
+							switch {
+							case errors.Is(err, multicluster.ErrClusterNotFound):
+							case err != nil:
+								errs <- err
+								return
+							case cl == nil:
+								errs <- errors.New("Get returned neither a cluster nor an error")
+								return
+							}
+						}
+					}
+				}()
+			}
+			Expect(h.AddCluster(ctx, "conformance-b")).To(Succeed())
+			Eventually(engaged.names, h.Timeout).Should(ContainElement("conformance-b"))
+			Expect(h.RemoveCluster(ctx, "conformance-a")).To(Succeed())
+			Eventually(notFound(ctx, "conformance-a"), h.Timeout).Should(MatchError(multicluster.ErrClusterNotFound))
+			stopGets()
+			wg.Wait()
+			close(errs)
+			Expect(errs).NotTo(Receive())
+		})
+	})
+}
+
+// recorder is a runnable recording the clusters engaged by the provider.
+// Like controllers, it gets the clusters from the manager while engaging.
+type recorder struct {
+	mgr mcmanager.Manager
+
+	lock    sync.Mutex
+	engaged []engagement
+}
+
+type engagement struct {
+	name    string
+	ctx     context.Context
+	cluster cluster.Cluster
+}
+
+func (r *recorder) Engage(ctx context.Context, name string, cl cluster.Cluster) error {
+	if _, err := r.mgr.GetCluster(ctx, name); err != nil {
+		return err
+	}
+	if _, err := r.mgr.GetClusterMetadata(ctx, name); err != nil {
+		return err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.engaged = append(r.engaged, engagement{name: name, ctx: ctx, cluster: cl})
+	return nil
+}
+
+func (r *recorder) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+// names returns the names of the clusters currently engaged.
+func (r *recorder) names() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var names []string
+	for _, e := range r.engaged {
+		if e.ctx.Err() == nil {
+			names = append(names, e.name)
+		}
+	}
+	return names
+}
+
+// count returns how often the cluster was engaged.
+func (r *recorder) count(name string) int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	n := 0
+	for _, e := range r.engaged {
+		if e.name == name {
+			n++
+		}
+	}
+	return n
+}
+
+// last returns the latest engagement of the cluster.
+func (r *recorder) last(name string) (engagement, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for i := len(r.engaged) - 1; i >= 0; i-- {
+		if r.engaged[i].name == name {
+			return r.engaged[i], true
+		}
+	}
+	return engagement{}, false
+}
+
+// context returns the context of the latest engagement of the cluster, or
+// nil if it was never engaged.
+func (r *recorder) context(name string) context.Context {
+	e, ok := r.last(name)
+	if !ok {
+		return nil
+	}
+	return e.ctx
+}
+
+// cluster returns the cluster of the latest engagement of the cluster.
+func (r *recorder) cluster(name string) cluster.Cluster {
+	e, _ := r.last(name)
+	return e.cluster
+}
@@ -21,12 +21,24 @@ import (
 
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
 	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
 )
 
 type clusterKeyType string
 
-const clusterKey clusterKeyType = "cluster"
+const (
+	clusterKey    clusterKeyType = "cluster"
+	clustersKey   clusterKeyType = "clusters"
+	metadataKey   clusterKeyType = "metadata"
+	initialKey    clusterKeyType = "initialSync"
+	controllerKey clusterKeyType = "controller"
+	priorityKey   clusterKeyType = "lowPriority"
+)
+
+// MetadataFunc looks up the metadata of a cluster, e.g.
+// Manager.GetClusterMetadata.
+type MetadataFunc func(ctx context.Context, clusterName string) (multicluster.Metadata, error)
 
 // WithCluster returns a new context with the given cluster.
 func WithCluster(ctx context.Context, cluster string) context.Context {
@@ -39,6 +51,90 @@ func ClusterFrom(ctx context.Context) (string, bool) {
 	return cluster, ok
 }
 
+// WithMetadataFunc returns a new context with the given function to look up
+// the metadata of the cluster in the context.
+func WithMetadataFunc(ctx context.Context, fn MetadataFunc) context.Context {
+	return context.WithValue(ctx, metadataKey, fn)
+}
+
+// ClusterMetadataFrom returns the current metadata of the cluster in the
+// context. It returns false if the context has no cluster or no metadata
+// function, or if the lookup fails.
+//
+// Reconcilers, event handlers and map functions of multi-cluster
+// controllers are called with a context that has both. Predicates are not
+// called with a context, use a cluster-aware event handler instead.
+func ClusterMetadataFrom(ctx context.Context) (multicluster.Metadata, bool) {
+	cluster, ok := ClusterFrom(ctx)
+	if !ok {
+		return multicluster.Metadata{}, false
+	}
+	fn, ok := ctx.Value(metadataKey).(MetadataFunc)
+	if !ok {
+		return multicluster.Metadata{}, false
+	}
+	md, err := fn(ctx, cluster)
+	if err != nil {
+		return multicluster.Metadata{}, false
+	}
+	return md, true
+}
+
+// WithClusters returns a new context with the given set of clusters. It is
+// used for requests that have been deduplicated across clusters.
+func WithClusters(ctx context.Context, clusters []string) context.Context {
+	return context.WithValue(ctx, clustersKey, clusters)
+}
+
+// ClustersFrom returns the set of clusters that triggered a deduplicated
+// request from the context.
+func ClustersFrom(ctx context.Context) ([]string, bool) {
+	clusters, ok := ctx.Value(clustersKey).([]string)
+	return clusters, ok
+}
+
+// WithInitialSync returns a new context marking the request as triggered by
+// the initial sync of its cluster only.
+func WithInitialSync(ctx context.Context) context.Context {
+	return context.WithValue(ctx, initialKey, true)
+}
+
+// IsInitialSync returns whether the request being reconciled was triggered
+// only by objects listed when its cluster was engaged, as opposed to changes
+// observed afterwards. Controllers can use it to suppress notifications
+// while a new cluster warms up. It returns false if the controller does not
+// detect the initial sync.
+func IsInitialSync(ctx context.Context) bool {
+	initial, _ := ctx.Value(initialKey).(bool)
+	return initial
+}
+
+// WithController returns a new context with the name of the controller
+// reconciling.
+func WithController(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, controllerKey, name)
+}
+
+// ControllerFrom returns the name of the controller reconciling from the
+// context. Multi-cluster controllers set it for their reconcilers.
+func ControllerFrom(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(controllerKey).(string)
+	return name, ok
+}
+
+// WithLowPriority returns a new context marking the requests sent with it
+// as non-urgent, e.g. periodic full reconciles. Clusters configured with
+// low-priority flows send them as such.
+func WithLowPriority(ctx context.Context) context.Context {
+	return context.WithValue(ctx, priorityKey, true)
+}
+
+// IsLowPriority returns whether the context marks requests as non-urgent.
+func IsLowPriority(ctx context.Context) bool {
+	low, _ := ctx.Value(priorityKey).(bool)
+	return low
+}
+
 // ReconcilerWithClusterInContext returns a reconciler that sets the cluster name in the
 // context.
 func ReconcilerWithClusterInContext(r reconcile.Reconciler) mcreconcile.Reconciler {
@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package context
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const provenanceKey clusterKeyType = "provenance"
+
+// EventType is the type of the event that enqueued a request.
+type EventType string
+
+const (
+	// EventCreate is an object seen for the first time, including on the
+	// initial list of a watch.
+	EventCreate EventType = "Create"
+	// EventUpdate is a changed object.
+	EventUpdate EventType = "Update"
+	// EventDelete is a deleted object.
+	EventDelete EventType = "Delete"
+	// EventGeneric is an event of a source other than a watch.
+	EventGeneric EventType = "Generic"
+)
+
+// Provenance is the event that enqueued a request.
+type Provenance struct {
+	// Cluster is the name of the cluster the event was observed in.
+	Cluster string
+
+	// GroupVersionKind is the kind of the object of the event, if known.
+	GroupVersionKind schema.GroupVersionKind
+
+	// Event is the type of the event.
+	Event EventType
+
+	// Object is the key of the object of the event, which is not
+	// necessarily the key of the request, e.g. for owned objects.
+	Object types.NamespacedName
+}
+
+// WithProvenance returns a new context with the given provenance.
+func WithProvenance(ctx context.Context, p Provenance) context.Context {
+	return context.WithValue(ctx, provenanceKey, p)
+}
+
+// ProvenanceFrom returns the event that enqueued the request being
+// reconciled. It returns false if provenance is not recorded by the
+// controller, or if the request was not enqueued by an event, e.g. when it
+// was requeued by the reconciler.
+func ProvenanceFrom(ctx context.Context) (Provenance, bool) {
+	p, ok := ctx.Value(provenanceKey).(Provenance)
+	return p, ok
+}
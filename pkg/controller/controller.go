@@ -18,7 +18,6 @@ package controller
 
 import (
 	"context"
-	"fmt"
 	"sync"
 
 	"k8s.io/client-go/util/workqueue"
@@ -26,8 +25,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	mccontext "sigs.k8s.io/multicluster-runtime/pkg/context"
+	mcerrors "sigs.k8s.io/multicluster-runtime/pkg/errors"
 	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
 	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
 	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
@@ -85,27 +87,46 @@ func NewUnmanaged(name string, mgr mcmanager.Manager, options Options) (Controll
 //
 // The name must be unique as it is used to identify the controller in metrics and logs.
 func NewTypedUnmanaged[request mcreconcile.ClusterAware[request]](name string, mgr mcmanager.Manager, options controller.TypedOptions[request]) (TypedController[request], error) {
+	if options.Reconciler != nil {
+		options.Reconciler = reconcilerWithClusterInContext(name, options.Reconciler, mgr.GetClusterMetadata)
+	}
 	c, err := controller.NewTypedUnmanaged[request](name, mgr.GetLocalManager(), options)
 	if err != nil {
 		return nil, err
 	}
 	return &mcController[request]{
 		TypedController: c,
+		metadata:        mgr.GetClusterMetadata,
 		clusters:        make(map[string]engagedCluster),
 	}, nil
 }
 
+// reconcilerWithClusterInContext sets the controller name, the cluster of
+// the request and the metadata lookup in the context of the reconciler.
+func reconcilerWithClusterInContext[request mcreconcile.ClusterAware[request]](controllerName string, r reconcile.TypedReconciler[request], metadata mccontext.MetadataFunc) reconcile.TypedReconciler[request] {
+	return reconcile.TypedFunc[request](func(ctx context.Context, req request) (reconcile.Result, error) {
+		ctx = mccontext.WithController(ctx, controllerName)
+		if name := req.Cluster(); name != "" {
+			ctx = mccontext.WithMetadataFunc(mccontext.WithCluster(ctx, name), metadata)
+		}
+		return r.Reconcile(ctx, req)
+	})
+}
+
 var _ TypedController[mcreconcile.Request] = &mcController[mcreconcile.Request]{}
 
 type mcController[request mcreconcile.ClusterAware[request]] struct {
 	controller.TypedController[request]
 
+	metadata mccontext.MetadataFunc
+
 	lock     sync.Mutex
 	clusters map[string]engagedCluster
 	sources  []mcsource.TypedSource[client.Object, request]
 }
 
 type engagedCluster struct {
+	ctx     context.Context
 	name    string
 	cluster cluster.Cluster
 }
@@ -130,23 +151,25 @@ func (c *mcController[request]) Engage(ctx context.Context, name string, cl clus
 
 	// engage cluster aware instances
 	for _, aware := range c.sources {
-		src, err := aware.ForCluster(name, cl)
+		src, err := mcsource.ForClusterWithContext[client.Object, request](ctx, aware, name, cl)
 		if err != nil {
 			cancel()
-			return fmt.Errorf("failed to engage for cluster %q: %w", name, err)
+			return mcerrors.New(name, "engage source", err)
 		}
-		if err := c.TypedController.Watch(startWithinContext[request](ctx, src)); err != nil {
+		if err := c.TypedController.Watch(startWithinContext[request](c.clusterContext(ctx, name), src)); err != nil {
 			cancel()
-			return fmt.Errorf("failed to watch for cluster %q: %w", name, err)
+			return mcerrors.New(name, "watch", err)
 		}
 	}
 
 	ec := engagedCluster{
+		ctx:     ctx,
 		name:    name,
 		cluster: cl,
 	}
 	c.clusters[name] = ec
 	go func() {
+		<-ctx.Done()
 		c.lock.Lock()
 		defer c.lock.Unlock()
 		if c.clusters[name] == ec {
@@ -161,23 +184,26 @@ func (c *mcController[request]) MultiClusterWatch(src mcsource.TypedSource[clien
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	ctx, cancel := context.WithCancel(context.Background()) //nolint:govet // cancel is called in the error case only.
-
+	// watch the clusters engaged so far until they are disengaged.
 	for name, eng := range c.clusters {
-		src, err := src.ForCluster(name, eng.cluster)
+		src, err := mcsource.ForClusterWithContext[client.Object, request](eng.ctx, src, name, eng.cluster)
 		if err != nil {
-			cancel()
-			return fmt.Errorf("failed to engage for cluster %q: %w", name, err)
+			return mcerrors.New(name, "engage source", err)
 		}
-		if err := c.TypedController.Watch(startWithinContext[request](ctx, src)); err != nil {
-			cancel()
-			return fmt.Errorf("failed to watch for cluster %q: %w", name, err)
+		if err := c.TypedController.Watch(startWithinContext[request](c.clusterContext(eng.ctx, name), src)); err != nil {
+			return mcerrors.New(name, "watch", err)
 		}
 	}
 
 	c.sources = append(c.sources, src)
 
-	return nil //nolint:govet // cancel is called in the error case only.
+	return nil
+}
+
+// clusterContext returns a context with the cluster and the metadata lookup,
+// passed to the event handlers and map functions of the cluster's sources.
+func (c *mcController[request]) clusterContext(ctx context.Context, name string) context.Context {
+	return mccontext.WithMetadataFunc(mccontext.WithCluster(ctx, name), c.metadata)
 }
 
 func startWithinContext[request mcreconcile.ClusterAware[request]](ctx context.Context, src source.TypedSource[request]) source.TypedSource[request] {
@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	mccontext "sigs.k8s.io/multicluster-runtime/pkg/context"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cluster in context", func() {
+	metadata := func(_ context.Context, name string) (multicluster.Metadata, error) {
+		if name != "cluster-a" {
+			return multicluster.Metadata{}, errors.New("unknown cluster")
+		}
+		return multicluster.Metadata{Labels: map[string]string{"env": "prod"}}, nil
+	}
+
+	It("runs reconciles with the cluster and its metadata", func(ctx context.Context) {
+		var cluster, controllerName string
+		var md multicluster.Metadata
+		r := reconcilerWithClusterInContext("test", reconcile.TypedFunc[mcreconcile.Request](func(ctx context.Context, _ mcreconcile.Request) (reconcile.Result, error) {
+			cluster, _ = mccontext.ClusterFrom(ctx)
+			controllerName, _ = mccontext.ControllerFrom(ctx)
+			md, _ = mccontext.ClusterMetadataFrom(ctx)
+			return reconcile.Result{}, nil
+		}), metadata)
+
+		_, err := r.Reconcile(ctx, mcreconcile.Request{ClusterName: "cluster-a"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cluster).To(Equal("cluster-a"))
+		Expect(controllerName).To(Equal("test"))
+		Expect(md.Labels).To(HaveKeyWithValue("env", "prod"))
+	})
+
+	It("passes the cluster to event handlers of cluster sources", func(ctx context.Context) {
+		c := &mcController[mcreconcile.Request]{metadata: metadata}
+
+		ctx = c.clusterContext(ctx, "cluster-a")
+		cluster, ok := mccontext.ClusterFrom(ctx)
+		Expect(ok).To(BeTrue())
+		Expect(cluster).To(Equal("cluster-a"))
+		md, ok := mccontext.ClusterMetadataFrom(ctx)
+		Expect(ok).To(BeTrue())
+		Expect(md.Labels).To(HaveKeyWithValue("env", "prod"))
+
+		_, ok = mccontext.ClusterMetadataFrom(c.clusterContext(ctx, "cluster-b"))
+		Expect(ok).To(BeFalse())
+	})
+})
+
+type watchingController struct {
+	controller.TypedController[mcreconcile.Request]
+	watches int
+}
+
+func (c *watchingController) Watch(source.TypedSource[mcreconcile.Request]) error {
+	c.watches++
+	return nil
+}
+
+type clusterSource struct {
+	clusters []string
+}
+
+func (s *clusterSource) ForCluster(name string, _ cluster.Cluster) (source.TypedSource[mcreconcile.Request], error) {
+	s.clusters = append(s.clusters, name)
+	return source.TypedFunc[mcreconcile.Request](nil), nil
+}
+
+var _ = Describe("Engaged clusters", func() {
+	It("watches sources added later in the clusters engaged until they are disengaged", func(ctx context.Context) {
+		inner := &watchingController{}
+		c := &mcController[mcreconcile.Request]{TypedController: inner, clusters: map[string]engagedCluster{}}
+
+		engageCtx, disengage := context.WithCancel(ctx)
+		Expect(c.Engage(engageCtx, "cluster-a", nil)).To(Succeed())
+
+		src := &clusterSource{}
+		Expect(c.MultiClusterWatch(src)).To(Succeed())
+		Expect(src.clusters).To(Equal([]string{"cluster-a"}))
+		Expect(inner.watches).To(Equal(1))
+
+		disengage()
+		Eventually(func() int {
+			c.lock.Lock()
+			defer c.lock.Unlock()
+			return len(c.clusters)
+		}).Should(BeZero())
+		Expect(c.MultiClusterWatch(&clusterSource{})).To(Succeed())
+		Expect(inner.watches).To(Equal(1))
+	})
+})
@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	mccontext "sigs.k8s.io/multicluster-runtime/pkg/context"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+)
+
+// ClusterDeduplicator collapses requests that only differ in their cluster
+// name into a single request. This is useful for fleet-scoped reconcilers
+// whose work is keyed by a hub object, but which are triggered by events in
+// many clusters.
+//
+// The reconciler returned by Reconciler receives requests without a cluster
+// name. The set of clusters that triggered the request is available through
+// context.ClustersFrom.
+type ClusterDeduplicator[request mcreconcile.ClusterAware[request]] struct {
+	lock     sync.Mutex
+	pending  map[request]sets.Set[string]
+	inflight map[request]sets.Set[string]
+}
+
+// NewClusterDeduplicator returns a new ClusterDeduplicator. Wire it into a
+// controller by setting Options.NewQueue to the result of NewQueue and by
+// wrapping the reconciler with Reconciler.
+func NewClusterDeduplicator[request mcreconcile.ClusterAware[request]]() *ClusterDeduplicator[request] {
+	return &ClusterDeduplicator[request]{
+		pending:  map[request]sets.Set[string]{},
+		inflight: map[request]sets.Set[string]{},
+	}
+}
+
+// NewQueue wraps the given queue constructor such that items are enqueued
+// without their cluster name. If newQueue is nil, a default rate limiting
+// queue is used.
+func (d *ClusterDeduplicator[request]) NewQueue(
+	newQueue func(controllerName string, rateLimiter workqueue.TypedRateLimiter[request]) workqueue.TypedRateLimitingInterface[request],
+) func(controllerName string, rateLimiter workqueue.TypedRateLimiter[request]) workqueue.TypedRateLimitingInterface[request] {
+	return func(controllerName string, rateLimiter workqueue.TypedRateLimiter[request]) workqueue.TypedRateLimitingInterface[request] {
+		var q workqueue.TypedRateLimitingInterface[request]
+		if newQueue != nil {
+			q = newQueue(controllerName, rateLimiter)
+		} else {
+			q = workqueue.NewTypedRateLimitingQueueWithConfig(rateLimiter, workqueue.TypedRateLimitingQueueConfig[request]{
+				Name: controllerName,
+			})
+		}
+		return &deduplicatingQueue[request]{TypedRateLimitingInterface: q, d: d}
+	}
+}
+
+// Reconciler wraps the given reconciler and injects the set of clusters
+// that triggered a request into the context.
+func (d *ClusterDeduplicator[request]) Reconciler(r reconcile.TypedReconciler[request]) reconcile.TypedReconciler[request] {
+	return reconcile.TypedFunc[request](func(ctx context.Context, req request) (reconcile.Result, error) {
+		d.lock.Lock()
+		clusters := sets.List(d.inflight[req])
+		d.lock.Unlock()
+
+		return r.Reconcile(mccontext.WithClusters(ctx, clusters), req)
+	})
+}
+
+// record remembers the cluster of item and returns the key to enqueue. If
+// requeue is true, the clusters of a matching in-flight item are kept.
+func (d *ClusterDeduplicator[request]) record(item request, requeue bool) request {
+	key := item.WithCluster("")
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if _, ok := d.pending[key]; !ok {
+		d.pending[key] = sets.New[string]()
+	}
+
+	// a requeue of the in-flight key by the controller keeps its clusters.
+	if clusters, ok := d.inflight[key]; ok && requeue && item == key {
+		d.pending[key] = d.pending[key].Union(clusters)
+		return key
+	}
+	d.pending[key].Insert(item.Cluster())
+
+	return key
+}
+
+// start moves the pending clusters of key to in-flight.
+func (d *ClusterDeduplicator[request]) start(key request) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if _, ok := d.inflight[key]; !ok {
+		d.inflight[key] = sets.New[string]()
+	}
+	d.inflight[key] = d.inflight[key].Union(d.pending[key])
+	delete(d.pending, key)
+}
+
+// finish forgets the in-flight clusters of key.
+func (d *ClusterDeduplicator[request]) finish(key request) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	delete(d.inflight, key)
+}
+
+var _ workqueue.TypedRateLimitingInterface[mcreconcile.Request] = &deduplicatingQueue[mcreconcile.Request]{}
+
+type deduplicatingQueue[request mcreconcile.ClusterAware[request]] struct {
+	workqueue.TypedRateLimitingInterface[request]
+	d *ClusterDeduplicator[request]
+}
+
+func (q *deduplicatingQueue[request]) Add(item request) {
+	q.TypedRateLimitingInterface.Add(q.d.record(item, false))
+}
+
+func (q *deduplicatingQueue[request]) AddAfter(item request, duration time.Duration) {
+	q.TypedRateLimitingInterface.AddAfter(q.d.record(item, true), duration)
+}
+
+func (q *deduplicatingQueue[request]) AddRateLimited(item request) {
+	q.TypedRateLimitingInterface.AddRateLimited(q.d.record(item, true))
+}
+
+func (q *deduplicatingQueue[request]) Get() (item request, shutdown bool) {
+	item, shutdown = q.TypedRateLimitingInterface.Get()
+	if !shutdown {
+		q.d.start(item)
+	}
+	return item, shutdown
+}
+
+func (q *deduplicatingQueue[request]) Done(item request) {
+	key := item.WithCluster("")
+	q.d.finish(key)
+	q.TypedRateLimitingInterface.Done(key)
+}
+
+func (q *deduplicatingQueue[request]) Forget(item request) {
+	q.TypedRateLimitingInterface.Forget(item.WithCluster(""))
+}
+
+func (q *deduplicatingQueue[request]) NumRequeues(item request) int {
+	return q.TypedRateLimitingInterface.NumRequeues(item.WithCluster(""))
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	mccontext "sigs.k8s.io/multicluster-runtime/pkg/context"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ClusterDeduplicator", func() {
+	req := func(cluster string) mcreconcile.Request {
+		return mcreconcile.Request{
+			Request:     reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "foo"}},
+			ClusterName: cluster,
+		}
+	}
+
+	It("should collapse requests across clusters", func() {
+		d := NewClusterDeduplicator[mcreconcile.Request]()
+		q := d.NewQueue(nil)("test", workqueue.DefaultTypedControllerRateLimiter[mcreconcile.Request]())
+		defer q.ShutDown()
+
+		q.Add(req("cluster-a"))
+		q.Add(req("cluster-b"))
+		q.Add(req("cluster-a"))
+		Expect(q.Len()).To(Equal(1))
+
+		var got []string
+		r := d.Reconciler(reconcile.TypedFunc[mcreconcile.Request](func(ctx context.Context, r mcreconcile.Request) (reconcile.Result, error) {
+			Expect(r.ClusterName).To(BeEmpty())
+			got, _ = mccontext.ClustersFrom(ctx)
+			return reconcile.Result{}, nil
+		}))
+
+		item, shutdown := q.Get()
+		Expect(shutdown).To(BeFalse())
+		_, err := r.Reconcile(context.Background(), item)
+		Expect(err).NotTo(HaveOccurred())
+		q.Done(item)
+
+		Expect(got).To(ConsistOf("cluster-a", "cluster-b"))
+		Expect(q.Len()).To(Equal(0))
+	})
+
+	It("should keep the clusters of a requeued request", func() {
+		d := NewClusterDeduplicator[mcreconcile.Request]()
+		q := d.NewQueue(nil)("test", workqueue.DefaultTypedControllerRateLimiter[mcreconcile.Request]())
+		defer q.ShutDown()
+
+		q.Add(req("cluster-a"))
+		item, _ := q.Get()
+		q.Add(req("cluster-b"))
+		q.AddRateLimited(item)
+		q.Done(item)
+
+		var got []string
+		r := d.Reconciler(reconcile.TypedFunc[mcreconcile.Request](func(ctx context.Context, r mcreconcile.Request) (reconcile.Result, error) {
+			got, _ = mccontext.ClustersFrom(ctx)
+			return reconcile.Result{}, nil
+		}))
+		item, _ = q.Get()
+		_, err := r.Reconcile(context.Background(), item)
+		Expect(err).NotTo(HaveOccurred())
+		q.Done(item)
+
+		Expect(got).To(ConsistOf("cluster-a", "cluster-b"))
+	})
+})
@@ -0,0 +1,431 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+)
+
+// DeliveryGuarantee describes what happens to the requests of a cluster
+// that is disengaged before they were reconciled successfully.
+type DeliveryGuarantee string
+
+const (
+	// DeliveryAtMostOnce drops the requests of disengaged clusters. This is
+	// the default.
+	DeliveryAtMostOnce DeliveryGuarantee = "AtMostOnce"
+
+	// DeliveryAtLeastOnce re-enqueues the requests of a cluster that were
+	// pending or failed when it was disengaged, once it is engaged again.
+	DeliveryAtLeastOnce DeliveryGuarantee = "AtLeastOnce"
+)
+
+// DeliveryStore persists the pending requests of a DeliveryTracker, such
+// that they survive restarts of the controller.
+type DeliveryStore[request comparable] interface {
+	// Load returns the persisted pending requests.
+	Load(ctx context.Context) ([]request, error)
+
+	// Save persists the pending requests.
+	Save(ctx context.Context, pending []request) error
+}
+
+const (
+	// DefaultDeliveryRetention is the time the pending requests of a
+	// disengaged cluster are kept by default.
+	DefaultDeliveryRetention = 24 * time.Hour
+
+	// DefaultMaxPendingDeliveries is the number of pending requests kept
+	// by default.
+	DefaultMaxPendingDeliveries = 10000
+)
+
+var _ mcmanager.Runnable = &DeliveryTracker[mcreconcile.Request]{}
+
+// DeliveryTracker implements DeliveryAtLeastOnce. It remembers the requests
+// of every cluster until they are reconciled successfully while the cluster
+// is engaged, and re-enqueues the remembered requests when a cluster is
+// engaged again.
+//
+// As the tracker cannot tell a flapping cluster from one that was removed
+// for good, the requests of a disengaged cluster are dropped once it has
+// been disengaged for longer than the retention. If more requests than
+// the maximum are pending, the requests of the clusters disengaged the
+// longest are dropped first. The requests of engaged clusters are never
+// dropped.
+//
+// Wire it into a controller by setting Options.NewQueue to the result of
+// NewQueue, by wrapping the reconciler with Reconciler, and by adding the
+// tracker to the manager.
+type DeliveryTracker[request mcreconcile.ClusterAware[request]] struct {
+	store        DeliveryStore[request]
+	saveInterval time.Duration
+	retention    time.Duration
+	maxPending   int
+
+	lock    sync.Mutex
+	queue   workqueue.TypedRateLimitingInterface[request]
+	engaged sets.Set[string]
+	// disengaged records when clusters with pending requests were
+	// disengaged, or loaded from the store without being engaged.
+	disengaged map[string]time.Time
+	pending    map[string]sets.Set[request]
+	dirty      bool
+}
+
+// NewDeliveryTracker returns a new DeliveryTracker. If store is nil, the
+// pending requests are kept in memory only.
+func NewDeliveryTracker[request mcreconcile.ClusterAware[request]](store DeliveryStore[request]) *DeliveryTracker[request] {
+	return &DeliveryTracker[request]{
+		store:        store,
+		saveInterval: 10 * time.Second,
+		retention:    DefaultDeliveryRetention,
+		maxPending:   DefaultMaxPendingDeliveries,
+		engaged:      sets.New[string](),
+		disengaged:   map[string]time.Time{},
+		pending:      map[string]sets.Set[request]{},
+	}
+}
+
+// WithRetention sets the time the pending requests of a disengaged cluster
+// are kept. Defaults to DefaultDeliveryRetention.
+func (t *DeliveryTracker[request]) WithRetention(retention time.Duration) *DeliveryTracker[request] {
+	t.retention = retention
+	return t
+}
+
+// WithMaxPending sets the number of pending requests above which the
+// requests of disengaged clusters are dropped. Defaults to
+// DefaultMaxPendingDeliveries.
+func (t *DeliveryTracker[request]) WithMaxPending(n int) *DeliveryTracker[request] {
+	t.maxPending = n
+	return t
+}
+
+// NewQueue wraps the given queue constructor such that enqueued items are
+// remembered. If newQueue is nil, a default rate limiting queue is used.
+func (t *DeliveryTracker[request]) NewQueue(
+	newQueue func(controllerName string, rateLimiter workqueue.TypedRateLimiter[request]) workqueue.TypedRateLimitingInterface[request],
+) func(controllerName string, rateLimiter workqueue.TypedRateLimiter[request]) workqueue.TypedRateLimitingInterface[request] {
+	return func(controllerName string, rateLimiter workqueue.TypedRateLimiter[request]) workqueue.TypedRateLimitingInterface[request] {
+		var q workqueue.TypedRateLimitingInterface[request]
+		if newQueue != nil {
+			q = newQueue(controllerName, rateLimiter)
+		} else {
+			q = workqueue.NewTypedRateLimitingQueueWithConfig(rateLimiter, workqueue.TypedRateLimitingQueueConfig[request]{
+				Name: controllerName,
+			})
+		}
+
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		t.queue = q
+		for name := range t.engaged {
+			t.requeue(name)
+		}
+		return &trackingQueue[request]{TypedRateLimitingInterface: q, t: t}
+	}
+}
+
+// Reconciler wraps the given reconciler and forgets requests that were
+// reconciled successfully while their cluster is engaged.
+func (t *DeliveryTracker[request]) Reconciler(r reconcile.TypedReconciler[request]) reconcile.TypedReconciler[request] {
+	return reconcile.TypedFunc[request](func(ctx context.Context, req request) (reconcile.Result, error) {
+		res, err := r.Reconcile(ctx, req)
+		if err == nil && res.IsZero() {
+			t.forget(req)
+		}
+		return res, err
+	})
+}
+
+// Engage re-enqueues the remembered requests of the cluster.
+func (t *DeliveryTracker[request]) Engage(ctx context.Context, name string, _ cluster.Cluster) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.engaged.Insert(name)
+	delete(t.disengaged, name)
+	t.requeue(name)
+
+	go func() {
+		<-ctx.Done()
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		t.engaged.Delete(name)
+		if t.pending[name].Len() > 0 {
+			t.disengaged[name] = time.Now()
+		}
+	}()
+	return nil
+}
+
+// Start loads the pending requests from the store, and drops expired
+// requests and saves them periodically until ctx is done.
+func (t *DeliveryTracker[request]) Start(ctx context.Context) error {
+	if t.store != nil {
+		loaded, err := t.store.Load(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to load pending requests: %w", err)
+		}
+		t.lock.Lock()
+		for _, req := range loaded {
+			t.remember(req)
+		}
+		for name := range t.engaged {
+			t.requeue(name)
+		}
+		t.lock.Unlock()
+	}
+
+	log := log.FromContext(ctx).WithName("delivery-tracker")
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		for name, n := range t.prune(time.Now()) {
+			log.Info("Dropped pending requests of disengaged cluster", "cluster", multicluster.EscapeClusterName(name), "requests", n)
+		}
+		if t.store == nil {
+			return
+		}
+		if err := t.save(ctx); err != nil {
+			log.Error(err, "Failed to save pending requests")
+		}
+	}, t.saveInterval)
+	if t.store == nil {
+		return nil
+	}
+
+	// final save, with a fresh context as ctx is done.
+	saveCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return t.save(saveCtx)
+}
+
+// Pending returns the remembered requests of the cluster.
+func (t *DeliveryTracker[request]) Pending(clusterName string) []request {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.pending[clusterName].UnsortedList()
+}
+
+func (t *DeliveryTracker[request]) save(ctx context.Context) error {
+	t.lock.Lock()
+	if !t.dirty {
+		t.lock.Unlock()
+		return nil
+	}
+	var all []request
+	for _, reqs := range t.pending {
+		all = append(all, reqs.UnsortedList()...)
+	}
+	t.dirty = false
+	t.lock.Unlock()
+
+	if err := t.store.Save(ctx, all); err != nil {
+		t.lock.Lock()
+		t.dirty = true
+		t.lock.Unlock()
+		return err
+	}
+	return nil
+}
+
+// prune drops the pending requests of clusters disengaged for longer than
+// the retention, and of the clusters disengaged the longest while more
+// than the maximum of requests are pending. It returns the number of
+// dropped requests by cluster.
+func (t *DeliveryTracker[request]) prune(now time.Time) map[string]int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	dropped := map[string]int{}
+	drop := func(name string) {
+		if n := t.pending[name].Len(); n > 0 {
+			dropped[name] = n
+			t.dirty = true
+		}
+		delete(t.pending, name)
+		delete(t.disengaged, name)
+	}
+	for name, since := range t.disengaged {
+		if now.Sub(since) > t.retention {
+			drop(name)
+		}
+	}
+
+	total := 0
+	for _, reqs := range t.pending {
+		total += reqs.Len()
+	}
+	if total <= t.maxPending {
+		return dropped
+	}
+	names := slices.SortedFunc(maps.Keys(t.disengaged), func(a, b string) int {
+		return t.disengaged[a].Compare(t.disengaged[b])
+	})
+	for _, name := range names {
+		if total <= t.maxPending {
+			break
+		}
+		total -= t.pending[name].Len()
+		drop(name)
+	}
+	return dropped
+}
+
+// remember adds req to the pending requests. The lock must be held.
+func (t *DeliveryTracker[request]) remember(req request) {
+	name := req.Cluster()
+	if _, ok := t.pending[name]; !ok {
+		t.pending[name] = sets.New[request]()
+	}
+	if _, ok := t.disengaged[name]; !ok && name != "" && !t.engaged.Has(name) {
+		t.disengaged[name] = time.Now()
+	}
+	if !t.pending[name].Has(req) {
+		t.pending[name].Insert(req)
+		t.dirty = true
+	}
+}
+
+// forget removes req from the pending requests, unless its cluster is
+// disengaged, e.g. because the reconciler ignored the missing cluster.
+func (t *DeliveryTracker[request]) forget(req request) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	name := req.Cluster()
+	if name != "" && !t.engaged.Has(name) {
+		return
+	}
+	if !t.pending[name].Has(req) {
+		return
+	}
+	t.pending[name].Delete(req)
+	if t.pending[name].Len() == 0 {
+		delete(t.pending, name)
+	}
+	t.dirty = true
+}
+
+// requeue enqueues the pending requests of the cluster. The lock must be
+// held.
+func (t *DeliveryTracker[request]) requeue(name string) {
+	if t.queue == nil {
+		return
+	}
+	for req := range t.pending[name] {
+		t.queue.Add(req)
+	}
+}
+
+type trackingQueue[request mcreconcile.ClusterAware[request]] struct {
+	workqueue.TypedRateLimitingInterface[request]
+	t *DeliveryTracker[request]
+}
+
+func (q *trackingQueue[request]) Add(item request) {
+	q.t.lock.Lock()
+	q.t.remember(item)
+	q.t.lock.Unlock()
+	q.TypedRateLimitingInterface.Add(item)
+}
+
+func (q *trackingQueue[request]) AddAfter(item request, duration time.Duration) {
+	q.t.lock.Lock()
+	q.t.remember(item)
+	q.t.lock.Unlock()
+	q.TypedRateLimitingInterface.AddAfter(item, duration)
+}
+
+func (q *trackingQueue[request]) AddRateLimited(item request) {
+	q.t.lock.Lock()
+	q.t.remember(item)
+	q.t.lock.Unlock()
+	q.TypedRateLimitingInterface.AddRateLimited(item)
+}
+
+// ConfigMapDeliveryStore is a DeliveryStore persisting the pending requests
+// as JSON in a ConfigMap of the hub cluster. The ConfigMap is created if it
+// does not exist. Its size limit of 1 MiB bounds the number of requests.
+type ConfigMapDeliveryStore[request comparable] struct {
+	Client client.Client
+	Key    types.NamespacedName
+}
+
+const deliveryStoreDataKey = "pending.json"
+
+// Load returns the requests stored in the ConfigMap.
+func (s *ConfigMapDeliveryStore[request]) Load(ctx context.Context) ([]request, error) {
+	cm := &corev1.ConfigMap{}
+	if err := s.Client.Get(ctx, s.Key, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	data, ok := cm.Data[deliveryStoreDataKey]
+	if !ok {
+		return nil, nil
+	}
+	var reqs []request
+	if err := json.Unmarshal([]byte(data), &reqs); err != nil {
+		return nil, fmt.Errorf("failed to decode pending requests of ConfigMap %s: %w", s.Key, err)
+	}
+	return reqs, nil
+}
+
+// Save writes the requests to the ConfigMap.
+func (s *ConfigMapDeliveryStore[request]) Save(ctx context.Context, pending []request) error {
+	data, err := json.Marshal(pending)
+	if err != nil {
+		return err
+	}
+	cm := &corev1.ConfigMap{}
+	if err := s.Client.Get(ctx, s.Key, cm); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		cm.Namespace, cm.Name = s.Key.Namespace, s.Key.Name
+		cm.Data = map[string]string{deliveryStoreDataKey: string(data)}
+		return s.Client.Create(ctx, cm)
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[deliveryStoreDataKey] = string(data)
+	return s.Client.Update(ctx, cm)
+}
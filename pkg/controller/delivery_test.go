@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeliveryTracker", func() {
+	req := func(cluster, name string) mcreconcile.Request {
+		return mcreconcile.Request{
+			Request:     reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: name}},
+			ClusterName: cluster,
+		}
+	}
+
+	reconcileAll := func(q workqueue.TypedRateLimitingInterface[mcreconcile.Request], r reconcile.TypedReconciler[mcreconcile.Request]) {
+		for q.Len() > 0 {
+			item, _ := q.Get()
+			_, _ = r.Reconcile(context.Background(), item)
+			q.Forget(item)
+			q.Done(item)
+		}
+	}
+
+	It("should re-enqueue failed and pending requests on re-engagement", func() {
+		t := NewDeliveryTracker[mcreconcile.Request](nil)
+		q := t.NewQueue(nil)("test", workqueue.DefaultTypedControllerRateLimiter[mcreconcile.Request]())
+		defer q.ShutDown()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		Expect(t.Engage(ctx, "cluster-a", nil)).To(Succeed())
+
+		r := t.Reconciler(reconcile.TypedFunc[mcreconcile.Request](func(_ context.Context, r mcreconcile.Request) (reconcile.Result, error) {
+			if r.Name == "fails" {
+				return reconcile.Result{}, errors.New("boom")
+			}
+			return reconcile.Result{}, nil
+		}))
+		q.Add(req("cluster-a", "ok"))
+		q.Add(req("cluster-a", "fails"))
+		reconcileAll(q, r)
+		Expect(t.Pending("cluster-a")).To(ConsistOf(req("cluster-a", "fails")))
+
+		By("disengaging the cluster, successful reconciles are not forgotten")
+		cancel()
+		Eventually(func() bool {
+			t.lock.Lock()
+			defer t.lock.Unlock()
+			return t.engaged.Has("cluster-a")
+		}).Should(BeFalse())
+		q.Add(req("cluster-a", "pending"))
+		reconcileAll(q, reconcile.TypedFunc[mcreconcile.Request](func(context.Context, mcreconcile.Request) (reconcile.Result, error) {
+			return reconcile.Result{}, nil
+		}))
+		Expect(t.Pending("cluster-a")).To(ConsistOf(req("cluster-a", "fails"), req("cluster-a", "pending")))
+
+		By("re-engaging the cluster")
+		Expect(t.Engage(context.Background(), "cluster-a", nil)).To(Succeed())
+		Expect(q.Len()).To(Equal(2))
+	})
+
+	It("should drop the requests of clusters disengaged for longer than the retention", func() {
+		t := NewDeliveryTracker[mcreconcile.Request](nil).WithRetention(time.Hour)
+		q := t.NewQueue(nil)("test", workqueue.DefaultTypedControllerRateLimiter[mcreconcile.Request]())
+		defer q.ShutDown()
+
+		removedCtx, remove := context.WithCancel(context.Background())
+		Expect(t.Engage(removedCtx, "removed", nil)).To(Succeed())
+		Expect(t.Engage(context.Background(), "engaged", nil)).To(Succeed())
+		q.Add(req("removed", "foo"))
+		q.Add(req("engaged", "bar"))
+		remove()
+		Eventually(func() bool {
+			t.lock.Lock()
+			defer t.lock.Unlock()
+			_, ok := t.disengaged["removed"]
+			return ok
+		}).Should(BeTrue())
+
+		Expect(t.prune(time.Now())).To(BeEmpty())
+		Expect(t.Pending("removed")).To(ConsistOf(req("removed", "foo")))
+
+		Expect(t.prune(time.Now().Add(2 * time.Hour))).To(Equal(map[string]int{"removed": 1}))
+		Expect(t.Pending("removed")).To(BeEmpty())
+		Expect(t.Pending("engaged")).To(ConsistOf(req("engaged", "bar")), "the requests of engaged clusters are kept")
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		Expect(t.disengaged).To(BeEmpty())
+		Expect(t.dirty).To(BeTrue())
+	})
+
+	It("should drop the requests of the clusters disengaged the longest above the maximum", func() {
+		t := NewDeliveryTracker[mcreconcile.Request](nil).WithMaxPending(3)
+		q := t.NewQueue(nil)("test", workqueue.DefaultTypedControllerRateLimiter[mcreconcile.Request]())
+		defer q.ShutDown()
+
+		Expect(t.Engage(context.Background(), "engaged", nil)).To(Succeed())
+		q.Add(req("engaged", "a"))
+		q.Add(req("engaged", "b"))
+		By("remembering the requests of clusters that are not engaged, e.g. loaded from the store")
+		q.Add(req("old", "c"))
+		q.Add(req("new", "d"))
+		t.lock.Lock()
+		t.disengaged["old"] = time.Now().Add(-time.Minute)
+		t.lock.Unlock()
+
+		Expect(t.prune(time.Now())).To(Equal(map[string]int{"old": 1}))
+		Expect(t.Pending("new")).To(ConsistOf(req("new", "d")))
+		Expect(t.Pending("engaged")).To(HaveLen(2))
+
+		By("never dropping the requests of engaged clusters")
+		q.Add(req("engaged", "e"))
+		q.Add(req("engaged", "f"))
+		Expect(t.prune(time.Now())).To(Equal(map[string]int{"new": 1}))
+		Expect(t.Pending("engaged")).To(HaveLen(4))
+	})
+
+	It("should persist pending requests in a ConfigMap", func(ctx context.Context) {
+		store := &ConfigMapDeliveryStore[mcreconcile.Request]{
+			Client: fake.NewClientBuilder().Build(),
+			Key:    types.NamespacedName{Namespace: "default", Name: "pending"},
+		}
+		loaded, err := store.Load(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded).To(BeEmpty())
+
+		Expect(store.Save(ctx, []mcreconcile.Request{req("cluster-a", "foo")})).To(Succeed())
+		Expect(store.Save(ctx, []mcreconcile.Request{req("cluster-a", "foo"), req("cluster-b", "bar")})).To(Succeed())
+		loaded, err = store.Load(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded).To(ConsistOf(req("cluster-a", "foo"), req("cluster-b", "bar")))
+
+		cm := &corev1.ConfigMap{}
+		Expect(store.Client.Get(ctx, store.Key, cm)).To(Succeed())
+		Expect(cm.Data).To(HaveKey("pending.json"))
+	})
+})
@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	mccontext "sigs.k8s.io/multicluster-runtime/pkg/context"
+	mchandler "sigs.k8s.io/multicluster-runtime/pkg/handler"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+)
+
+// InitialSyncDetector tells reconcilers whether a request was triggered
+// only by the initial list of its cluster, see context.IsInitialSync.
+//
+// A create event belongs to the initial sync if its object was created
+// before the watch of the cluster was set up, i.e. it was listed rather
+// than observed being created. Objects created within the clock skew
+// between the cluster and the manager around engagement may be
+// misclassified. A request is part of the initial sync if all events that
+// enqueued it since it was last reconciled do.
+//
+// Wire it into a controller by wrapping the event handlers of its sources
+// with Handler, and the reconciler with Reconciler.
+type InitialSyncDetector[request mcreconcile.ClusterAware[request]] struct {
+	now func() time.Time
+
+	lock    sync.Mutex
+	pending map[request]bool
+}
+
+// NewInitialSyncDetector returns a new InitialSyncDetector.
+func NewInitialSyncDetector[request mcreconcile.ClusterAware[request]]() *InitialSyncDetector[request] {
+	return &InitialSyncDetector[request]{now: time.Now, pending: map[request]bool{}}
+}
+
+// Handler wraps the given event handler constructor such that the requests
+// enqueued by the handlers remember whether they belong to the initial
+// sync.
+func (d *InitialSyncDetector[request]) Handler(h mchandler.TypedEventHandlerFunc[client.Object, request]) mchandler.TypedEventHandlerFunc[client.Object, request] {
+	return func(clusterName string, cl cluster.Cluster) handler.TypedEventHandler[client.Object, request] {
+		// creation timestamps have a resolution of seconds. Objects created
+		// in the second of the engagement are not part of the initial sync.
+		return &initialSyncHandler[request]{h: h(clusterName, cl), d: d, engaged: d.now().Truncate(time.Second)}
+	}
+}
+
+// Reconciler wraps the given reconciler and marks the context of requests
+// of the initial sync.
+func (d *InitialSyncDetector[request]) Reconciler(r reconcile.TypedReconciler[request]) reconcile.TypedReconciler[request] {
+	return reconcile.TypedFunc[request](func(ctx context.Context, req request) (reconcile.Result, error) {
+		d.lock.Lock()
+		initial := d.pending[req]
+		delete(d.pending, req)
+		d.lock.Unlock()
+
+		if initial {
+			ctx = mccontext.WithInitialSync(ctx)
+		}
+		return r.Reconcile(ctx, req)
+	})
+}
+
+func (d *InitialSyncDetector[request]) record(item request, initial bool) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if pending, ok := d.pending[item]; ok {
+		initial = initial && pending
+	}
+	d.pending[item] = initial
+}
+
+var _ handler.TypedEventHandler[client.Object, mcreconcile.Request] = &initialSyncHandler[mcreconcile.Request]{}
+
+type initialSyncHandler[request mcreconcile.ClusterAware[request]] struct {
+	h       handler.TypedEventHandler[client.Object, request]
+	d       *InitialSyncDetector[request]
+	engaged time.Time
+}
+
+func (h *initialSyncHandler[request]) queue(q workqueue.TypedRateLimitingInterface[request], initial bool) workqueue.TypedRateLimitingInterface[request] {
+	return newRecordingQueue(q, func(item request) { h.d.record(item, initial) })
+}
+
+// Create implements EventHandler.
+func (h *initialSyncHandler[request]) Create(ctx context.Context, evt event.TypedCreateEvent[client.Object], q workqueue.TypedRateLimitingInterface[request]) {
+	initial := evt.Object != nil && evt.Object.GetCreationTimestamp().Time.Before(h.engaged)
+	h.h.Create(ctx, evt, h.queue(q, initial))
+}
+
+// Update implements EventHandler.
+func (h *initialSyncHandler[request]) Update(ctx context.Context, evt event.TypedUpdateEvent[client.Object], q workqueue.TypedRateLimitingInterface[request]) {
+	h.h.Update(ctx, evt, h.queue(q, false))
+}
+
+// Delete implements EventHandler.
+func (h *initialSyncHandler[request]) Delete(ctx context.Context, evt event.TypedDeleteEvent[client.Object], q workqueue.TypedRateLimitingInterface[request]) {
+	h.h.Delete(ctx, evt, h.queue(q, false))
+}
+
+// Generic implements EventHandler.
+func (h *initialSyncHandler[request]) Generic(ctx context.Context, evt event.TypedGenericEvent[client.Object], q workqueue.TypedRateLimitingInterface[request]) {
+	h.h.Generic(ctx, evt, h.queue(q, false))
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	mccontext "sigs.k8s.io/multicluster-runtime/pkg/context"
+	mchandler "sigs.k8s.io/multicluster-runtime/pkg/handler"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("InitialSyncDetector", func() {
+	It("should mark requests of objects listed on engagement", func() {
+		engaged := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		d := NewInitialSyncDetector[mcreconcile.Request]()
+		d.now = func() time.Time { return engaged }
+		h := d.Handler(mchandler.TypedLift[client.Object](&handler.EnqueueRequestForObject{}))("spoke", nil)
+
+		q := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[mcreconcile.Request]())
+		defer q.ShutDown()
+		pod := func(name string, created time.Time) *corev1.Pod {
+			return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name, CreationTimestamp: metav1.NewTime(created)}}
+		}
+		listed, created, updated := pod("listed", engaged.Add(-time.Hour)), pod("created", engaged.Add(time.Second)), pod("updated", engaged.Add(-time.Hour))
+		h.Create(context.Background(), event.TypedCreateEvent[client.Object]{Object: listed}, q)
+		h.Create(context.Background(), event.TypedCreateEvent[client.Object]{Object: created}, q)
+		h.Create(context.Background(), event.TypedCreateEvent[client.Object]{Object: updated}, q)
+		h.Update(context.Background(), event.TypedUpdateEvent[client.Object]{ObjectOld: updated, ObjectNew: updated}, q)
+
+		initial := map[string]bool{}
+		r := d.Reconciler(reconcile.TypedFunc[mcreconcile.Request](func(ctx context.Context, req mcreconcile.Request) (reconcile.Result, error) {
+			initial[req.Name] = mccontext.IsInitialSync(ctx)
+			return reconcile.Result{}, nil
+		}))
+		for q.Len() > 0 {
+			item, _ := q.Get()
+			_, err := r.Reconcile(context.Background(), item)
+			Expect(err).NotTo(HaveOccurred())
+			q.Done(item)
+		}
+		Expect(initial).To(Equal(map[string]bool{"listed": true, "created": false, "updated": false}))
+	})
+})
@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	mccontext "sigs.k8s.io/multicluster-runtime/pkg/context"
+	mchandler "sigs.k8s.io/multicluster-runtime/pkg/handler"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+)
+
+// ProvenanceRecorder remembers the last event that enqueued each request,
+// and passes it to the reconciler through context.ProvenanceFrom. This
+// tells which cluster, kind, object and event type triggered a reconcile.
+//
+// Wire it into a controller by wrapping the event handlers of its sources
+// with Handler, and the reconciler with Reconciler. Requests rewritten by
+// the queue, e.g. with cluster deduplication, carry no provenance.
+type ProvenanceRecorder[request mcreconcile.ClusterAware[request]] struct {
+	lock    sync.Mutex
+	pending map[request]mccontext.Provenance
+}
+
+// NewProvenanceRecorder returns a new ProvenanceRecorder.
+func NewProvenanceRecorder[request mcreconcile.ClusterAware[request]]() *ProvenanceRecorder[request] {
+	return &ProvenanceRecorder[request]{pending: map[request]mccontext.Provenance{}}
+}
+
+// Handler wraps the given event handler constructor such that the requests
+// enqueued by the handlers remember the events of objects of the given
+// kind.
+func (p *ProvenanceRecorder[request]) Handler(gvk schema.GroupVersionKind, h mchandler.TypedEventHandlerFunc[client.Object, request]) mchandler.TypedEventHandlerFunc[client.Object, request] {
+	return func(clusterName string, cl cluster.Cluster) handler.TypedEventHandler[client.Object, request] {
+		return &provenanceHandler[request]{h: h(clusterName, cl), p: p, cluster: clusterName, gvk: gvk}
+	}
+}
+
+// Reconciler wraps the given reconciler and injects the provenance of a
+// request into the context.
+func (p *ProvenanceRecorder[request]) Reconciler(r reconcile.TypedReconciler[request]) reconcile.TypedReconciler[request] {
+	return reconcile.TypedFunc[request](func(ctx context.Context, req request) (reconcile.Result, error) {
+		p.lock.Lock()
+		prov, ok := p.pending[req]
+		delete(p.pending, req)
+		p.lock.Unlock()
+
+		if ok {
+			ctx = mccontext.WithProvenance(ctx, prov)
+		}
+		return r.Reconcile(ctx, req)
+	})
+}
+
+func (p *ProvenanceRecorder[request]) record(item request, prov mccontext.Provenance) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.pending[item] = prov
+}
+
+var _ handler.TypedEventHandler[client.Object, mcreconcile.Request] = &provenanceHandler[mcreconcile.Request]{}
+
+type provenanceHandler[request mcreconcile.ClusterAware[request]] struct {
+	h       handler.TypedEventHandler[client.Object, request]
+	p       *ProvenanceRecorder[request]
+	cluster string
+	gvk     schema.GroupVersionKind
+}
+
+func (h *provenanceHandler[request]) queue(q workqueue.TypedRateLimitingInterface[request], evt mccontext.EventType, obj client.Object) workqueue.TypedRateLimitingInterface[request] {
+	prov := mccontext.Provenance{Cluster: h.cluster, GroupVersionKind: h.gvk, Event: evt}
+	if obj != nil {
+		prov.Object = client.ObjectKeyFromObject(obj)
+	}
+	return newRecordingQueue(q, func(item request) { h.p.record(item, prov) })
+}
+
+// Create implements EventHandler.
+func (h *provenanceHandler[request]) Create(ctx context.Context, evt event.TypedCreateEvent[client.Object], q workqueue.TypedRateLimitingInterface[request]) {
+	h.h.Create(ctx, evt, h.queue(q, mccontext.EventCreate, evt.Object))
+}
+
+// Update implements EventHandler.
+func (h *provenanceHandler[request]) Update(ctx context.Context, evt event.TypedUpdateEvent[client.Object], q workqueue.TypedRateLimitingInterface[request]) {
+	h.h.Update(ctx, evt, h.queue(q, mccontext.EventUpdate, evt.ObjectNew))
+}
+
+// Delete implements EventHandler.
+func (h *provenanceHandler[request]) Delete(ctx context.Context, evt event.TypedDeleteEvent[client.Object], q workqueue.TypedRateLimitingInterface[request]) {
+	h.h.Delete(ctx, evt, h.queue(q, mccontext.EventDelete, evt.Object))
+}
+
+// Generic implements EventHandler.
+func (h *provenanceHandler[request]) Generic(ctx context.Context, evt event.TypedGenericEvent[client.Object], q workqueue.TypedRateLimitingInterface[request]) {
+	h.h.Generic(ctx, evt, h.queue(q, mccontext.EventGeneric, evt.Object))
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	mccontext "sigs.k8s.io/multicluster-runtime/pkg/context"
+	mchandler "sigs.k8s.io/multicluster-runtime/pkg/handler"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ProvenanceRecorder", func() {
+	It("should pass the event that enqueued a request to the reconciler", func() {
+		p := NewProvenanceRecorder[mcreconcile.Request]()
+		gvk := corev1.SchemeGroupVersion.WithKind("Pod")
+		owner := types.NamespacedName{Namespace: "ns", Name: "owner"}
+		h := p.Handler(gvk, mchandler.TypedLift[client.Object](handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
+			return []reconcile.Request{{NamespacedName: owner}}
+		})))("spoke", nil)
+
+		q := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[mcreconcile.Request]())
+		defer q.ShutDown()
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "pod"}}
+		h.Create(context.Background(), event.TypedCreateEvent[client.Object]{Object: pod}, q)
+		h.Update(context.Background(), event.TypedUpdateEvent[client.Object]{ObjectOld: pod, ObjectNew: pod}, q)
+		Expect(q.Len()).To(Equal(1))
+
+		var got []mccontext.Provenance
+		r := p.Reconciler(reconcile.TypedFunc[mcreconcile.Request](func(ctx context.Context, _ mcreconcile.Request) (reconcile.Result, error) {
+			if prov, ok := mccontext.ProvenanceFrom(ctx); ok {
+				got = append(got, prov)
+			}
+			return reconcile.Result{}, nil
+		}))
+		item, _ := q.Get()
+		Expect(item).To(Equal(mcreconcile.Request{ClusterName: "spoke", Request: reconcile.Request{NamespacedName: owner}}))
+		_, err := r.Reconcile(context.Background(), item)
+		Expect(err).NotTo(HaveOccurred())
+		q.Done(item)
+
+		Expect(got).To(Equal([]mccontext.Provenance{{
+			Cluster:          "spoke",
+			GroupVersionKind: gvk,
+			Event:            mccontext.EventUpdate,
+			Object:           types.NamespacedName{Namespace: "ns", Name: "pod"},
+		}}))
+
+		By("not passing provenance to requeued requests")
+		_, err = r.Reconcile(context.Background(), item)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(got).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,248 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/metrics"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+)
+
+// ClusterQueueMetricsOptions configure ClusterQueueMetrics.
+type ClusterQueueMetricsOptions struct {
+	// Provider returns the name of the provider of a cluster, reported as
+	// the provider label, e.g. Manager.ProviderName. Optional.
+	Provider func(clusterName string) string
+
+	// Shard returns the shard a cluster is assigned to, reported as the
+	// shard label, e.g. MembershipOptions.Shard of the manager. Optional.
+	Shard func(clusterName string) string
+
+	// TopN is the number of clusters reported as the noisiest. Defaults to
+	// 10.
+	TopN int
+
+	// Window is the period over which the adds of the noisiest clusters are
+	// counted. Defaults to one minute.
+	Window time.Duration
+}
+
+// ClusterQueueMetrics reports the adds, depth and retries of the queue of
+// a controller per cluster, and the clusters with the most adds within a
+// window. This locates the cluster responsible for the growth of a queue.
+//
+// The depth of a cluster includes requests waiting for their delay or rate
+// limit, but not requests that are being reconciled. The noisiest clusters
+// are reported with their rank, such that the cardinality of the metric is
+// bounded by TopN per controller.
+//
+// Wire it into a controller by setting Options.NewQueue to the result of
+// NewQueue.
+type ClusterQueueMetrics[request mcreconcile.ClusterAware[request]] struct {
+	opts ClusterQueueMetricsOptions
+	now  func() time.Time
+}
+
+// NewClusterQueueMetrics returns a new ClusterQueueMetrics.
+func NewClusterQueueMetrics[request mcreconcile.ClusterAware[request]](opts ClusterQueueMetricsOptions) *ClusterQueueMetrics[request] {
+	if opts.TopN <= 0 {
+		opts.TopN = 10
+	}
+	if opts.Window <= 0 {
+		opts.Window = time.Minute
+	}
+	return &ClusterQueueMetrics[request]{opts: opts, now: time.Now}
+}
+
+// NewQueue wraps the given queue constructor such that the queue reports
+// the metrics of its clusters. If newQueue is nil, a default rate limiting
+// queue is used. Priority queues stay priority queues.
+func (m *ClusterQueueMetrics[request]) NewQueue(
+	newQueue func(controllerName string, rateLimiter workqueue.TypedRateLimiter[request]) workqueue.TypedRateLimitingInterface[request],
+) func(controllerName string, rateLimiter workqueue.TypedRateLimiter[request]) workqueue.TypedRateLimitingInterface[request] {
+	return func(controllerName string, rateLimiter workqueue.TypedRateLimiter[request]) workqueue.TypedRateLimitingInterface[request] {
+		var q workqueue.TypedRateLimitingInterface[request]
+		if newQueue != nil {
+			q = newQueue(controllerName, rateLimiter)
+		} else {
+			q = workqueue.NewTypedRateLimitingQueueWithConfig(rateLimiter, workqueue.TypedRateLimitingQueueConfig[request]{
+				Name: controllerName,
+			})
+		}
+		mq := &metricsQueue[request]{
+			TypedRateLimitingInterface: q,
+			m:                          m,
+			controller:                 controllerName,
+			queued:                     map[request]bool{},
+			depth:                      map[string]int{},
+			labels:                     map[string]prometheus.Labels{},
+			windowAdds:                 map[string]int{},
+			windowStart:                m.now(),
+		}
+		if prio, ok := q.(priorityqueue.PriorityQueue[request]); ok {
+			return &metricsPriorityQueue[request]{metricsQueue: mq, prio: prio}
+		}
+		return mq
+	}
+}
+
+type metricsQueue[request mcreconcile.ClusterAware[request]] struct {
+	workqueue.TypedRateLimitingInterface[request]
+	m          *ClusterQueueMetrics[request]
+	controller string
+
+	lock        sync.Mutex
+	queued      map[request]bool
+	depth       map[string]int
+	labels      map[string]prometheus.Labels
+	windowAdds  map[string]int
+	windowStart time.Time
+}
+
+// clusterLabels returns the labels of the metrics of a cluster. The lock
+// must be held.
+func (q *metricsQueue[request]) clusterLabels(clusterName string) prometheus.Labels {
+	if l, ok := q.labels[clusterName]; ok {
+		return l
+	}
+	l := prometheus.Labels{"controller": q.controller, "cluster": metrics.ClusterLabel(clusterName), "provider": "", "shard": ""}
+	if q.m.opts.Provider != nil {
+		l["provider"] = q.m.opts.Provider(clusterName)
+	}
+	if q.m.opts.Shard != nil {
+		l["shard"] = q.m.opts.Shard(clusterName)
+	}
+	q.labels[clusterName] = l
+	return l
+}
+
+// added accounts for an item added to the queue, retried if rateLimited.
+func (q *metricsQueue[request]) added(item request, rateLimited bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	clusterName := item.Cluster()
+	l := q.clusterLabels(clusterName)
+	metrics.ClusterWorkqueueAdds.With(l).Inc()
+	if rateLimited {
+		metrics.ClusterWorkqueueRetries.With(l).Inc()
+	}
+	if !q.queued[item] {
+		q.queued[item] = true
+		q.depth[clusterName]++
+		metrics.ClusterWorkqueueDepth.With(l).Set(float64(q.depth[clusterName]))
+	}
+	q.rollWindow()
+	q.windowAdds[clusterName]++
+}
+
+// handedOut accounts for an item taken from the queue.
+func (q *metricsQueue[request]) handedOut(item request) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if !q.queued[item] {
+		return
+	}
+	delete(q.queued, item)
+	clusterName := item.Cluster()
+	q.depth[clusterName]--
+	metrics.ClusterWorkqueueDepth.With(q.clusterLabels(clusterName)).Set(float64(q.depth[clusterName]))
+	if q.depth[clusterName] == 0 {
+		delete(q.depth, clusterName)
+	}
+	q.rollWindow()
+}
+
+// rollWindow reports the noisiest clusters of the past window once it
+// ended, and starts a new one. The lock must be held.
+func (q *metricsQueue[request]) rollWindow() {
+	now := q.m.now()
+	if now.Sub(q.windowStart) < q.m.opts.Window {
+		return
+	}
+	names := make([]string, 0, len(q.windowAdds))
+	for name := range q.windowAdds {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if q.windowAdds[names[i]] != q.windowAdds[names[j]] {
+			return q.windowAdds[names[i]] > q.windowAdds[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	if len(names) > q.m.opts.TopN {
+		names = names[:q.m.opts.TopN]
+	}
+	metrics.ClusterWorkqueueNoisiest.DeletePartialMatch(prometheus.Labels{"controller": q.controller})
+	for i, name := range names {
+		metrics.ClusterWorkqueueNoisiest.WithLabelValues(q.controller, strconv.Itoa(i+1), metrics.ClusterLabel(name)).Set(float64(q.windowAdds[name]))
+	}
+	q.windowAdds = map[string]int{}
+	q.windowStart = now
+}
+
+func (q *metricsQueue[request]) Add(item request) {
+	q.added(item, false)
+	q.TypedRateLimitingInterface.Add(item)
+}
+
+func (q *metricsQueue[request]) AddAfter(item request, duration time.Duration) {
+	q.added(item, false)
+	q.TypedRateLimitingInterface.AddAfter(item, duration)
+}
+
+func (q *metricsQueue[request]) AddRateLimited(item request) {
+	q.added(item, true)
+	q.TypedRateLimitingInterface.AddRateLimited(item)
+}
+
+func (q *metricsQueue[request]) Get() (item request, shutdown bool) {
+	item, shutdown = q.TypedRateLimitingInterface.Get()
+	if !shutdown {
+		q.handedOut(item)
+	}
+	return item, shutdown
+}
+
+type metricsPriorityQueue[request mcreconcile.ClusterAware[request]] struct {
+	*metricsQueue[request]
+	prio priorityqueue.PriorityQueue[request]
+}
+
+func (q *metricsPriorityQueue[request]) AddWithOpts(o priorityqueue.AddOpts, items ...request) {
+	for _, item := range items {
+		q.added(item, o.RateLimited)
+	}
+	q.prio.AddWithOpts(o, items...)
+}
+
+func (q *metricsPriorityQueue[request]) GetWithPriority() (request, int, bool) {
+	item, priority, shutdown := q.prio.GetWithPriority()
+	if !shutdown {
+		q.handedOut(item)
+	}
+	return item, priority, shutdown
+}
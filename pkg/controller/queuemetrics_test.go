@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/metrics"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ClusterQueueMetrics", func() {
+	req := func(cluster, name string) mcreconcile.Request {
+		return mcreconcile.Request{
+			Request:     reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: name}},
+			ClusterName: cluster,
+		}
+	}
+
+	It("reports the queue per cluster and the noisiest clusters", func() {
+		now := time.Now()
+		m := NewClusterQueueMetrics[mcreconcile.Request](ClusterQueueMetricsOptions{
+			Provider: func(string) string { return "kind" },
+			Shard:    func(string) string { return "0" },
+			TopN:     1,
+		})
+		m.now = func() time.Time { return now }
+		q := m.NewQueue(nil)("queue-metrics", workqueue.DefaultTypedControllerRateLimiter[mcreconcile.Request]())
+		defer q.ShutDown()
+
+		q.Add(req("cluster-a", "foo"))
+		q.Add(req("cluster-a", "foo"))
+		q.Add(req("cluster-a", "bar"))
+		q.Add(req("cluster-b", "foo"))
+
+		labels := func(cluster string) []string { return []string{"queue-metrics", cluster, "kind", "0"} }
+		Expect(testutil.ToFloat64(metrics.ClusterWorkqueueAdds.WithLabelValues(labels("cluster-a")...))).To(Equal(3.0))
+		Expect(testutil.ToFloat64(metrics.ClusterWorkqueueDepth.WithLabelValues(labels("cluster-a")...))).To(Equal(2.0))
+		Expect(testutil.ToFloat64(metrics.ClusterWorkqueueDepth.WithLabelValues(labels("cluster-b")...))).To(Equal(1.0))
+
+		item, _ := q.Get()
+		Expect(item).To(Equal(req("cluster-a", "foo")))
+		Expect(testutil.ToFloat64(metrics.ClusterWorkqueueDepth.WithLabelValues(labels("cluster-a")...))).To(Equal(1.0))
+
+		now = now.Add(time.Minute)
+		q.AddRateLimited(item)
+		q.Done(item)
+		Expect(testutil.ToFloat64(metrics.ClusterWorkqueueRetries.WithLabelValues(labels("cluster-a")...))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(metrics.ClusterWorkqueueDepth.WithLabelValues(labels("cluster-a")...))).To(Equal(2.0))
+
+		Expect(testutil.CollectAndCount(metrics.ClusterWorkqueueNoisiest)).To(Equal(1))
+		Expect(testutil.ToFloat64(metrics.ClusterWorkqueueNoisiest.WithLabelValues("queue-metrics", "1", "cluster-a"))).To(Equal(3.0))
+	})
+})
@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+)
+
+// newRecordingQueue returns a queue calling record for every item added to
+// q by an event handler. Priority queues stay priority queues, such that
+// handlers keep enqueueing with priorities.
+func newRecordingQueue[request comparable](q workqueue.TypedRateLimitingInterface[request], record func(request)) workqueue.TypedRateLimitingInterface[request] {
+	rq := &recordingQueue[request]{TypedRateLimitingInterface: q, record: record}
+	if prio, ok := q.(priorityqueue.PriorityQueue[request]); ok {
+		return &recordingPriorityQueue[request]{recordingQueue: rq, prio: prio}
+	}
+	return rq
+}
+
+type recordingQueue[request comparable] struct {
+	workqueue.TypedRateLimitingInterface[request]
+	record func(request)
+}
+
+func (q *recordingQueue[request]) Add(item request) {
+	q.record(item)
+	q.TypedRateLimitingInterface.Add(item)
+}
+
+func (q *recordingQueue[request]) AddAfter(item request, duration time.Duration) {
+	q.record(item)
+	q.TypedRateLimitingInterface.AddAfter(item, duration)
+}
+
+func (q *recordingQueue[request]) AddRateLimited(item request) {
+	q.record(item)
+	q.TypedRateLimitingInterface.AddRateLimited(item)
+}
+
+type recordingPriorityQueue[request comparable] struct {
+	*recordingQueue[request]
+	prio priorityqueue.PriorityQueue[request]
+}
+
+func (q *recordingPriorityQueue[request]) AddWithOpts(o priorityqueue.AddOpts, items ...request) {
+	for _, item := range items {
+		q.record(item)
+	}
+	q.prio.AddWithOpts(o, items...)
+}
+
+func (q *recordingPriorityQueue[request]) GetWithPriority() (request, int, bool) {
+	return q.prio.GetWithPriority()
+}
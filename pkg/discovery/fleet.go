@@ -0,0 +1,244 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package discovery answers which engaged clusters serve a kind or API
+// version, backed by per-cluster cached discovery that is refreshed after a
+// TTL.
+package discovery
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientdiscovery "k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+// DefaultTTL is the default time after which the discovery information of
+// a cluster is refreshed.
+const DefaultTTL = 5 * time.Minute
+
+// Interface answers questions about the capabilities of the fleet.
+type Interface interface {
+	// ClustersServing returns the sorted names of the engaged clusters
+	// serving the given kind.
+	ClustersServing(gvk schema.GroupVersionKind) []string
+
+	// ClustersServingVersion returns the sorted names of the engaged
+	// clusters serving the given API version.
+	ClustersServingVersion(gv schema.GroupVersion) []string
+
+	// ServesKind returns whether the engaged cluster serves the given kind.
+	ServesKind(clusterName string, gvk schema.GroupVersionKind) (bool, error)
+}
+
+// Options are the options for the Fleet.
+type Options struct {
+	// TTL is the time after which the discovery information of a cluster
+	// is refreshed. Defaults to DefaultTTL.
+	TTL time.Duration
+
+	// NewDiscoveryClient creates the discovery client of a cluster.
+	// Defaults to a client created from the rest config of the cluster.
+	NewDiscoveryClient func(cfg *rest.Config) (clientdiscovery.DiscoveryInterface, error)
+}
+
+var (
+	_ mcmanager.Runnable = &Fleet{}
+	_ Interface          = &Fleet{}
+)
+
+// Fleet caches the discovery information of the engaged clusters. Add it to
+// the manager with Manager.Add.
+type Fleet struct {
+	opts Options
+	log  logr.Logger
+
+	lock     sync.RWMutex
+	clusters map[string]*entry
+}
+
+type entry struct {
+	client    clientdiscovery.DiscoveryInterface
+	kinds     sets.Set[schema.GroupVersionKind]
+	versions  sets.Set[schema.GroupVersion]
+	refreshed time.Time
+}
+
+// New returns a new Fleet.
+func New(opts Options) *Fleet {
+	if opts.TTL <= 0 {
+		opts.TTL = DefaultTTL
+	}
+	if opts.NewDiscoveryClient == nil {
+		opts.NewDiscoveryClient = func(cfg *rest.Config) (clientdiscovery.DiscoveryInterface, error) {
+			return clientdiscovery.NewDiscoveryClientForConfig(cfg)
+		}
+	}
+	return &Fleet{
+		opts:     opts,
+		log:      log.Log.WithName("fleet-discovery"),
+		clusters: map[string]*entry{},
+	}
+}
+
+// ClustersServing implements Interface.
+func (f *Fleet) ClustersServing(gvk schema.GroupVersionKind) []string {
+	return f.clustersWhere(func(e *entry) bool { return e.kinds.Has(gvk) })
+}
+
+// ClustersServingVersion implements Interface.
+func (f *Fleet) ClustersServingVersion(gv schema.GroupVersion) []string {
+	return f.clustersWhere(func(e *entry) bool { return e.versions.Has(gv) })
+}
+
+// ServesKind implements Interface.
+func (f *Fleet) ServesKind(clusterName string, gvk schema.GroupVersionKind) (bool, error) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	e, ok := f.clusters[clusterName]
+	if !ok {
+		return false, multicluster.ErrClusterNotFound
+	}
+	return e.kinds.Has(gvk), nil
+}
+
+// Engage discovers the cluster, and removes it again when ctx is done.
+func (f *Fleet) Engage(ctx context.Context, name string, cl cluster.Cluster) error {
+	dc, err := f.opts.NewDiscoveryClient(cl.GetConfig())
+	if err != nil {
+		return err
+	}
+	e := &entry{client: dc}
+	if err := f.discover(e); err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	f.clusters[name] = e
+	f.lock.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		f.lock.Lock()
+		if f.clusters[name] == e {
+			delete(f.clusters, name)
+		}
+		f.lock.Unlock()
+	}()
+	return nil
+}
+
+// Refresh discovers the engaged cluster again, regardless of the TTL.
+func (f *Fleet) Refresh(clusterName string) error {
+	f.lock.RLock()
+	e, ok := f.clusters[clusterName]
+	f.lock.RUnlock()
+	if !ok {
+		return multicluster.ErrClusterNotFound
+	}
+	return f.discover(e)
+}
+
+// Start refreshes the discovery information of clusters older than the TTL,
+// and blocks until ctx is done.
+func (f *Fleet) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		f.lock.RLock()
+		var stale []string
+		for name, e := range f.clusters {
+			if time.Since(e.refreshed) >= f.opts.TTL {
+				stale = append(stale, name)
+			}
+		}
+		f.lock.RUnlock()
+
+		for _, name := range stale {
+			if err := f.Refresh(name); err != nil && !errors.Is(err, multicluster.ErrClusterNotFound) {
+				f.log.Error(err, "Failed to refresh discovery", "cluster", multicluster.EscapeClusterName(name))
+			}
+		}
+	}, f.opts.TTL/2)
+	return nil
+}
+
+// discover reads the served resources of the cluster into the entry. Groups
+// that fail discovery keep their previous information.
+func (f *Fleet) discover(e *entry) error {
+	_, lists, err := e.client.ServerGroupsAndResources()
+	var failed *clientdiscovery.ErrGroupDiscoveryFailed
+	if err != nil && !errors.As(err, &failed) {
+		return err
+	}
+
+	kinds := sets.New[schema.GroupVersionKind]()
+	versions := sets.New[schema.GroupVersion]()
+	for _, list := range lists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			continue
+		}
+		versions.Insert(gv)
+		for _, r := range list.APIResources {
+			kinds.Insert(gv.WithKind(r.Kind))
+		}
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if failed != nil {
+		for gv := range failed.Groups {
+			if !e.versions.Has(gv) {
+				continue
+			}
+			versions.Insert(gv)
+			for gvk := range e.kinds {
+				if gvk.GroupVersion() == gv {
+					kinds.Insert(gvk)
+				}
+			}
+		}
+	}
+	e.kinds, e.versions, e.refreshed = kinds, versions, time.Now()
+	return nil
+}
+
+func (f *Fleet) clustersWhere(pred func(*entry) bool) []string {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	var names []string
+	for name, e := range f.clusters {
+		if pred(e) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package discovery
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientdiscovery "k8s.io/client-go/discovery"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/rest"
+	clienttesting "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/fake"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+var _ = Describe("Fleet", func() {
+	gateway := schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "Gateway"}
+	core := []*metav1.APIResourceList{{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{{Name: "configmaps", Kind: "ConfigMap"}},
+	}}
+	withGateway := append(core, &metav1.APIResourceList{
+		GroupVersion: gateway.GroupVersion().String(),
+		APIResources: []metav1.APIResource{{Name: "gateways", Kind: "Gateway"}},
+	})
+
+	var (
+		mgr       *fake.Manager
+		f         *Fleet
+		discovery map[string]*fakediscovery.FakeDiscovery
+	)
+
+	engage := func(ctx context.Context, name string, resources []*metav1.APIResourceList) {
+		discovery[name] = &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{Resources: resources}}
+		f.opts.NewDiscoveryClient = func(*rest.Config) (clientdiscovery.DiscoveryInterface, error) {
+			return discovery[name], nil
+		}
+		Expect(f.Engage(ctx, name, mgr.FakeCluster(name))).To(Succeed())
+	}
+
+	BeforeEach(func(ctx context.Context) {
+		mgr = fake.NewManagerBuilder().WithCluster("edge").WithCluster("core").WithCluster("gone").Build()
+		f = New(Options{})
+		discovery = map[string]*fakediscovery.FakeDiscovery{}
+		engage(ctx, "edge", withGateway)
+		engage(ctx, "core", core)
+	})
+
+	It("answers which clusters serve a kind", func() {
+		Expect(f.ClustersServing(gateway)).To(Equal([]string{"edge"}))
+		Expect(f.ClustersServing(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"})).To(Equal([]string{"core", "edge"}))
+		Expect(f.ClustersServingVersion(gateway.GroupVersion())).To(Equal([]string{"edge"}))
+
+		served, err := f.ServesKind("core", gateway)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(served).To(BeFalse())
+		_, err = f.ServesKind("unknown", gateway)
+		Expect(err).To(MatchError(multicluster.ErrClusterNotFound))
+	})
+
+	It("picks up newly served kinds on refresh", func() {
+		discovery["core"].Resources = withGateway
+		Expect(f.ClustersServing(gateway)).To(Equal([]string{"edge"}))
+		Expect(f.Refresh("core")).To(Succeed())
+		Expect(f.ClustersServing(gateway)).To(Equal([]string{"core", "edge"}))
+	})
+
+	It("forgets disengaged clusters", func(ctx context.Context) {
+		clusterCtx, cancel := context.WithCancel(ctx)
+		engage(clusterCtx, "gone", withGateway)
+		Expect(f.ClustersServing(gateway)).To(ContainElement("gone"))
+		cancel()
+		Eventually(func() []string { return f.ClustersServing(gateway) }).ShouldNot(ContainElement("gone"))
+	})
+})
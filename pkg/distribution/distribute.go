@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package distribution provides helpers to split a number of replicas across
+// clusters by weight and capacity.
+package distribution
+
+import (
+	"sort"
+)
+
+// Target is a cluster replicas can be distributed to.
+type Target struct {
+	// Name is the name of the cluster.
+	Name string
+
+	// Weight is the relative share of replicas the cluster should receive.
+	// Targets with a weight of zero receive no replicas.
+	Weight int32
+
+	// Capacity is the maximum number of replicas the cluster can take. Nil
+	// means unlimited.
+	Capacity *int32
+}
+
+// Distribute splits total replicas across the given targets proportionally
+// to their weights, respecting their capacities. Replicas that cannot be
+// placed because of capacity limits are redistributed to the remaining
+// targets. The result is deterministic: remainders are assigned to the
+// targets with the largest fractional share, ties are broken by name.
+//
+// The returned map contains an entry for every target. If the capacities do
+// not suffice, the sum of the result is smaller than total.
+func Distribute(total int32, targets []Target) map[string]int32 {
+	result := make(map[string]int32, len(targets))
+	for _, t := range targets {
+		result[t.Name] = 0
+	}
+
+	remaining := total
+	open := make([]Target, 0, len(targets))
+	for _, t := range targets {
+		if t.Weight > 0 && (t.Capacity == nil || *t.Capacity > 0) {
+			open = append(open, t)
+		}
+	}
+	sort.Slice(open, func(i, j int) bool { return open[i].Name < open[j].Name })
+
+	for remaining > 0 && len(open) > 0 {
+		var weights int64
+		for _, t := range open {
+			weights += int64(t.Weight)
+		}
+
+		type share struct {
+			target    Target
+			whole     int32
+			remainder int64
+		}
+		shares := make([]share, 0, len(open))
+		var assigned int32
+		for _, t := range open {
+			n := int64(remaining) * int64(t.Weight)
+			s := share{target: t, whole: int32(n / weights), remainder: n % weights}
+			assigned += s.whole
+			shares = append(shares, s)
+		}
+		sort.SliceStable(shares, func(i, j int) bool { return shares[i].remainder > shares[j].remainder })
+		for i := 0; assigned < remaining; i++ {
+			shares[i%len(shares)].whole++
+			assigned++
+		}
+
+		// apply capacities, and redistribute what does not fit.
+		remaining = 0
+		next := open[:0]
+		for _, s := range shares {
+			n := s.whole
+			free := int32(-1)
+			if s.target.Capacity != nil {
+				free = *s.target.Capacity - result[s.target.Name]
+			}
+			if free >= 0 && n >= free {
+				remaining += n - free
+				n = free
+			} else {
+				next = append(next, s.target)
+			}
+			result[s.target.Name] += n
+		}
+		sort.Slice(next, func(i, j int) bool { return next[i].Name < next[j].Name })
+		open = next
+	}
+
+	return result
+}
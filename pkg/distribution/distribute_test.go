@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package distribution
+
+import (
+	"k8s.io/utils/ptr"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Distribute", func() {
+	It("should split evenly with equal weights", func() {
+		Expect(Distribute(10, []Target{
+			{Name: "a", Weight: 1},
+			{Name: "b", Weight: 1},
+			{Name: "c", Weight: 1},
+		})).To(Equal(map[string]int32{"a": 4, "b": 3, "c": 3}))
+	})
+
+	It("should split proportionally to weights", func() {
+		Expect(Distribute(10, []Target{
+			{Name: "a", Weight: 3},
+			{Name: "b", Weight: 1},
+			{Name: "c", Weight: 1},
+		})).To(Equal(map[string]int32{"a": 6, "b": 2, "c": 2}))
+	})
+
+	It("should redistribute what does not fit the capacity", func() {
+		Expect(Distribute(10, []Target{
+			{Name: "a", Weight: 1, Capacity: ptr.To[int32](1)},
+			{Name: "b", Weight: 1},
+			{Name: "c", Weight: 1},
+		})).To(Equal(map[string]int32{"a": 1, "b": 5, "c": 4}))
+	})
+
+	It("should not exceed the total capacity", func() {
+		Expect(Distribute(10, []Target{
+			{Name: "a", Weight: 1, Capacity: ptr.To[int32](2)},
+			{Name: "b", Weight: 1, Capacity: ptr.To[int32](3)},
+		})).To(Equal(map[string]int32{"a": 2, "b": 3}))
+	})
+
+	It("should skip targets without weight", func() {
+		Expect(Distribute(4, []Target{
+			{Name: "a", Weight: 0},
+			{Name: "b", Weight: 1},
+		})).To(Equal(map[string]int32{"a": 0, "b": 4}))
+	})
+})
@@ -0,0 +1,184 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package distribution
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strconv"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	"sigs.k8s.io/multicluster-runtime/pkg/selector"
+)
+
+const (
+	// WeightAnnotation is the cluster annotation holding the weight of the
+	// cluster. Defaults to 1.
+	WeightAnnotation = "multicluster.x-k8s.io/weight"
+
+	// CapacityAnnotation is the cluster annotation holding the maximum number
+	// of replicas of the cluster. Defaults to unlimited.
+	CapacityAnnotation = "multicluster.x-k8s.io/capacity"
+)
+
+// Options are the options for the Distributor.
+type Options struct {
+	// Selector selects the clusters replicas are distributed to. If nil,
+	// all engaged clusters are selected.
+	Selector *selector.Selector
+
+	// Tolerations are the tolerated cluster taints. Clusters with NoSchedule
+	// or NoEngage taints that are not tolerated get no replicas.
+	Tolerations []multicluster.Toleration
+
+	// TargetFunc computes the weight and capacity of a cluster. Defaults to
+	// TargetFromAnnotations.
+	TargetFunc func(clusterName string, md multicluster.Metadata) (Target, error)
+
+	// OnChange is called with the new distribution whenever it changes.
+	OnChange func(distribution map[string]int32)
+}
+
+var _ mcmanager.Runnable = &Distributor{}
+
+// Distributor maintains a distribution of replicas across the engaged
+// clusters, rebalancing when clusters are engaged or disengaged. Add it to
+// the manager with Manager.Add.
+type Distributor struct {
+	mgr  mcmanager.Manager
+	opts Options
+
+	lock         sync.Mutex
+	total        int32
+	targets      map[string]Target
+	distribution map[string]int32
+}
+
+// New returns a new Distributor for the given total number of replicas.
+func New(mgr mcmanager.Manager, total int32, opts Options) *Distributor {
+	if opts.TargetFunc == nil {
+		opts.TargetFunc = TargetFromAnnotations
+	}
+	return &Distributor{
+		mgr:          mgr,
+		opts:         opts,
+		total:        total,
+		targets:      map[string]Target{},
+		distribution: map[string]int32{},
+	}
+}
+
+// TargetFromAnnotations computes a Target from the WeightAnnotation and
+// CapacityAnnotation of the cluster.
+func TargetFromAnnotations(clusterName string, md multicluster.Metadata) (Target, error) {
+	t := Target{Name: clusterName, Weight: 1}
+	if s, ok := md.Annotations[WeightAnnotation]; ok {
+		w, err := strconv.ParseInt(s, 10, 32)
+		if err != nil || w < 0 {
+			return Target{}, fmt.Errorf("invalid weight %q of cluster %q", s, clusterName)
+		}
+		t.Weight = int32(w)
+	}
+	if s, ok := md.Annotations[CapacityAnnotation]; ok {
+		c, err := strconv.ParseInt(s, 10, 32)
+		if err != nil || c < 0 {
+			return Target{}, fmt.Errorf("invalid capacity %q of cluster %q", s, clusterName)
+		}
+		capacity := int32(c)
+		t.Capacity = &capacity
+	}
+	return t, nil
+}
+
+// Engage adds the cluster to the distribution if it is selected, and removes
+// it again when ctx is done.
+func (d *Distributor) Engage(ctx context.Context, name string, _ cluster.Cluster) error {
+	md, err := d.mgr.GetClusterMetadata(ctx, name)
+	if err != nil {
+		return err
+	}
+	if !d.opts.Selector.Matches(name, md) {
+		return nil
+	}
+	if _, ok := multicluster.FindUntoleratedTaint(md.Taints, d.opts.Tolerations, multicluster.TaintEffectNoSchedule, multicluster.TaintEffectNoEngage); ok {
+		return nil
+	}
+	target, err := d.opts.TargetFunc(name, md)
+	if err != nil {
+		return err
+	}
+
+	d.lock.Lock()
+	d.targets[name] = target
+	d.rebalance()
+	d.lock.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		d.lock.Lock()
+		defer d.lock.Unlock()
+		delete(d.targets, name)
+		d.rebalance()
+	}()
+
+	return nil
+}
+
+// Start blocks until ctx is done.
+func (d *Distributor) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+// SetTotal changes the total number of replicas and rebalances.
+func (d *Distributor) SetTotal(total int32) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.total = total
+	d.rebalance()
+}
+
+// Get returns the current distribution.
+func (d *Distributor) Get() map[string]int32 {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return maps.Clone(d.distribution)
+}
+
+// rebalance recomputes the distribution. The lock must be held.
+func (d *Distributor) rebalance() {
+	targets := make([]Target, 0, len(d.targets))
+	for _, t := range d.targets {
+		targets = append(targets, t)
+	}
+	distribution := Distribute(d.total, targets)
+	if maps.Equal(distribution, d.distribution) {
+		return
+	}
+	d.distribution = distribution
+
+	log.Log.WithName("distribution").V(1).Info("Rebalanced replicas", "total", d.total, "distribution", distribution)
+	if d.opts.OnChange != nil {
+		d.opts.OnChange(maps.Clone(distribution))
+	}
+}
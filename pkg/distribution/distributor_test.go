@@ -0,0 +1,54 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package distribution
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/fake"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+var _ = Describe("Distributor", func() {
+	maintenance := multicluster.Taint{Key: "maintenance", Effect: multicluster.TaintEffectNoSchedule}
+
+	engage := func(ctx context.Context, opts Options) map[string]int32 {
+		mgr := fake.NewManagerBuilder().
+			WithCluster("a").
+			WithCluster("b").
+			WithClusterMetadata("b", multicluster.Metadata{Taints: []multicluster.Taint{maintenance}}).
+			Build()
+		d := New(mgr, 4, opts)
+		for _, name := range []string{"a", "b"} {
+			Expect(d.Engage(ctx, name, mgr.FakeCluster(name))).To(Succeed())
+		}
+		return d.Get()
+	}
+
+	It("skips clusters with untolerated taints", func(ctx context.Context) {
+		Expect(engage(ctx, Options{})).To(Equal(map[string]int32{"a": 4}))
+	})
+
+	It("distributes to clusters with tolerated taints", func(ctx context.Context) {
+		Expect(engage(ctx, Options{
+			Tolerations: []multicluster.Toleration{{Key: "maintenance", Operator: multicluster.TolerationOpExists}},
+		})).To(Equal(map[string]int32{"a": 2, "b": 2}))
+	})
+})
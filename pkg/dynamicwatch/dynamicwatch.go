@@ -0,0 +1,317 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dynamicwatch watches kinds decided at runtime in the engaged
+// clusters, e.g. declared by a policy or backup object in the hub cluster,
+// and feeds the events of all clusters to a single handler.
+package dynamicwatch
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/go-logr/logr"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	toolscache "k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	mccluster "sigs.k8s.io/multicluster-runtime/pkg/cluster"
+	mccontext "sigs.k8s.io/multicluster-runtime/pkg/context"
+	mcerrors "sigs.k8s.io/multicluster-runtime/pkg/errors"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	"sigs.k8s.io/multicluster-runtime/pkg/selector"
+)
+
+// EventType is the type of an Event.
+type EventType string
+
+const (
+	// EventAdd is sent for objects that were created, and for all existing
+	// objects when a watch starts.
+	EventAdd EventType = "Add"
+
+	// EventUpdate is sent for objects that were updated.
+	EventUpdate EventType = "Update"
+
+	// EventDelete is sent for objects that were deleted.
+	EventDelete EventType = "Delete"
+)
+
+// Event is a change of an object in a watched cluster.
+type Event struct {
+	// Watch is the name of the watch the event belongs to.
+	Watch string
+
+	// Cluster is the name of the cluster of the object.
+	Cluster string
+
+	// Type is the type of the change.
+	Type EventType
+
+	// Object is the object after the change, or the last known state of a
+	// deleted object.
+	Object *unstructured.Unstructured
+}
+
+// Handler handles the events of all watches. ctx carries the cluster of the
+// event and is done when the watch stops. It must not block.
+type Handler func(ctx context.Context, ev Event)
+
+// Spec declares which kinds to watch in which clusters.
+type Spec struct {
+	// Kinds are the kinds to watch.
+	Kinds []schema.GroupVersionKind
+
+	// Namespace restricts the events to objects of this namespace. If
+	// empty, the events of all namespaces are sent.
+	Namespace string
+
+	// ClusterSelector selects the clusters. If nil, all engaged clusters
+	// are watched.
+	ClusterSelector *selector.ClusterSelector
+}
+
+var _ mcmanager.Runnable = &Watcher{}
+
+// Watcher starts and stops unstructured informers in the engaged clusters
+// as watches are added and removed, e.g. by the reconciler of a hub object
+// declaring them. Add it to the manager with Manager.Add.
+type Watcher struct {
+	mgr     mcmanager.Manager
+	handler Handler
+	log     logr.Logger
+
+	lock      sync.Mutex
+	clusters  map[string]engagedCluster
+	watches   map[string]watch
+	handles   map[handleKey]handle
+	informers map[informerKey]int
+}
+
+type engagedCluster struct {
+	ctx     context.Context
+	cluster cluster.Cluster
+}
+
+type watch struct {
+	Spec
+	selector *selector.Selector
+}
+
+type informerKey struct {
+	cluster string
+	gvk     schema.GroupVersionKind
+}
+
+type handleKey struct {
+	watch string
+	informerKey
+}
+
+type handle struct {
+	informer     cache.Informer
+	registration toolscache.ResourceEventHandlerRegistration
+	cancel       context.CancelFunc
+}
+
+// New returns a new Watcher sending the events to handler.
+func New(mgr mcmanager.Manager, handler Handler) *Watcher {
+	return &Watcher{
+		mgr:       mgr,
+		handler:   handler,
+		log:       log.Log.WithName("dynamicwatch"),
+		clusters:  map[string]engagedCluster{},
+		watches:   map[string]watch{},
+		handles:   map[handleKey]handle{},
+		informers: map[informerKey]int{},
+	}
+}
+
+// Watch adds or updates the watch with the given name. Informers of kinds
+// that are no longer watched in a cluster are stopped. Errors of individual
+// clusters, e.g. because a kind is not served there, are aggregated; the
+// other clusters are watched nevertheless.
+func (w *Watcher) Watch(ctx context.Context, name string, spec Spec) error {
+	sel, err := spec.ClusterSelector.Compile()
+	if err != nil {
+		return err
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.watches[name] = watch{Spec: spec, selector: sel}
+
+	var errs []error
+	for clusterName := range w.clusters {
+		errs = append(errs, w.sync(ctx, name, clusterName))
+	}
+	return mcerrors.NewAggregate(errs...)
+}
+
+// Unwatch removes the watch with the given name.
+func (w *Watcher) Unwatch(ctx context.Context, name string) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	delete(w.watches, name)
+
+	var errs []error
+	for clusterName := range w.clusters {
+		errs = append(errs, w.sync(ctx, name, clusterName))
+	}
+	return mcerrors.NewAggregate(errs...)
+}
+
+// Engage starts the watches selecting the cluster.
+func (w *Watcher) Engage(ctx context.Context, name string, cl cluster.Cluster) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.clusters[name] = engagedCluster{ctx: ctx, cluster: cl}
+	go func() {
+		<-ctx.Done()
+		w.lock.Lock()
+		defer w.lock.Unlock()
+		if w.clusters[name].cluster != cl {
+			return
+		}
+		delete(w.clusters, name)
+		for key, h := range w.handles {
+			if key.cluster == name {
+				h.cancel()
+				delete(w.handles, key)
+				delete(w.informers, key.informerKey)
+			}
+		}
+	}()
+
+	var errs []error
+	for watchName := range w.watches {
+		errs = append(errs, w.sync(ctx, watchName, name))
+	}
+	if err := mcerrors.NewAggregate(errs...); err != nil {
+		w.log.Error(err, "Failed to watch cluster", "cluster", multicluster.EscapeClusterName(name))
+	}
+	return nil
+}
+
+// Start blocks until ctx is done.
+func (w *Watcher) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+// sync starts and stops the informers of the watch in the cluster. The lock
+// must be held.
+func (w *Watcher) sync(ctx context.Context, watchName, clusterName string) error {
+	ec := w.clusters[clusterName]
+	desired := map[schema.GroupVersionKind]bool{}
+	if wt, ok := w.watches[watchName]; ok {
+		selected, err := wt.selector.MatchesCluster(ctx, w.mgr, clusterName)
+		if err != nil {
+			return mcerrors.New(clusterName, "select", err)
+		}
+		if selected {
+			for _, gvk := range wt.Kinds {
+				desired[gvk] = true
+			}
+		}
+	}
+
+	for key := range w.handles {
+		if key.watch == watchName && key.cluster == clusterName && !desired[key.gvk] {
+			w.stop(ctx, key)
+		}
+	}
+	var errs []error
+	for gvk := range desired {
+		key := handleKey{watch: watchName, informerKey: informerKey{cluster: clusterName, gvk: gvk}}
+		if _, ok := w.handles[key]; ok {
+			continue
+		}
+		if err := w.start(ec, key); err != nil {
+			errs = append(errs, mcerrors.New(clusterName, fmt.Sprintf("watch %s", gvk.Kind), err))
+		}
+	}
+	return mcerrors.NewAggregate(errs...)
+}
+
+func (w *Watcher) start(ec engagedCluster, key handleKey) error {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(key.gvk)
+	// the informer is referenced until the watch stops, for caches
+	// counting the references.
+	ctx, cancel := context.WithCancel(mccontext.WithCluster(ec.ctx, key.cluster))
+	inf, err := ec.cluster.GetCache().GetInformer(ctx, obj, cache.BlockUntilSynced(false))
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	namespace := w.watches[key.watch].Namespace
+	send := func(typ EventType, o interface{}) {
+		if tombstone, ok := o.(toolscache.DeletedFinalStateUnknown); ok {
+			o = tombstone.Obj
+		}
+		u, ok := o.(*unstructured.Unstructured)
+		if !ok || ctx.Err() != nil || (namespace != "" && u.GetNamespace() != namespace) {
+			return
+		}
+		w.handler(ctx, Event{Watch: key.watch, Cluster: key.cluster, Type: typ, Object: u})
+	}
+	reg, err := inf.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    func(o interface{}) { send(EventAdd, o) },
+		UpdateFunc: func(_, o interface{}) { send(EventUpdate, o) },
+		DeleteFunc: func(o interface{}) { send(EventDelete, o) },
+	})
+	if err != nil {
+		cancel()
+		return err
+	}
+	w.handles[key] = handle{informer: inf, registration: reg, cancel: cancel}
+	w.informers[key.informerKey]++
+	return nil
+}
+
+// stop removes the event handler of the watch, and the informer if no other
+// watch uses it.
+func (w *Watcher) stop(ctx context.Context, key handleKey) {
+	h := w.handles[key]
+	h.cancel()
+	delete(w.handles, key)
+	if err := h.informer.RemoveEventHandler(h.registration); err != nil {
+		w.log.Error(err, "Failed to remove event handler", "cluster", multicluster.EscapeClusterName(key.cluster), "kind", key.gvk)
+	}
+	w.informers[key.informerKey]--
+	if w.informers[key.informerKey] > 0 {
+		return
+	}
+	delete(w.informers, key.informerKey)
+	cl := w.clusters[key.cluster].cluster
+	if _, ok := mccluster.GetInformerReferences(cl); ok {
+		// removed by the cache when no longer referenced elsewhere.
+		return
+	}
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(key.gvk)
+	if err := cl.GetCache().RemoveInformer(ctx, obj); err != nil {
+		w.log.Error(err, "Failed to stop informer", "cluster", multicluster.EscapeClusterName(key.cluster), "kind", key.gvk)
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dynamicwatch
+
+import (
+	"context"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+
+	mccontext "sigs.k8s.io/multicluster-runtime/pkg/context"
+	"sigs.k8s.io/multicluster-runtime/pkg/fake"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	"sigs.k8s.io/multicluster-runtime/pkg/selector"
+)
+
+var _ = Describe("Watcher", func() {
+	ctx := context.Background()
+	configMaps := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+	secrets := schema.GroupVersionKind{Version: "v1", Kind: "Secret"}
+
+	var (
+		mgr    *fake.Manager
+		lock   sync.Mutex
+		events []Event
+		w      *Watcher
+	)
+
+	BeforeEach(func() {
+		mgr = fake.NewManagerBuilder().
+			WithClusterMetadata("prod", multicluster.Metadata{Labels: map[string]string{"env": "prod"}}).
+			WithClusterMetadata("dev", multicluster.Metadata{Labels: map[string]string{"env": "dev"}}).
+			Build()
+		events = nil
+		w = New(mgr, func(ctx context.Context, ev Event) {
+			cluster, _ := mccontext.ClusterFrom(ctx)
+			Expect(cluster).To(Equal(ev.Cluster))
+			lock.Lock()
+			defer lock.Unlock()
+			events = append(events, ev)
+		})
+		ctx, cancel := context.WithCancel(ctx)
+		DeferCleanup(cancel)
+		Expect(w.Engage(ctx, "prod", mgr.FakeCluster("prod"))).To(Succeed())
+		Expect(w.Engage(ctx, "dev", mgr.FakeCluster("dev"))).To(Succeed())
+	})
+
+	informer := func(cluster string, gvk schema.GroupVersionKind) *controllertest.FakeInformer {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		inf, err := mgr.FakeCluster(cluster).GetCache().GetInformer(ctx, obj)
+		Expect(err).NotTo(HaveOccurred())
+		return inf.(*controllertest.FakeInformer)
+	}
+	object := func(gvk schema.GroupVersionKind, namespace, name string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		obj.SetNamespace(namespace)
+		obj.SetName(name)
+		return obj
+	}
+	received := func() []Event {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]Event(nil), events...)
+	}
+
+	It("feeds the events of the selected clusters to the handler", func() {
+		Expect(w.Watch(ctx, "backup", Spec{
+			Kinds:           []schema.GroupVersionKind{configMaps},
+			Namespace:       "apps",
+			ClusterSelector: &selector.ClusterSelector{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}},
+		})).To(Succeed())
+
+		cm := object(configMaps, "apps", "a")
+		informer("prod", configMaps).Add(cm)
+		informer("prod", configMaps).Add(object(configMaps, "kube-system", "b"))
+		informer("dev", configMaps).Add(object(configMaps, "apps", "c"))
+		Expect(received()).To(Equal([]Event{{Watch: "backup", Cluster: "prod", Type: EventAdd, Object: cm}}))
+	})
+
+	It("stops informers of kinds no longer watched", func() {
+		Expect(w.Watch(ctx, "backup", Spec{Kinds: []schema.GroupVersionKind{configMaps, secrets}})).To(Succeed())
+		Expect(w.Watch(ctx, "policy", Spec{Kinds: []schema.GroupVersionKind{secrets}})).To(Succeed())
+		old := informer("dev", configMaps)
+
+		Expect(w.Watch(ctx, "backup", Spec{Kinds: []schema.GroupVersionKind{secrets}})).To(Succeed())
+		Expect(informer("dev", configMaps)).NotTo(BeIdenticalTo(old))
+		old.Add(object(configMaps, "apps", "a"))
+		Expect(received()).To(BeEmpty())
+
+		sec := informer("dev", secrets)
+		Expect(w.Unwatch(ctx, "backup")).To(Succeed())
+		Expect(informer("dev", secrets)).To(BeIdenticalTo(sec))
+		sec.Delete(object(secrets, "apps", "s"))
+		Expect(received()).To(ConsistOf(HaveField("Watch", "policy")))
+	})
+})
@@ -0,0 +1,198 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package envtest provides a test environment with a hub and a number of
+// spoke control planes, wired into a multi-cluster manager through a
+// Provider. Control planes can be added and removed mid-test to exercise
+// engagement and disengagement.
+package envtest
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+)
+
+// Environment starts a hub control plane and a number of spoke control
+// planes with envtest.
+type Environment struct {
+	// Clusters is the number of spoke control planes started by Start. They
+	// are named "cluster-0", "cluster-1", and so on.
+	Clusters int
+
+	// Scheme is used by the control planes and the clusters of the provider.
+	// If nil, scheme.Scheme is used.
+	Scheme *runtime.Scheme
+
+	// CRDs are installed into the hub and all spokes.
+	CRDs []*apiextensionsv1.CustomResourceDefinition
+
+	// CRDDirectoryPaths are paths with CRDs installed into the hub and all
+	// spokes.
+	CRDDirectoryPaths []string
+
+	// BinaryAssetsDirectory is the path of the envtest binaries. It can be
+	// overridden by the KUBEBUILDER_ASSETS environment variable.
+	BinaryAssetsDirectory string
+
+	// Hub is the hub control plane, set by Start.
+	Hub *envtest.Environment
+
+	lock     sync.Mutex
+	spokes   map[string]*envtest.Environment
+	provider *Provider
+}
+
+// Start starts the hub and the spoke control planes, and returns the config
+// of the hub.
+func (e *Environment) Start() (*rest.Config, error) {
+	e.lock.Lock()
+	e.spokes = map[string]*envtest.Environment{}
+	var opts []cluster.Option
+	if e.Scheme != nil {
+		opts = append(opts, func(o *cluster.Options) { o.Scheme = e.Scheme })
+	}
+	e.provider = NewProvider(opts...)
+	hub := e.newControlPlane()
+	e.lock.Unlock()
+
+	cfg, err := hub.Start()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start hub: %w", err)
+	}
+	e.Hub = hub
+
+	for i := range e.Clusters {
+		if _, err := e.AddCluster(fmt.Sprintf("cluster-%d", i)); err != nil {
+			return nil, errors.Join(err, e.Stop())
+		}
+	}
+
+	return cfg, nil
+}
+
+// Stop stops all control planes.
+func (e *Environment) Stop() error {
+	e.lock.Lock()
+	names := make([]string, 0, len(e.spokes))
+	for name := range e.spokes {
+		names = append(names, name)
+	}
+	e.lock.Unlock()
+
+	var errs []error
+	for _, name := range names {
+		if err := e.RemoveCluster(name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if e.Hub != nil {
+		if err := e.Hub.Stop(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop hub: %w", err))
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// Provider returns the provider engaging the spoke control planes.
+func (e *Environment) Provider() *Provider {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return e.provider
+}
+
+// NewManager returns a new multi-cluster manager against the hub, using
+// the provider of the environment. The caller must run the provider with
+// Provider().Run to engage the spokes.
+func (e *Environment) NewManager(opts manager.Options) (mcmanager.Manager, error) {
+	if e.Hub == nil || e.Hub.Config == nil {
+		return nil, errors.New("environment is not started")
+	}
+	if opts.Scheme == nil {
+		opts.Scheme = e.Scheme
+	}
+	return mcmanager.New(e.Hub.Config, e.Provider(), opts)
+}
+
+// AddCluster starts a new spoke control plane with the given name, and
+// engages it if the provider is running.
+func (e *Environment) AddCluster(name string) (*rest.Config, error) {
+	e.lock.Lock()
+	if _, ok := e.spokes[name]; ok {
+		e.lock.Unlock()
+		return nil, fmt.Errorf("cluster %q already exists", name)
+	}
+	env := e.newControlPlane()
+	e.spokes[name] = env
+	e.lock.Unlock()
+
+	cfg, err := env.Start()
+	if err != nil {
+		e.lock.Lock()
+		delete(e.spokes, name)
+		e.lock.Unlock()
+		return nil, fmt.Errorf("failed to start cluster %q: %w", name, err)
+	}
+	if _, err := e.Provider().Add(name, cfg); err != nil {
+		return nil, errors.Join(err, env.Stop())
+	}
+	return cfg, nil
+}
+
+// RemoveCluster disengages the spoke with the given name and stops its
+// control plane.
+func (e *Environment) RemoveCluster(name string) error {
+	e.lock.Lock()
+	env, ok := e.spokes[name]
+	delete(e.spokes, name)
+	e.lock.Unlock()
+	if !ok {
+		return fmt.Errorf("cluster %q not found", name)
+	}
+
+	e.Provider().Remove(name)
+	if err := env.Stop(); err != nil {
+		return fmt.Errorf("failed to stop cluster %q: %w", name, err)
+	}
+	return nil
+}
+
+func (e *Environment) newControlPlane() *envtest.Environment {
+	// envtest mutates the CRDs on install, hence every control plane gets
+	// its own copy.
+	crds := make([]*apiextensionsv1.CustomResourceDefinition, 0, len(e.CRDs))
+	for _, crd := range e.CRDs {
+		crds = append(crds, crd.DeepCopy())
+	}
+	return &envtest.Environment{
+		Scheme:                e.Scheme,
+		CRDs:                  crds,
+		CRDDirectoryPaths:     e.CRDDirectoryPaths,
+		ErrorIfCRDPathMissing: len(e.CRDDirectoryPaths) > 0,
+		BinaryAssetsDirectory: e.BinaryAssetsDirectory,
+	}
+}
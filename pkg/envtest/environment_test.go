@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envtest
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	mcbuilder "sigs.k8s.io/multicluster-runtime/pkg/builder"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Environment", Ordered, func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
+
+	var mgr mcmanager.Manager
+	var lock sync.Mutex
+	seen := map[string]bool{}
+	hasSeen := func(clusterName string) func() bool {
+		return func() bool {
+			lock.Lock()
+			defer lock.Unlock()
+			return seen[clusterName]
+		}
+	}
+
+	BeforeAll(func() {
+		By("Setting up the manager against the hub, with the provider of the environment", func() {
+			var err error
+			mgr, err = env.NewManager(manager.Options{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		By("Setting up a controller recording the clusters of the namespaces it sees", func() {
+			err := mcbuilder.ControllerManagedBy(mgr).
+				Named("envtest-namespace-controller").
+				For(&corev1.Namespace{}).
+				Complete(mcreconcile.Func(
+					func(ctx context.Context, req mcreconcile.Request) (ctrl.Result, error) {
+						lock.Lock()
+						defer lock.Unlock()
+						seen[req.ClusterName] = true
+						return ctrl.Result{}, nil
+					},
+				))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		By("Starting the provider and the manager", func() {
+			g.Go(func() error {
+				return ignoreCanceled(env.Provider().Run(ctx, mgr))
+			})
+			g.Go(func() error {
+				return ignoreCanceled(mgr.Start(ctx))
+			})
+		})
+	})
+
+	It("engages the clusters started with the environment", func() {
+		Eventually(hasSeen("cluster-0"), "10s").Should(BeTrue())
+
+		cl, err := mgr.GetCluster(ctx, "cluster-0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cl.GetConfig().Host).NotTo(Equal(env.Hub.Config.Host))
+	})
+
+	It("engages clusters added mid-test", func() {
+		cfg, err := env.AddCluster("cluster-1")
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(hasSeen("cluster-1"), "10s").Should(BeTrue())
+
+		cl, err := mgr.GetCluster(ctx, "cluster-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cl.GetConfig().Host).To(Equal(cfg.Host))
+
+		By("Creating a namespace only in the added cluster", func() {
+			err := cl.GetClient().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "added"}})
+			Expect(err).NotTo(HaveOccurred())
+		})
+		hub, err := client.New(env.Hub.Config, client.Options{})
+		Expect(err).NotTo(HaveOccurred())
+		err = hub.Get(ctx, client.ObjectKey{Name: "added"}, &corev1.Namespace{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("rejects adding a cluster twice", func() {
+		_, err := env.AddCluster("cluster-1")
+		Expect(err).To(MatchError(ContainSubstring(`cluster "cluster-1" already exists`)))
+	})
+
+	It("disengages removed clusters", func() {
+		err := env.RemoveCluster("cluster-1")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = mgr.GetCluster(ctx, "cluster-1")
+		Expect(err).To(MatchError(multicluster.ErrClusterNotFound))
+
+		_, err = mgr.GetCluster(ctx, "cluster-0")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("rejects removing an unknown cluster", func() {
+		err := env.RemoveCluster("cluster-1")
+		Expect(err).To(MatchError(ContainSubstring(`cluster "cluster-1" not found`)))
+	})
+
+	AfterAll(func() {
+		By("Stopping the provider and the manager", func() {
+			cancel()
+		})
+		By("Waiting for the error group to finish", func() {
+			err := g.Wait()
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
+
+func ignoreCanceled(err error) error {
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envtest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+var _ multicluster.Provider = &Provider{}
+
+// Provider is a provider that engages the control planes of an
+// Environment. Control planes can be added and removed at any time,
+// including before Run is called.
+type Provider struct {
+	opts []cluster.Option
+
+	lock      sync.Mutex
+	ctx       context.Context
+	mgr       mcmanager.Manager
+	clusters  map[string]cluster.Cluster
+	cancelFns map[string]context.CancelFunc
+	indexers  []index
+}
+
+type index struct {
+	object       client.Object
+	field        string
+	extractValue client.IndexerFunc
+}
+
+// NewProvider returns a new provider creating clusters with the given
+// options.
+func NewProvider(opts ...cluster.Option) *Provider {
+	return &Provider{
+		opts:      opts,
+		clusters:  map[string]cluster.Cluster{},
+		cancelFns: map[string]context.CancelFunc{},
+	}
+}
+
+// Run engages all known clusters with the manager and blocks.
+func (p *Provider) Run(ctx context.Context, mgr mcmanager.Manager) error {
+	p.lock.Lock()
+	p.ctx = ctx
+	p.mgr = mgr
+	clusters := make(map[string]cluster.Cluster, len(p.clusters))
+	for name, cl := range p.clusters {
+		clusters[name] = cl
+	}
+	p.lock.Unlock()
+
+	for name, cl := range clusters {
+		if err := p.engage(name, cl); err != nil {
+			return err
+		}
+	}
+
+	<-ctx.Done()
+	return nil
+}
+
+// Add creates a cluster for the given config and engages it if the provider
+// is running.
+func (p *Provider) Add(name string, cfg *rest.Config) (cluster.Cluster, error) {
+	cl, err := cluster.New(cfg, p.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cluster %q: %w", name, err)
+	}
+
+	p.lock.Lock()
+	if _, ok := p.clusters[name]; ok {
+		p.lock.Unlock()
+		return nil, fmt.Errorf("cluster %q already exists", name)
+	}
+	for _, idx := range p.indexers {
+		if err := cl.GetFieldIndexer().IndexField(context.Background(), idx.object, idx.field, idx.extractValue); err != nil {
+			p.lock.Unlock()
+			return nil, fmt.Errorf("failed to index field %q on cluster %q: %w", idx.field, name, err)
+		}
+	}
+	p.clusters[name] = cl
+	running := p.ctx != nil
+	p.lock.Unlock()
+
+	if running {
+		if err := p.engage(name, cl); err != nil {
+			return nil, err
+		}
+	}
+	return cl, nil
+}
+
+// Remove disengages the cluster with the given name.
+func (p *Provider) Remove(name string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if cancel, ok := p.cancelFns[name]; ok {
+		cancel()
+	}
+	delete(p.clusters, name)
+	delete(p.cancelFns, name)
+}
+
+func (p *Provider) engage(name string, cl cluster.Cluster) error {
+	p.lock.Lock()
+	ctx, cancel := context.WithCancel(p.ctx)
+	p.cancelFns[name] = cancel
+	mgr := p.mgr
+	p.lock.Unlock()
+
+	go func() {
+		if err := cl.Start(ctx); err != nil {
+			cancel()
+		}
+	}()
+	if !cl.GetCache().WaitForCacheSync(ctx) {
+		cancel()
+		return fmt.Errorf("failed to sync cache of cluster %q", name)
+	}
+
+	if err := mgr.Engage(ctx, name, cl); err != nil {
+		cancel()
+		return fmt.Errorf("failed to engage cluster %q: %w", name, err)
+	}
+	return nil
+}
+
+// Get returns the cluster with the given name.
+func (p *Provider) Get(_ context.Context, clusterName string) (cluster.Cluster, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if cl, ok := p.clusters[clusterName]; ok {
+		return cl, nil
+	}
+	return nil, multicluster.ErrClusterNotFound
+}
+
+// IndexField indexes a field on all clusters, existing and future.
+func (p *Provider) IndexField(ctx context.Context, obj client.Object, field string, extractValue client.IndexerFunc) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.indexers = append(p.indexers, index{
+		object:       obj,
+		field:        field,
+		extractValue: extractValue,
+	})
+
+	for name, cl := range p.clusters {
+		if err := cl.GetFieldIndexer().IndexField(ctx, obj, field, extractValue); err != nil {
+			return fmt.Errorf("failed to index field %q on cluster %q: %w", field, name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package errors provides errors that tell which cluster failed in a
+// multi-cluster operation.
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ClusterError is an error of an operation on a single cluster. Use
+// errors.As to find out which cluster failed.
+type ClusterError struct {
+	// Cluster is the name of the cluster.
+	Cluster string
+
+	// Op is the failed operation, e.g. "engage" or "delete ConfigMap
+	// default/foo".
+	Op string
+
+	// Err is the underlying error.
+	Err error
+}
+
+// New returns a ClusterError for err, or nil if err is nil. If err already
+// is a ClusterError for the same cluster, it is returned as is.
+func New(cluster, op string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if ce, ok := err.(*ClusterError); ok && ce.Cluster == cluster { //nolint:errorlint // only direct errors are not wrapped again.
+		return ce
+	}
+	return &ClusterError{Cluster: cluster, Op: op, Err: err}
+}
+
+// Error implements error.
+func (e *ClusterError) Error() string {
+	if e.Op == "" {
+		return fmt.Sprintf("cluster %q: %v", e.Cluster, e.Err)
+	}
+	return fmt.Sprintf("cluster %q: failed to %s: %v", e.Cluster, e.Op, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ClusterError) Unwrap() error {
+	return e.Err
+}
+
+// Aggregate is a list of errors of a multi-cluster operation, usually
+// ClusterErrors of different clusters. It implements the Aggregate
+// interface of k8s.io/apimachinery/pkg/util/errors, and supports errors.Is
+// and errors.As for all of its errors.
+type Aggregate struct {
+	errs []error
+}
+
+// NewAggregate returns an Aggregate of the non-nil errors, flattening
+// nested Aggregates. It returns nil if there are no errors.
+func NewAggregate(errs ...error) error {
+	var flat []error
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if agg, ok := err.(*Aggregate); ok { //nolint:errorlint // only direct aggregates are flattened.
+			flat = append(flat, agg.errs...)
+			continue
+		}
+		flat = append(flat, err)
+	}
+	if len(flat) == 0 {
+		return nil
+	}
+	return &Aggregate{errs: flat}
+}
+
+// Error implements error.
+func (a *Aggregate) Error() string {
+	if len(a.errs) == 1 {
+		return a.errs[0].Error()
+	}
+	msgs := make([]string, 0, len(a.errs))
+	for _, err := range a.errs {
+		msgs = append(msgs, err.Error())
+	}
+	return "[" + strings.Join(msgs, ", ") + "]"
+}
+
+// Errors returns the aggregated errors.
+func (a *Aggregate) Errors() []error {
+	return a.errs
+}
+
+// Unwrap returns the aggregated errors for errors.Is and errors.As.
+func (a *Aggregate) Unwrap() []error {
+	return a.errs
+}
+
+// Is returns whether any of the aggregated errors is target.
+func (a *Aggregate) Is(target error) bool {
+	for _, err := range a.errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Clusters returns the sorted names of the failed clusters.
+func (a *Aggregate) Clusters() []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, err := range a.errs {
+		var ce *ClusterError
+		if errors.As(err, &ce) && !seen[ce.Cluster] {
+			seen[ce.Cluster] = true
+			names = append(names, ce.Cluster)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ForCluster returns the errors of the given cluster.
+func (a *Aggregate) ForCluster(cluster string) []error {
+	var errs []error
+	for _, err := range a.errs {
+		var ce *ClusterError
+		if errors.As(err, &ce) && ce.Cluster == cluster {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+var _ = Describe("ClusterError", func() {
+	boom := errors.New("boom")
+
+	It("tells which cluster failed", func() {
+		err := fmt.Errorf("wrapped: %w", New("prod", "engage", boom))
+		Expect(err).To(MatchError("wrapped: cluster \"prod\": failed to engage: boom"))
+		Expect(err).To(MatchError(boom))
+
+		var ce *ClusterError
+		Expect(errors.As(err, &ce)).To(BeTrue())
+		Expect(ce.Cluster).To(Equal("prod"))
+		Expect(ce.Op).To(Equal("engage"))
+	})
+
+	It("does not wrap nil or errors of the same cluster again", func() {
+		Expect(New("prod", "engage", nil)).To(Succeed())
+		err := New("prod", "watch", boom)
+		Expect(New("prod", "engage", err)).To(BeIdenticalTo(err))
+		Expect(New("dev", "engage", err).Error()).To(Equal(`cluster "dev": failed to engage: cluster "prod": failed to watch: boom`))
+	})
+})
+
+var _ = Describe("Aggregate", func() {
+	It("is nil without errors", func() {
+		Expect(NewAggregate()).To(Succeed())
+		Expect(NewAggregate(nil, nil)).To(Succeed())
+	})
+
+	It("aggregates errors of multiple clusters", func() {
+		err := NewAggregate(
+			New("prod", "delete", context.DeadlineExceeded),
+			nil,
+			NewAggregate(New("dev", "delete", errors.New("forbidden")), New("prod", "list", errors.New("boom"))),
+		)
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+
+		var agg *Aggregate
+		Expect(errors.As(err, &agg)).To(BeTrue())
+		Expect(agg.Errors()).To(HaveLen(3))
+		Expect(agg.Clusters()).To(Equal([]string{"dev", "prod"}))
+		Expect(agg.ForCluster("prod")).To(HaveLen(2))
+
+		var ce *ClusterError
+		Expect(errors.As(err, &ce)).To(BeTrue())
+		Expect(ce.Cluster).To(Equal("prod"))
+
+		var _ kerrors.Aggregate = agg
+		Expect(err.Error()).To(HavePrefix(`[cluster "prod": failed to delete: context deadline exceeded, `))
+	})
+})
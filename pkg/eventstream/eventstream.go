@@ -0,0 +1,312 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package eventstream exposes the engagements of a multi-cluster manager and
+// the changes of selected objects in all engaged clusters as one fleet event
+// feed, served over websocket or server-sent events to non-Go components
+// like UIs and sidecars.
+package eventstream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/go-logr/logr"
+
+	toolscache "k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	mcerrors "sigs.k8s.io/multicluster-runtime/pkg/errors"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+// EventType is the type of an Event.
+type EventType string
+
+const (
+	// EventEngaged is sent when a cluster is engaged, and for all engaged
+	// clusters when a subscription starts.
+	EventEngaged EventType = "Engaged"
+
+	// EventDisengaged is sent when a cluster is disengaged.
+	EventDisengaged EventType = "Disengaged"
+
+	// EventAdded is sent for objects that were created, and for all
+	// existing objects of a cluster when it is engaged.
+	EventAdded EventType = "Added"
+
+	// EventUpdated is sent for objects that were updated.
+	EventUpdated EventType = "Updated"
+
+	// EventDeleted is sent for objects that were deleted.
+	EventDeleted EventType = "Deleted"
+)
+
+// Event is an event of the fleet feed.
+type Event struct {
+	// Type is the type of the event.
+	Type EventType `json:"type"`
+
+	// Cluster is the name of the cluster.
+	Cluster string `json:"cluster"`
+
+	// APIVersion is the API version of the object of object events.
+	APIVersion string `json:"apiVersion,omitempty"`
+
+	// Kind is the kind of the object of object events.
+	Kind string `json:"kind,omitempty"`
+
+	// Namespace is the namespace of the object of object events.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name is the name of the object of object events.
+	Name string `json:"name,omitempty"`
+
+	// ResourceVersion is the resource version of the object of object
+	// events.
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+
+	// Object is the object of object events if Options.IncludeObjects is
+	// set.
+	Object json.RawMessage `json:"object,omitempty"`
+}
+
+// Options are the options of a Stream.
+type Options struct {
+	// Kinds are the types of the objects whose changes are streamed. Their
+	// informers are shared with the controllers of the manager.
+	Kinds []client.Object
+
+	// Filter decides whether the change of an object is streamed. If nil,
+	// the changes of all objects of the kinds are streamed.
+	Filter func(clusterName string, obj client.Object) bool
+
+	// IncludeObjects adds the full objects to object events.
+	IncludeObjects bool
+
+	// BufferSize is the number of events buffered per subscriber.
+	// Subscribers falling further behind are disconnected, such that they
+	// cannot block the informers. Defaults to 256.
+	BufferSize int
+}
+
+var _ mcmanager.Runnable = &Stream{}
+
+// Stream publishes the fleet event feed to its subscribers. Add it to the
+// manager with Manager.Add, and serve it e.g. on the metrics server:
+//
+//	stream, err := eventstream.New(mgr, eventstream.Options{Kinds: []client.Object{&appsv1.Deployment{}}})
+//	mgr.Add(stream)
+//	mgr.AddMetricsServerExtraHandler("/fleet/events", stream)
+type Stream struct {
+	mgr  mcmanager.Manager
+	opts Options
+	log  logr.Logger
+
+	lock        sync.Mutex
+	clusters    map[string]cluster.Cluster
+	subscribers map[*subscriber]struct{}
+}
+
+type subscriber struct {
+	events chan Event
+	filter func(Event) bool
+}
+
+// New returns a new Stream.
+func New(mgr mcmanager.Manager, opts Options) (*Stream, error) {
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = 256
+	}
+	for _, obj := range opts.Kinds {
+		if obj == nil {
+			return nil, errors.New("kinds must not be nil")
+		}
+	}
+	return &Stream{
+		mgr:         mgr,
+		opts:        opts,
+		log:         log.Log.WithName("eventstream"),
+		clusters:    map[string]cluster.Cluster{},
+		subscribers: map[*subscriber]struct{}{},
+	}, nil
+}
+
+// Subscribe returns the events passing filter until ctx is done, starting
+// with an EventEngaged for every engaged cluster. A nil filter passes all
+// events. The channel is closed when ctx is done, or when the subscriber
+// falls behind by more than the buffer size.
+func (s *Stream) Subscribe(ctx context.Context, filter func(Event) bool) <-chan Event {
+	if filter == nil {
+		filter = func(Event) bool { return true }
+	}
+	sub := &subscriber{events: make(chan Event, s.opts.BufferSize), filter: filter}
+
+	s.lock.Lock()
+	names := make([]string, 0, len(s.clusters))
+	for name := range s.clusters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	s.subscribers[sub] = struct{}{}
+	for _, name := range names {
+		s.send(sub, Event{Type: EventEngaged, Cluster: name})
+	}
+	s.lock.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		s.unsubscribe(sub)
+	}()
+	return sub.events
+}
+
+// Engage streams the engagement of the cluster and the changes of its
+// objects until ctx is done.
+func (s *Stream) Engage(ctx context.Context, name string, cl cluster.Cluster) error {
+	s.lock.Lock()
+	s.clusters[name] = cl
+	s.publish(Event{Type: EventEngaged, Cluster: name})
+	s.lock.Unlock()
+
+	type registration struct {
+		informer cache.Informer
+		reg      toolscache.ResourceEventHandlerRegistration
+	}
+	var regs []registration
+	var errs []error
+	for _, obj := range s.opts.Kinds {
+		gvk, err := apiutil.GVKForObject(obj, cl.GetScheme())
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		inf, err := cl.GetCache().GetInformer(ctx, obj)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get informer for %s: %w", gvk.Kind, err))
+			continue
+		}
+		send := func(typ EventType, o interface{}) {
+			if tombstone, ok := o.(toolscache.DeletedFinalStateUnknown); ok {
+				o = tombstone.Obj
+			}
+			obj, ok := o.(client.Object)
+			if !ok || (s.opts.Filter != nil && !s.opts.Filter(name, obj)) {
+				return
+			}
+			ev := Event{
+				Type:            typ,
+				Cluster:         name,
+				APIVersion:      gvk.GroupVersion().String(),
+				Kind:            gvk.Kind,
+				Namespace:       obj.GetNamespace(),
+				Name:            obj.GetName(),
+				ResourceVersion: obj.GetResourceVersion(),
+			}
+			if s.opts.IncludeObjects {
+				data, err := json.Marshal(obj)
+				if err != nil {
+					s.log.Error(err, "Failed to encode object", "cluster", multicluster.EscapeClusterName(name), "kind", gvk.Kind, "namespace", ev.Namespace, "name", ev.Name)
+					return
+				}
+				ev.Object = data
+			}
+			s.lock.Lock()
+			defer s.lock.Unlock()
+			s.publish(ev)
+		}
+		reg, err := inf.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+			AddFunc:    func(o interface{}) { send(EventAdded, o) },
+			UpdateFunc: func(_, o interface{}) { send(EventUpdated, o) },
+			DeleteFunc: func(o interface{}) { send(EventDeleted, o) },
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to watch %s: %w", gvk.Kind, err))
+			continue
+		}
+		regs = append(regs, registration{informer: inf, reg: reg})
+	}
+
+	go func() {
+		<-ctx.Done()
+		for _, r := range regs {
+			if err := r.informer.RemoveEventHandler(r.reg); err != nil {
+				s.log.Error(err, "Failed to remove event handler", "cluster", multicluster.EscapeClusterName(name))
+			}
+		}
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		if s.clusters[name] == cl {
+			delete(s.clusters, name)
+			s.publish(Event{Type: EventDisengaged, Cluster: name})
+		}
+	}()
+
+	return mcerrors.New(name, "stream", mcerrors.NewAggregate(errs...))
+}
+
+// Start disconnects all subscribers when ctx is done.
+func (s *Stream) Start(ctx context.Context) error {
+	<-ctx.Done()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for sub := range s.subscribers {
+		s.unsubscribe(sub)
+	}
+	return nil
+}
+
+// publish sends the event to all subscribers. The lock must be held.
+func (s *Stream) publish(ev Event) {
+	for sub := range s.subscribers {
+		s.send(sub, ev)
+	}
+}
+
+// send sends the event to the subscriber, disconnecting it if it fell
+// behind. The lock must be held.
+func (s *Stream) send(sub *subscriber, ev Event) {
+	if _, ok := s.subscribers[sub]; !ok || !sub.filter(ev) {
+		return
+	}
+	select {
+	case sub.events <- ev:
+	default:
+		s.log.Info("Disconnecting subscriber falling behind", "bufferSize", s.opts.BufferSize)
+		s.unsubscribe(sub)
+	}
+}
+
+// unsubscribe closes the channel of the subscriber. The lock must be held.
+func (s *Stream) unsubscribe(sub *subscriber) {
+	if _, ok := s.subscribers[sub]; !ok {
+		return
+	}
+	delete(s.subscribers, sub)
+	close(sub.events)
+}
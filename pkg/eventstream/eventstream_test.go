@@ -0,0 +1,137 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventstream
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/net/websocket"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/fake"
+)
+
+var _ = Describe("Stream", func() {
+	var (
+		mgr    *fake.Manager
+		stream *Stream
+		ctx    context.Context
+	)
+	cm := func(name string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, ResourceVersion: "1"}}
+	}
+	informer := func(cluster string) *controllertest.FakeInformer {
+		inf, err := mgr.FakeCluster(cluster).GetCache().GetInformer(ctx, &corev1.ConfigMap{})
+		Expect(err).NotTo(HaveOccurred())
+		return inf.(*controllertest.FakeInformer)
+	}
+
+	BeforeEach(func() {
+		mgr = fake.NewManagerBuilder().WithCluster("one").WithCluster("two").Build()
+		var err error
+		stream, err = New(mgr, Options{
+			Kinds:          []client.Object{&corev1.ConfigMap{}},
+			Filter:         func(_ string, obj client.Object) bool { return obj.GetName() != "ignored" },
+			IncludeObjects: true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+		Expect(stream.Engage(ctx, "one", mgr.FakeCluster("one"))).To(Succeed())
+	})
+
+	It("streams engagements and object changes", func() {
+		events := stream.Subscribe(ctx, nil)
+		Expect(<-events).To(Equal(Event{Type: EventEngaged, Cluster: "one"}))
+
+		twoCtx, disengageTwo := context.WithCancel(ctx)
+		Expect(stream.Engage(twoCtx, "two", mgr.FakeCluster("two"))).To(Succeed())
+		Expect(<-events).To(Equal(Event{Type: EventEngaged, Cluster: "two"}))
+
+		informer("one").Add(cm("ignored"))
+		informer("one").Add(cm("a"))
+		ev := <-events
+		Expect(string(ev.Object)).To(ContainSubstring(`"name":"a"`))
+		ev.Object = nil
+		Expect(ev).To(Equal(Event{Type: EventAdded, Cluster: "one", APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "a", ResourceVersion: "1"}))
+
+		informer("two").Delete(cm("b"))
+		Expect(<-events).To(HaveField("Type", EventDeleted))
+
+		disengageTwo()
+		Eventually(events).Should(Receive(Equal(Event{Type: EventDisengaged, Cluster: "two"})))
+	})
+
+	It("disconnects subscribers falling behind", func() {
+		stream.opts.BufferSize = 1
+		events := stream.Subscribe(ctx, nil)
+		informer("one").Add(cm("a"))
+		Expect(<-events).To(HaveField("Type", EventEngaged))
+		Eventually(events).Should(BeClosed())
+	})
+
+	It("serves server-sent events", func() {
+		srv := httptest.NewServer(stream)
+		defer srv.Close()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"?cluster=one&type=Engaged", nil)
+		Expect(err).NotTo(HaveOccurred())
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.Header.Get("Content-Type")).To(Equal("text/event-stream"))
+
+		r := bufio.NewReader(resp.Body)
+		line, err := r.ReadString('\n')
+		Expect(err).NotTo(HaveOccurred())
+		Expect(line).To(Equal("event: Engaged\n"))
+		line, err = r.ReadString('\n')
+		Expect(err).NotTo(HaveOccurred())
+		Expect(strings.TrimPrefix(line, "data: ")).To(MatchJSON(`{"type":"Engaged","cluster":"one"}`))
+	})
+
+	It("serves websockets", func() {
+		srv := httptest.NewServer(stream)
+		defer srv.Close()
+		ws, err := websocket.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"?kind=ConfigMap", "", srv.URL)
+		Expect(err).NotTo(HaveOccurred())
+		defer ws.Close()
+
+		var ev Event
+		Expect(websocket.JSON.Receive(ws, &ev)).To(Succeed())
+		Expect(ev).To(Equal(Event{Type: EventEngaged, Cluster: "one"}))
+
+		informer("one").Add(cm("a"))
+		Expect(websocket.JSON.Receive(ws, &ev)).To(Succeed())
+		Expect(ev.Name).To(Equal("a"))
+		var obj corev1.ConfigMap
+		Expect(json.Unmarshal(ev.Object, &obj)).To(Succeed())
+		Expect(obj.Name).To(Equal("a"))
+	})
+})
@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventstream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/websocket"
+)
+
+// ServeHTTP streams the events to the client, as JSON messages over a
+// websocket if the request asks for an upgrade, and as server-sent events
+// otherwise. Websocket clients must send an Origin header. The query
+// parameters "cluster", "type" and "kind" restrict the events, each of
+// them may be repeated.
+func (s *Stream) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	filter := queryFilter(req)
+	if strings.EqualFold(req.Header.Get("Upgrade"), "websocket") {
+		websocket.Handler(func(ws *websocket.Conn) {
+			s.serveWebsocket(ws, filter)
+		}).ServeHTTP(w, req)
+		return
+	}
+	s.serveEventStream(w, req, filter)
+}
+
+func (s *Stream) serveWebsocket(ws *websocket.Conn, filter func(Event) bool) {
+	ctx, cancel := context.WithCancel(ws.Request().Context())
+	defer cancel()
+	// the client closing the connection ends the read.
+	go func() {
+		_, _ = io.Copy(io.Discard, ws)
+		cancel()
+	}()
+	for ev := range s.Subscribe(ctx, filter) {
+		if err := websocket.JSON.Send(ws, ev); err != nil {
+			return
+		}
+	}
+}
+
+func (s *Stream) serveEventStream(w http.ResponseWriter, req *http.Request, filter func(Event) bool) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for ev := range s.Subscribe(req.Context(), filter) {
+		data, err := json.Marshal(ev)
+		if err != nil {
+			return
+		}
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", ev.Type, data); err != nil {
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// queryFilter returns a filter for the cluster, type and kind query
+// parameters of the request.
+func queryFilter(req *http.Request) func(Event) bool {
+	query := req.URL.Query()
+	clusters, types, kinds := query["cluster"], query["type"], query["kind"]
+	return func(ev Event) bool {
+		if len(clusters) > 0 && !slices.Contains(clusters, ev.Cluster) {
+			return false
+		}
+		if len(types) > 0 && !slices.Contains(types, string(ev.Type)) {
+			return false
+		}
+		// cluster events pass kind filters.
+		if len(kinds) > 0 && ev.Kind != "" && !slices.Contains(kinds, ev.Kind) {
+			return false
+		}
+		return true
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+)
+
+// DefaultVersion is the Kubernetes version reported by fake clusters unless
+// set otherwise.
+const DefaultVersion = "v1.32.1"
+
+var _ cluster.Cluster = &Cluster{}
+
+// Cluster is a fake cluster.Cluster backed by a fake client. Reads through
+// the cache are served by the fake client as well.
+type Cluster struct {
+	client   client.WithWatch
+	cache    *clientCache
+	recorder *record.FakeRecorder
+
+	lock    sync.RWMutex
+	version string
+}
+
+// NewCluster returns a fake cluster backed by the given client, usually
+// created with the controller-runtime fake client builder.
+func NewCluster(c client.WithWatch) *Cluster {
+	return &Cluster{
+		client:   c,
+		cache:    &clientCache{FakeInformers: &informertest.FakeInformers{Scheme: c.Scheme()}, reader: c},
+		recorder: record.NewFakeRecorder(100),
+		version:  DefaultVersion,
+	}
+}
+
+// GetHTTPClient returns nil.
+func (c *Cluster) GetHTTPClient() *http.Client { return nil }
+
+// GetConfig returns an empty config.
+func (c *Cluster) GetConfig() *rest.Config { return &rest.Config{} }
+
+// GetCache returns a cache reading through the fake client.
+func (c *Cluster) GetCache() cache.Cache { return c.cache }
+
+// GetScheme returns the scheme of the fake client.
+func (c *Cluster) GetScheme() *runtime.Scheme { return c.client.Scheme() }
+
+// GetClient returns the fake client.
+func (c *Cluster) GetClient() client.Client { return c.client }
+
+// GetFieldIndexer returns a field indexer that does nothing.
+func (c *Cluster) GetFieldIndexer() client.FieldIndexer { return c.cache }
+
+// GetEventRecorderFor returns a fake recorder shared by all names.
+func (c *Cluster) GetEventRecorderFor(string) record.EventRecorder { return c.recorder }
+
+// GetEventRecorder returns the fake recorder to inspect recorded events.
+func (c *Cluster) GetEventRecorder() *record.FakeRecorder { return c.recorder }
+
+// GetRESTMapper returns the REST mapper of the fake client.
+func (c *Cluster) GetRESTMapper() meta.RESTMapper { return c.client.RESTMapper() }
+
+// GetAPIReader returns the fake client.
+func (c *Cluster) GetAPIReader() client.Reader { return c.client }
+
+// ServerVersion returns the Kubernetes version of the cluster.
+func (c *Cluster) ServerVersion() (*version.Info, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return &version.Info{GitVersion: c.version}, nil
+}
+
+// SetServerVersion sets the Kubernetes version of the cluster, e.g.
+// "v1.30.2".
+func (c *Cluster) SetServerVersion(gitVersion string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.version = gitVersion
+}
+
+// Start blocks until ctx is done.
+func (c *Cluster) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+// clientCache is a fake cache serving reads from a client.
+type clientCache struct {
+	*informertest.FakeInformers
+	reader client.Reader
+}
+
+// Get implements cache.Cache.
+func (c *clientCache) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return c.reader.Get(ctx, key, obj, opts...)
+}
+
+// List implements cache.Cache.
+func (c *clientCache) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.reader.List(ctx, list, opts...)
+}
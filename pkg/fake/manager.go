@@ -0,0 +1,310 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/go-logr/logr"
+
+	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/config"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+// ManagerBuilder builds a fake multicluster manager. Every cluster is backed
+// by its own controller-runtime fake client.
+type ManagerBuilder struct {
+	scheme       *runtime.Scheme
+	names        []string
+	objects      map[string][]client.Object
+	interceptors map[string]interceptor.Funcs
+	metadata     map[string]multicluster.Metadata
+	versions     map[string]string
+	status       []client.Object
+	indexes      []index
+	options      []mcmanager.Option
+}
+
+type index struct {
+	obj     client.Object
+	field   string
+	extract client.IndexerFunc
+}
+
+// NewManagerBuilder returns a new builder for a fake manager with an empty
+// local cluster.
+func NewManagerBuilder() *ManagerBuilder {
+	return &ManagerBuilder{
+		scheme:       clientgoscheme.Scheme,
+		objects:      map[string][]client.Object{},
+		interceptors: map[string]interceptor.Funcs{},
+		metadata:     map[string]multicluster.Metadata{},
+		versions:     map[string]string{},
+	}
+}
+
+// WithScheme sets the scheme of all clusters. It defaults to the client-go
+// scheme. The REST mappers of the clusters serve all kinds of the scheme.
+func (b *ManagerBuilder) WithScheme(scheme *runtime.Scheme) *ManagerBuilder {
+	b.scheme = scheme
+	return b
+}
+
+// WithCluster adds a cluster with the given initial objects. Objects can be
+// added to the local cluster by passing mcmanager.LocalCluster as name.
+func (b *ManagerBuilder) WithCluster(name string, objs ...client.Object) *ManagerBuilder {
+	b.addName(name)
+	b.objects[name] = append(b.objects[name], objs...)
+	return b
+}
+
+// WithClusterMetadata sets the metadata returned for the given cluster.
+func (b *ManagerBuilder) WithClusterMetadata(name string, md multicluster.Metadata) *ManagerBuilder {
+	b.addName(name)
+	b.metadata[name] = md
+	return b
+}
+
+// WithClusterVersion sets the Kubernetes version of the given cluster, e.g.
+// "v1.30.2". It defaults to DefaultVersion.
+func (b *ManagerBuilder) WithClusterVersion(name, gitVersion string) *ManagerBuilder {
+	b.addName(name)
+	b.versions[name] = gitVersion
+	return b
+}
+
+// WithInterceptorFuncs sets the interceptor functions of the client of the
+// given cluster, e.g. to inject errors.
+func (b *ManagerBuilder) WithInterceptorFuncs(name string, funcs interceptor.Funcs) *ManagerBuilder {
+	b.addName(name)
+	b.interceptors[name] = funcs
+	return b
+}
+
+// WithStatusSubresource marks the given object types as having a status
+// subresource in all clusters.
+func (b *ManagerBuilder) WithStatusSubresource(objs ...client.Object) *ManagerBuilder {
+	b.status = append(b.status, objs...)
+	return b
+}
+
+// WithIndex adds a field index to the clients of all clusters.
+func (b *ManagerBuilder) WithIndex(obj client.Object, field string, extract client.IndexerFunc) *ManagerBuilder {
+	b.indexes = append(b.indexes, index{obj: obj, field: field, extract: extract})
+	return b
+}
+
+// WithOptions sets the options of the multicluster manager.
+func (b *ManagerBuilder) WithOptions(opts ...mcmanager.Option) *ManagerBuilder {
+	b.options = append(b.options, opts...)
+	return b
+}
+
+func (b *ManagerBuilder) addName(name string) {
+	if name == mcmanager.LocalCluster {
+		return
+	}
+	for _, n := range b.names {
+		if n == name {
+			return
+		}
+	}
+	b.names = append(b.names, name)
+}
+
+// BuildCluster returns a new fake cluster configured like the clusters of
+// the manager, with the objects, interceptors and version set for name.
+func (b *ManagerBuilder) BuildCluster(name string) *Cluster {
+	cb := clientfake.NewClientBuilder().
+		WithScheme(b.scheme).
+		WithRESTMapper(testrestmapper.TestOnlyStaticRESTMapper(b.scheme)).
+		WithObjects(b.objects[name]...).
+		WithStatusSubresource(b.status...).
+		WithInterceptorFuncs(b.interceptors[name])
+	for _, idx := range b.indexes {
+		cb = cb.WithIndex(idx.obj, idx.field, idx.extract)
+	}
+	cl := NewCluster(cb.Build())
+	if v, ok := b.versions[name]; ok {
+		cl.SetServerVersion(v)
+	}
+	return cl
+}
+
+// Build returns the fake manager.
+func (b *ManagerBuilder) Build() *Manager {
+	provider := NewProvider()
+	for _, name := range b.names {
+		provider.Add(name, b.BuildCluster(name), b.metadata[name])
+	}
+	local := &localManager{
+		Cluster:  b.BuildCluster(mcmanager.LocalCluster),
+		elected:  make(chan struct{}),
+		handlers: map[string]http.Handler{},
+		healthz:  map[string]healthz.Checker{},
+	}
+	close(local.elected)
+
+	// In single-cluster mode, the clusters of the provider are only
+	// reachable through FakeCluster.
+	var mcOpts mcmanager.MultiClusterOptions
+	for _, o := range b.options {
+		o(&mcOpts)
+	}
+	var mcProvider multicluster.Provider = provider
+	if mcOpts.SingleCluster {
+		mcProvider = nil
+	}
+	mgr, _ := mcmanager.WithMultiCluster(local, mcProvider, b.options...)
+	return &Manager{Manager: mgr, local: local, provider: provider}
+}
+
+var _ mcmanager.Manager = &Manager{}
+
+// Manager is a fake multicluster manager for unit tests. Runnables added to
+// it are recorded, but never started. Start engages all clusters of the
+// provider instead, so that multicluster.Aware components can be tested
+// without running controllers.
+type Manager struct {
+	mcmanager.Manager
+	local    *localManager
+	provider *Provider
+}
+
+// FakeProvider returns the fake provider of the manager to add clusters
+// after the manager has been built.
+func (m *Manager) FakeProvider() *Provider {
+	return m.provider
+}
+
+// FakeCluster returns the fake cluster with the given name, or nil if it
+// does not exist.
+func (m *Manager) FakeCluster(name string) *Cluster {
+	if name == mcmanager.LocalCluster {
+		return m.local.Cluster
+	}
+	m.provider.lock.RLock()
+	defer m.provider.lock.RUnlock()
+	return m.provider.clusters[name]
+}
+
+// Runnables returns the runnables added to the manager.
+func (m *Manager) Runnables() []manager.Runnable {
+	m.local.lock.Lock()
+	defer m.local.lock.Unlock()
+	return append([]manager.Runnable(nil), m.local.runnables...)
+}
+
+// HealthzCheck returns the health check added with the given name, or nil.
+func (m *Manager) HealthzCheck(name string) healthz.Checker {
+	m.local.lock.Lock()
+	defer m.local.lock.Unlock()
+	return m.local.healthz[name]
+}
+
+// MetricsServerExtraHandler returns the handler added to the metrics server
+// on the given path, or nil.
+func (m *Manager) MetricsServerExtraHandler(path string) http.Handler {
+	m.local.lock.Lock()
+	defer m.local.lock.Unlock()
+	return m.local.handlers[path]
+}
+
+// Start engages all clusters of the provider and blocks until ctx is done.
+// In single-cluster mode, no cluster is engaged.
+func (m *Manager) Start(ctx context.Context) error {
+	if m.SingleCluster() {
+		<-ctx.Done()
+		return nil
+	}
+	for _, name := range m.provider.Names() {
+		if err := m.Manager.Engage(ctx, name, m.FakeCluster(name)); err != nil {
+			return err
+		}
+	}
+	<-ctx.Done()
+	return nil
+}
+
+var _ manager.Manager = &localManager{}
+
+// localManager is a fake controller-runtime manager of the local cluster.
+type localManager struct {
+	*Cluster
+	elected chan struct{}
+
+	lock      sync.Mutex
+	runnables []manager.Runnable
+	handlers  map[string]http.Handler
+	healthz   map[string]healthz.Checker
+}
+
+func (m *localManager) Add(r manager.Runnable) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.runnables = append(m.runnables, r)
+	return nil
+}
+
+func (m *localManager) Elected() <-chan struct{} {
+	return m.elected
+}
+
+func (m *localManager) AddMetricsServerExtraHandler(path string, handler http.Handler) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.handlers[path] = handler
+	return nil
+}
+
+func (m *localManager) AddHealthzCheck(name string, check healthz.Checker) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.healthz[name] = check
+	return nil
+}
+
+func (m *localManager) AddReadyzCheck(string, healthz.Checker) error {
+	return nil
+}
+
+func (m *localManager) GetWebhookServer() webhook.Server {
+	return webhook.NewServer(webhook.Options{})
+}
+
+func (m *localManager) GetLogger() logr.Logger {
+	return logr.Discard()
+}
+
+func (m *localManager) GetControllerOptions() config.Controller {
+	return config.Controller{SkipNameValidation: ptr.To(true)}
+}
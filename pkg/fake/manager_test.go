@@ -0,0 +1,1033 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	mccluster "sigs.k8s.io/multicluster-runtime/pkg/cluster"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+var _ = Describe("Manager", func() {
+	ctx := context.Background()
+	cm := func(name string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}
+	}
+
+	It("serves the objects of each cluster from client and cache", func() {
+		mgr := NewManagerBuilder().
+			WithCluster("one", cm("a")).
+			WithCluster("two", cm("b")).
+			Build()
+
+		cl, err := mgr.GetCluster(ctx, "one")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cl.GetClient().Get(ctx, client.ObjectKey{Namespace: "default", Name: "a"}, &corev1.ConfigMap{})).To(Succeed())
+		Expect(cl.GetCache().Get(ctx, client.ObjectKey{Namespace: "default", Name: "a"}, &corev1.ConfigMap{})).To(Succeed())
+		Expect(cl.GetClient().Get(ctx, client.ObjectKey{Namespace: "default", Name: "b"}, &corev1.ConfigMap{})).NotTo(Succeed())
+
+		_, err = mgr.GetCluster(ctx, "three")
+		Expect(errors.Is(err, multicluster.ErrClusterNotFound)).To(BeTrue())
+	})
+
+	It("returns the cluster metadata", func() {
+		mgr := NewManagerBuilder().
+			WithClusterMetadata("one", multicluster.Metadata{Labels: map[string]string{"env": "prod"}}).
+			Build()
+
+		md, err := mgr.GetClusterMetadata(ctx, "one")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(md.Labels).To(HaveKeyWithValue("env", "prod"))
+	})
+
+	It("returns the cluster versions, cached while engaged", func() {
+		mgr := NewManagerBuilder().
+			WithCluster("one").
+			WithClusterVersion("two", "v1.28.4-eks-1234").
+			Build()
+
+		v, err := mgr.GetClusterVersion(ctx, "one")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v.GitVersion).To(Equal(DefaultVersion))
+
+		engageCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		Expect(mgr.Engage(engageCtx, "two", mgr.FakeCluster("two"))).To(Succeed())
+		mgr.FakeCluster("two").SetServerVersion("v1.30.0")
+		v, err = mgr.GetClusterVersion(ctx, "two")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v.GitVersion).To(Equal("v1.28.4-eks-1234"))
+
+		cancel()
+		Eventually(func() string {
+			v, _ := mgr.GetClusterVersion(ctx, "two")
+			return v.GitVersion
+		}).Should(Equal("v1.30.0"))
+	})
+
+	It("applies interceptors per cluster", func() {
+		boom := errors.New("boom")
+		mgr := NewManagerBuilder().
+			WithCluster("one").
+			WithInterceptorFuncs("one", interceptor.Funcs{
+				Create: func(context.Context, client.WithWatch, client.Object, ...client.CreateOption) error {
+					return boom
+				},
+			}).
+			Build()
+
+		Expect(mgr.FakeCluster("one").GetClient().Create(ctx, cm("a"))).To(MatchError(boom))
+		Expect(mgr.FakeCluster("").GetClient().Create(ctx, cm("a"))).To(Succeed())
+	})
+
+	It("engages clusters manually until they are stopped", func() {
+		mgr := NewManagerBuilder().WithCluster("one").Build()
+		r := &recordingRunnable{}
+		Expect(mgr.Add(r)).To(Succeed())
+
+		adhoc := NewManagerBuilder().WithCluster("adhoc", cm("a")).Build().FakeCluster("adhoc")
+		_, err := mgr.EngageCluster(ctx, "one", adhoc)
+		Expect(err).To(MatchError(ContainSubstring("managed by the provider")))
+
+		h, err := mgr.EngageCluster(ctx, "adhoc", adhoc)
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(h.Ready()).Should(BeClosed())
+		Expect(r.engaged).To(Equal([]string{"adhoc"}))
+
+		cl, err := mgr.GetCluster(ctx, "adhoc")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cl).To(BeIdenticalTo(adhoc))
+		_, err = mgr.EngageCluster(ctx, "adhoc", adhoc)
+		Expect(err).To(MatchError(ContainSubstring("already engaged")))
+
+		h.Stop()
+		Expect(h.Done()).To(BeClosed())
+		Expect(h.Err()).NotTo(HaveOccurred())
+		_, err = mgr.GetCluster(ctx, "adhoc")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("rolls up cluster health checks", func() {
+		mgr := NewManagerBuilder().WithCluster("one").WithCluster("two").Build()
+		Expect(mgr.AddClusterHealthzCheck("reconciled", func(_ *http.Request, name string) error {
+			if name == "two" {
+				return errors.New("stuck")
+			}
+			return nil
+		})).To(Succeed())
+		Expect(mgr.HealthzCheck("reconciled")(nil)).To(Succeed())
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		Expect(mgr.Engage(ctx, "one", mgr.FakeCluster("one"))).To(Succeed())
+		Expect(mgr.Engage(ctx, "two", mgr.FakeCluster("two"))).To(Succeed())
+		Expect(mgr.HealthzCheck("reconciled")(nil)).To(MatchError(`cluster "two": failed to check reconciled: stuck`))
+
+		rec := httptest.NewRecorder()
+		mgr.MetricsServerExtraHandler(mcmanager.ClustersPath).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, mcmanager.ClustersPath, nil))
+		Expect(rec.Body.String()).To(MatchJSON(`[{"name":"one","healthy":true},{"name":"two","healthy":false,"checks":{"reconciled":"stuck"}}]`))
+	})
+
+	It("disengages all clusters before running shutdown hooks", func() {
+		mgr := NewManagerBuilder().WithCluster("one").Build()
+		var engaged context.Context
+		Expect(mgr.Add(&contextRunnable{engage: func(ctx context.Context) { engaged = ctx }})).To(Succeed())
+		Expect(mgr.Engage(ctx, "one", mgr.FakeCluster("one"))).To(Succeed())
+
+		var disengagedFirst bool
+		Expect(mgr.AddShutdownHook(func(context.Context) error {
+			disengagedFirst = engaged.Err() != nil
+			return nil
+		})).To(Succeed())
+
+		ctx, cancel := context.WithCancel(ctx)
+		done := make(chan error)
+		go func() { done <- mgr.Manager.Start(ctx) }()
+		cancel()
+		Eventually(done).Should(Receive(BeNil()))
+		Expect(disengagedFirst).To(BeTrue())
+		Expect(mgr.Engage(context.Background(), "one", mgr.FakeCluster("one"))).To(MatchError(ContainSubstring("shutting down")))
+	})
+
+	It("tears down the objects of the clusters and of the host cluster", func() {
+		failDelete := interceptor.Funcs{Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+			if obj.GetName() == "failing" {
+				return errors.New("boom")
+			}
+			return c.Delete(ctx, obj, opts...)
+		}}
+		fm := NewManagerBuilder().
+			WithCluster(mcmanager.LocalCluster, cm("host"), cm("failing"), cm("members")).
+			WithInterceptorFuncs(mcmanager.LocalCluster, failDelete).
+			WithCluster("one", cm("webhook"), cm("failing")).
+			WithInterceptorFuncs("one", failDelete).
+			WithCluster("two").
+			Build()
+
+		var lock sync.Mutex
+		var logs []string
+		log := funcr.New(func(_, args string) {
+			lock.Lock()
+			defer lock.Unlock()
+			logs = append(logs, args)
+		}, funcr.Options{})
+		mgr, err := mcmanager.WithMultiCluster(&loggingManager{Manager: fm.GetLocalManager(), log: log}, fm.FakeProvider(),
+			mcmanager.WithMembership(mcmanager.MembershipOptions{ConfigMap: types.NamespacedName{Namespace: "default", Name: "members"}}),
+			mcmanager.WithTeardown(mcmanager.TeardownOptions{
+				ClusterObjects: []client.Object{cm("webhook"), cm("failing")},
+				HostObjects:    []client.Object{cm("host"), cm("failing"), cm("missing")},
+			}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mgr.Engage(ctx, "one", fm.FakeCluster("one"))).To(Succeed())
+		Expect(mgr.Engage(ctx, "two", fm.FakeCluster("two"))).To(Succeed())
+
+		startCtx, cancel := context.WithCancel(ctx)
+		done := make(chan error)
+		go func() { done <- mgr.Start(startCtx) }()
+		cancel()
+		Eventually(done).Should(Receive(BeNil()))
+
+		exists := func(cl *Cluster, name string) bool {
+			err := cl.GetClient().Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, &corev1.ConfigMap{})
+			if apierrors.IsNotFound(err) {
+				return false
+			}
+			Expect(err).NotTo(HaveOccurred())
+			return true
+		}
+		Expect(exists(fm.FakeCluster("one"), "webhook")).To(BeFalse())
+		Expect(exists(fm.FakeCluster("one"), "failing")).To(BeTrue())
+		Expect(exists(fm.FakeCluster(mcmanager.LocalCluster), "host")).To(BeFalse())
+		Expect(exists(fm.FakeCluster(mcmanager.LocalCluster), "members")).To(BeFalse(), "the membership ConfigMap is torn down")
+		Expect(exists(fm.FakeCluster(mcmanager.LocalCluster), "failing")).To(BeTrue())
+
+		lock.Lock()
+		defer lock.Unlock()
+		var tornDown, failed []string
+		for _, l := range logs {
+			switch {
+			case strings.Contains(l, `"msg"="Tore down object"`):
+				tornDown = append(tornDown, l)
+			case strings.Contains(l, `"msg"="Failed to tear down object"`):
+				failed = append(failed, l)
+			}
+		}
+		Expect(tornDown).To(ConsistOf(
+			And(ContainSubstring(`"cluster"="one"`), ContainSubstring(`"name"="webhook"`)),
+			ContainSubstring(`"name"="host"`),
+			ContainSubstring(`"name"="members"`),
+		), "objects that were not found are not reported")
+		Expect(failed).To(ConsistOf(
+			And(ContainSubstring(`"cluster"="one"`), ContainSubstring(`"name"="failing"`), ContainSubstring("boom")),
+			And(ContainSubstring(`"cluster"=""`), ContainSubstring(`"name"="failing"`), ContainSubstring("boom")),
+		))
+	})
+
+	It("runs providers requiring leadership as leader election runnables", func() {
+		mgr := NewManagerBuilder().Build()
+		var ran mcmanager.Manager
+		provider := providerFunc(func(ctx context.Context, m mcmanager.Manager) error {
+			ran = m
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		Expect(mcmanager.AddProvider(mgr, provider)).To(Succeed())
+		Expect(mcmanager.AddProvider(mgr, provider, mcmanager.RequireLeadership())).To(Succeed())
+
+		runnables := mgr.Runnables()
+		Expect(runnables).To(HaveLen(2))
+		Expect(runnables[0].(manager.LeaderElectionRunnable).NeedLeaderElection()).To(BeFalse())
+		Expect(runnables[1].(manager.LeaderElectionRunnable).NeedLeaderElection()).To(BeTrue())
+
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		Expect(runnables[1].Start(ctx)).To(Succeed())
+		Expect(ran).To(BeIdenticalTo(mgr))
+	})
+
+	It("reports the provider that engaged a cluster", func() {
+		mgr := NewManagerBuilder().WithCluster("one").WithCluster("two").Build()
+		Expect(mgr.AddClusterHealthzCheck("reconciled", func(*http.Request, string) error { return nil })).To(Succeed())
+		provider := providerFunc(func(ctx context.Context, m mcmanager.Manager) error {
+			Expect(m.Engage(ctx, "one", mgr.FakeCluster("one"))).To(Succeed())
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		Expect(mcmanager.AddProvider(mgr, provider, mcmanager.WithProviderName("kind"))).To(Succeed())
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() { _ = mgr.Runnables()[0].Start(ctx) }()
+		Eventually(func() string { return mgr.ProviderName("one") }).Should(Equal("kind"))
+		Expect(mgr.Engage(ctx, "two", mgr.FakeCluster("two"))).To(Succeed())
+		Expect(mgr.ProviderName("two")).To(BeEmpty())
+
+		rec := httptest.NewRecorder()
+		mgr.MetricsServerExtraHandler(mcmanager.ClustersPath).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, mcmanager.ClustersPath, nil))
+		Expect(rec.Body.String()).To(MatchJSON(`[{"name":"one","provider":"kind","healthy":true},{"name":"two","healthy":true}]`))
+	})
+
+	It("publishes the engaged clusters with health and shard", func() {
+		key := types.NamespacedName{Namespace: "default", Name: "membership"}
+		mgr := NewManagerBuilder().WithCluster("one").WithCluster("two").
+			WithOptions(mcmanager.WithMembership(mcmanager.MembershipOptions{
+				ConfigMap: key,
+				Shard:     func(string) string { return "shard-0" },
+			})).Build()
+		Expect(mgr.AddClusterHealthzCheck("reconciled", func(_ *http.Request, name string) error {
+			if name == "two" {
+				return errors.New("stuck")
+			}
+			return nil
+		})).To(Succeed())
+		runnables := mgr.Runnables()
+		Expect(runnables).To(HaveLen(1))
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() { _ = runnables[0].Start(ctx) }()
+		Expect(mgr.Engage(ctx, "one", mgr.FakeCluster("one"))).To(Succeed())
+		twoCtx, disengageTwo := context.WithCancel(ctx)
+		Expect(mgr.Engage(twoCtx, "two", mgr.FakeCluster("two"))).To(Succeed())
+
+		data := func() map[string]string {
+			cm := &corev1.ConfigMap{}
+			if err := mgr.GetLocalManager().GetClient().Get(ctx, key, cm); err != nil {
+				return nil
+			}
+			return cm.Data
+		}
+		Eventually(data).Should(HaveKeyWithValue(mcmanager.MembershipClustersKey, `["one","two"]`))
+		Expect(data()[mcmanager.MembershipStatusKey]).To(MatchJSON(`[{"name":"one","shard":"shard-0","healthy":true},{"name":"two","shard":"shard-0","healthy":false,"checks":{"reconciled":"stuck"}}]`))
+
+		disengageTwo()
+		Eventually(data).Should(HaveKeyWithValue(mcmanager.MembershipClustersKey, `["one"]`))
+	})
+
+	It("engages runnables added later with the engaged clusters", func() {
+		mgr := NewManagerBuilder().WithCluster("one").WithCluster("two").Build()
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		Expect(mgr.Engage(ctx, "one", mgr.FakeCluster("one"))).To(Succeed())
+
+		r := &recordingRunnable{}
+		Expect(mgr.Add(r)).To(Succeed())
+		Expect(r.engaged).To(Equal([]string{"one"}))
+
+		Expect(mgr.Engage(ctx, "two", mgr.FakeCluster("two"))).To(Succeed())
+		Expect(r.engaged).To(Equal([]string{"one", "two"}))
+	})
+
+	It("engages all clusters on start", func() {
+		mgr := NewManagerBuilder().WithCluster("one").WithCluster("two").Build()
+		r := &recordingRunnable{}
+		Expect(mgr.Add(r)).To(Succeed())
+		Expect(mgr.Runnables()).To(HaveLen(1))
+
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		Expect(mgr.Start(ctx)).To(Succeed())
+		Expect(r.engaged).To(Equal([]string{"one", "two"}))
+	})
+
+	It("dry-runs writes to the selected clusters", func() {
+		mgr := NewManagerBuilder().WithCluster("one", cm("a")).WithCluster("two", cm("a")).
+			WithOptions(mcmanager.WithDryRun("one")).Build()
+
+		one, err := mgr.GetCluster(ctx, "one")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mccluster.IsDryRun(one)).To(BeTrue())
+		Expect(one.GetClient().Create(ctx, cm("b"))).To(Succeed())
+		Expect(one.GetClient().Delete(ctx, cm("a"))).To(Succeed())
+		Expect(mgr.FakeCluster("one").GetClient().Get(ctx, client.ObjectKey{Namespace: "default", Name: "a"}, &corev1.ConfigMap{})).To(Succeed())
+		Expect(mgr.FakeCluster("one").GetClient().Get(ctx, client.ObjectKey{Namespace: "default", Name: "b"}, &corev1.ConfigMap{})).NotTo(Succeed())
+
+		two, err := mgr.GetCluster(ctx, "two")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mccluster.IsDryRun(two)).To(BeFalse())
+
+		var engaged cluster.Cluster
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		Expect(mgr.Add(&clusterRunnable{engage: func(cl cluster.Cluster) { engaged = cl }})).To(Succeed())
+		Expect(mgr.Engage(ctx, "one", mgr.FakeCluster("one"))).To(Succeed())
+		Expect(mccluster.IsDryRun(engaged)).To(BeTrue())
+	})
+
+	It("runs a runnable per engaged cluster and restarts it when it fails", func() {
+		mgr := NewManagerBuilder().WithCluster("one").WithCluster("two").Build()
+
+		var lock sync.Mutex
+		starts := map[string]int{}
+		started := func(name string) int {
+			lock.Lock()
+			defer lock.Unlock()
+			return starts[name]
+		}
+		pc := mcmanager.NewPerCluster(func(clusterName string, _ cluster.Cluster) (manager.Runnable, error) {
+			return manager.RunnableFunc(func(ctx context.Context) error {
+				lock.Lock()
+				starts[clusterName]++
+				n := starts[clusterName]
+				lock.Unlock()
+				if clusterName == "two" && n < 3 {
+					return errors.New("boom")
+				}
+				<-ctx.Done()
+				return nil
+			}), nil
+		}, mcmanager.PerClusterOptions{RestartBackoff: time.Millisecond})
+		Expect(mgr.Add(pc)).To(Succeed())
+
+		ctx, cancel := context.WithCancel(ctx)
+		oneCtx, disengageOne := context.WithCancel(ctx)
+		Expect(mgr.Engage(oneCtx, "one", mgr.FakeCluster("one"))).To(Succeed())
+		Expect(mgr.Engage(ctx, "two", mgr.FakeCluster("two"))).To(Succeed())
+		Eventually(pc.Running).Should(Equal([]string{"one", "two"}))
+		Eventually(func() int { return started("two") }).Should(Equal(3))
+		Consistently(func() int { return started("two") }).Should(Equal(3))
+		Expect(started("one")).To(Equal(1))
+
+		disengageOne()
+		Eventually(pc.Running).Should(Equal([]string{"two"}))
+
+		By("ignoring another engagement of the same cluster")
+		Expect(pc.Engage(ctx, "two", mgr.FakeCluster("two"))).To(Succeed())
+		Consistently(func() int { return started("two") }).Should(Equal(3))
+
+		done := make(chan error)
+		startCtx, stop := context.WithCancel(context.Background())
+		go func() { done <- pc.Start(startCtx) }()
+		stop()
+		Eventually(done).Should(Receive(BeNil()))
+		Expect(pc.Running()).To(BeEmpty())
+
+		By("rejecting engagements after shutdown")
+		Expect(pc.Engage(ctx, "three", mgr.FakeCluster("two"))).To(HaveOccurred())
+		Expect(pc.Running()).To(BeEmpty())
+		cancel()
+	})
+
+	It("behaves like a single-cluster manager in single-cluster mode", func() {
+		mgr := NewManagerBuilder().WithCluster(mcmanager.LocalCluster, cm("a")).WithCluster("one").
+			WithOptions(mcmanager.WithSingleCluster()).Build()
+		Expect(mgr.SingleCluster()).To(BeTrue())
+		Expect(mgr.GetProvider()).To(BeNil())
+
+		local, err := mgr.GetCluster(ctx, mcmanager.LocalCluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(local.GetClient().Get(ctx, client.ObjectKey{Namespace: "default", Name: "a"}, &corev1.ConfigMap{})).To(Succeed())
+		_, err = mgr.GetCluster(ctx, "one")
+		Expect(err).To(HaveOccurred())
+
+		Expect(mgr.Engage(ctx, "one", mgr.FakeCluster("one"))).To(MatchError(ContainSubstring("single-cluster mode")))
+		_, err = mgr.EngageCluster(ctx, "one", mgr.FakeCluster("one"))
+		Expect(err).To(MatchError(ContainSubstring("single-cluster mode")))
+
+		_, err = mcmanager.WithMultiCluster(mgr.GetLocalManager(), NewProvider(), mcmanager.WithSingleCluster())
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("creates clusters with the configured constructor", func() {
+		var created []string
+		mgr := NewManagerBuilder().WithOptions(mcmanager.WithNewCluster(func(name string, cfg *rest.Config, opts ...cluster.Option) (cluster.Cluster, error) {
+			created = append(created, name+"@"+cfg.Host)
+			return mcmanager.DefaultNewCluster(name, cfg, opts...)
+		})).Build()
+
+		cl, err := mgr.NewCluster("one", &rest.Config{Host: "https://one.example.com"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cl.GetConfig().Host).To(Equal("https://one.example.com"))
+		Expect(created).To(Equal([]string{"one@https://one.example.com"}))
+
+		cl, err = mgr.NewClusterOr(mcmanager.PlainNewCluster, "two", &rest.Config{Host: "https://two.example.com"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cl.GetConfig().Host).To(Equal("https://two.example.com"))
+		Expect(created).To(Equal([]string{"one@https://one.example.com", "two@https://two.example.com"}), "the configured constructor takes precedence")
+
+		cl, err = NewManagerBuilder().Build().NewCluster("two", &rest.Config{Host: "https://two.example.com"})
+		Expect(err).NotTo(HaveOccurred())
+		_, ok := cl.(mccluster.Updatable)
+		Expect(ok).To(BeTrue(), "clusters are updatable by default")
+
+		cl, err = NewManagerBuilder().Build().NewClusterOr(mcmanager.PlainNewCluster, "two", &rest.Config{Host: "https://two.example.com"})
+		Expect(err).NotTo(HaveOccurred())
+		_, ok = cl.(mccluster.Updatable)
+		Expect(ok).To(BeFalse(), "providers keep their own default constructor")
+	})
+
+	It("rejects invalid cluster names", func() {
+		mgr := NewManagerBuilder().WithCluster("one").Build()
+		Expect(mgr.Engage(ctx, "a\nb", mgr.FakeCluster("one"))).To(MatchError(multicluster.ErrInvalidClusterName))
+		_, err := mgr.EngageCluster(ctx, "", mgr.FakeCluster("one"))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("delays engagements while overloaded", func() {
+		var lock sync.Mutex
+		depth := 10
+		mgr := NewManagerBuilder().WithCluster("one").WithOptions(mcmanager.WithBackpressure(mcmanager.BackpressureOptions{
+			MaxQueueDepth: 5,
+			Interval:      10 * time.Millisecond,
+			QueueDepth: func() (int, error) {
+				lock.Lock()
+				defer lock.Unlock()
+				return depth, nil
+			},
+		})).Build()
+		r := &recordingRunnable{}
+		Expect(mgr.Add(r)).To(Succeed())
+
+		engaged := make(chan error, 1)
+		go func() { engaged <- mgr.Engage(ctx, "one", mgr.FakeCluster("one")) }()
+		Consistently(engaged, 100*time.Millisecond).ShouldNot(Receive())
+		Expect(r.engaged).To(BeEmpty())
+
+		lock.Lock()
+		depth = 5
+		lock.Unlock()
+		Eventually(engaged).Should(Receive(BeNil()))
+		Expect(r.engaged).To(ConsistOf("one"))
+
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		lock.Lock()
+		depth = 10
+		lock.Unlock()
+		Expect(mgr.Engage(cancelled, "two", mgr.FakeCluster("one"))).To(MatchError(context.Canceled))
+	})
+
+	It("delivers cluster events to subscribers", func() {
+		mgr := NewManagerBuilder().
+			WithClusterMetadata("one", multicluster.Metadata{Labels: map[string]string{"env": "prod"}}).
+			WithCluster("two").
+			Build()
+		Expect(mgr.AddClusterHealthzCheck("reconciled", func(_ *http.Request, name string) error {
+			if name == "two" {
+				return errors.New("stuck")
+			}
+			return nil
+		})).To(Succeed())
+
+		engageCtx, disengage := context.WithCancel(ctx)
+		defer disengage()
+		Expect(mgr.Engage(engageCtx, "one", mgr.FakeCluster("one"))).To(Succeed())
+
+		subCtx, unsubscribe := context.WithCancel(ctx)
+		events := mgr.SubscribeClusterEvents(subCtx)
+		var evt mcmanager.ClusterEvent
+		Eventually(events).Should(Receive(&evt))
+		Expect(evt.Type).To(Equal(mcmanager.ClusterEngaged), "engaged clusters are replayed")
+		Expect(evt.Name).To(Equal("one"))
+		Expect(evt.Metadata.Labels).To(HaveKeyWithValue("env", "prod"))
+
+		var got []string
+		receive := func() []string {
+			select {
+			case evt := <-events:
+				got = append(got, string(evt.Type)+" "+evt.Name)
+			default:
+			}
+			return got
+		}
+		Eventually(receive).Should(ContainElement("Synced one"))
+
+		Expect(mgr.Engage(engageCtx, "two", mgr.FakeCluster("two"))).To(Succeed())
+		Eventually(receive).Should(ContainElements("Engaged two", "Synced two"))
+		Expect(mgr.HealthzCheck("reconciled")(nil)).NotTo(Succeed())
+		Expect(mgr.HealthzCheck("reconciled")(nil)).NotTo(Succeed())
+		disengage()
+
+		Eventually(receive).Should(ContainElements("Unhealthy two", "Disengaged one", "Disengaged two"))
+		Consistently(receive).Should(HaveLen(6), "unhealthy clusters are reported once")
+
+		unsubscribe()
+		Eventually(events).Should(BeClosed())
+	})
+
+	It("delivers each engagement once to subscribers joining while it is in progress", func() {
+		mgr := NewManagerBuilder().WithCluster("one").Build()
+		var events <-chan mcmanager.ClusterEvent
+		Expect(mgr.Add(&contextRunnable{engage: func(context.Context) {
+			events = mgr.SubscribeClusterEvents(ctx)
+		}})).To(Succeed())
+
+		Expect(mgr.Engage(ctx, "one", mgr.FakeCluster("one"))).To(Succeed())
+		var got []string
+		receive := func() []string {
+			select {
+			case evt := <-events:
+				got = append(got, string(evt.Type)+" "+evt.Name)
+			default:
+			}
+			return got
+		}
+		Eventually(receive).Should(ContainElement("Synced one"))
+		Consistently(receive).Should(Equal([]string{"Engaged one", "Synced one"}))
+	})
+
+	It("does not deliver failed engagements", func() {
+		mgr := NewManagerBuilder().WithCluster("one").Build()
+		events := mgr.SubscribeClusterEvents(ctx)
+		Expect(mgr.Add(&failingRunnable{err: errors.New("boom")})).To(Succeed())
+
+		Expect(mgr.Engage(ctx, "one", mgr.FakeCluster("one"))).To(MatchError(ContainSubstring("boom")))
+		Consistently(events).ShouldNot(Receive())
+	})
+
+	It("coalesces the events of subscribers that fall behind", func() {
+		mgr := NewManagerBuilder().Build()
+		subCtx, unsubscribe := context.WithCancel(ctx)
+		defer unsubscribe()
+		events := mgr.SubscribeClusterEvents(subCtx)
+
+		// 1200 events, Engaged and Synced of each cluster.
+		for i := range 600 {
+			cl := NewCluster(clientfake.NewClientBuilder().Build())
+			Expect(mgr.Engage(ctx, "cluster-"+strconv.Itoa(i), cl)).To(Succeed())
+		}
+		time.Sleep(100 * time.Millisecond)
+
+		received := 0
+		for done := false; !done; {
+			select {
+			case <-events:
+				received++
+			case <-time.After(100 * time.Millisecond):
+				done = true
+			}
+		}
+		Expect(received).To(BeNumerically(">", 0))
+		Expect(received).To(BeNumerically("<=", 1025), "the buffer and the event being delivered")
+	})
+
+	It("probes the capabilities of engaged clusters", func() {
+		var lock sync.Mutex
+		probed := 0
+		mgr := NewManagerBuilder().
+			WithClusterMetadata("one", multicluster.Metadata{Labels: map[string]string{"env": "prod"}}).
+			WithCluster("one", cm("a")).
+			WithCluster("two").
+			WithClusterVersion("two", "v1.20.0").
+			WithOptions(mcmanager.WithCapabilityProbes(mcmanager.CapabilityOptions{Probes: []mccluster.CapabilityProbe{
+				mccluster.ObjectsProbe("configmaps", &corev1.ConfigMapList{}),
+				mccluster.KindProbe("metrics-server", schema.GroupVersionKind{Group: "metrics.k8s.io", Version: "v1beta1", Kind: "PodMetrics"}),
+				mccluster.MinimumVersionProbe("server-side-apply", "1.22"),
+				{Name: "counted", Probe: func(context.Context, cluster.Cluster) (bool, error) {
+					lock.Lock()
+					defer lock.Unlock()
+					probed++
+					return true, nil
+				}},
+			}})).
+			Build()
+		mgr.FakeCluster("one").SetServerVersion("v1.30.1")
+
+		var engagedLabels map[string]string
+		Expect(mgr.Add(&contextRunnable{engage: func(ctx context.Context) {
+			md, err := mgr.GetClusterMetadata(ctx, "one")
+			Expect(err).NotTo(HaveOccurred())
+			engagedLabels = md.Labels
+		}})).To(Succeed())
+
+		engageCtx, disengage := context.WithCancel(ctx)
+		Expect(mgr.Engage(engageCtx, "one", mgr.FakeCluster("one"))).To(Succeed())
+		Expect(engagedLabels).To(HaveKeyWithValue(mcmanager.CapabilityLabelPrefix+"configmaps", "true"), "probed before the runnables are engaged")
+
+		md, err := mgr.GetClusterMetadata(ctx, "one")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(md.Labels).To(Equal(map[string]string{
+			"env": "prod",
+			mcmanager.CapabilityLabelPrefix + "configmaps":        "true",
+			mcmanager.CapabilityLabelPrefix + "metrics-server":    "false",
+			mcmanager.CapabilityLabelPrefix + "server-side-apply": "true",
+			mcmanager.CapabilityLabelPrefix + "counted":           "true",
+		}))
+
+		Expect(mgr.Engage(engageCtx, "two", mgr.FakeCluster("two"))).To(Succeed())
+		md, err = mgr.GetClusterMetadata(ctx, "two")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(md.Labels).To(HaveKeyWithValue(mcmanager.CapabilityLabelPrefix+"configmaps", "false"))
+		Expect(md.Labels).To(HaveKeyWithValue(mcmanager.CapabilityLabelPrefix+"server-side-apply", "false"))
+
+		Expect(mgr.ProbeCapabilities(ctx, "one")).To(Succeed())
+		lock.Lock()
+		Expect(probed).To(Equal(3), "probed again on demand")
+		lock.Unlock()
+		Expect(mgr.ProbeCapabilities(ctx, "three")).To(MatchError(multicluster.ErrClusterNotFound))
+
+		disengage()
+		Eventually(func() map[string]string {
+			md, _ := mgr.GetClusterMetadata(ctx, "one")
+			return md.Labels
+		}).ShouldNot(HaveKey(mcmanager.CapabilityLabelPrefix + "configmaps"))
+	})
+
+	It("probes the capabilities again in the interval", func() {
+		var lock sync.Mutex
+		available := false
+		mgr := NewManagerBuilder().
+			WithCluster("one").
+			WithOptions(mcmanager.WithCapabilityProbes(mcmanager.CapabilityOptions{
+				Interval: 10 * time.Millisecond,
+				Probes: []mccluster.CapabilityProbe{{Name: "feature", Probe: func(context.Context, cluster.Cluster) (bool, error) {
+					lock.Lock()
+					defer lock.Unlock()
+					if !available {
+						return false, errors.New("unreachable")
+					}
+					return true, nil
+				}}},
+			})).
+			Build()
+
+		engageCtx, disengage := context.WithCancel(ctx)
+		defer disengage()
+		Expect(mgr.Engage(engageCtx, "one", mgr.FakeCluster("one"))).To(Succeed())
+		md, err := mgr.GetClusterMetadata(ctx, "one")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(md.Labels).NotTo(HaveKey(mcmanager.CapabilityLabelPrefix+"feature"), "failed probes are unknown")
+
+		lock.Lock()
+		available = true
+		lock.Unlock()
+		Eventually(func() map[string]string {
+			md, _ := mgr.GetClusterMetadata(ctx, "one")
+			return md.Labels
+		}).Should(HaveKeyWithValue(mcmanager.CapabilityLabelPrefix+"feature", "true"))
+	})
+
+	It("reports the phases of starting", func() {
+		var lock sync.Mutex
+		var phases []string
+		mgr := NewManagerBuilder().
+			WithCluster("one").
+			WithCluster("two").
+			WithOptions(mcmanager.WithStartPhaseCallback(func(evt mcmanager.StartPhaseEvent) {
+				lock.Lock()
+				defer lock.Unlock()
+				phase := string(evt.Phase)
+				if evt.Wave > 0 {
+					phase += " " + strconv.Itoa(evt.Wave)
+				}
+				if evt.Completed {
+					phase += " completed"
+					if evt.Phase == mcmanager.PhaseEngagementWave {
+						phase += " with " + strconv.Itoa(evt.Clusters)
+					}
+				}
+				phases = append(phases, phase)
+			})).
+			Build()
+		reported := func() []string {
+			lock.Lock()
+			defer lock.Unlock()
+			return append([]string(nil), phases...)
+		}
+
+		startCtx, stop := context.WithCancel(ctx)
+		defer stop()
+		go func() { _ = mgr.Manager.Start(startCtx) }()
+		Eventually(mgr.Runnables).Should(HaveLen(1))
+		Expect(reported()).To(ContainElements("ProviderStart", "InitialDiscovery", "ControllersStarted"))
+		Expect(reported()).NotTo(ContainElement("ProviderStart completed"))
+		go func() { _ = mgr.Runnables()[0].Start(startCtx) }()
+		Eventually(reported).Should(ContainElements("ProviderStart completed", "ControllersStarted completed"))
+		Expect(reported()).NotTo(ContainElement("InitialDiscovery completed"))
+
+		Expect(mgr.Engage(startCtx, "one", mgr.FakeCluster("one"))).To(Succeed())
+		Eventually(reported).Should(ContainElements("InitialDiscovery completed", "EngagementWave 1", "EngagementWave 1 completed with 1"))
+
+		Expect(mgr.Engage(startCtx, "two", mgr.FakeCluster("two"))).To(Succeed())
+		Consistently(reported).Should(HaveLen(8), "waves are reported while starting only")
+	})
+
+	It("engages one failure domain at a time", func() {
+		domain := func(d string) multicluster.Metadata {
+			return multicluster.Metadata{Labels: map[string]string{mcmanager.FailureDomainLabel: d}}
+		}
+		mgr := NewManagerBuilder().
+			WithClusterMetadata("east-1", domain("east")).
+			WithClusterMetadata("east-2", domain("east")).
+			WithClusterMetadata("west-1", domain("west")).
+			WithOptions(mcmanager.WithFailureDomains(mcmanager.FailureDomainOptions{})).
+			Build()
+		unblock := make(chan struct{})
+		var lock sync.Mutex
+		var engaged []string
+		Expect(mgr.Add(&blockingRunnable{engage: func(name string) {
+			if name == "east-1" {
+				<-unblock
+			}
+			lock.Lock()
+			defer lock.Unlock()
+			engaged = append(engaged, name)
+		}})).To(Succeed())
+		names := func() []string {
+			lock.Lock()
+			defer lock.Unlock()
+			return append([]string(nil), engaged...)
+		}
+
+		errs := make(chan error, 3)
+		go func() { errs <- mgr.Engage(ctx, "east-1", mgr.FakeCluster("east-1")) }()
+		Consistently(names, 50*time.Millisecond).Should(BeEmpty())
+		go func() { errs <- mgr.Engage(ctx, "west-1", mgr.FakeCluster("west-1")) }()
+		Consistently(names, 50*time.Millisecond).Should(BeEmpty(), "waits for the other failure domain")
+
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		Expect(mgr.Engage(cancelled, "east-2", mgr.FakeCluster("east-2"))).To(MatchError(context.Canceled), "queued behind the waiting failure domain")
+
+		close(unblock)
+		Eventually(names).Should(Equal([]string{"east-1", "west-1"}))
+		Eventually(errs).Should(Receive(BeNil()))
+		Eventually(errs).Should(Receive(BeNil()))
+		Expect(mgr.Engage(ctx, "east-2", mgr.FakeCluster("east-2"))).To(Succeed())
+	})
+
+	It("limits the number of concurrent engagements", func() {
+		mgr := NewManagerBuilder().WithOptions(mcmanager.WithEngagementParallelism(2, 0)).Build()
+		var lock sync.Mutex
+		inFlight, maxInFlight := 0, 0
+		release := make(chan struct{})
+		Expect(mgr.Add(&blockingRunnable{engage: func(string) {
+			lock.Lock()
+			inFlight++
+			maxInFlight = max(maxInFlight, inFlight)
+			lock.Unlock()
+			<-release
+			lock.Lock()
+			inFlight--
+			lock.Unlock()
+		}})).To(Succeed())
+		current := func() int {
+			lock.Lock()
+			defer lock.Unlock()
+			return inFlight
+		}
+
+		errs := make(chan error)
+		for i := range 5 {
+			go func() {
+				errs <- mgr.Engage(ctx, "cluster-"+strconv.Itoa(i), NewCluster(clientfake.NewClientBuilder().Build()))
+			}()
+		}
+		Eventually(current).Should(Equal(2))
+		Consistently(current).Should(Equal(2))
+		close(release)
+		for range 5 {
+			Eventually(errs).Should(Receive(BeNil()))
+		}
+		Expect(maxInFlight).To(Equal(2))
+	})
+
+	It("staggers consecutive engagements", func() {
+		mgr := NewManagerBuilder().WithOptions(mcmanager.WithEngagementParallelism(0, 50*time.Millisecond)).Build()
+		start := time.Now()
+		for i := range 3 {
+			Expect(mgr.Engage(ctx, "cluster-"+strconv.Itoa(i), NewCluster(clientfake.NewClientBuilder().Build()))).To(Succeed())
+		}
+		Expect(time.Since(start)).To(BeNumerically(">=", 100*time.Millisecond))
+	})
+
+	It("releases the engagement slot when the engagement is cancelled before its cache synced", func() {
+		mgr := NewManagerBuilder().WithOptions(mcmanager.WithEngagementParallelism(1, 0)).Build()
+		engageCtx, disengage := context.WithCancel(ctx)
+		Expect(mgr.Engage(engageCtx, "unsynced", &unsyncedCluster{Cluster: NewCluster(clientfake.NewClientBuilder().Build())})).To(Succeed())
+
+		By("cancelling engagements waiting for a slot")
+		waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		Expect(mgr.Engage(waitCtx, "waiting", NewCluster(clientfake.NewClientBuilder().Build()))).To(MatchError(context.DeadlineExceeded))
+
+		errs := make(chan error)
+		go func() { errs <- mgr.Engage(ctx, "next", NewCluster(clientfake.NewClientBuilder().Build())) }()
+		Consistently(errs).ShouldNot(Receive(), "the slot is held until the cache synced")
+		disengage()
+		Eventually(errs).Should(Receive(BeNil()))
+	})
+
+	It("releases the engagement slot when the engagement fails", func() {
+		mgr := NewManagerBuilder().WithOptions(mcmanager.WithEngagementParallelism(1, 0)).Build()
+		Expect(mgr.Add(&failingRunnable{err: errors.New("boom")})).To(Succeed())
+		Expect(mgr.Engage(ctx, "one", NewCluster(clientfake.NewClientBuilder().Build()))).To(MatchError(ContainSubstring("boom")))
+
+		engageCtx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+		Expect(mgr.Engage(engageCtx, "two", NewCluster(clientfake.NewClientBuilder().Build()))).To(MatchError(ContainSubstring("boom")), "the slot is free")
+	})
+
+	It("rejects engagements once the caches exceed the memory budget", func() {
+		mgr := NewManagerBuilder().WithOptions(mcmanager.WithMemoryBudget(1)).Build()
+		store := toolscache.NewStore(toolscache.MetaNamespaceKeyFunc)
+		Expect(store.Add(cm("a"))).To(Succeed())
+		cl, err := cluster.New(&rest.Config{Host: "https://127.0.0.1:1"}, func(o *cluster.Options) {
+			o.NewCache = func(*rest.Config, cache.Options) (cache.Cache, error) {
+				return &storeCache{store: store}, nil
+			}
+		}, mccluster.WithCacheAccounting())
+		Expect(err).NotTo(HaveOccurred())
+		_, err = cl.GetCache().GetInformer(ctx, &corev1.ConfigMap{})
+		Expect(err).NotTo(HaveOccurred())
+
+		engageCtx, disengage := context.WithCancel(ctx)
+		Expect(mgr.Engage(engageCtx, "big", cl)).To(Succeed())
+		Eventually(func() error {
+			return mgr.Engage(ctx, "next", NewCluster(clientfake.NewClientBuilder().Build()))
+		}).Should(MatchError(mcmanager.ErrMemoryBudgetExceeded))
+
+		disengage()
+		Eventually(func() error {
+			return mgr.Engage(ctx, "next", NewCluster(clientfake.NewClientBuilder().Build()))
+		}).Should(Succeed(), "the caches of disengaged clusters no longer count")
+	})
+})
+
+type clusterRunnable struct {
+	engage func(cluster.Cluster)
+}
+
+func (r *clusterRunnable) Start(context.Context) error { return nil }
+
+func (r *clusterRunnable) Engage(_ context.Context, _ string, cl cluster.Cluster) error {
+	r.engage(cl)
+	return nil
+}
+
+type recordingRunnable struct {
+	engaged []string
+}
+
+func (r *recordingRunnable) Start(context.Context) error { return nil }
+
+func (r *recordingRunnable) Engage(_ context.Context, name string, _ cluster.Cluster) error {
+	r.engaged = append(r.engaged, name)
+	return nil
+}
+
+type blockingRunnable struct {
+	engage func(name string)
+}
+
+func (r *blockingRunnable) Start(context.Context) error { return nil }
+
+func (r *blockingRunnable) Engage(_ context.Context, name string, _ cluster.Cluster) error {
+	r.engage(name)
+	return nil
+}
+
+// storeCache is a cache whose informers serve the objects of store.
+type storeCache struct {
+	informertest.FakeInformers
+	store toolscache.Store
+}
+
+func (c *storeCache) GetInformer(context.Context, client.Object, ...cache.InformerGetOption) (cache.Informer, error) {
+	return &storeInformer{store: c.store}, nil
+}
+
+type storeInformer struct {
+	cache.Informer
+	store toolscache.Store
+}
+
+func (i *storeInformer) GetStore() toolscache.Store {
+	return i.store
+}
+
+// unsyncedCluster is a cluster whose cache never syncs, like that of an
+// unreachable API server.
+type unsyncedCluster struct {
+	*Cluster
+}
+
+func (c *unsyncedCluster) GetCache() cache.Cache {
+	return unsyncedCache{Cache: c.Cluster.GetCache()}
+}
+
+type unsyncedCache struct {
+	cache.Cache
+}
+
+func (c unsyncedCache) WaitForCacheSync(ctx context.Context) bool {
+	<-ctx.Done()
+	return false
+}
+
+// loggingManager is a host manager logging to log.
+type loggingManager struct {
+	manager.Manager
+	log logr.Logger
+}
+
+func (m *loggingManager) GetLogger() logr.Logger { return m.log }
+
+type failingRunnable struct {
+	err error
+}
+
+func (r *failingRunnable) Start(context.Context) error { return nil }
+
+func (r *failingRunnable) Engage(context.Context, string, cluster.Cluster) error {
+	return r.err
+}
+
+type contextRunnable struct {
+	engage func(context.Context)
+}
+
+func (r *contextRunnable) Start(context.Context) error { return nil }
+
+func (r *contextRunnable) Engage(ctx context.Context, _ string, _ cluster.Cluster) error {
+	r.engage(ctx)
+	return nil
+}
+
+type providerFunc func(ctx context.Context, mgr mcmanager.Manager) error
+
+func (f providerFunc) Run(ctx context.Context, mgr mcmanager.Manager) error { return f(ctx, mgr) }
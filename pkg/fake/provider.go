@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+var _ multicluster.Provider = &Provider{}
+var _ multicluster.MetadataProvider = &Provider{}
+
+// Provider is a fake multicluster provider serving a fixed set of fake
+// clusters.
+type Provider struct {
+	lock     sync.RWMutex
+	names    []string
+	clusters map[string]*Cluster
+	metadata map[string]multicluster.Metadata
+}
+
+// NewProvider returns an empty fake provider.
+func NewProvider() *Provider {
+	return &Provider{
+		clusters: map[string]*Cluster{},
+		metadata: map[string]multicluster.Metadata{},
+	}
+}
+
+// Add adds or replaces a cluster with the given metadata.
+func (p *Provider) Add(name string, cl *Cluster, md multicluster.Metadata) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if _, ok := p.clusters[name]; !ok {
+		p.names = append(p.names, name)
+	}
+	p.clusters[name] = cl
+	p.metadata[name] = md
+}
+
+// Remove removes the cluster, such that it is no longer found.
+func (p *Provider) Remove(name string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if _, ok := p.clusters[name]; !ok {
+		return
+	}
+	delete(p.clusters, name)
+	delete(p.metadata, name)
+	for i, n := range p.names {
+		if n == name {
+			p.names = append(p.names[:i:i], p.names[i+1:]...)
+			break
+		}
+	}
+}
+
+// Names returns the names of all clusters in the order they were added.
+func (p *Provider) Names() []string {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return append([]string(nil), p.names...)
+}
+
+// Get returns the cluster with the given name.
+func (p *Provider) Get(_ context.Context, clusterName string) (cluster.Cluster, error) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	if cl, ok := p.clusters[clusterName]; ok {
+		return cl, nil
+	}
+	return nil, multicluster.ErrClusterNotFound
+}
+
+// GetMetadata returns the metadata of the cluster with the given name.
+func (p *Provider) GetMetadata(_ context.Context, clusterName string) (multicluster.Metadata, error) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	if _, ok := p.clusters[clusterName]; !ok {
+		return multicluster.Metadata{}, multicluster.ErrClusterNotFound
+	}
+	return p.metadata[clusterName], nil
+}
+
+// IndexField does nothing. Field selectors are evaluated by the fake client
+// through the indexes registered on the client builder.
+func (p *Provider) IndexField(context.Context, client.Object, string, client.IndexerFunc) error {
+	return nil
+}
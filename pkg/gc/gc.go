@@ -0,0 +1,236 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gc implements a garbage collector for objects whose owner lives in
+// another cluster. Native Kubernetes garbage collection cannot follow owner
+// references across clusters, hence children are stamped with the
+// cross-cluster owner reference through SetOwnerReference, and deleted by the
+// GarbageCollector once their owner disappears.
+package gc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	mcerrors "sigs.k8s.io/multicluster-runtime/pkg/errors"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+// Options are the options for the GarbageCollector.
+type Options struct {
+	// Owner is the type of the owner objects, e.g. a custom resource in the
+	// hub cluster.
+	Owner client.Object
+
+	// Children are the types of the child objects in the engaged clusters.
+	Children []client.Object
+
+	// Interval is the period between two collections. Defaults to one minute.
+	Interval time.Duration
+
+	// PropagationPolicy is used when deleting orphaned children. Defaults to
+	// background deletion.
+	PropagationPolicy metav1.DeletionPropagation
+
+	// Hub is the identity of this hub as used with Ownership. Children
+	// claimed by other hubs are never collected.
+	Hub string
+}
+
+var _ mcmanager.Runnable = &GarbageCollector{}
+
+// GarbageCollector periodically deletes children in the engaged clusters
+// whose cross-cluster owner does not exist anymore. Add it to the manager
+// with Manager.Add.
+//
+// A child is only deleted when its owner is known to be gone, i.e. the
+// owner cluster answers that the owner does not exist or has another UID.
+// Children are kept when in doubt: when the owner cluster is not engaged,
+// or when it or the provider fails to answer.
+type GarbageCollector struct {
+	mgr      mcmanager.Manager
+	opts     Options
+	log      logr.Logger
+	ownerGVK schema.GroupVersionKind
+	children []schema.GroupVersionKind
+
+	lock     sync.RWMutex
+	clusters map[string]cluster.Cluster
+}
+
+// New returns a new GarbageCollector for the given owner and children types.
+func New(mgr mcmanager.Manager, opts Options) (*GarbageCollector, error) {
+	if opts.Owner == nil {
+		return nil, errors.New("owner type must be set")
+	}
+	if len(opts.Children) == 0 {
+		return nil, errors.New("at least one child type must be set")
+	}
+	if opts.Interval == 0 {
+		opts.Interval = time.Minute
+	}
+	if opts.PropagationPolicy == "" {
+		opts.PropagationPolicy = metav1.DeletePropagationBackground
+	}
+
+	scheme := mgr.GetLocalManager().GetScheme()
+	ownerGVK, err := apiutil.GVKForObject(opts.Owner, scheme)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GVK of owner type %T: %w", opts.Owner, err)
+	}
+	children := make([]schema.GroupVersionKind, 0, len(opts.Children))
+	for _, child := range opts.Children {
+		gvk, err := apiutil.GVKForObject(child, scheme)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get GVK of child type %T: %w", child, err)
+		}
+		children = append(children, gvk)
+	}
+
+	return &GarbageCollector{
+		mgr:      mgr,
+		opts:     opts,
+		log:      log.Log.WithName("cross-cluster-gc").WithValues("ownerKind", ownerGVK.Kind),
+		ownerGVK: ownerGVK,
+		children: children,
+		clusters: map[string]cluster.Cluster{},
+	}, nil
+}
+
+// Engage starts collecting orphans in the given cluster until ctx is done.
+func (gc *GarbageCollector) Engage(ctx context.Context, name string, cl cluster.Cluster) error {
+	gc.lock.Lock()
+	defer gc.lock.Unlock()
+	gc.clusters[name] = cl
+
+	go func() {
+		<-ctx.Done()
+		gc.lock.Lock()
+		defer gc.lock.Unlock()
+		if gc.clusters[name] == cl {
+			delete(gc.clusters, name)
+		}
+	}()
+
+	return nil
+}
+
+// Start runs the garbage collector until ctx is done.
+func (gc *GarbageCollector) Start(ctx context.Context) error {
+	gc.log.Info("Starting cross-cluster garbage collector", "interval", gc.opts.Interval)
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := gc.Collect(ctx); err != nil {
+			gc.log.Error(err, "failed to collect orphaned objects")
+		}
+	}, gc.opts.Interval)
+	return nil
+}
+
+// Collect runs one collection over all engaged clusters.
+func (gc *GarbageCollector) Collect(ctx context.Context) error {
+	gc.lock.RLock()
+	clusters := make(map[string]cluster.Cluster, len(gc.clusters))
+	for name, cl := range gc.clusters {
+		clusters[name] = cl
+	}
+	gc.lock.RUnlock()
+
+	var errs []error
+	for name, cl := range clusters {
+		for _, gvk := range gc.children {
+			if err := gc.collect(ctx, name, cl, gvk); err != nil {
+				errs = append(errs, mcerrors.New(name, "collect "+gvk.Kind, err))
+			}
+		}
+	}
+	return mcerrors.NewAggregate(errs...)
+}
+
+func (gc *GarbageCollector) collect(ctx context.Context, clusterName string, cl cluster.Cluster, gvk schema.GroupVersionKind) error {
+	children := &metav1.PartialObjectMetadataList{}
+	children.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := cl.GetAPIReader().List(ctx, children, client.HasLabels{OwnerUIDLabel}); err != nil {
+		return err
+	}
+
+	var errs []error
+	for i := range children.Items {
+		child := &children.Items[i]
+		child.SetGroupVersionKind(gvk)
+
+		orphaned, err := gc.isOrphaned(ctx, child)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !orphaned {
+			continue
+		}
+
+		gc.log.Info("Deleting orphaned object", "cluster", multicluster.EscapeClusterName(clusterName), "kind", gvk.Kind, "namespace", child.Namespace, "name", child.Name)
+		if err := cl.GetClient().Delete(ctx, child, client.Preconditions{UID: &child.UID}, client.PropagationPolicy(gc.opts.PropagationPolicy)); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, fmt.Errorf("failed to delete %s %s/%s: %w", gvk.Kind, child.Namespace, child.Name, err))
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// isOrphaned returns whether the owner of child is known to be gone. It
+// returns false on every error, which is only returned to be reported, so
+// that children are never deleted on uncertainty.
+func (gc *GarbageCollector) isOrphaned(ctx context.Context, child client.Object) (bool, error) {
+	ref, ok := GetOwnerReference(child)
+	if !ok || !(Ownership{Hub: gc.opts.Hub}).Owned(ref) {
+		return false, nil
+	}
+
+	ownerCluster, err := gc.mgr.GetCluster(ctx, ref.Cluster)
+	if errors.Is(err, multicluster.ErrClusterNotFound) {
+		// the owner cluster might only be disengaged temporarily.
+		return false, nil
+	}
+	if err != nil {
+		// e.g. the provider failing transiently.
+		return false, fmt.Errorf("failed to get owner cluster %q: %w", ref.Cluster, err)
+	}
+
+	owner := &metav1.PartialObjectMetadata{}
+	owner.SetGroupVersionKind(gc.ownerGVK)
+	if err := ownerCluster.GetAPIReader().Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, owner); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to get owner %s/%s in cluster %q: %w", ref.Namespace, ref.Name, ref.Cluster, err)
+	}
+	return owner.UID != ref.UID, nil
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gc
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/fake"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+// failingManager fails to return the clusters in failing.
+type failingManager struct {
+	mcmanager.Manager
+	failing map[string]bool
+}
+
+func (m *failingManager) GetCluster(ctx context.Context, name string) (cluster.Cluster, error) {
+	if m.failing[name] {
+		return nil, errors.New("provider unavailable")
+	}
+	return m.Manager.GetCluster(ctx, name)
+}
+
+var _ = Describe("GarbageCollector", func() {
+	ctx := context.Background()
+	owner := func(name string, uid string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, UID: types.UID(uid)}}
+	}
+	child := func(name, ownerCluster string, o *corev1.ConfigMap) *corev1.Secret {
+		c := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}
+		SetOwnerReference(c, ownerCluster, o)
+		return c
+	}
+	exists := func(mgr *fake.Manager, name string) bool {
+		err := mgr.FakeCluster("spoke").GetClient().Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, &corev1.Secret{})
+		if apierrors.IsNotFound(err) {
+			return false
+		}
+		Expect(err).NotTo(HaveOccurred())
+		return true
+	}
+	collector := func(mgr mcmanager.Manager, spoke cluster.Cluster) *GarbageCollector {
+		gc, err := New(mgr, Options{Owner: &corev1.ConfigMap{}, Children: []client.Object{&corev1.Secret{}}})
+		Expect(err).NotTo(HaveOccurred())
+		ctx, cancel := context.WithCancel(ctx)
+		DeferCleanup(cancel)
+		Expect(gc.Engage(ctx, "spoke", spoke)).To(Succeed())
+		return gc
+	}
+
+	It("deletes children whose owner is gone or was recreated", func() {
+		alive, recreated := owner("alive", "uid-1"), owner("recreated", "uid-2")
+		mgr := fake.NewManagerBuilder().
+			WithCluster("hub", alive, owner("recreated", "uid-3")).
+			WithCluster("spoke",
+				child("of-alive", "hub", alive),
+				child("of-recreated", "hub", recreated),
+				child("of-deleted", "hub", owner("deleted", "uid-4")),
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "unowned"}},
+			).
+			Build()
+
+		Expect(collector(mgr, mgr.FakeCluster("spoke")).Collect(ctx)).To(Succeed())
+		Expect(exists(mgr, "of-alive")).To(BeTrue())
+		Expect(exists(mgr, "of-recreated")).To(BeFalse())
+		Expect(exists(mgr, "of-deleted")).To(BeFalse())
+		Expect(exists(mgr, "unowned")).To(BeTrue())
+	})
+
+	It("follows owner references into the local cluster", func() {
+		alive := owner("alive", "uid-1")
+		mgr := fake.NewManagerBuilder().
+			WithCluster(mcmanager.LocalCluster, alive).
+			WithCluster("spoke",
+				child("of-alive", mcmanager.LocalCluster, alive),
+				child("of-deleted", mcmanager.LocalCluster, owner("deleted", "uid-2")),
+			).
+			Build()
+
+		Expect(collector(mgr, mgr.FakeCluster("spoke")).Collect(ctx)).To(Succeed())
+		Expect(exists(mgr, "of-alive")).To(BeTrue())
+		Expect(exists(mgr, "of-deleted")).To(BeFalse())
+	})
+
+	It("keeps children of owners in disengaged clusters", func() {
+		mgr := fake.NewManagerBuilder().
+			WithCluster("spoke", child("of-gone", "gone", owner("owner", "uid-1"))).
+			Build()
+		_, err := mgr.GetCluster(ctx, "gone")
+		Expect(errors.Is(err, multicluster.ErrClusterNotFound)).To(BeTrue())
+
+		Expect(collector(mgr, mgr.FakeCluster("spoke")).Collect(ctx)).To(Succeed())
+		Expect(exists(mgr, "of-gone")).To(BeTrue())
+	})
+
+	It("keeps children when the owner cluster fails to answer", func() {
+		o := owner("owner", "uid-1")
+		mgr := fake.NewManagerBuilder().
+			WithCluster("hub").
+			WithCluster("flaky").
+			WithInterceptorFuncs("flaky", interceptor.Funcs{
+				Get: func(context.Context, client.WithWatch, client.ObjectKey, client.Object, ...client.GetOption) error {
+					return apierrors.NewServiceUnavailable("overloaded")
+				},
+			}).
+			WithCluster("spoke", child("of-unavailable", "hub", o), child("of-flaky", "flaky", o)).
+			Build()
+
+		err := collector(&failingManager{Manager: mgr, failing: map[string]bool{"hub": true}}, mgr.FakeCluster("spoke")).Collect(ctx)
+		Expect(err).To(MatchError(ContainSubstring("provider unavailable")))
+		Expect(err).To(MatchError(ContainSubstring("overloaded")))
+		Expect(exists(mgr, "of-unavailable")).To(BeTrue())
+		Expect(exists(mgr, "of-flaky")).To(BeTrue())
+	})
+})
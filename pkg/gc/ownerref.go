@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gc
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// OwnerUIDLabel is the label on a child object holding the UID of its
+	// owner in another cluster. It is a label such that children can be
+	// listed efficiently.
+	OwnerUIDLabel = "multicluster.x-k8s.io/owner-uid"
+
+	// OwnerClusterAnnotation is the annotation on a child object holding the
+	// name of the cluster its owner lives in.
+	OwnerClusterAnnotation = "multicluster.x-k8s.io/owner-cluster"
+
+	// OwnerNamespaceAnnotation is the annotation on a child object holding
+	// the namespace of its owner.
+	OwnerNamespaceAnnotation = "multicluster.x-k8s.io/owner-namespace"
+
+	// OwnerNameAnnotation is the annotation on a child object holding the
+	// name of its owner.
+	OwnerNameAnnotation = "multicluster.x-k8s.io/owner-name"
+
+	// OwnerHubAnnotation is the annotation on a child object holding the
+	// identity of the hub that claimed it. It is only set through
+	// Ownership.
+	OwnerHubAnnotation = "multicluster.x-k8s.io/owner-hub"
+)
+
+// OwnerReference points to the owner of an object in another cluster.
+type OwnerReference struct {
+	// Cluster is the name of the cluster the owner lives in.
+	Cluster string
+
+	// Namespace is the namespace of the owner, empty for cluster-scoped owners.
+	Namespace string
+
+	// Name is the name of the owner.
+	Name string
+
+	// UID is the UID of the owner.
+	UID types.UID
+
+	// Hub is the identity of the hub that claimed the child, empty if the
+	// reference was stamped without Ownership.
+	Hub string
+}
+
+// SetOwnerReference stamps the cross-cluster owner reference of owner in the
+// given cluster onto child. The child is not updated on the API server.
+func SetOwnerReference(child client.Object, ownerCluster string, owner client.Object) {
+	labels := child.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[OwnerUIDLabel] = string(owner.GetUID())
+	child.SetLabels(labels)
+
+	annotations := child.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[OwnerClusterAnnotation] = ownerCluster
+	annotations[OwnerNamespaceAnnotation] = owner.GetNamespace()
+	annotations[OwnerNameAnnotation] = owner.GetName()
+	child.SetAnnotations(annotations)
+}
+
+// RemoveOwnerReference removes the cross-cluster owner reference from child.
+// The child is not updated on the API server.
+func RemoveOwnerReference(child client.Object) {
+	labels := child.GetLabels()
+	delete(labels, OwnerUIDLabel)
+	child.SetLabels(labels)
+
+	annotations := child.GetAnnotations()
+	delete(annotations, OwnerClusterAnnotation)
+	delete(annotations, OwnerNamespaceAnnotation)
+	delete(annotations, OwnerNameAnnotation)
+	delete(annotations, OwnerHubAnnotation)
+	child.SetAnnotations(annotations)
+}
+
+// GetOwnerReference returns the cross-cluster owner reference of child, and
+// false if child has none or it is incomplete.
+func GetOwnerReference(child client.Object) (OwnerReference, bool) {
+	uid, ok := child.GetLabels()[OwnerUIDLabel]
+	if !ok || uid == "" {
+		return OwnerReference{}, false
+	}
+	annotations := child.GetAnnotations()
+	cluster, ok := annotations[OwnerClusterAnnotation]
+	if !ok {
+		return OwnerReference{}, false
+	}
+	name, ok := annotations[OwnerNameAnnotation]
+	if !ok || name == "" {
+		return OwnerReference{}, false
+	}
+	return OwnerReference{
+		Cluster:   cluster,
+		Namespace: annotations[OwnerNamespaceAnnotation],
+		Name:      name,
+		UID:       types.UID(uid),
+		Hub:       annotations[OwnerHubAnnotation],
+	}, true
+}
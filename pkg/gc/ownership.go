@@ -0,0 +1,150 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gc
+
+import (
+	"errors"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ErrOwnershipConflict is returned when a child is already owned by another
+// owner or hub.
+var ErrOwnershipConflict = errors.New("ownership conflict")
+
+// ConflictPolicy decides what happens to a child that is claimed by another
+// owner or hub.
+type ConflictPolicy string
+
+const (
+	// ConflictFail leaves the child untouched and returns a ConflictError.
+	ConflictFail ConflictPolicy = "Fail"
+
+	// ConflictAdopt takes the child over from its current owner.
+	ConflictAdopt ConflictPolicy = "Adopt"
+
+	// ConflictOrphan removes the owner reference from the child, such that
+	// no hub garbage collects it anymore.
+	ConflictOrphan ConflictPolicy = "Orphan"
+)
+
+// ConflictError is returned when a child is claimed by another owner or hub.
+type ConflictError struct {
+	// Child is the key of the conflicting child.
+	Child client.ObjectKey
+
+	// Existing is the owner reference the child carries.
+	Existing OwnerReference
+
+	// Hub is the hub that tried to claim the child.
+	Hub string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s is owned by %s %s/%s of hub %q, not claimed by hub %q",
+		e.Child, e.Existing.Cluster, e.Existing.Namespace, e.Existing.Name, e.Existing.Hub, e.Hub)
+}
+
+// Unwrap returns ErrOwnershipConflict.
+func (e *ConflictError) Unwrap() error {
+	return ErrOwnershipConflict
+}
+
+// Ownership stamps, verifies and adopts cross-cluster owner references on
+// behalf of one hub. Several hubs managing the same clusters must use
+// distinct identities to detect each other's children.
+type Ownership struct {
+	// Hub is the identity of the hub, e.g. the name of its management
+	// cluster. Children stamped by a hub with an empty identity are
+	// considered to belong to any hub.
+	Hub string
+
+	// Policy decides what Claim does with children owned by another owner
+	// or hub. Defaults to ConflictFail.
+	Policy ConflictPolicy
+}
+
+// Stamp sets the owner reference of owner in the given cluster and the hub
+// identity onto child. The child is not updated on the API server.
+func (o Ownership) Stamp(child client.Object, ownerCluster string, owner client.Object) {
+	SetOwnerReference(child, ownerCluster, owner)
+	if o.Hub == "" {
+		return
+	}
+	annotations := child.GetAnnotations()
+	annotations[OwnerHubAnnotation] = o.Hub
+	child.SetAnnotations(annotations)
+}
+
+// Verify returns nil if child is owned by owner in the given cluster through
+// this hub, and a ConflictError if it is claimed by another owner or hub.
+// Unowned children are reported as a conflict with an empty reference.
+func (o Ownership) Verify(child client.Object, ownerCluster string, owner client.Object) error {
+	ref, ok := GetOwnerReference(child)
+	if ok && o.owns(ref, ownerCluster, owner) && ref.UID == owner.GetUID() {
+		return nil
+	}
+	return &ConflictError{Child: client.ObjectKeyFromObject(child), Existing: ref, Hub: o.Hub}
+}
+
+// Claim makes child owned by owner in the given cluster, and returns whether
+// child was changed and must be updated on the API server. Unowned children,
+// and children whose owner with the same name was recreated, are adopted.
+// Children of other owners or hubs are handled according to the policy.
+func (o Ownership) Claim(child client.Object, ownerCluster string, owner client.Object) (bool, error) {
+	ref, ok := GetOwnerReference(child)
+	switch {
+	case !ok:
+		o.Stamp(child, ownerCluster, owner)
+		return true, nil
+	case o.owns(ref, ownerCluster, owner):
+		if ref.UID == owner.GetUID() && ref.Hub == o.Hub {
+			return false, nil
+		}
+		o.Stamp(child, ownerCluster, owner)
+		return true, nil
+	}
+
+	switch o.Policy {
+	case ConflictAdopt:
+		o.Stamp(child, ownerCluster, owner)
+		return true, nil
+	case ConflictOrphan:
+		RemoveOwnerReference(child)
+		return true, nil
+	case ConflictFail, "":
+		return false, &ConflictError{Child: client.ObjectKeyFromObject(child), Existing: ref, Hub: o.Hub}
+	default:
+		return false, fmt.Errorf("unknown conflict policy %q", o.Policy)
+	}
+}
+
+// Owned returns whether ref was stamped by this hub, or by a hub without
+// identity.
+func (o Ownership) Owned(ref OwnerReference) bool {
+	return ref.Hub == "" || o.Hub == "" || ref.Hub == o.Hub
+}
+
+// owns returns whether ref points to owner in the given cluster through this
+// hub, ignoring the UID.
+func (o Ownership) owns(ref OwnerReference, ownerCluster string, owner client.Object) bool {
+	return o.Owned(ref) &&
+		ref.Cluster == ownerCluster &&
+		ref.Namespace == owner.GetNamespace() &&
+		ref.Name == owner.GetName()
+}
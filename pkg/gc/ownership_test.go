@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gc
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Ownership", func() {
+	owner := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "owner", UID: "uid-1"}}
+	other := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "other", UID: "uid-2"}}
+	hubA := Ownership{Hub: "a"}
+
+	var child *corev1.Secret
+	BeforeEach(func() {
+		child = &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "child"}}
+	})
+
+	It("stamps the hub identity", func() {
+		hubA.Stamp(child, "hub", owner)
+		ref, ok := GetOwnerReference(child)
+		Expect(ok).To(BeTrue())
+		Expect(ref).To(Equal(OwnerReference{Cluster: "hub", Namespace: "default", Name: "owner", UID: "uid-1", Hub: "a"}))
+		Expect(hubA.Verify(child, "hub", owner)).To(Succeed())
+	})
+
+	It("adopts unowned children", func() {
+		Expect(hubA.Verify(child, "hub", owner)).To(MatchError(ErrOwnershipConflict))
+		changed, err := hubA.Claim(child, "hub", owner)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).To(BeTrue())
+
+		changed, err = hubA.Claim(child, "hub", owner)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).To(BeFalse())
+	})
+
+	It("refreshes the UID of a recreated owner", func() {
+		hubA.Stamp(child, "hub", owner)
+		recreated := owner.DeepCopy()
+		recreated.UID = "uid-3"
+		Expect(hubA.Verify(child, "hub", recreated)).To(MatchError(ErrOwnershipConflict))
+		changed, err := hubA.Claim(child, "hub", recreated)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).To(BeTrue())
+		Expect(hubA.Verify(child, "hub", recreated)).To(Succeed())
+	})
+
+	It("fails on children of other hubs by default", func() {
+		Ownership{Hub: "b"}.Stamp(child, "hub", owner)
+		changed, err := hubA.Claim(child, "hub", owner)
+		Expect(changed).To(BeFalse())
+		var conflict *ConflictError
+		Expect(err).To(BeAssignableToTypeOf(conflict))
+		Expect(err.(*ConflictError).Existing.Hub).To(Equal("b"))
+	})
+
+	It("fails on children of other owners by default", func() {
+		hubA.Stamp(child, "hub", other)
+		_, err := hubA.Claim(child, "hub", owner)
+		Expect(err).To(MatchError(ErrOwnershipConflict))
+	})
+
+	It("adopts conflicting children with the adopt policy", func() {
+		Ownership{Hub: "b"}.Stamp(child, "hub", other)
+		changed, err := Ownership{Hub: "a", Policy: ConflictAdopt}.Claim(child, "hub", owner)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).To(BeTrue())
+		Expect(hubA.Verify(child, "hub", owner)).To(Succeed())
+	})
+
+	It("orphans conflicting children with the orphan policy", func() {
+		Ownership{Hub: "b"}.Stamp(child, "hub", owner)
+		changed, err := Ownership{Hub: "a", Policy: ConflictOrphan}.Claim(child, "hub", owner)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).To(BeTrue())
+		_, ok := GetOwnerReference(child)
+		Expect(ok).To(BeFalse())
+		Expect(child.Annotations).NotTo(HaveKey(OwnerHubAnnotation))
+	})
+
+	It("treats children without hub identity as owned by any hub", func() {
+		SetOwnerReference(child, "hub", owner)
+		Expect(hubA.Verify(child, "hub", owner)).To(Succeed())
+		changed, err := hubA.Claim(child, "hub", owner)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).To(BeTrue())
+		Expect(child.Annotations).To(HaveKeyWithValue(OwnerHubAnnotation, "a"))
+	})
+})
@@ -0,0 +1,146 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"context"
+	"runtime"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	mcerrors "sigs.k8s.io/multicluster-runtime/pkg/errors"
+	mcmetrics "sigs.k8s.io/multicluster-runtime/pkg/metrics"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+// BackpressureOptions configure delaying engagements while the manager is
+// overloaded, e.g. during a registration storm.
+type BackpressureOptions struct {
+	// MaxQueueDepth is the total depth of the queues of all controllers
+	// above which engagements are delayed. Zero means unlimited.
+	MaxQueueDepth int
+
+	// MaxHeapBytes is the size of the heap above which engagements are
+	// delayed. Zero means unlimited.
+	MaxHeapBytes uint64
+
+	// Interval is the interval in which the pressure is checked again while
+	// engagements are delayed. Defaults to 5 seconds.
+	Interval time.Duration
+
+	// QueueDepth returns the total depth of the queues. Defaults to the sum
+	// of the workqueue_depth metric of the controller-runtime registry.
+	QueueDepth func() (int, error)
+
+	// HeapBytes returns the size of the heap. Defaults to the allocated
+	// heap bytes of the Go runtime.
+	HeapBytes func() uint64
+}
+
+// WithBackpressure delays engaging clusters while the queues or the heap
+// of the manager are above the thresholds of opts, and resumes when the
+// pressure subsided. Engagements already in progress are not interrupted.
+func WithBackpressure(opts BackpressureOptions) Option {
+	return func(o *MultiClusterOptions) {
+		o.Backpressure = &opts
+	}
+}
+
+// waitForPressure blocks until the manager is below the backpressure
+// thresholds or ctx is done.
+func (m *mcManager) waitForPressure(ctx context.Context, name string) error {
+	opts := m.opts.Backpressure
+	if opts == nil {
+		return nil
+	}
+	reason := m.pressure(opts)
+	if reason == "" {
+		return nil
+	}
+
+	m.GetLogger().Info("Delaying engagement while overloaded", "cluster", multicluster.EscapeClusterName(name), "reason", reason)
+	mcmetrics.EngagementsDelayed.Inc()
+	defer mcmetrics.EngagementsDelayed.Dec()
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return mcerrors.New(name, "engage", ctx.Err())
+		}
+		if m.pressure(opts) == "" {
+			return nil
+		}
+	}
+}
+
+// pressure returns why the manager is overloaded, or an empty string.
+func (m *mcManager) pressure(opts *BackpressureOptions) string {
+	if opts.MaxQueueDepth > 0 {
+		queueDepth := opts.QueueDepth
+		if queueDepth == nil {
+			queueDepth = totalQueueDepth
+		}
+		depth, err := queueDepth()
+		if err != nil {
+			m.GetLogger().Error(err, "Failed to get queue depth, ignoring it for backpressure")
+		} else if depth > opts.MaxQueueDepth {
+			return "QueueDepth"
+		}
+	}
+	if opts.MaxHeapBytes > 0 {
+		heapBytes := opts.HeapBytes
+		if heapBytes == nil {
+			heapBytes = allocatedHeapBytes
+		}
+		if heapBytes() > opts.MaxHeapBytes {
+			return "HeapBytes"
+		}
+	}
+	return ""
+}
+
+// totalQueueDepth sums the depth of all queues registered with the
+// controller-runtime metrics registry.
+func totalQueueDepth() (int, error) {
+	families, err := metrics.Registry.Gather()
+	if err != nil {
+		return 0, err
+	}
+	var depth float64
+	for _, f := range families {
+		if f.GetName() != metrics.WorkQueueSubsystem+"_"+metrics.DepthKey {
+			continue
+		}
+		for _, mf := range f.GetMetric() {
+			depth += mf.GetGauge().GetValue()
+		}
+	}
+	return int(depth), nil
+}
+
+func allocatedHeapBytes() uint64 {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats.HeapAlloc
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	mccluster "sigs.k8s.io/multicluster-runtime/pkg/cluster"
+	mcmetrics "sigs.k8s.io/multicluster-runtime/pkg/metrics"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+// ErrMemoryBudgetExceeded is returned when a cluster is not engaged because
+// the caches of the engaged clusters exceed the memory budget.
+var ErrMemoryBudgetExceeded = errors.New("memory budget exceeded")
+
+// GetCacheStats returns the statistics of the cache of the cluster with the
+// given name.
+func (m *mcManager) GetCacheStats(ctx context.Context, clusterName string) (mccluster.CacheStats, error) {
+	cl, err := m.GetCluster(ctx, clusterName)
+	if err != nil {
+		return mccluster.CacheStats{}, err
+	}
+	stats, ok, err := mccluster.GetCacheStats(ctx, cl)
+	if err != nil {
+		return mccluster.CacheStats{}, err
+	}
+	if !ok {
+		return mccluster.CacheStats{}, fmt.Errorf("cluster %q has no cache accounting", clusterName)
+	}
+	return stats, nil
+}
+
+// GetInformerReferences returns the references to the informers of the
+// cache of the cluster with the given name.
+func (m *mcManager) GetInformerReferences(ctx context.Context, clusterName string) ([]mccluster.InformerReferences, error) {
+	cl, err := m.GetCluster(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	refs, ok := mccluster.GetInformerReferences(cl)
+	if !ok {
+		return nil, fmt.Errorf("cluster %q does not count informer references", clusterName)
+	}
+	return refs, nil
+}
+
+// checkMemoryBudget returns an error if the caches of the engaged clusters
+// exceed the memory budget.
+func (m *mcManager) checkMemoryBudget(name string) error {
+	if m.opts.MemoryBudget <= 0 {
+		return nil
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	var total int64
+	for _, b := range m.cacheBytes {
+		total += b
+	}
+	if total >= m.opts.MemoryBudget {
+		return fmt.Errorf("failed to engage cluster %q: %w: %d of %d bytes used", name, ErrMemoryBudgetExceeded, total, m.opts.MemoryBudget)
+	}
+	return nil
+}
+
+// watchCacheStats refreshes the cache statistics of the cluster until it is
+// disengaged.
+func (m *mcManager) watchCacheStats(ctx context.Context, name string, cl cluster.Cluster) {
+	log := m.GetLogger().WithValues("cluster", multicluster.EscapeClusterName(name))
+	label := mcmetrics.ClusterLabel(name)
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		stats, _, err := mccluster.GetCacheStats(ctx, cl)
+		if err != nil {
+			log.Error(err, "Failed to get cache statistics")
+			return
+		}
+		mcmetrics.ClusterCacheInformers.WithLabelValues(label).Set(float64(stats.Informers))
+		mcmetrics.ClusterCacheBytes.WithLabelValues(label).Set(float64(stats.ApproximateBytes))
+		for gvk, n := range stats.Objects {
+			mcmetrics.ClusterCacheObjects.WithLabelValues(label, gvk.String()).Set(float64(n))
+		}
+
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		m.cacheBytes[name] = stats.ApproximateBytes
+	}, m.opts.CacheStatsInterval)
+
+	m.lock.Lock()
+	delete(m.cacheBytes, name)
+	m.lock.Unlock()
+	mcmetrics.ClusterCacheInformers.DeleteLabelValues(label)
+	mcmetrics.ClusterCacheBytes.DeleteLabelValues(label)
+	mcmetrics.ClusterCacheObjects.DeletePartialMatch(prometheus.Labels{"cluster": label})
+}
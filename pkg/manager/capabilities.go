@@ -0,0 +1,147 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"context"
+	"maps"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	mccluster "sigs.k8s.io/multicluster-runtime/pkg/cluster"
+	mcerrors "sigs.k8s.io/multicluster-runtime/pkg/errors"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+// CapabilityLabelPrefix prefixes the names of the probed capabilities in
+// the labels of the cluster metadata. The label is "true" or "false", and
+// absent if the capability could not be probed.
+const CapabilityLabelPrefix = "capability.multicluster.x-k8s.io/"
+
+// CapabilityOptions configure probing the capabilities of the engaged
+// clusters.
+type CapabilityOptions struct {
+	// Probes are the capabilities probed.
+	Probes []mccluster.CapabilityProbe
+
+	// Interval is the interval in which the capabilities are probed again.
+	// Zero means they are only probed on engagement and on demand.
+	Interval time.Duration
+
+	// Timeout bounds probing all capabilities of a cluster. Defaults to 30
+	// seconds.
+	Timeout time.Duration
+}
+
+// WithCapabilityProbes probes the capabilities of every cluster when it is
+// engaged, before the components of the manager are engaged with it, and
+// adds the results to the labels of its metadata, such that reconcilers
+// and cluster selectors can rely on them.
+func WithCapabilityProbes(opts CapabilityOptions) Option {
+	return func(o *MultiClusterOptions) {
+		o.Capabilities = &opts
+	}
+}
+
+// ProbeCapabilities probes the capabilities of the engaged cluster with the
+// given name again.
+func (m *mcManager) ProbeCapabilities(ctx context.Context, clusterName string) error {
+	m.lock.Lock()
+	e, ok := m.engaged[clusterName]
+	m.lock.Unlock()
+	if !ok {
+		return mcerrors.New(clusterName, "probe capabilities", multicluster.ErrClusterNotFound)
+	}
+	m.probeCapabilities(ctx, e.ctx, clusterName, e.cluster)
+	return nil
+}
+
+// engageCapabilities probes the capabilities of the cluster, and probes
+// them again in the configured interval until ctx is done.
+func (m *mcManager) engageCapabilities(ctx context.Context, name string, cl cluster.Cluster) {
+	opts := m.opts.Capabilities
+	if opts == nil || len(opts.Probes) == 0 {
+		return
+	}
+	m.probeCapabilities(ctx, ctx, name, cl)
+	go func() {
+		if opts.Interval > 0 {
+			wait.UntilWithContext(ctx, func(ctx context.Context) {
+				m.probeCapabilities(ctx, ctx, name, cl)
+			}, opts.Interval)
+		}
+		<-ctx.Done()
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		if e, ok := m.engaged[name]; !ok || e.ctx.Err() != nil {
+			delete(m.capabilities, name)
+		}
+	}()
+}
+
+// probeCapabilities runs all probes against the cluster and remembers the
+// results unless the engagement ended, i.e. live is done. Failed
+// probes are logged, and their capability is unknown.
+func (m *mcManager) probeCapabilities(ctx, live context.Context, name string, cl cluster.Cluster) {
+	opts := m.opts.Capabilities
+	if opts == nil {
+		return
+	}
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	results := map[string]string{}
+	for _, p := range opts.Probes {
+		ok, err := p.Probe(ctx, cl)
+		if err != nil {
+			m.GetLogger().Error(err, "Failed to probe capability", "cluster", multicluster.EscapeClusterName(name), "capability", p.Name)
+			continue
+		}
+		results[CapabilityLabelPrefix+p.Name] = strconv.FormatBool(ok)
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if live.Err() == nil {
+		m.capabilities[name] = results
+	}
+}
+
+// withCapabilities adds the capabilities of the cluster to the labels of
+// md.
+func (m *mcManager) withCapabilities(clusterName string, md multicluster.Metadata) multicluster.Metadata {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	caps, ok := m.capabilities[clusterName]
+	if !ok {
+		return md
+	}
+	labels := maps.Clone(md.Labels)
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	maps.Copy(labels, caps)
+	md.Labels = labels
+	return md
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	mccluster "sigs.k8s.io/multicluster-runtime/pkg/cluster"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+// GetClusterVersion returns the Kubernetes version of the cluster with the
+// given name.
+func (m *mcManager) GetClusterVersion(ctx context.Context, clusterName string) (*version.Info, error) {
+	m.lock.Lock()
+	v, ok := m.versions[clusterName]
+	m.lock.Unlock()
+	if ok {
+		return v, nil
+	}
+	cl, err := m.GetCluster(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	return mccluster.ServerVersion(cl)
+}
+
+// UpdateCluster switches the cluster to the endpoint and credentials of
+// cfg, if it supports updates.
+func (m *mcManager) UpdateCluster(ctx context.Context, clusterName string, cfg *rest.Config) error {
+	cl, err := m.GetCluster(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+	if err := mccluster.UpdateConfig(cl, cfg); err != nil {
+		return err
+	}
+	// the endpoint might serve a different version now.
+	m.lock.Lock()
+	delete(m.versions, clusterName)
+	m.lock.Unlock()
+	return nil
+}
+
+// cacheVersion remembers the version of the cluster until it is
+// disengaged. Failures are logged only, the version is looked up again on
+// demand.
+func (m *mcManager) cacheVersion(ctx context.Context, name string, cl cluster.Cluster) {
+	v, err := mccluster.ServerVersion(cl)
+	if err != nil {
+		m.GetLogger().Error(err, "Failed to get cluster version", "cluster", multicluster.EscapeClusterName(name))
+		return
+	}
+	m.lock.Lock()
+	m.versions[name] = v
+	m.lock.Unlock()
+	go func() {
+		<-ctx.Done()
+		m.lock.Lock()
+		if m.versions[name] == v {
+			delete(m.versions, name)
+		}
+		m.lock.Unlock()
+	}()
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	mccluster "sigs.k8s.io/multicluster-runtime/pkg/cluster"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+// WithDryRun forces all writes to the given clusters into server-side
+// dry-run, logging the changes that would have been made. It applies to
+// the clusters returned by GetCluster and passed to the engaged runnables,
+// such that whole controllers can be canaried against production clusters
+// without mutating them.
+func WithDryRun(clusterNames ...string) Option {
+	names := make(map[string]struct{}, len(clusterNames))
+	for _, name := range clusterNames {
+		names[name] = struct{}{}
+	}
+	return WithDryRunFunc(func(clusterName string) bool {
+		_, ok := names[clusterName]
+		return ok
+	})
+}
+
+// WithDryRunFunc forces all writes to the clusters for which dryRun returns
+// true into server-side dry-run, like WithDryRun.
+func WithDryRunFunc(dryRun func(clusterName string) bool) Option {
+	return func(o *MultiClusterOptions) {
+		o.DryRun = dryRun
+	}
+}
+
+// dryRun wraps the cluster with the given name for dry-run if configured.
+func (m *mcManager) dryRun(name string, cl cluster.Cluster) cluster.Cluster {
+	if m.opts.DryRun == nil || !m.opts.DryRun(name) {
+		return cl
+	}
+	return mccluster.NewDryRun(cl, m.GetLogger().WithValues("cluster", multicluster.EscapeClusterName(name)))
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"context"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	mcerrors "sigs.k8s.io/multicluster-runtime/pkg/errors"
+)
+
+// acquireEngagement waits for a free engagement slot and the stagger
+// interval. The returned function releases the slot.
+func (m *mcManager) acquireEngagement(ctx context.Context, name string) (func(), error) {
+	if m.engageSlots != nil {
+		select {
+		case m.engageSlots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, mcerrors.New(name, "engage", ctx.Err())
+		}
+	}
+	release := func() {
+		if m.engageSlots != nil {
+			<-m.engageSlots
+		}
+	}
+
+	if m.opts.EngagementStagger > 0 {
+		m.staggerLock.Lock()
+		defer m.staggerLock.Unlock()
+		if wait := time.Until(m.lastEngage.Add(m.opts.EngagementStagger)); wait > 0 {
+			t := time.NewTimer(wait)
+			defer t.Stop()
+			select {
+			case <-t.C:
+			case <-ctx.Done():
+				release()
+				return nil, mcerrors.New(name, "engage", ctx.Err())
+			}
+		}
+		m.lastEngage = time.Now()
+	}
+
+	return release, nil
+}
+
+// releaseAfterSync releases the engagement slot and failure domain once the
+// cache of the cluster synced the informers started by the engagement, or
+// the sync timeout passed.
+func (m *mcManager) releaseAfterSync(ctx context.Context, cl cluster.Cluster, release func()) {
+	defer release()
+	if m.engageSlots == nil && m.opts.FailureDomains == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, m.opts.EngagementSyncTimeout)
+	defer cancel()
+	if !cl.GetCache().WaitForCacheSync(ctx) {
+		m.GetLogger().Info("Cache did not sync in time, releasing engagement slot", "timeout", m.opts.EngagementSyncTimeout)
+	}
+}
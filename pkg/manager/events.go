@@ -0,0 +1,236 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"context"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+// ClusterEventType is the type of a ClusterEvent.
+type ClusterEventType string
+
+const (
+	// ClusterEngaged is delivered when a cluster was engaged with all
+	// components of the manager.
+	ClusterEngaged ClusterEventType = "Engaged"
+
+	// ClusterSynced is delivered when the cache of an engaged cluster
+	// synced the informers started by its engagement.
+	ClusterSynced ClusterEventType = "Synced"
+
+	// ClusterUnhealthy is delivered when a cluster health check added with
+	// AddClusterHealthzCheck fails for a cluster that passed all checks
+	// before.
+	ClusterUnhealthy ClusterEventType = "Unhealthy"
+
+	// ClusterDisengaged is delivered when a cluster was disengaged.
+	ClusterDisengaged ClusterEventType = "Disengaged"
+)
+
+// ClusterEvent is a change of the membership or health of a cluster of the
+// fleet.
+type ClusterEvent struct {
+	// Type is the type of the event.
+	Type ClusterEventType
+
+	// Name is the name of the cluster.
+	Name string
+
+	// Provider is the name of the provider that engaged the cluster, if it
+	// was added with WithProviderName.
+	Provider string
+
+	// Metadata is the metadata of the cluster when it was engaged.
+	Metadata multicluster.Metadata
+
+	// Err is the failed health check of ClusterUnhealthy events.
+	Err error
+}
+
+// clusterEventBuffer is the number of events buffered for a subscriber
+// before the buffer is coalesced to the latest event of each cluster.
+const clusterEventBuffer = 1024
+
+// SubscribeClusterEvents returns a channel delivering the cluster events
+// until ctx is done, when it is closed. Events are buffered for slow
+// consumers, they never block the manager. If a consumer falls behind by
+// more than clusterEventBuffer events, only the latest event of each
+// cluster is kept.
+func (m *mcManager) SubscribeClusterEvents(ctx context.Context) <-chan ClusterEvent {
+	s := &subscription{notify: make(chan struct{}, 1)}
+	ch := make(chan ClusterEvent)
+
+	m.lock.Lock()
+	// replay the clusters engaged so far. Engagements still in progress
+	// are delivered by publishEngaged.
+	for name, e := range m.engaged {
+		if e.published {
+			s.add(ClusterEvent{Type: ClusterEngaged, Name: name, Provider: e.provider, Metadata: e.metadata})
+		}
+	}
+	m.subscriptions = append(m.subscriptions, s)
+	m.lock.Unlock()
+	s.wake()
+
+	go func() {
+		defer close(ch)
+		defer m.unsubscribe(s)
+		for {
+			select {
+			case <-s.notify:
+			case <-ctx.Done():
+				return
+			}
+			for evt, ok := s.next(); ok; evt, ok = s.next() {
+				select {
+				case ch <- evt:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return ch
+}
+
+// publish delivers evt to all subscriptions.
+func (m *mcManager) publish(evt ClusterEvent) {
+	m.lock.Lock()
+	subs := append([]*subscription(nil), m.subscriptions...)
+	m.lock.Unlock()
+	for _, s := range subs {
+		s.add(evt)
+	}
+}
+
+// publishEngaged delivers ClusterEngaged for the engagement of cl, and
+// marks it as published so that it is neither replayed to subscribers that
+// received it already, nor disengaged without being engaged.
+func (m *mcManager) publishEngaged(name string, cl cluster.Cluster) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	e, ok := m.engaged[name]
+	if !ok || e.cluster != cl {
+		return
+	}
+	e.published = true
+	m.engaged[name] = e
+	for _, s := range m.subscriptions {
+		s.add(ClusterEvent{Type: ClusterEngaged, Name: name, Provider: e.provider, Metadata: e.metadata})
+	}
+}
+
+func (m *mcManager) unsubscribe(s *subscription) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for i, other := range m.subscriptions {
+		if other == s {
+			m.subscriptions = append(m.subscriptions[:i:i], m.subscriptions[i+1:]...)
+			return
+		}
+	}
+}
+
+// publishSynced delivers ClusterSynced once the cache of the cluster
+// synced, unless the cluster is disengaged before.
+func (m *mcManager) publishSynced(ctx context.Context, name string, cl cluster.Cluster, provider string, md multicluster.Metadata) {
+	if cl.GetCache().WaitForCacheSync(ctx) && ctx.Err() == nil {
+		m.publish(ClusterEvent{Type: ClusterSynced, Name: name, Provider: provider, Metadata: md})
+	}
+}
+
+// setHealth records the health of an engaged cluster, and delivers
+// ClusterUnhealthy when it turns unhealthy.
+func (m *mcManager) setHealth(name string, err error) {
+	m.lock.Lock()
+	e, ok := m.engaged[name]
+	if !ok || !e.published || (err != nil) == m.unhealthy[name] {
+		m.lock.Unlock()
+		return
+	}
+	if err == nil {
+		delete(m.unhealthy, name)
+		m.lock.Unlock()
+		return
+	}
+	m.unhealthy[name] = true
+	m.lock.Unlock()
+	m.publish(ClusterEvent{Type: ClusterUnhealthy, Name: name, Provider: e.provider, Metadata: e.metadata, Err: err})
+}
+
+// subscription buffers the events of a subscriber.
+type subscription struct {
+	notify chan struct{}
+
+	lock    sync.Mutex
+	pending []ClusterEvent
+}
+
+// add buffers evt, coalescing the buffer if it is full.
+func (s *subscription) add(evt ClusterEvent) {
+	s.lock.Lock()
+	if len(s.pending) >= clusterEventBuffer {
+		s.pending = coalesce(s.pending)
+	}
+	if len(s.pending) >= clusterEventBuffer {
+		// more clusters than buffered events, drop the oldest.
+		s.pending = s.pending[1:]
+	}
+	s.pending = append(s.pending, evt)
+	s.lock.Unlock()
+	s.wake()
+}
+
+// coalesce keeps the latest event of each cluster, in order.
+func coalesce(evts []ClusterEvent) []ClusterEvent {
+	latest := make(map[string]int, len(evts))
+	for i, evt := range evts {
+		latest[evt.Name] = i
+	}
+	out := make([]ClusterEvent, 0, len(latest))
+	for i, evt := range evts {
+		if latest[evt.Name] == i {
+			out = append(out, evt)
+		}
+	}
+	return out
+}
+
+func (s *subscription) wake() {
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+// next takes the oldest buffered event, if any. Events are taken one by one
+// so that the events not delivered yet stay subject to coalescing.
+func (s *subscription) next() (ClusterEvent, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if len(s.pending) == 0 {
+		return ClusterEvent{}, false
+	}
+	evt := s.pending[0]
+	s.pending = s.pending[1:]
+	return evt, true
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	mcerrors "sigs.k8s.io/multicluster-runtime/pkg/errors"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+// FailureDomainLabel is the label or annotation of clusters naming their
+// failure domain, e.g. a region or an availability zone.
+const FailureDomainLabel = "multicluster.x-k8s.io/failure-domain"
+
+// FailureDomainOptions configure engaging clusters one failure domain at a
+// time.
+type FailureDomainOptions struct {
+	// Domain returns the failure domain of a cluster. Defaults to the value
+	// of FailureDomainLabel in the labels, or else the annotations of the
+	// cluster metadata. Clusters without failure domain form a domain of
+	// their own.
+	Domain func(clusterName string, md multicluster.Metadata) string
+}
+
+// WithFailureDomains engages clusters one failure domain at a time: an
+// engagement waits while clusters of another failure domain are being
+// engaged, until their caches synced or the engagement sync timeout
+// passed. Waiting domains are engaged in the order they arrived.
+//
+// Mass engagements on startup and rolling re-engagements, e.g. with
+// Manager.ReEngage, thereby process one failure domain after the other,
+// which limits the blast radius of a bug of the hub.
+func WithFailureDomains(opts FailureDomainOptions) Option {
+	return func(o *MultiClusterOptions) {
+		o.FailureDomains = &opts
+	}
+}
+
+// DefaultFailureDomain returns the value of FailureDomainLabel in the
+// labels, or else the annotations of md.
+func DefaultFailureDomain(_ string, md multicluster.Metadata) string {
+	if domain, ok := md.Labels[FailureDomainLabel]; ok {
+		return domain
+	}
+	return md.Annotations[FailureDomainLabel]
+}
+
+// acquireFailureDomain waits until the failure domain of the cluster is
+// engaged. The returned function releases the engagement.
+func (m *mcManager) acquireFailureDomain(ctx context.Context, name string) (func(), error) {
+	opts := m.opts.FailureDomains
+	if opts == nil {
+		return func() {}, nil
+	}
+	md, err := m.GetClusterMetadata(ctx, name)
+	if err != nil {
+		md = multicluster.Metadata{}
+	}
+	domainOf := opts.Domain
+	if domainOf == nil {
+		domainOf = DefaultFailureDomain
+	}
+	domain := domainOf(name, md)
+	if err := m.domains.acquire(ctx, domain); err != nil {
+		return nil, mcerrors.New(name, "engage", err)
+	}
+	if domain != "" {
+		m.GetLogger().V(1).Info("Engaging in failure domain", "cluster", multicluster.EscapeClusterName(name), "failureDomain", domain)
+	}
+	var once sync.Once
+	return func() { once.Do(m.domains.release) }, nil
+}
+
+// domainGate admits the engagements of one failure domain at a time.
+type domainGate struct {
+	lock    sync.Mutex
+	active  string
+	running int
+	waiting []*domainWaiter
+}
+
+type domainWaiter struct {
+	domain string
+	ready  chan struct{}
+}
+
+// acquire waits until the domain is admitted.
+func (g *domainGate) acquire(ctx context.Context, domain string) error {
+	g.lock.Lock()
+	if len(g.waiting) == 0 && (g.running == 0 || g.active == domain) {
+		g.active = domain
+		g.running++
+		g.lock.Unlock()
+		return nil
+	}
+	w := &domainWaiter{domain: domain, ready: make(chan struct{})}
+	g.waiting = append(g.waiting, w)
+	g.lock.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+	}
+	g.lock.Lock()
+	if i := slices.Index(g.waiting, w); i >= 0 {
+		g.waiting = slices.Delete(g.waiting, i, i+1)
+		g.lock.Unlock()
+		return ctx.Err()
+	}
+	g.lock.Unlock()
+	// admitted meanwhile.
+	g.release()
+	return ctx.Err()
+}
+
+// release ends an admitted engagement, and admits the next waiting domain
+// after the last one.
+func (g *domainGate) release() {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.running--
+	if g.running > 0 {
+		return
+	}
+	g.active = ""
+	if len(g.waiting) == 0 {
+		return
+	}
+	g.active = g.waiting[0].domain
+	g.waiting = slices.DeleteFunc(g.waiting, func(w *domainWaiter) bool {
+		if w.domain != g.active {
+			return false
+		}
+		g.running++
+		close(w.ready)
+		return true
+	})
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	mccluster "sigs.k8s.io/multicluster-runtime/pkg/cluster"
+	mcerrors "sigs.k8s.io/multicluster-runtime/pkg/errors"
+	mcmetrics "sigs.k8s.io/multicluster-runtime/pkg/metrics"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+// ClustersPath is the path of the metrics server listing the engaged
+// clusters and the results of their health checks.
+const ClustersPath = "/clusters"
+
+// ClusterChecker checks the health of a single engaged cluster, e.g.
+// whether it was reconciled successfully recently.
+type ClusterChecker func(req *http.Request, clusterName string) error
+
+// ClusterStatus is the health of an engaged cluster served on ClustersPath.
+type ClusterStatus struct {
+	// Name is the name of the cluster.
+	Name string `json:"name"`
+
+	// Provider is the name of the provider that engaged the cluster, if it
+	// was added with WithProviderName.
+	Provider string `json:"provider,omitempty"`
+
+	// Shard is the shard the cluster is assigned to, if the manager
+	// publishes its membership with a shard function.
+	Shard string `json:"shard,omitempty"`
+
+	// Healthy is whether all checks passed.
+	Healthy bool `json:"healthy"`
+
+	// Checks are the failed checks with their error.
+	Checks map[string]string `json:"checks,omitempty"`
+
+	// Informers are the references to the informers of the cluster, if it
+	// was created with mccluster.WithInformerReferences.
+	Informers []mccluster.InformerReferences `json:"informers,omitempty"`
+}
+
+// AddClusterHealthzCheck adds a health check that runs check for every
+// engaged cluster, and fails if any of the clusters fails. The first check
+// also serves the health of every cluster on ClustersPath of the metrics
+// server.
+func (m *mcManager) AddClusterHealthzCheck(name string, check ClusterChecker) error {
+	m.lock.Lock()
+	first := len(m.clusterChecks) == 0
+	m.lock.Unlock()
+	if first {
+		if err := m.AddMetricsServerExtraHandler(ClustersPath, http.HandlerFunc(m.serveClusters)); err != nil {
+			return err
+		}
+	}
+	if err := m.AddHealthzCheck(name, func(req *http.Request) error {
+		var errs []error
+		for _, clusterName := range m.engagedClusters() {
+			err := check(req, clusterName)
+			m.setHealth(clusterName, err)
+			errs = append(errs, mcerrors.New(clusterName, "check "+name, err))
+		}
+		return mcerrors.NewAggregate(errs...)
+	}); err != nil {
+		return err
+	}
+	m.lock.Lock()
+	m.clusterChecks[name] = check
+	m.lock.Unlock()
+	return nil
+}
+
+func (m *mcManager) serveClusters(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(m.clusterStatuses(req))
+}
+
+// clusterStatuses runs the cluster checks for all engaged clusters.
+func (m *mcManager) clusterStatuses(req *http.Request) []ClusterStatus {
+	m.lock.Lock()
+	checks := make(map[string]ClusterChecker, len(m.clusterChecks))
+	for name, check := range m.clusterChecks {
+		checks[name] = check
+	}
+	m.lock.Unlock()
+
+	statuses := []ClusterStatus{}
+	for _, clusterName := range m.engagedClusters() {
+		status := ClusterStatus{Name: clusterName, Provider: m.ProviderName(clusterName), Healthy: true}
+		if mo := m.opts.Membership; mo != nil && mo.Shard != nil {
+			status.Shard = mo.Shard(clusterName)
+		}
+		status.Informers, _ = m.GetInformerReferences(req.Context(), clusterName)
+		for name, check := range checks {
+			if err := check(req, clusterName); err != nil {
+				if status.Checks == nil {
+					status.Checks = map[string]string{}
+				}
+				status.Healthy = false
+				status.Checks[name] = err.Error()
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+type engagement struct {
+	ctx      context.Context
+	cluster  cluster.Cluster
+	metadata multicluster.Metadata
+	provider string
+	cancel   context.CancelFunc
+
+	// published is set once ClusterEngaged was delivered for the engagement.
+	published bool
+}
+
+// trackEngaged remembers the cluster of e as engaged until e.ctx is done,
+// and returns the runnables to engage it with. e.cancel disengages the
+// cluster on shutdown. Runnables added later are engaged by Add.
+func (m *mcManager) trackEngaged(name string, e engagement) ([]multicluster.Aware, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.stopping {
+		return nil, mcerrors.New(name, "engage", errShuttingDown)
+	}
+	m.engaged[name] = e
+	delete(m.unhealthy, name)
+	m.notifyMembership()
+	mcmetrics.ProviderEngagedClusters.WithLabelValues(e.provider).Inc()
+	go func() {
+		<-e.ctx.Done()
+		m.lock.Lock()
+		current := m.engaged[name].cluster == e.cluster
+		// engagements that failed were never delivered as engaged.
+		published := current && m.engaged[name].published
+		if current {
+			delete(m.engaged, name)
+			delete(m.unhealthy, name)
+		}
+		m.lock.Unlock()
+		mcmetrics.ProviderEngagedClusters.WithLabelValues(e.provider).Dec()
+		m.notifyMembership()
+		if published {
+			m.publish(ClusterEvent{Type: ClusterDisengaged, Name: name, Provider: e.provider, Metadata: e.metadata})
+		}
+	}()
+	return append([]multicluster.Aware(nil), m.mcRunnables...), nil
+}
+
+// engagedClusters returns the sorted names of the engaged clusters.
+func (m *mcManager) engagedClusters() []string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	names := make([]string, 0, len(m.engaged))
+	for name := range m.engaged {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
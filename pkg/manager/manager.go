@@ -18,11 +18,15 @@ package manager
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 
+	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/client-go/rest"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -32,7 +36,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
+	mccluster "sigs.k8s.io/multicluster-runtime/pkg/cluster"
 	mccontext "sigs.k8s.io/multicluster-runtime/pkg/context"
+	mcerrors "sigs.k8s.io/multicluster-runtime/pkg/errors"
+	mcmetrics "sigs.k8s.io/multicluster-runtime/pkg/metrics"
 	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
 )
 
@@ -46,6 +53,7 @@ type Manager interface {
 	// started when Start is called.
 	// Depending on if a Runnable implements LeaderElectionRunnable interface, a Runnable can be run in either
 	// non-leaderelection mode (always running) or leader election mode (managed by leader election if enabled).
+	// Runnables added after Start are engaged with the clusters engaged so far.
 	Add(Runnable) error
 
 	// Elected is closed when this manager is elected leader of a group of
@@ -68,9 +76,23 @@ type Manager interface {
 	// AddReadyzCheck allows you to add Readyz checker
 	AddReadyzCheck(name string, check healthz.Checker) error
 
+	// AddShutdownHook adds a hook that is called on shutdown after all
+	// clusters were disengaged, while reconcilers are still running, e.g. to
+	// write aggregated status. Hooks run in the order they were added.
+	AddShutdownHook(hook ShutdownHook) error
+
+	// AddClusterHealthzCheck adds a health check that runs check for every
+	// engaged cluster, and fails if any of the clusters fails. The health
+	// of every cluster is served on ClustersPath of the metrics server.
+	AddClusterHealthzCheck(name string, check ClusterChecker) error
+
 	// Start starts all registered Controllers and blocks until the context is cancelled.
 	// Returns an error if there is an error starting any controller.
 	//
+	// When the context is cancelled, all clusters are disengaged first so
+	// that no more events are delivered, then the shutdown hooks run while
+	// the reconcilers finish, and finally all components are stopped.
+	//
 	// If LeaderElection is used, the binary must be exited immediately after this returns,
 	// otherwise components that need leader election might continue to run after the leader
 	// lock was lost.
@@ -94,6 +116,65 @@ type Manager interface {
 	// ClusterFromContext returns the default cluster set in the context.
 	ClusterFromContext(ctx context.Context) (cluster.Cluster, error)
 
+	// GetClusterMetadata returns the metadata of the cluster with the given
+	// name as supplied by the provider. If the provider does not implement
+	// multicluster.MetadataProvider, empty metadata is returned.
+	GetClusterMetadata(ctx context.Context, clusterName string) (multicluster.Metadata, error)
+
+	// GetCacheStats returns the statistics of the cache of the cluster with
+	// the given name. The cluster must have been created with
+	// mccluster.WithCacheAccounting.
+	GetCacheStats(ctx context.Context, clusterName string) (mccluster.CacheStats, error)
+
+	// GetInformerReferences returns the references to the informers of the
+	// cache of the cluster with the given name. The cluster must have been
+	// created with mccluster.WithInformerReferences.
+	GetInformerReferences(ctx context.Context, clusterName string) ([]mccluster.InformerReferences, error)
+
+	// GetClusterVersion returns the Kubernetes version of the cluster with
+	// the given name. The version of engaged clusters is cached.
+	GetClusterVersion(ctx context.Context, clusterName string) (*version.Info, error)
+
+	// UpdateCluster switches the engaged cluster with the given name to the
+	// endpoint and credentials of cfg, keeping its caches and watches.
+	// Providers call it when only credentials or the endpoint of a cluster
+	// changed. If it returns mccluster.ErrUpdateNotSupported, the provider
+	// has to disengage and engage the cluster again.
+	UpdateCluster(ctx context.Context, clusterName string, cfg *rest.Config) error
+
+	// ReEngage disengages the cluster with the given name, lets its provider
+	// create its client and cache anew, and engages it again, which resyncs
+	// all controllers with the cluster. Use it to recover a single wedged
+	// cluster without restarting the process. It returns
+	// multicluster.ErrReEngageNotSupported if the provider does not
+	// implement multicluster.ReEngager, as the DNS, Cluster-API,
+	// registration, namespace and kind providers do.
+	ReEngage(ctx context.Context, clusterName string) error
+
+	// SubscribeClusterEvents returns a channel delivering an event whenever
+	// a cluster is engaged, synced, turns unhealthy or is disengaged, until
+	// ctx is done. The clusters engaged before are delivered as engaged
+	// first. This lets components outside of controllers, e.g. HTTP APIs,
+	// follow the fleet without implementing multicluster.Aware.
+	SubscribeClusterEvents(ctx context.Context) <-chan ClusterEvent
+
+	// NewCluster creates a cluster with the given name from cfg, with the
+	// constructor configured with WithNewCluster or DefaultNewCluster.
+	// Providers use it to create their clusters unless they are configured
+	// with their own constructor.
+	NewCluster(name string, cfg *rest.Config, opts ...cluster.Option) (cluster.Cluster, error)
+
+	// NewClusterOr is like NewCluster, but creates the cluster with
+	// fallback if no constructor is configured with WithNewCluster.
+	// Providers use it to keep a default constructor of their own.
+	NewClusterOr(fallback NewClusterFunc, name string, cfg *rest.Config, opts ...cluster.Option) (cluster.Cluster, error)
+
+	// EngageCluster starts and engages a cluster that is not managed by the
+	// provider, e.g. one created from a kubeconfig supplied by a user. The
+	// cluster is disengaged when the returned handle is stopped or ctx is
+	// done, and can be retrieved with GetCluster until then.
+	EngageCluster(ctx context.Context, name string, cl cluster.Cluster) (*ClusterHandle, error)
+
 	// GetManager returns a manager for the given cluster name.
 	GetManager(ctx context.Context, clusterName string) (manager.Manager, error)
 
@@ -104,6 +185,19 @@ type Manager interface {
 	// GetProvider returns the multicluster provider, or nil if it is not set.
 	GetProvider() multicluster.Provider
 
+	// ProbeCapabilities probes the capabilities of the engaged cluster with
+	// the given name again, see WithCapabilityProbes.
+	ProbeCapabilities(ctx context.Context, clusterName string) error
+
+	// ProviderName returns the name of the provider that engaged the
+	// cluster with the given name, as set with WithProviderName, or an
+	// empty string.
+	ProviderName(clusterName string) string
+
+	// SingleCluster returns whether the manager runs in single-cluster
+	// compatibility mode, see WithSingleCluster.
+	SingleCluster() bool
+
 	// GetFieldIndexer returns a client.FieldIndexer that adds indexes to the
 	// multicluster provider (if set) and the local manager.
 	GetFieldIndexer() client.FieldIndexer
@@ -114,6 +208,119 @@ type Manager interface {
 // Options are the arguments for creating a new Manager.
 type Options = manager.Options
 
+// MultiClusterOptions are the options of the multi-cluster part of a
+// Manager.
+type MultiClusterOptions struct {
+	// MemoryBudget is the maximum approximate size in bytes of the caches of
+	// all engaged clusters. When it is exceeded, further clusters are not
+	// engaged. Only clusters created with mccluster.WithCacheAccounting are
+	// accounted for. Zero means unlimited.
+	MemoryBudget int64
+
+	// CacheStatsInterval is the interval in which the cache statistics of
+	// the engaged clusters are refreshed. Defaults to 30 seconds.
+	CacheStatsInterval time.Duration
+
+	// EngagementParallelism is the maximum number of clusters whose caches
+	// sync at the same time after engagement. Further engagements block
+	// until a slot is free. Zero means unlimited.
+	EngagementParallelism int
+
+	// EngagementStagger is the minimum time between the start of two
+	// engagements.
+	EngagementStagger time.Duration
+
+	// EngagementSyncTimeout is the maximum time an engagement holds its
+	// parallelism slot while waiting for the cache to sync. Defaults to two
+	// minutes.
+	EngagementSyncTimeout time.Duration
+
+	// ShutdownFlushTimeout is the time the shutdown hooks get to persist
+	// progress after all clusters were disengaged, before the reconcilers
+	// are stopped. Defaults to 10 seconds.
+	ShutdownFlushTimeout time.Duration
+
+	// Membership publishes the engaged clusters to a ConfigMap of the host
+	// cluster if set.
+	Membership *MembershipOptions
+
+	// DryRun returns whether all writes to the cluster with the given name
+	// are sent as server-side dry-run. Nil means no cluster is dry-run.
+	DryRun func(clusterName string) bool
+
+	// SingleCluster makes the manager behave like a plain controller-runtime
+	// manager of the local cluster. See WithSingleCluster.
+	SingleCluster bool
+
+	// NewCluster creates the clusters of providers that are not configured
+	// with their own constructor. If nil, providers use their default
+	// constructor, DefaultNewCluster unless documented otherwise.
+	NewCluster NewClusterFunc
+
+	// Backpressure delays engagements while the manager is overloaded. See
+	// WithBackpressure.
+	Backpressure *BackpressureOptions
+
+	// Attribution attributes the requests of the clusters created with
+	// NewCluster. See WithRequestAttribution.
+	Attribution *mccluster.AttributionOptions
+
+	// Priority classifies the requests of the clusters created with
+	// NewCluster. See WithLowPriorityFlows.
+	Priority mccluster.PriorityFunc
+
+	// StartPhaseCallback is called when a phase of starting the manager
+	// begins or completes. See WithStartPhaseCallback.
+	StartPhaseCallback StartPhaseFunc
+
+	// FailureDomains engages clusters one failure domain at a time. See
+	// WithFailureDomains.
+	FailureDomains *FailureDomainOptions
+
+	// Capabilities probes the capabilities of the engaged clusters. See
+	// WithCapabilityProbes.
+	Capabilities *CapabilityOptions
+
+	// Teardown deletes the objects the manager created when it stops. See
+	// WithTeardown.
+	Teardown *TeardownOptions
+}
+
+// Option configures the multi-cluster part of a Manager.
+type Option func(*MultiClusterOptions)
+
+// WithMemoryBudget sets the memory budget of the engaged clusters.
+func WithMemoryBudget(bytes int64) Option {
+	return func(o *MultiClusterOptions) {
+		o.MemoryBudget = bytes
+	}
+}
+
+// WithCacheStatsInterval sets the interval in which cache statistics are
+// refreshed.
+func WithCacheStatsInterval(d time.Duration) Option {
+	return func(o *MultiClusterOptions) {
+		o.CacheStatsInterval = d
+	}
+}
+
+// WithEngagementParallelism limits the number of clusters whose caches sync
+// at the same time, and staggers the start of engagements.
+func WithEngagementParallelism(n int, stagger time.Duration) Option {
+	return func(o *MultiClusterOptions) {
+		o.EngagementParallelism = n
+		o.EngagementStagger = stagger
+	}
+}
+
+// WithShutdownFlushTimeout sets the time the shutdown hooks get to persist
+// progress on shutdown.
+func WithShutdownFlushTimeout(d time.Duration) Option {
+	return func(o *MultiClusterOptions) {
+		o.ShutdownFlushTimeout = d
+	}
+}
+
 // Runnable allows a component to be started.
 // It's very important that Start blocks until
 // it's done running.
@@ -127,27 +334,82 @@ var _ Manager = &mcManager{}
 type mcManager struct {
 	manager.Manager
 	provider multicluster.Provider
+	opts     MultiClusterOptions
 
 	mcRunnables []multicluster.Aware
+
+	lock         sync.Mutex
+	cacheBytes   map[string]int64
+	versions     map[string]*version.Info
+	capabilities map[string]map[string]string
+	manual       map[string]cluster.Cluster
+	engaged      map[string]engagement
+	unhealthy    map[string]bool
+	stopping     bool
+
+	subscriptions []*subscription
+
+	clusterChecks map[string]ClusterChecker
+	shutdownHooks []ShutdownHook
+
+	membershipChanged chan struct{}
+
+	engageSlots chan struct{}
+	staggerLock sync.Mutex
+	lastEngage  time.Time
+
+	phases  startPhases
+	domains domainGate
 }
 
 // New returns a new Manager for creating Controllers. The provider is used to
 // discover and manage clusters. With a provider set to nil, the manager will
 // behave like a regular controller-runtime manager.
-func New(config *rest.Config, provider multicluster.Provider, opts manager.Options) (Manager, error) {
+func New(config *rest.Config, provider multicluster.Provider, opts manager.Options, mcOpts ...Option) (Manager, error) {
 	mgr, err := manager.New(config, opts)
 	if err != nil {
 		return nil, err
 	}
-	return WithMultiCluster(mgr, provider)
+	return WithMultiCluster(mgr, provider, mcOpts...)
 }
 
 // WithMultiCluster wraps a host manager to run multi-cluster controllers.
-func WithMultiCluster(mgr manager.Manager, provider multicluster.Provider) (Manager, error) {
-	return &mcManager{
-		Manager:  mgr,
-		provider: provider,
-	}, nil
+func WithMultiCluster(mgr manager.Manager, provider multicluster.Provider, mcOpts ...Option) (Manager, error) {
+	opts := MultiClusterOptions{
+		CacheStatsInterval:    30 * time.Second,
+		EngagementSyncTimeout: 2 * time.Minute,
+		ShutdownFlushTimeout:  10 * time.Second,
+	}
+	for _, o := range mcOpts {
+		o(&opts)
+	}
+	if opts.SingleCluster && provider != nil {
+		return nil, errors.New("a provider cannot be set in single-cluster mode")
+	}
+	m := &mcManager{
+		Manager:      mgr,
+		provider:     provider,
+		opts:         opts,
+		cacheBytes:   map[string]int64{},
+		versions:     map[string]*version.Info{},
+		capabilities: map[string]map[string]string{},
+		manual:       map[string]cluster.Cluster{},
+		engaged:      map[string]engagement{},
+		unhealthy:    map[string]bool{},
+
+		clusterChecks: map[string]ClusterChecker{},
+	}
+	m.phases.m = m
+	if opts.EngagementParallelism > 0 {
+		m.engageSlots = make(chan struct{}, opts.EngagementParallelism)
+	}
+	if opts.Membership != nil {
+		m.membershipChanged = make(chan struct{}, 1)
+		if err := mgr.Add(&membershipPublisher{m: m}); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
 }
 
 // GetCluster returns a cluster for the given identifying cluster name. Get
@@ -158,10 +420,20 @@ func (m *mcManager) GetCluster(ctx context.Context, clusterName string) (cluster
 	if clusterName == LocalCluster {
 		return m.Manager, nil
 	}
+	m.lock.Lock()
+	cl, ok := m.manual[clusterName]
+	m.lock.Unlock()
+	if ok {
+		return m.dryRun(clusterName, cl), nil
+	}
 	if m.provider == nil {
 		return nil, fmt.Errorf("no multicluster provider set, but cluster %q passed", clusterName)
 	}
-	return m.provider.Get(ctx, clusterName)
+	cl, err := m.provider.Get(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	return m.dryRun(clusterName, cl), nil
 }
 
 // ClusterFromContext returns the default cluster set in the context.
@@ -173,6 +445,32 @@ func (m *mcManager) ClusterFromContext(ctx context.Context) (cluster.Cluster, er
 	return m.GetCluster(ctx, clusterName)
 }
 
+// GetClusterMetadata returns the metadata of the cluster with the given name
+// as supplied by the provider.
+func (m *mcManager) GetClusterMetadata(ctx context.Context, clusterName string) (multicluster.Metadata, error) {
+	md, err := m.providerMetadata(ctx, clusterName)
+	if err != nil {
+		return md, err
+	}
+	return m.withCapabilities(clusterName, md), nil
+}
+
+func (m *mcManager) providerMetadata(ctx context.Context, clusterName string) (multicluster.Metadata, error) {
+	if clusterName == LocalCluster || m.provider == nil {
+		return multicluster.Metadata{}, nil
+	}
+	m.lock.Lock()
+	_, manual := m.manual[clusterName]
+	m.lock.Unlock()
+	if manual {
+		return multicluster.Metadata{}, nil
+	}
+	if mp, ok := m.provider.(multicluster.MetadataProvider); ok {
+		return mp.GetMetadata(ctx, clusterName)
+	}
+	return multicluster.Metadata{}, nil
+}
+
 // GetLocalManager returns the underlying controller-runtime manager of the host.
 func (m *mcManager) GetLocalManager() manager.Manager {
 	return m.Manager
@@ -185,28 +483,112 @@ func (m *mcManager) GetProvider() multicluster.Provider {
 
 // Add will set requested dependencies on the component, and cause the component to be
 // started when Start is called.
-func (m *mcManager) Add(r Runnable) (err error) {
+//
+// Components added while clusters are engaged, e.g. controllers added after
+// Start, are engaged with these clusters right away. The informers of their
+// sources then replay the cached objects of every cluster as Add events, like
+// for controllers added to a running controller-runtime manager.
+func (m *mcManager) Add(r Runnable) error {
+	m.lock.Lock()
 	m.mcRunnables = append(m.mcRunnables, r)
-	defer func() {
-		if err != nil {
-			m.mcRunnables = m.mcRunnables[:len(m.mcRunnables)-1]
+	engaged := make(map[string]engagement, len(m.engaged))
+	for name, e := range m.engaged {
+		engaged[name] = e
+	}
+	m.lock.Unlock()
+
+	if err := m.Manager.Add(r); err != nil {
+		m.lock.Lock()
+		for i, other := range m.mcRunnables {
+			if other == multicluster.Aware(r) {
+				m.mcRunnables = append(m.mcRunnables[:i], m.mcRunnables[i+1:]...)
+				break
+			}
 		}
-	}()
+		m.lock.Unlock()
+		return err
+	}
 
-	return m.Manager.Add(r)
+	var errs []error
+	for name, e := range engaged {
+		if e.ctx.Err() != nil {
+			continue
+		}
+		errs = append(errs, mcerrors.New(name, "engage", r.Engage(e.ctx, name, e.cluster)))
+	}
+	return mcerrors.NewAggregate(errs...)
 }
 
 // Engage gets called when the component should start operations for the given
 // Cluster. ctx is cancelled when the cluster is disengaged.
 func (m *mcManager) Engage(ctx context.Context, name string, cl cluster.Cluster) error {
-	ctx, cancel := context.WithCancel(ctx) //nolint:govet // cancel is called in the error case only.
-	for _, r := range m.mcRunnables {
+	if m.opts.SingleCluster {
+		return mcerrors.New(name, "engage", errSingleCluster)
+	}
+	if err := multicluster.ValidateClusterName(name); err != nil {
+		return mcerrors.New(name, "engage", err)
+	}
+	// the engagement counts towards the engagement wave until the cache
+	// synced, or until it fails.
+	endPhase, engaged := m.phases.engaging(), false
+	defer func() {
+		if !engaged {
+			endPhase()
+		}
+	}()
+	if err := m.checkMemoryBudget(name); err != nil {
+		return err
+	}
+	if err := m.waitForPressure(ctx, name); err != nil {
+		return err
+	}
+	releaseDomain, err := m.acquireFailureDomain(ctx, name)
+	if err != nil {
+		return err
+	}
+	releaseSlot, err := m.acquireEngagement(ctx, name)
+	if err != nil {
+		releaseDomain()
+		return err
+	}
+	release := func() {
+		releaseSlot()
+		releaseDomain()
+	}
+	cl = m.dryRun(name, cl)
+	provider := providerFrom(ctx)
+	ctx, cancel := context.WithCancel(ctx)
+	m.engageCapabilities(ctx, name, cl)
+	md, err := m.GetClusterMetadata(ctx, name)
+	if err != nil {
+		md = multicluster.Metadata{}
+	}
+	runnables, err := m.trackEngaged(name, engagement{ctx: ctx, cluster: cl, metadata: md, provider: provider, cancel: cancel})
+	if err != nil {
+		cancel()
+		release()
+		mcmetrics.ProviderEngagements.WithLabelValues(provider, "error").Inc()
+		return err
+	}
+	m.cacheVersion(ctx, name, cl)
+	for _, r := range runnables {
 		if err := r.Engage(ctx, name, cl); err != nil {
 			cancel()
-			return fmt.Errorf("failed to engage cluster %q: %w", name, err)
+			release()
+			mcmetrics.ProviderEngagements.WithLabelValues(provider, "error").Inc()
+			return mcerrors.New(name, "engage", err)
 		}
 	}
-	return nil //nolint:govet // cancel is called in the error case only.
+	mcmetrics.ProviderEngagements.WithLabelValues(provider, "success").Inc()
+	m.publishEngaged(name, cl)
+	go m.releaseAfterSync(ctx, cl, release)
+	go m.publishSynced(ctx, name, cl, provider, md)
+	go m.phases.engaged(ctx, cl, endPhase)
+	engaged = true
+	if _, ok, _ := mccluster.GetCacheStats(ctx, cl); ok {
+		go m.watchCacheStats(ctx, name, cl)
+	}
+	return nil
 }
 
 func (m *mcManager) GetManager(ctx context.Context, clusterName string) (manager.Manager, error) {
@@ -0,0 +1,144 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	mcerrors "sigs.k8s.io/multicluster-runtime/pkg/errors"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+// ClusterHandle is a cluster engaged manually with EngageCluster.
+type ClusterHandle struct {
+	name   string
+	cancel context.CancelFunc
+	ready  chan struct{}
+	done   chan struct{}
+
+	lock sync.Mutex
+	err  error
+}
+
+// Name returns the name of the cluster.
+func (h *ClusterHandle) Name() string {
+	return h.name
+}
+
+// Ready returns a channel that is closed when the cache of the cluster has
+// synced and all components of the manager have engaged the cluster.
+func (h *ClusterHandle) Ready() <-chan struct{} {
+	return h.ready
+}
+
+// Done returns a channel that is closed when the cluster is disengaged and
+// stopped, either by Stop, by the context passed to EngageCluster, or
+// because the cluster could not be engaged.
+func (h *ClusterHandle) Done() <-chan struct{} {
+	return h.done
+}
+
+// Err returns why the cluster could not be engaged or stopped, if anything.
+func (h *ClusterHandle) Err() error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return h.err
+}
+
+// Stop disengages the cluster and blocks until it is stopped. It can be
+// called multiple times.
+func (h *ClusterHandle) Stop() {
+	h.cancel()
+	<-h.done
+}
+
+func (h *ClusterHandle) fail(err error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.err == nil {
+		h.err = err
+	}
+}
+
+// EngageCluster starts and engages a cluster that is not managed by the
+// provider, e.g. one created from a kubeconfig supplied by a user. The
+// cluster is disengaged when the returned handle is stopped or ctx is done,
+// and can be retrieved with GetCluster until then.
+func (m *mcManager) EngageCluster(ctx context.Context, name string, cl cluster.Cluster) (*ClusterHandle, error) {
+	if name == LocalCluster {
+		return nil, errors.New("the local cluster cannot be engaged manually")
+	}
+	if m.opts.SingleCluster {
+		return nil, mcerrors.New(name, "engage", errSingleCluster)
+	}
+	if err := multicluster.ValidateClusterName(name); err != nil {
+		return nil, mcerrors.New(name, "engage", err)
+	}
+	if m.provider != nil {
+		if _, err := m.provider.Get(ctx, name); err == nil {
+			return nil, mcerrors.New(name, "engage", errors.New("cluster is managed by the provider"))
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	h := &ClusterHandle{name: name, cancel: cancel, ready: make(chan struct{}), done: make(chan struct{})}
+
+	m.lock.Lock()
+	if _, ok := m.manual[name]; ok {
+		m.lock.Unlock()
+		cancel()
+		return nil, mcerrors.New(name, "engage", errors.New("cluster is already engaged manually"))
+	}
+	m.manual[name] = cl
+	m.lock.Unlock()
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		if err := cl.Start(ctx); err != nil {
+			h.fail(mcerrors.New(name, "start", err))
+			cancel()
+		}
+	}()
+	go func() {
+		defer func() {
+			<-stopped
+			m.lock.Lock()
+			delete(m.manual, name)
+			m.lock.Unlock()
+			close(h.done)
+		}()
+		if !cl.GetCache().WaitForCacheSync(ctx) {
+			h.fail(mcerrors.New(name, "engage", errors.New("cache did not sync")))
+			cancel()
+			return
+		}
+		if err := m.Engage(ctx, name, cl); err != nil {
+			m.GetLogger().Error(err, "Failed to engage cluster", "cluster", multicluster.EscapeClusterName(name))
+			h.fail(err)
+			cancel()
+			return
+		}
+		close(h.ready)
+		<-ctx.Done()
+	}()
+	return h, nil
+}
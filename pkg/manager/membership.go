@@ -0,0 +1,154 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// MembershipClustersKey is the key of the membership ConfigMap holding
+	// the JSON list of the names of the engaged clusters. It is the format
+	// followed by the peer provider.
+	MembershipClustersKey = "clusters"
+
+	// MembershipStatusKey is the key of the membership ConfigMap holding
+	// the JSON list of the ClusterStatus of the engaged clusters.
+	MembershipStatusKey = "status"
+)
+
+// MembershipOptions configure publishing the engaged clusters of the
+// manager to a ConfigMap of the host cluster, for dashboards and for
+// managers following this one.
+type MembershipOptions struct {
+	// ConfigMap is the ConfigMap the membership is written to. Replicas
+	// engaging clusters independently, e.g. shards, must use distinct
+	// ConfigMaps.
+	ConfigMap types.NamespacedName
+
+	// Interval is the interval in which the health of the clusters is
+	// refreshed. Changes of the engaged clusters are published
+	// immediately. Defaults to 30 seconds.
+	Interval time.Duration
+
+	// Shard returns the shard a cluster is assigned to, published with its
+	// status. Optional.
+	Shard func(clusterName string) string
+}
+
+// WithMembership publishes the engaged clusters, their health and shard to
+// a ConfigMap of the host cluster.
+func WithMembership(opts MembershipOptions) Option {
+	return func(o *MultiClusterOptions) {
+		o.Membership = &opts
+	}
+}
+
+// membershipPublisher writes the membership of the manager while it runs.
+type membershipPublisher struct {
+	m *mcManager
+}
+
+// NeedLeaderElection returns false, every replica publishes the clusters it
+// engaged.
+func (p *membershipPublisher) NeedLeaderElection() bool {
+	return false
+}
+
+// Start publishes the membership on changes and every interval until ctx
+// is done.
+func (p *membershipPublisher) Start(ctx context.Context) error {
+	opts := p.m.opts.Membership
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := p.publish(ctx); err != nil {
+			p.m.GetLogger().Error(err, "Failed to publish cluster membership", "configMap", opts.ConfigMap)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-p.m.membershipChanged:
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *membershipPublisher) publish(ctx context.Context) error {
+	opts := p.m.opts.Membership
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ClustersPath, nil)
+	if err != nil {
+		return err
+	}
+	statuses := p.m.clusterStatuses(req)
+	names := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		names = append(names, status.Name)
+	}
+	clusters, err := json.Marshal(names)
+	if err != nil {
+		return err
+	}
+	status, err := json.Marshal(statuses)
+	if err != nil {
+		return err
+	}
+
+	cl := p.m.GetClient()
+	cm := &corev1.ConfigMap{}
+	if err := p.m.GetAPIReader().Get(ctx, opts.ConfigMap, cm); apierrors.IsNotFound(err) {
+		cm.Namespace, cm.Name = opts.ConfigMap.Namespace, opts.ConfigMap.Name
+		cm.Data = map[string]string{
+			MembershipClustersKey: string(clusters),
+			MembershipStatusKey:   string(status),
+		}
+		return cl.Create(ctx, cm)
+	} else if err != nil {
+		return err
+	}
+	if cm.Data[MembershipClustersKey] == string(clusters) && cm.Data[MembershipStatusKey] == string(status) {
+		return nil
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[MembershipClustersKey] = string(clusters)
+	cm.Data[MembershipStatusKey] = string(status)
+	return cl.Update(ctx, cm)
+}
+
+// notifyMembership triggers publishing the membership, if enabled.
+func (m *mcManager) notifyMembership() {
+	if m.membershipChanged == nil {
+		return
+	}
+	select {
+	case m.membershipChanged <- struct{}{}:
+	default:
+	}
+}
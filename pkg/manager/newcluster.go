@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	mccluster "sigs.k8s.io/multicluster-runtime/pkg/cluster"
+)
+
+// NewClusterFunc creates the cluster with the given name from a rest
+// config. The cluster is started by the caller.
+type NewClusterFunc func(name string, cfg *rest.Config, opts ...cluster.Option) (cluster.Cluster, error)
+
+// DefaultNewCluster creates clusters with mccluster.NewUpdatable, which keep
+// their watches when only their endpoint or credentials change.
+func DefaultNewCluster(_ string, cfg *rest.Config, opts ...cluster.Option) (cluster.Cluster, error) {
+	return mccluster.NewUpdatable(cfg, opts...)
+}
+
+// PlainNewCluster creates clusters with cluster.New. It is the default of
+// the providers whose clusters never change their endpoint.
+func PlainNewCluster(_ string, cfg *rest.Config, opts ...cluster.Option) (cluster.Cluster, error) {
+	return cluster.New(cfg, opts...)
+}
+
+// WithNewCluster sets the function providers create clusters with, unless
+// they are configured with their own. It allows wrapping all clusters of
+// the fleet, e.g. with custom REST mappers, tracing transports or
+// alternative cache implementations.
+func WithNewCluster(fn NewClusterFunc) Option {
+	return func(o *MultiClusterOptions) {
+		o.NewCluster = fn
+	}
+}
+
+// WithRequestAttribution makes the clusters created with
+// Manager.NewCluster attribute their requests to the manager and the
+// reconciling controller, see mccluster.WithAttribution.
+func WithRequestAttribution(opts mccluster.AttributionOptions) Option {
+	return func(o *MultiClusterOptions) {
+		o.Attribution = &opts
+	}
+}
+
+// WithLowPriorityFlows makes the clusters created with Manager.NewCluster
+// send non-urgent requests, like the warm-up LISTs of their caches, as
+// low-priority flows, see mccluster.WrapConfigForPriority.
+func WithLowPriorityFlows(fn mccluster.PriorityFunc) Option {
+	return func(o *MultiClusterOptions) {
+		o.Priority = fn
+	}
+}
+
+// NewCluster creates a cluster with the NewCluster function of the
+// options, or with DefaultNewCluster.
+func (m *mcManager) NewCluster(name string, cfg *rest.Config, opts ...cluster.Option) (cluster.Cluster, error) {
+	return m.NewClusterOr(DefaultNewCluster, name, cfg, opts...)
+}
+
+// NewClusterOr creates a cluster with the NewCluster function of the
+// options, or with fallback.
+func (m *mcManager) NewClusterOr(fallback NewClusterFunc, name string, cfg *rest.Config, opts ...cluster.Option) (cluster.Cluster, error) {
+	if m.opts.Attribution != nil {
+		cfg = mccluster.WithAttribution(cfg, name, *m.opts.Attribution)
+	}
+	cfg = mccluster.WrapConfigForPriority(cfg, name, m.opts.Priority)
+	newCluster := m.opts.NewCluster
+	if newCluster == nil {
+		newCluster = fallback
+	}
+	return newCluster(name, cfg, opts...)
+}
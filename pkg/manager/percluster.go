@@ -0,0 +1,186 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	mcerrors "sigs.k8s.io/multicluster-runtime/pkg/errors"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+// RunnableFactory returns the runnable to run for an engaged cluster. It is
+// called again for every restart.
+type RunnableFactory func(clusterName string, cl cluster.Cluster) (manager.Runnable, error)
+
+// PerClusterOptions are the options of a PerCluster runnable.
+type PerClusterOptions struct {
+	// RestartBackoff is the delay before a runnable that failed, or whose
+	// factory failed, is restarted. It doubles with every failure in a row,
+	// up to MaxRestartBackoff. Defaults to one second.
+	RestartBackoff time.Duration
+
+	// MaxRestartBackoff is the maximum delay before a restart. Runnables
+	// that ran longer than this before failing are restarted after
+	// RestartBackoff again. Defaults to five minutes.
+	MaxRestartBackoff time.Duration
+}
+
+var _ Runnable = &PerCluster{}
+
+// PerCluster runs one instance of a runnable per engaged cluster, e.g. a
+// background loop that is not a controller. Add it to the manager with
+// Manager.Add. Like controllers, it follows the engagements of the manager:
+// the instance of a cluster is started when the cluster is engaged, and
+// stopped when the engagement context is done, i.e. when the cluster is
+// disengaged or the manager shuts down. It is restarted when it fails.
+type PerCluster struct {
+	factory RunnableFactory
+	opts    PerClusterOptions
+	log     logr.Logger
+
+	// stopCtx is done when Start returns, stopping the remaining instances.
+	stopCtx context.Context
+	stop    context.CancelFunc
+
+	lock     sync.Mutex
+	clusters map[string]engagedRunnable
+	stopped  bool
+	wg       sync.WaitGroup
+}
+
+type engagedRunnable struct {
+	ctx     context.Context
+	cluster cluster.Cluster
+}
+
+// NewPerCluster returns a PerCluster running the runnables of factory.
+func NewPerCluster(factory RunnableFactory, opts PerClusterOptions) *PerCluster {
+	if opts.RestartBackoff <= 0 {
+		opts.RestartBackoff = time.Second
+	}
+	if opts.MaxRestartBackoff <= 0 {
+		opts.MaxRestartBackoff = 5 * time.Minute
+	}
+	stopCtx, stop := context.WithCancel(context.Background())
+	return &PerCluster{
+		factory:  factory,
+		opts:     opts,
+		log:      log.Log.WithName("per-cluster"),
+		stopCtx:  stopCtx,
+		stop:     stop,
+		clusters: map[string]engagedRunnable{},
+	}
+}
+
+// Engage starts the runnable of the cluster until ctx is done. Engaging the
+// same cluster again is a no-op. Engagements are rejected once Start
+// returns.
+func (p *PerCluster) Engage(ctx context.Context, name string, cl cluster.Cluster) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.stopped {
+		return mcerrors.New(name, "engage", errShuttingDown)
+	}
+	if old, ok := p.clusters[name]; ok && old.cluster == cl {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(p.stopCtx, cancel)
+	er := engagedRunnable{ctx: ctx, cluster: cl}
+	p.clusters[name] = er
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer stop()
+		defer cancel()
+		p.run(ctx, name, cl)
+
+		p.lock.Lock()
+		defer p.lock.Unlock()
+		if p.clusters[name] == er {
+			delete(p.clusters, name)
+		}
+	}()
+	return nil
+}
+
+// Start blocks until ctx is done, then rejects further engagements, stops
+// the runnables and waits for them to return.
+func (p *PerCluster) Start(ctx context.Context) error {
+	<-ctx.Done()
+	p.lock.Lock()
+	p.stopped = true
+	p.lock.Unlock()
+	p.stop()
+	p.wg.Wait()
+	return nil
+}
+
+// Running returns the sorted names of the clusters a runnable runs for.
+func (p *PerCluster) Running() []string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	names := make([]string, 0, len(p.clusters))
+	for name := range p.clusters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// run runs and restarts the runnable of the cluster until ctx is done.
+func (p *PerCluster) run(ctx context.Context, name string, cl cluster.Cluster) {
+	log := p.log.WithValues("cluster", multicluster.EscapeClusterName(name))
+	backoff := p.opts.RestartBackoff
+	for {
+		started := time.Now()
+		r, err := p.factory(name, cl)
+		if err == nil {
+			err = r.Start(ctx)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if time.Since(started) > p.opts.MaxRestartBackoff {
+			backoff = p.opts.RestartBackoff
+		}
+		if err != nil {
+			log.Error(err, "Per-cluster runnable failed, restarting", "backoff", backoff)
+		} else {
+			log.Info("Per-cluster runnable returned, restarting", "backoff", backoff)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, p.opts.MaxRestartBackoff)
+	}
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	mcmetrics "sigs.k8s.io/multicluster-runtime/pkg/metrics"
+)
+
+// StartPhase is a phase of starting the manager.
+type StartPhase string
+
+const (
+	// PhaseProviderStart lasts until the host manager starts the
+	// providers added with AddProvider. Providers requiring leadership
+	// start with the controllers.
+	PhaseProviderStart StartPhase = "ProviderStart"
+
+	// PhaseInitialDiscovery lasts until the providers engage the first
+	// cluster.
+	PhaseInitialDiscovery StartPhase = "InitialDiscovery"
+
+	// PhaseEngagementWave lasts from an engagement while no other
+	// engagement is in progress until the caches of all clusters engaged
+	// meanwhile synced.
+	PhaseEngagementWave StartPhase = "EngagementWave"
+
+	// PhaseControllersStarted lasts until the host manager is elected
+	// leader, or has no leader election, and started its controllers.
+	PhaseControllersStarted StartPhase = "ControllersStarted"
+)
+
+// StartPhaseEvent reports the beginning or the completion of a start
+// phase.
+type StartPhaseEvent struct {
+	// Phase is the phase.
+	Phase StartPhase
+
+	// Wave is the number of the engagement wave, starting at 1.
+	Wave int
+
+	// Completed is whether the phase completed, as opposed to began.
+	Completed bool
+
+	// Elapsed is the time since the manager started.
+	Elapsed time.Duration
+
+	// Duration is the duration of a completed phase.
+	Duration time.Duration
+
+	// Clusters is the number of clusters engaged in a completed
+	// engagement wave.
+	Clusters int
+}
+
+// StartPhaseFunc is called when a start phase begins or completes. It must
+// not block.
+type StartPhaseFunc func(StartPhaseEvent)
+
+// WithStartPhaseCallback calls fn when a phase of starting the manager
+// begins or completes, e.g. to report the progress of starting against a
+// big fleet. The phases are logged and exported as metrics regardless.
+// Engagement waves are reported until the controllers started, and the
+// first clusters were engaged and their caches synced.
+func WithStartPhaseCallback(fn StartPhaseFunc) Option {
+	return func(o *MultiClusterOptions) {
+		o.StartPhaseCallback = fn
+	}
+}
+
+// startPhases tracks the phases of starting the manager.
+type startPhases struct {
+	m *mcManager
+
+	lock       sync.Mutex
+	started    time.Time
+	began      map[StartPhase]time.Time
+	completed  map[StartPhase]bool
+	wave       int
+	inProgress int
+	clusters   int
+	done       bool
+}
+
+// begin starts tracking the phases.
+func (p *startPhases) begin() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.started = time.Now()
+	p.began = map[StartPhase]time.Time{}
+	p.completed = map[StartPhase]bool{}
+	p.beginPhase(PhaseProviderStart)
+	p.beginPhase(PhaseControllersStarted)
+	p.beginPhase(PhaseInitialDiscovery)
+}
+
+// complete completes the phase, unless it completed already.
+func (p *startPhases) complete(phase StartPhase) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.completePhase(phase)
+	p.checkDone()
+}
+
+// engaging records the beginning of an engagement, and returns a function
+// recording its end.
+func (p *startPhases) engaging() func() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.started.IsZero() || p.done {
+		return func() {}
+	}
+	p.completePhase(PhaseInitialDiscovery)
+	if p.inProgress == 0 {
+		p.wave++
+		p.clusters = 0
+		p.beginPhase(PhaseEngagementWave)
+	}
+	p.inProgress++
+	p.clusters++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			p.lock.Lock()
+			defer p.lock.Unlock()
+			p.inProgress--
+			if p.inProgress == 0 {
+				p.completePhase(PhaseEngagementWave)
+				p.checkDone()
+			}
+		})
+	}
+}
+
+// engaged records the end of an engagement once the cache of the cluster
+// synced, the sync timeout passed, or the cluster is disengaged.
+func (p *startPhases) engaged(ctx context.Context, cl cluster.Cluster, done func()) {
+	defer done()
+	ctx, cancel := context.WithTimeout(ctx, p.m.opts.EngagementSyncTimeout)
+	defer cancel()
+	cl.GetCache().WaitForCacheSync(ctx)
+}
+
+// checkDone stops reporting engagement waves once the controllers started,
+// the first clusters were discovered and no wave is in progress. The lock
+// must be held.
+func (p *startPhases) checkDone() {
+	if p.completed[PhaseControllersStarted] && p.completed[PhaseInitialDiscovery] && p.inProgress == 0 {
+		p.done = true
+	}
+}
+
+// beginPhase reports the beginning of the phase. The lock must be held.
+func (p *startPhases) beginPhase(phase StartPhase) {
+	now := time.Now()
+	p.began[phase] = now
+	p.completed[phase] = false
+	evt := StartPhaseEvent{Phase: phase, Elapsed: now.Sub(p.started)}
+	log := p.m.GetLogger().WithValues("phase", phase)
+	if phase == PhaseEngagementWave {
+		evt.Wave = p.wave
+		log = log.WithValues("wave", p.wave)
+	}
+	log.Info("Start phase began", "elapsed", evt.Elapsed)
+	if fn := p.m.opts.StartPhaseCallback; fn != nil {
+		fn(evt)
+	}
+}
+
+// completePhase reports the completion of the phase, unless it completed
+// already. The lock must be held.
+func (p *startPhases) completePhase(phase StartPhase) {
+	began, ok := p.began[phase]
+	if !ok || p.completed[phase] {
+		return
+	}
+	p.completed[phase] = true
+	now := time.Now()
+	evt := StartPhaseEvent{Phase: phase, Completed: true, Elapsed: now.Sub(p.started), Duration: now.Sub(began)}
+	log := p.m.GetLogger().WithValues("phase", phase)
+	if phase == PhaseEngagementWave {
+		evt.Wave, evt.Clusters = p.wave, p.clusters
+		log = log.WithValues("wave", p.wave, "clusters", p.clusters)
+		mcmetrics.StartEngagementWaves.Set(float64(p.wave))
+	}
+	log.Info("Start phase completed", "elapsed", evt.Elapsed, "duration", evt.Duration)
+	mcmetrics.StartPhaseDuration.WithLabelValues(string(phase)).Set(evt.Duration.Seconds())
+	if fn := p.m.opts.StartPhaseCallback; fn != nil {
+		fn(evt)
+	}
+}
+
+// phaseRunnable completes a start phase when the host manager starts it.
+type phaseRunnable struct {
+	phases *startPhases
+	phase  StartPhase
+}
+
+func (r *phaseRunnable) Start(ctx context.Context) error {
+	r.phases.complete(r.phase)
+	<-ctx.Done()
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+func (r *phaseRunnable) NeedLeaderElection() bool {
+	return false
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-logr/logr"
+
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// ProviderRunner is a provider that discovers clusters and engages them
+// with the manager until ctx is done.
+type ProviderRunner interface {
+	Run(ctx context.Context, mgr Manager) error
+}
+
+// ProviderOption configures how a provider is run by the manager.
+type ProviderOption func(*providerRunnable)
+
+// RequireLeadership runs the provider only on the replica elected leader.
+// Other replicas engage no clusters, but keep serving webhooks and
+// metrics.
+func RequireLeadership() ProviderOption {
+	return func(r *providerRunnable) {
+		r.needLeaderElection = true
+	}
+}
+
+// WithProviderName names the provider, to tell apart multiple providers
+// engaging clusters with one manager. The clusters it engages are reported
+// with the provider name in the provider metrics, cluster statuses and
+// cluster events, and its log lines carry the provider name if it logs
+// with the logger of the manager or of its context.
+func WithProviderName(name string) ProviderOption {
+	return func(r *providerRunnable) {
+		r.name = name
+	}
+}
+
+// WithProviderLogVerbosity drops the log lines of the provider above the
+// given verbosity, e.g. to silence a noisy provider. It applies to the
+// logger of the manager and of the context the provider is run with.
+func WithProviderLogVerbosity(v int) ProviderOption {
+	return func(r *providerRunnable) {
+		r.verbosity = &v
+	}
+}
+
+// AddProvider runs the provider with the manager, instead of next to it. The
+// provider is started with the manager, and stopped on shutdown.
+func AddProvider(mgr Manager, p ProviderRunner, opts ...ProviderOption) error {
+	r := &providerRunnable{provider: p, mgr: mgr}
+	for _, o := range opts {
+		o(r)
+	}
+	return mgr.GetLocalManager().Add(r)
+}
+
+var _ manager.LeaderElectionRunnable = &providerRunnable{}
+
+type providerRunnable struct {
+	provider           ProviderRunner
+	mgr                Manager
+	needLeaderElection bool
+	name               string
+	verbosity          *int
+}
+
+// Start runs the provider until ctx is done.
+func (r *providerRunnable) Start(ctx context.Context) error {
+	mgr := r.mgr
+	if r.name != "" || r.verbosity != nil {
+		logger := r.mgr.GetLogger()
+		if r.name != "" {
+			logger = logger.WithValues("provider", r.name)
+		}
+		if r.verbosity != nil && logger.GetSink() != nil {
+			logger = logger.WithSink(&verbositySink{LogSink: logger.GetSink(), max: *r.verbosity})
+		}
+		ctx = log.IntoContext(ctx, logger)
+		mgr = &providerManager{Manager: r.mgr, name: r.name, log: logger}
+	}
+	if err := r.provider.Run(ctx, mgr); err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+func (r *providerRunnable) NeedLeaderElection() bool {
+	return r.needLeaderElection
+}
+
+// providerManager is the manager a named provider is run with. It
+// remembers which provider engaged a cluster.
+type providerManager struct {
+	Manager
+	name string
+	log  logr.Logger
+}
+
+// Engage engages the cluster on behalf of the provider.
+func (m *providerManager) Engage(ctx context.Context, name string, cl cluster.Cluster) error {
+	return m.Manager.Engage(context.WithValue(ctx, providerKey{}, m.name), name, cl)
+}
+
+// GetLogger returns the logger of the provider.
+func (m *providerManager) GetLogger() logr.Logger {
+	return m.log
+}
+
+type providerKey struct{}
+
+// providerFrom returns the name of the provider engaging a cluster with
+// ctx.
+func providerFrom(ctx context.Context) string {
+	name, _ := ctx.Value(providerKey{}).(string)
+	return name
+}
+
+// ProviderName returns the name of the provider that engaged the cluster.
+func (m *mcManager) ProviderName(clusterName string) string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.engaged[clusterName].provider
+}
+
+// verbositySink drops log lines above a maximum verbosity.
+type verbositySink struct {
+	logr.LogSink
+	max int
+}
+
+func (s *verbositySink) Enabled(level int) bool {
+	return level <= s.max && s.LogSink.Enabled(level)
+}
+
+func (s *verbositySink) Info(level int, msg string, keysAndValues ...any) {
+	if level <= s.max {
+		s.LogSink.Info(level, msg, keysAndValues...)
+	}
+}
+
+func (s *verbositySink) WithValues(keysAndValues ...any) logr.LogSink {
+	return &verbositySink{LogSink: s.LogSink.WithValues(keysAndValues...), max: s.max}
+}
+
+func (s *verbositySink) WithName(name string) logr.LogSink {
+	return &verbositySink{LogSink: s.LogSink.WithName(name), max: s.max}
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"context"
+	"errors"
+
+	mcerrors "sigs.k8s.io/multicluster-runtime/pkg/errors"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+// ReEngage lets the provider disengage, recreate and engage the cluster
+// with the given name again.
+func (m *mcManager) ReEngage(ctx context.Context, clusterName string) error {
+	if clusterName == LocalCluster {
+		return errors.New("the local cluster cannot be re-engaged")
+	}
+	if m.opts.SingleCluster {
+		return mcerrors.New(clusterName, "re-engage", errSingleCluster)
+	}
+	m.lock.Lock()
+	_, manual := m.manual[clusterName]
+	stopping := m.stopping
+	m.lock.Unlock()
+	if manual {
+		return mcerrors.New(clusterName, "re-engage", errors.New("manually engaged clusters cannot be re-engaged, stop and engage them again"))
+	}
+	if stopping {
+		return mcerrors.New(clusterName, "re-engage", errShuttingDown)
+	}
+	re, ok := m.provider.(multicluster.ReEngager)
+	if !ok {
+		return mcerrors.New(clusterName, "re-engage", multicluster.ErrReEngageNotSupported)
+	}
+	m.GetLogger().Info("Re-engaging cluster on demand", "cluster", multicluster.EscapeClusterName(clusterName))
+	return mcerrors.New(clusterName, "re-engage", re.ReEngage(ctx, clusterName))
+}
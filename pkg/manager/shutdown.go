@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"context"
+	"errors"
+)
+
+var errShuttingDown = errors.New("manager is shutting down")
+
+// ShutdownHook is called on shutdown after all clusters were disengaged. ctx
+// is done when the flush timeout expires.
+type ShutdownHook func(ctx context.Context) error
+
+// AddShutdownHook adds a hook that is called on shutdown after all clusters
+// were disengaged, while reconcilers are still running.
+func (m *mcManager) AddShutdownHook(hook ShutdownHook) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.shutdownHooks = append(m.shutdownHooks, hook)
+	return nil
+}
+
+// Start starts the host manager and blocks until ctx is done. The phases of
+// starting are logged, exported as metrics and reported to the callback of
+// WithStartPhaseCallback. It then shuts
+// down in phases: the objects to tear down are deleted from the clusters,
+// all clusters are disengaged so that no more events are delivered, the
+// shutdown hooks flush progress while the reconcilers keep running, the
+// host manager is stopped, and finally the objects to tear down are deleted
+// from the host cluster.
+func (m *mcManager) Start(ctx context.Context) error {
+	mgrCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancel()
+
+	m.phases.begin()
+	if err := m.Manager.Add(&phaseRunnable{phases: &m.phases, phase: PhaseProviderStart}); err != nil {
+		return err
+	}
+	go func() {
+		select {
+		case <-m.Manager.Elected():
+			m.phases.complete(PhaseControllersStarted)
+		case <-mgrCtx.Done():
+		}
+	}()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- m.Manager.Start(mgrCtx)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	log := m.GetLogger()
+	m.teardownClusters(mgrCtx)
+
+	log.Info("Stopping event delivery, disengaging all clusters")
+	m.disengageAll()
+
+	log.Info("Flushing before shutdown", "timeout", m.opts.ShutdownFlushTimeout)
+	m.runShutdownHooks(mgrCtx)
+
+	cancel()
+	err := <-errCh
+	// the membership publisher is stopped, it does not recreate its
+	// ConfigMap.
+	m.teardownHost(context.WithoutCancel(ctx))
+	return err
+}
+
+// disengageAll disengages all clusters and refuses further engagements.
+func (m *mcManager) disengageAll() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.stopping = true
+	for _, e := range m.engaged {
+		e.cancel()
+	}
+}
+
+// runShutdownHooks runs the shutdown hooks within the flush timeout.
+func (m *mcManager) runShutdownHooks(ctx context.Context) {
+	m.lock.Lock()
+	hooks := append([]ShutdownHook(nil), m.shutdownHooks...)
+	m.lock.Unlock()
+	if len(hooks) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.opts.ShutdownFlushTimeout)
+	defer cancel()
+	for _, hook := range hooks {
+		if err := hook(ctx); err != nil {
+			m.GetLogger().Error(err, "Shutdown hook failed")
+		}
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"errors"
+
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+var errSingleCluster = errors.New("the manager runs in single-cluster mode")
+
+// WithSingleCluster makes the manager behave exactly like a plain
+// controller-runtime manager of the local cluster, named LocalCluster. No
+// provider can be set, no other clusters can be engaged, and controllers
+// built with the multicluster builder watch the local cluster regardless
+// of their engage options. This allows a controller written against the
+// multi-cluster APIs to ship a single-cluster distribution from the same
+// code.
+func WithSingleCluster() Option {
+	return func(o *MultiClusterOptions) {
+		o.SingleCluster = true
+	}
+}
+
+// NewSingleCluster returns a new Manager in single-cluster mode, see
+// WithSingleCluster.
+func NewSingleCluster(config *rest.Config, opts manager.Options, mcOpts ...Option) (Manager, error) {
+	return New(config, nil, opts, append(mcOpts, WithSingleCluster())...)
+}
+
+// SingleCluster returns whether the manager runs in single-cluster mode.
+func (m *mcManager) SingleCluster() bool {
+	return m.opts.SingleCluster
+}
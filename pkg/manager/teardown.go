@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+// TeardownOptions configure deleting the objects the manager created in
+// the clusters and in the host cluster when it stops.
+type TeardownOptions struct {
+	// ClusterObjects are deleted from every engaged cluster before the
+	// clusters are disengaged, e.g. the webhook configurations and leases
+	// propagated to the clusters. Only their kind, namespace and name are
+	// used.
+	ClusterObjects []client.Object
+
+	// HostObjects are deleted from the host cluster after all components
+	// stopped. The membership ConfigMap is deleted as well, if membership
+	// is published.
+	HostObjects []client.Object
+
+	// Timeout bounds the deletions in each phase. Defaults to 30 seconds.
+	Timeout time.Duration
+}
+
+// WithTeardown deletes the objects of opts when the manager stops. It is
+// meant for clean uninstalls and development environments: the manager
+// cannot tell a permanent stop from a restart or a failover to another
+// replica, so the objects are deleted whenever the context of Start is
+// done. Losing the leader election does not tear down.
+func WithTeardown(opts TeardownOptions) Option {
+	return func(o *MultiClusterOptions) {
+		o.Teardown = &opts
+	}
+}
+
+// teardownClusters deletes the cluster objects from all engaged clusters.
+func (m *mcManager) teardownClusters(ctx context.Context) {
+	opts := m.opts.Teardown
+	if opts == nil || len(opts.ClusterObjects) == 0 {
+		return
+	}
+	m.lock.Lock()
+	clusters := make(map[string]cluster.Cluster, len(m.engaged))
+	for name, e := range m.engaged {
+		clusters[name] = e.cluster
+	}
+	m.lock.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, teardownTimeout(opts))
+	defer cancel()
+	for name, cl := range clusters {
+		for _, obj := range opts.ClusterObjects {
+			m.teardown(ctx, name, cl.GetClient(), obj)
+		}
+	}
+}
+
+// teardownHost deletes the host objects and the membership ConfigMap.
+func (m *mcManager) teardownHost(ctx context.Context) {
+	opts := m.opts.Teardown
+	if opts == nil {
+		return
+	}
+	objs := opts.HostObjects
+	if mo := m.opts.Membership; mo != nil {
+		objs = append(objs[:len(objs):len(objs)], &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: mo.ConfigMap.Namespace, Name: mo.ConfigMap.Name}})
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, teardownTimeout(opts))
+	defer cancel()
+	for _, obj := range objs {
+		m.teardown(ctx, "", m.GetClient(), obj)
+	}
+}
+
+// teardown deletes a copy of obj with cl, logging failures.
+func (m *mcManager) teardown(ctx context.Context, clusterName string, cl client.Client, obj client.Object) {
+	obj = obj.DeepCopyObject().(client.Object)
+	log := m.GetLogger().WithValues("cluster", multicluster.EscapeClusterName(clusterName), "namespace", obj.GetNamespace(), "name", obj.GetName())
+	switch err := cl.Delete(ctx, obj); {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		log.Error(err, "Failed to tear down object")
+	default:
+		log.Info("Tore down object")
+	}
+}
+
+func teardownTimeout(opts *TeardownOptions) time.Duration {
+	if opts.Timeout > 0 {
+		return opts.Timeout
+	}
+	return 30 * time.Second
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics contains the multi-cluster metrics, registered with the
+// controller-runtime metrics registry.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+var (
+	// DeferredWatchesStarted counts the watches started after the watched
+	// kind became available in a cluster.
+	DeferredWatchesStarted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "multicluster_deferred_watches_started_total",
+		Help: "Total number of deferred watches started after their kind became available in a cluster.",
+	}, []string{"cluster", "group_kind"})
+
+	// ClusterCacheInformers is the number of informers in the cache of a
+	// cluster.
+	ClusterCacheInformers = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "multicluster_cluster_cache_informers",
+		Help: "Number of informers in the cache of a cluster.",
+	}, []string{"cluster"})
+
+	// ClusterCacheObjects is the number of cached objects of a cluster per
+	// kind.
+	ClusterCacheObjects = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "multicluster_cluster_cache_objects",
+		Help: "Number of cached objects of a cluster per kind.",
+	}, []string{"cluster", "group_version_kind"})
+
+	// ClusterCacheBytes is the approximate serialized size of the cached
+	// objects of a cluster.
+	ClusterCacheBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "multicluster_cluster_cache_approximate_bytes",
+		Help: "Approximate serialized size of the cached objects of a cluster in bytes.",
+	}, []string{"cluster"})
+
+	// CacheTrimmedBytes counts the bytes of managed fields and last applied
+	// configurations stripped from cached objects per kind.
+	CacheTrimmedBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "multicluster_cache_trimmed_bytes_total",
+		Help: "Total number of bytes of managed fields and last applied configurations stripped from cached objects per kind.",
+	}, []string{"group_version_kind"})
+
+	// ClustersSkipped counts the clusters not watched by a controller, e.g.
+	// because their Kubernetes version is below the minimum supported one.
+	ClustersSkipped = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "multicluster_clusters_skipped_total",
+		Help: "Total number of clusters not watched by a controller, by reason.",
+	}, []string{"cluster", "reason"})
+
+	// ClusterReconcileOutcomes counts the reconcile outcomes reported per
+	// cluster.
+	ClusterReconcileOutcomes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "multicluster_cluster_reconcile_outcomes_total",
+		Help: "Total number of reconcile outcomes reported per controller, cluster and outcome.",
+	}, []string{"controller", "cluster", "outcome"})
+
+	// ClusterRESTMapperReloads counts the reloads of the REST mapper of a
+	// cluster by reason.
+	ClusterRESTMapperReloads = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "multicluster_cluster_rest_mapper_reloads_total",
+		Help: "Total number of reloads of the REST mapper of a cluster, by reason.",
+	}, []string{"cluster", "reason"})
+
+	// ClusterWatchLastEvent is the time of the last event delivered by the
+	// watch of a kind in a cluster.
+	ClusterWatchLastEvent = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "multicluster_cluster_watch_last_event_timestamp_seconds",
+		Help: "Unix time of the last event delivered by the watch of a kind in a cluster.",
+	}, []string{"cluster", "group_version_kind"})
+
+	// ClusterWatchStale is 1 if the watch of a kind in a cluster stopped
+	// delivering events while the API server of the cluster is reachable,
+	// and 0 otherwise.
+	ClusterWatchStale = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "multicluster_cluster_watch_stale",
+		Help: "Whether the watch of a kind in a cluster stopped delivering events while the API server is reachable.",
+	}, []string{"cluster", "group_version_kind"})
+
+	// ClusterWorkqueueAdds counts the requests of a cluster added to the
+	// queue of a controller.
+	ClusterWorkqueueAdds = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "multicluster_workqueue_adds_total",
+		Help: "Total number of requests of a cluster added to the queue of a controller.",
+	}, []string{"controller", "cluster", "provider", "shard"})
+
+	// ClusterWorkqueueDepth is the number of requests of a cluster waiting
+	// in the queue of a controller.
+	ClusterWorkqueueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "multicluster_workqueue_depth",
+		Help: "Number of requests of a cluster waiting in the queue of a controller.",
+	}, []string{"controller", "cluster", "provider", "shard"})
+
+	// ClusterWorkqueueRetries counts the rate limited requeues of requests
+	// of a cluster in the queue of a controller.
+	ClusterWorkqueueRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "multicluster_workqueue_retries_total",
+		Help: "Total number of rate limited requeues of requests of a cluster in the queue of a controller.",
+	}, []string{"controller", "cluster", "provider", "shard"})
+
+	// ClusterWorkqueueNoisiest is the number of requests added to the queue
+	// of a controller in the last window by the clusters with the most
+	// adds, by rank.
+	ClusterWorkqueueNoisiest = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "multicluster_workqueue_noisiest_clusters",
+		Help: "Number of requests added to the queue of a controller in the last window by the clusters with the most adds, by rank.",
+	}, []string{"controller", "rank", "cluster"})
+
+	// ProviderEngagedClusters is the number of clusters engaged by a
+	// provider.
+	ProviderEngagedClusters = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "multicluster_provider_engaged_clusters",
+		Help: "Number of clusters engaged by a provider.",
+	}, []string{"provider"})
+
+	// ProviderEngagements counts the engagements of clusters by a provider
+	// by result.
+	ProviderEngagements = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "multicluster_provider_engagements_total",
+		Help: "Total number of engagements of clusters by a provider, by result.",
+	}, []string{"provider", "result"})
+
+	// EngagementsDelayed is the number of engagements delayed because the
+	// manager is overloaded.
+	EngagementsDelayed = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "multicluster_engagements_delayed",
+		Help: "Number of cluster engagements delayed because the manager is overloaded.",
+	})
+
+	// StartPhaseDuration is the duration of the phases of starting the
+	// manager, of the last completed wave for engagement waves.
+	StartPhaseDuration = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "multicluster_start_phase_duration_seconds",
+		Help: "Duration of the phases of starting the manager, of the last completed wave for engagement waves.",
+	}, []string{"phase"})
+
+	// StartEngagementWaves is the number of engagement waves completed
+	// while starting the manager.
+	StartEngagementWaves = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "multicluster_start_engagement_waves",
+		Help: "Number of engagement waves completed while starting the manager.",
+	})
+)
+
+// ClusterLabel returns the value of the cluster label for the given
+// cluster name, its canonical escaped form.
+func ClusterLabel(name string) string {
+	return multicluster.EscapeClusterName(name)
+}
+
+func init() {
+	metrics.Registry.MustRegister(
+		DeferredWatchesStarted,
+		ClusterCacheInformers,
+		ClusterCacheObjects,
+		ClusterCacheBytes,
+		CacheTrimmedBytes,
+		ClustersSkipped,
+		ClusterReconcileOutcomes,
+		ClusterWatchLastEvent,
+		ClusterWatchStale,
+		ClusterRESTMapperReloads,
+		ClusterWorkqueueAdds,
+		ClusterWorkqueueDepth,
+		ClusterWorkqueueRetries,
+		ClusterWorkqueueNoisiest,
+		EngagementsDelayed,
+		ProviderEngagedClusters,
+		ProviderEngagements,
+		StartPhaseDuration,
+		StartEngagementWaves,
+	)
+}
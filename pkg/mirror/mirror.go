@@ -0,0 +1,407 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mirror copies objects like image pull secrets and CA bundles from
+// the hub cluster into selected spoke clusters, and removes the copies from
+// clusters that are no longer selected.
+package mirror
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/apis/v1alpha1"
+	mcerrors "sigs.k8s.io/multicluster-runtime/pkg/errors"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	"sigs.k8s.io/multicluster-runtime/pkg/selector"
+)
+
+const (
+	// MirrorLabel is set on the copies to the name of the mirror that
+	// created them.
+	MirrorLabel = "multicluster.x-k8s.io/mirror"
+
+	// HashAnnotation is set on the copies to the hash of the mirrored
+	// content. Copies with a matching hash are not written again.
+	HashAnnotation = "multicluster.x-k8s.io/mirror-hash"
+)
+
+// Spec describes an object to mirror.
+type Spec struct {
+	// Source references the object in the hub cluster. Its cluster must be
+	// empty.
+	Source v1alpha1.ClusterObjectReference
+
+	// TargetNamespace is the namespace of the copies. Defaults to the
+	// namespace of the source.
+	TargetNamespace string
+
+	// ClusterSelector selects the spoke clusters. If nil, the object is
+	// mirrored to all engaged clusters.
+	ClusterSelector *selector.ClusterSelector
+}
+
+// Options are the options for the Syncer.
+type Options struct {
+	// ResyncInterval is the interval in which all copies are checked, to
+	// repair changes in the spokes and to follow cluster label changes.
+	// Defaults to five minutes.
+	ResyncInterval time.Duration
+}
+
+var _ mcmanager.Runnable = &Syncer{}
+
+// Syncer mirrors objects from the hub cluster into the engaged clusters.
+// Add it to the manager with Manager.Add.
+type Syncer struct {
+	mgr   mcmanager.Manager
+	opts  Options
+	log   logr.Logger
+	queue workqueue.TypedRateLimitingInterface[item]
+
+	lock     sync.RWMutex
+	clusters map[string]cluster.Cluster
+	mirrors  map[string]mirror
+	watching sets.Set[schema.GroupVersionKind]
+}
+
+type mirror struct {
+	Spec
+	selector *selector.Selector
+}
+
+type item struct {
+	cluster string
+	mirror  string
+}
+
+// New returns a new Syncer.
+func New(mgr mcmanager.Manager, opts Options) *Syncer {
+	if opts.ResyncInterval == 0 {
+		opts.ResyncInterval = 5 * time.Minute
+	}
+	return &Syncer{
+		mgr:      mgr,
+		opts:     opts,
+		log:      log.Log.WithName("mirror"),
+		queue:    workqueue.NewTypedRateLimitingQueueWithConfig(workqueue.DefaultTypedControllerRateLimiter[item](), workqueue.TypedRateLimitingQueueConfig[item]{Name: "mirror"}),
+		clusters: map[string]cluster.Cluster{},
+		mirrors:  map[string]mirror{},
+		watching: sets.New[schema.GroupVersionKind](),
+	}
+}
+
+// Mirror adds or updates the mirror with the given name.
+func (s *Syncer) Mirror(ctx context.Context, name string, spec Spec) error {
+	if spec.Source.Cluster != "" {
+		return fmt.Errorf("source of mirror %q must be in the hub cluster, got cluster %q", name, spec.Source.Cluster)
+	}
+	if spec.Source.Name == "" || spec.Source.Kind == "" || spec.Source.APIVersion == "" {
+		return fmt.Errorf("source of mirror %q must have apiVersion, kind and name", name)
+	}
+	sel, err := spec.ClusterSelector.Compile()
+	if err != nil {
+		return err
+	}
+	if err := s.ensureWatch(ctx, spec.Source.GroupVersionKind()); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	s.mirrors[name] = mirror{Spec: spec, selector: sel}
+	s.lock.Unlock()
+	s.enqueueMirror(name)
+	return nil
+}
+
+// Remove removes the mirror with the given name and deletes its copies
+// from the engaged clusters.
+func (s *Syncer) Remove(ctx context.Context, name string) error {
+	s.lock.Lock()
+	m, ok := s.mirrors[name]
+	delete(s.mirrors, name)
+	clusters := make(map[string]cluster.Cluster, len(s.clusters))
+	for n, cl := range s.clusters {
+		clusters[n] = cl
+	}
+	s.lock.Unlock()
+	if !ok {
+		return nil
+	}
+
+	var errs []error
+	for clusterName, cl := range clusters {
+		if err := deleteCopy(ctx, cl, name, m.Spec); err != nil {
+			errs = append(errs, mcerrors.New(clusterName, fmt.Sprintf("delete copy of mirror %q", name), err))
+		}
+	}
+	return mcerrors.NewAggregate(errs...)
+}
+
+// Engage mirrors the objects into the cluster.
+func (s *Syncer) Engage(ctx context.Context, name string, cl cluster.Cluster) error {
+	s.lock.Lock()
+	s.clusters[name] = cl
+	mirrors := make([]string, 0, len(s.mirrors))
+	for m := range s.mirrors {
+		mirrors = append(mirrors, m)
+	}
+	s.lock.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		if s.clusters[name] == cl {
+			delete(s.clusters, name)
+		}
+	}()
+
+	for _, m := range mirrors {
+		s.queue.Add(item{cluster: name, mirror: m})
+	}
+	return nil
+}
+
+// Start runs the syncer until ctx is done.
+func (s *Syncer) Start(ctx context.Context) error {
+	go func() {
+		<-ctx.Done()
+		s.queue.ShutDown()
+	}()
+	go wait.UntilWithContext(ctx, func(context.Context) {
+		s.lock.RLock()
+		defer s.lock.RUnlock()
+		for name := range s.mirrors {
+			for clusterName := range s.clusters {
+				s.queue.Add(item{cluster: clusterName, mirror: name})
+			}
+		}
+	}, s.opts.ResyncInterval)
+
+	for s.processNextItem(ctx) {
+	}
+	return nil
+}
+
+func (s *Syncer) processNextItem(ctx context.Context) bool {
+	it, shutdown := s.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer s.queue.Done(it)
+
+	if err := s.sync(ctx, it); err != nil {
+		s.log.Error(err, "Failed to mirror object", "cluster", multicluster.EscapeClusterName(it.cluster), "mirror", it.mirror)
+		s.queue.AddRateLimited(it)
+		return true
+	}
+	s.queue.Forget(it)
+	return true
+}
+
+// sync brings the copy of the mirror in the cluster up to date, or deletes
+// it if the cluster is not selected or the source is gone.
+func (s *Syncer) sync(ctx context.Context, it item) error {
+	s.lock.RLock()
+	cl, engaged := s.clusters[it.cluster]
+	m, ok := s.mirrors[it.mirror]
+	s.lock.RUnlock()
+	if !engaged || !ok {
+		return nil
+	}
+
+	selected, err := m.selector.MatchesCluster(ctx, s.mgr, it.cluster)
+	if err != nil {
+		return err
+	}
+	if !selected {
+		return deleteCopy(ctx, cl, it.mirror, m.Spec)
+	}
+
+	source := &unstructured.Unstructured{}
+	source.SetGroupVersionKind(m.Source.GroupVersionKind())
+	if err := s.mgr.GetLocalManager().GetClient().Get(ctx, m.Source.NamespacedName(), source); err != nil {
+		if apierrors.IsNotFound(err) {
+			return deleteCopy(ctx, cl, it.mirror, m.Spec)
+		}
+		return err
+	}
+	desired, err := copyOf(source, it.mirror, m.Spec)
+	if err != nil {
+		return err
+	}
+
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(desired.GroupVersionKind())
+	err = cl.GetClient().Get(ctx, client.ObjectKeyFromObject(desired), live)
+	switch {
+	case apierrors.IsNotFound(err):
+		return cl.GetClient().Create(ctx, desired)
+	case err != nil:
+		return err
+	case live.GetLabels()[MirrorLabel] != it.mirror:
+		return fmt.Errorf("%s %s in cluster %q is not managed by mirror %q", live.GetKind(), client.ObjectKeyFromObject(live), it.cluster, it.mirror)
+	case live.GetAnnotations()[HashAnnotation] == desired.GetAnnotations()[HashAnnotation]:
+		return nil
+	}
+	desired.SetResourceVersion(live.GetResourceVersion())
+	return cl.GetClient().Update(ctx, desired)
+}
+
+// ensureWatch enqueues the mirrors of hub objects of the given kind when
+// they change.
+func (s *Syncer) ensureWatch(ctx context.Context, gvk schema.GroupVersionKind) error {
+	s.lock.RLock()
+	watching := s.watching.Has(gvk)
+	s.lock.RUnlock()
+	if watching {
+		return nil
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	inf, err := s.mgr.GetLocalManager().GetCache().GetInformer(ctx, obj, cache.BlockUntilSynced(false))
+	if err != nil {
+		return fmt.Errorf("failed to get informer for %s: %w", gvk, err)
+	}
+	enqueue := func(o interface{}) {
+		if tombstone, ok := o.(toolscache.DeletedFinalStateUnknown); ok {
+			o = tombstone.Obj
+		}
+		obj, ok := o.(client.Object)
+		if !ok {
+			return
+		}
+		key := client.ObjectKeyFromObject(obj)
+		s.lock.RLock()
+		var names []string
+		for name, m := range s.mirrors {
+			if m.Source.GroupVersionKind() == gvk && m.Source.NamespacedName() == key {
+				names = append(names, name)
+			}
+		}
+		s.lock.RUnlock()
+		for _, name := range names {
+			s.enqueueMirror(name)
+		}
+	}
+	if _, err := inf.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    enqueue,
+		UpdateFunc: func(_, o interface{}) { enqueue(o) },
+		DeleteFunc: enqueue,
+	}); err != nil {
+		return fmt.Errorf("failed to watch %s: %w", gvk, err)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.watching.Insert(gvk)
+	return nil
+}
+
+func (s *Syncer) enqueueMirror(name string) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	for clusterName := range s.clusters {
+		s.queue.Add(item{cluster: clusterName, mirror: name})
+	}
+}
+
+// copyOf returns the copy of source written to the spokes, with the hash of
+// its content.
+func copyOf(source *unstructured.Unstructured, mirrorName string, spec Spec) (*unstructured.Unstructured, error) {
+	content := make(map[string]interface{}, len(source.Object))
+	for k, v := range source.Object {
+		switch k {
+		case "metadata", "status", "apiVersion", "kind":
+			continue
+		}
+		content[k] = v
+	}
+	hashed, err := json.Marshal(map[string]interface{}{
+		"content":     content,
+		"labels":      source.GetLabels(),
+		"annotations": source.GetAnnotations(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(hashed)
+
+	u := &unstructured.Unstructured{Object: content}
+	u = u.DeepCopy()
+	u.SetGroupVersionKind(source.GroupVersionKind())
+	u.SetNamespace(targetKey(spec).Namespace)
+	u.SetName(source.GetName())
+	labels := map[string]string{}
+	for k, v := range source.GetLabels() {
+		labels[k] = v
+	}
+	labels[MirrorLabel] = mirrorName
+	u.SetLabels(labels)
+	annotations := map[string]string{}
+	for k, v := range source.GetAnnotations() {
+		annotations[k] = v
+	}
+	annotations[HashAnnotation] = hex.EncodeToString(sum[:])
+	u.SetAnnotations(annotations)
+	return u, nil
+}
+
+func targetKey(spec Spec) types.NamespacedName {
+	key := spec.Source.NamespacedName()
+	if spec.TargetNamespace != "" {
+		key.Namespace = spec.TargetNamespace
+	}
+	return key
+}
+
+// deleteCopy deletes the copy of the mirror, if it exists and was created
+// by the mirror.
+func deleteCopy(ctx context.Context, cl cluster.Cluster, mirrorName string, spec Spec) error {
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(spec.Source.GroupVersionKind())
+	if err := cl.GetClient().Get(ctx, targetKey(spec), live); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if live.GetLabels()[MirrorLabel] != mirrorName {
+		return nil
+	}
+	return client.IgnoreNotFound(cl.GetClient().Delete(ctx, live))
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mirror
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/apis/v1alpha1"
+	"sigs.k8s.io/multicluster-runtime/pkg/fake"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	"sigs.k8s.io/multicluster-runtime/pkg/selector"
+)
+
+var _ = Describe("Syncer", func() {
+	var (
+		mgr *fake.Manager
+		s   *Syncer
+	)
+	key := types.NamespacedName{Namespace: "default", Name: "pull"}
+	spec := Spec{
+		Source:          v1alpha1.ClusterObjectReference{APIVersion: "v1", Kind: "Secret", Namespace: "default", Name: "pull"},
+		TargetNamespace: "kube-system",
+		ClusterSelector: &selector.ClusterSelector{Expression: "env=prod"},
+	}
+	target := types.NamespacedName{Namespace: "kube-system", Name: "pull"}
+
+	drain := func(ctx context.Context) {
+		for s.queue.Len() > 0 {
+			s.processNextItem(ctx)
+		}
+	}
+	copyIn := func(ctx context.Context, cluster string) (*corev1.Secret, error) {
+		secret := &corev1.Secret{}
+		err := mgr.FakeCluster(cluster).GetClient().Get(ctx, target, secret)
+		return secret, err
+	}
+
+	BeforeEach(func(ctx context.Context) {
+		mgr = fake.NewManagerBuilder().
+			WithCluster(mcmanager.LocalCluster, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+				Data:       map[string][]byte{"token": []byte("v1")},
+			}).
+			WithClusterMetadata("prod", multicluster.Metadata{Labels: map[string]string{"env": "prod"}}).
+			WithClusterMetadata("dev", multicluster.Metadata{Labels: map[string]string{"env": "dev"}}).
+			Build()
+		s = New(mgr, Options{})
+		Expect(s.Engage(ctx, "prod", mgr.FakeCluster("prod"))).To(Succeed())
+		Expect(s.Engage(ctx, "dev", mgr.FakeCluster("dev"))).To(Succeed())
+		Expect(s.Mirror(ctx, "pull", spec)).To(Succeed())
+		drain(ctx)
+	})
+
+	It("mirrors the object into selected clusters", func(ctx context.Context) {
+		secret, err := copyIn(ctx, "prod")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(secret.Data).To(HaveKeyWithValue("token", []byte("v1")))
+		Expect(secret.Labels).To(HaveKeyWithValue(MirrorLabel, "pull"))
+		Expect(secret.Annotations).To(HaveKey(HashAnnotation))
+
+		_, err = copyIn(ctx, "dev")
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("updates copies only when the content changes", func(ctx context.Context) {
+		before, err := copyIn(ctx, "prod")
+		Expect(err).NotTo(HaveOccurred())
+		s.enqueueMirror("pull")
+		drain(ctx)
+		unchanged, err := copyIn(ctx, "prod")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(unchanged.ResourceVersion).To(Equal(before.ResourceVersion))
+
+		hub := mgr.GetLocalManager().GetClient()
+		source := &corev1.Secret{}
+		Expect(hub.Get(ctx, key, source)).To(Succeed())
+		source.Data["token"] = []byte("v2")
+		Expect(hub.Update(ctx, source)).To(Succeed())
+		s.enqueueMirror("pull")
+		drain(ctx)
+
+		updated, err := copyIn(ctx, "prod")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(updated.Data).To(HaveKeyWithValue("token", []byte("v2")))
+		Expect(updated.Annotations[HashAnnotation]).NotTo(Equal(before.Annotations[HashAnnotation]))
+	})
+
+	It("deletes copies from deselected clusters", func(ctx context.Context) {
+		mgr.FakeProvider().Add("prod", mgr.FakeCluster("prod"), multicluster.Metadata{Labels: map[string]string{"env": "dev"}})
+		s.enqueueMirror("pull")
+		drain(ctx)
+		_, err := copyIn(ctx, "prod")
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("deletes copies when the mirror is removed", func(ctx context.Context) {
+		Expect(s.Remove(ctx, "pull")).To(Succeed())
+		_, err := copyIn(ctx, "prod")
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("does not overwrite objects it does not manage", func(ctx context.Context) {
+		Expect(mgr.FakeCluster("prod").GetClient().Delete(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: target.Namespace, Name: target.Name}})).To(Succeed())
+		Expect(mgr.FakeCluster("prod").GetClient().Create(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: target.Namespace, Name: target.Name}})).To(Succeed())
+		Expect(s.sync(ctx, item{cluster: "prod", mirror: "pull"})).To(MatchError(ContainSubstring("not managed by mirror")))
+	})
+})
@@ -24,6 +24,11 @@ var (
 	// ErrClusterNotFound can be returned by provider implementations if the cluster requested
 	// doesn't exist and cannot be constructed.
 	ErrClusterNotFound = errClusterNotFound()
+
+	// ErrReEngageNotSupported is returned when a cluster cannot be
+	// re-engaged on demand because its provider does not implement
+	// ReEngager.
+	ErrReEngageNotSupported = errors.New("re-engaging clusters is not supported by the provider")
 )
 
 func errClusterNotFound() error { return errors.New("cluster not found") }
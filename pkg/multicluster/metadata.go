@@ -0,0 +1,43 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multicluster
+
+import (
+	"context"
+)
+
+// Metadata is metadata about a cluster supplied by its provider, e.g. derived
+// from the labels of a Cluster API Cluster object.
+type Metadata struct {
+	// Labels are the labels of the cluster.
+	Labels map[string]string
+
+	// Annotations are the annotations of the cluster.
+	Annotations map[string]string
+
+	// Taints are the taints of the cluster.
+	Taints []Taint
+}
+
+// MetadataProvider is an optional interface a Provider can implement to
+// supply metadata about its clusters.
+type MetadataProvider interface {
+	// GetMetadata returns the metadata of the cluster with the given name.
+	// If no cluster is known to the provider under the given cluster name,
+	// ErrClusterNotFound should be returned.
+	GetMetadata(ctx context.Context, clusterName string) (Metadata, error)
+}
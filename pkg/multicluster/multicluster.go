@@ -47,6 +47,10 @@ type Aware interface {
 // it is run against whenever a new cluster is discovered and cancelling the
 // context used on engage when a cluster is unregistered.
 //
+// The manager and the components it engages call back into the provider, e.g.
+// Get and GetMetadata, while a cluster is being engaged. Providers must not
+// hold the locks these methods take while calling Engage.
+//
 // Example: A Cluster API provider would be responsible for discovering and
 // managing clusters that are backed by Cluster API resources, which can live
 // in multiple namespaces in a single management cluster.
@@ -61,3 +65,13 @@ type Provider interface {
 	// clusters, current and future.
 	IndexField(ctx context.Context, obj client.Object, field string, extractValue client.IndexerFunc) error
 }
+
+// ReEngager is an optional interface a Provider can implement to recover a
+// single cluster on demand, e.g. one whose watches are wedged.
+type ReEngager interface {
+	// ReEngage disengages the cluster with the given name, creates its
+	// client and cache anew, and engages it again once the cache synced.
+	// If no cluster is known to the provider under the given cluster name,
+	// ErrClusterNotFound should be returned.
+	ReEngage(ctx context.Context, clusterName string) error
+}
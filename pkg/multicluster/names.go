@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multicluster
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxClusterNameLength is the maximum length in bytes of a cluster name.
+const MaxClusterNameLength = 253
+
+// ErrInvalidClusterName is returned by ValidateClusterName and
+// UnescapeClusterName for names that are not acceptable.
+var ErrInvalidClusterName = errors.New("invalid cluster name")
+
+// ValidateClusterName checks that name can be used as a cluster name: it
+// must be non-empty valid UTF-8 of at most MaxClusterNameLength bytes
+// without control characters. Any other character is allowed and made
+// safe by EscapeClusterName where needed.
+func ValidateClusterName(name string) error {
+	switch {
+	case name == "":
+		return fmt.Errorf("%w: must not be empty", ErrInvalidClusterName)
+	case len(name) > MaxClusterNameLength:
+		return fmt.Errorf("%w: %q is longer than %d bytes", ErrInvalidClusterName, name, MaxClusterNameLength)
+	case !utf8.ValidString(name):
+		return fmt.Errorf("%w: %q is not valid UTF-8", ErrInvalidClusterName, name)
+	case strings.IndexFunc(name, unicode.IsControl) >= 0:
+		return fmt.Errorf("%w: %q contains control characters", ErrInvalidClusterName, name)
+	}
+	return nil
+}
+
+// EscapeClusterName returns the canonical escaped form of a cluster name,
+// to be used wherever the name ends up in a metric label, log value or URL
+// path.
+//
+// Lowercase letters and digits are kept, as are '-' and '.' except at the
+// start or end. Every other byte is written as 'X' followed by two
+// uppercase hex digits. As uppercase letters are always escaped, the
+// escaping is unambiguous and UnescapeClusterName restores the original
+// name. Lowercase DNS names, the most common cluster names, are returned
+// unchanged.
+//
+// The escaped name can be up to three times as long as the name. It is
+// therefore not necessarily a valid Kubernetes label value or object name,
+// which are limited to 63 and 253 characters. Hash the name where these
+// limits apply.
+func EscapeClusterName(name string) string {
+	var b strings.Builder
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9':
+			b.WriteByte(c)
+		case (c == '-' || c == '.') && i > 0 && i < len(name)-1:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "X%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// UnescapeClusterName reverses EscapeClusterName. It returns an error
+// wrapping ErrInvalidClusterName if escaped is not in canonical form.
+func UnescapeClusterName(escaped string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(escaped); i++ {
+		c := escaped[i]
+		if c != 'X' {
+			b.WriteByte(c)
+			continue
+		}
+		if i+2 >= len(escaped) {
+			return "", fmt.Errorf("%w: truncated escape in %q", ErrInvalidClusterName, escaped)
+		}
+		hi, lo := unhex(escaped[i+1]), unhex(escaped[i+2])
+		if hi < 0 || lo < 0 {
+			return "", fmt.Errorf("%w: malformed escape in %q", ErrInvalidClusterName, escaped)
+		}
+		b.WriteByte(byte(hi<<4 | lo))
+		i += 2
+	}
+	name := b.String()
+	if EscapeClusterName(name) != escaped {
+		return "", fmt.Errorf("%w: %q is not canonically escaped", ErrInvalidClusterName, escaped)
+	}
+	return name, nil
+}
+
+func unhex(c byte) int {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0')
+	case c >= 'A' && c <= 'F':
+		return int(c-'A') + 10
+	}
+	return -1
+}
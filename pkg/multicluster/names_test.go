@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multicluster
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+var _ = Describe("Cluster names", func() {
+	DescribeTable("escapes to label-safe values that round-trip",
+		func(name, escaped string) {
+			Expect(ValidateClusterName(name)).To(Succeed())
+			Expect(EscapeClusterName(name)).To(Equal(escaped))
+			Expect(validation.IsValidLabelValue(escaped)).To(BeEmpty())
+			Expect(UnescapeClusterName(escaped)).To(Equal(name))
+		},
+		Entry("DNS name", "prod-eu.example.com", "prod-eu.example.com"),
+		Entry("uppercase", "Prod", "X50rod"),
+		Entry("escape character", "X", "X58"),
+		Entry("path", "team/cluster", "teamX2Fcluster"),
+		Entry("leading and trailing separators", "-a.", "X2DaX2E"),
+		Entry("single dot", ".", "X2E"),
+		Entry("unicode", "clüster", "clXC3XBCster"),
+		Entry("underscore and colon", "arn:aws_1", "arnX3AawsX5F1"),
+	)
+
+	It("does not map different names to the same escaped name", func() {
+		names := []string{"a-b", "a_b", "aX2Db", "A-b", "a--b", "a/b", "a%2Fb"}
+		seen := map[string]string{}
+		for _, n := range names {
+			e := EscapeClusterName(n)
+			Expect(seen).NotTo(HaveKey(e), "%q and %q", n, seen[e])
+			seen[e] = n
+		}
+	})
+
+	DescribeTable("rejects names that are not canonically escaped",
+		func(escaped string) {
+			_, err := UnescapeClusterName(escaped)
+			Expect(err).To(MatchError(ErrInvalidClusterName))
+		},
+		Entry("truncated escape", "aX2"),
+		Entry("lowercase hex", "aX2f"),
+		Entry("escaped lowercase letter", "X61"),
+		Entry("unescaped uppercase", "aB"),
+		Entry("unescaped slash", "a/b"),
+		Entry("leading dash", "-a"),
+	)
+
+	DescribeTable("rejects invalid names",
+		func(name string) {
+			Expect(ValidateClusterName(name)).To(MatchError(ErrInvalidClusterName))
+		},
+		Entry("empty", ""),
+		Entry("too long", strings.Repeat("a", MaxClusterNameLength+1)),
+		Entry("invalid UTF-8", "a\xffb"),
+		Entry("control character", "a\nb"),
+	)
+})
@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multicluster
+
+// TaintEffect describes how controllers treat a tainted cluster.
+type TaintEffect string
+
+const (
+	// TaintEffectNoSchedule means that no new work is placed onto the
+	// cluster by controllers not tolerating the taint.
+	TaintEffectNoSchedule TaintEffect = "NoSchedule"
+
+	// TaintEffectNoEngage means that controllers not tolerating the taint
+	// do not watch the cluster.
+	TaintEffectNoEngage TaintEffect = "NoEngage"
+)
+
+// Taint marks a cluster such that controllers not tolerating it avoid the
+// cluster, e.g. during maintenance.
+type Taint struct {
+	// Key is the taint key.
+	Key string
+
+	// Value is the taint value.
+	Value string
+
+	// Effect is the effect of the taint on controllers not tolerating it.
+	Effect TaintEffect
+}
+
+// TolerationOperator is the operator of a toleration.
+type TolerationOperator string
+
+const (
+	// TolerationOpExists tolerates taints with the key, regardless of the
+	// value.
+	TolerationOpExists TolerationOperator = "Exists"
+
+	// TolerationOpEqual tolerates taints with the key and value.
+	TolerationOpEqual TolerationOperator = "Equal"
+)
+
+// Toleration lets a controller work on clusters with matching taints.
+type Toleration struct {
+	// Key is the taint key the toleration applies to. An empty key with
+	// operator Exists tolerates all taints.
+	Key string
+
+	// Operator is the operator. Defaults to Equal.
+	Operator TolerationOperator
+
+	// Value is the taint value the toleration matches with operator Equal.
+	Value string
+
+	// Effect is the taint effect to tolerate. Empty tolerates all effects.
+	Effect TaintEffect
+}
+
+// Tolerates returns whether the toleration tolerates the taint.
+func (t Toleration) Tolerates(taint Taint) bool {
+	if t.Effect != "" && t.Effect != taint.Effect {
+		return false
+	}
+	if t.Key != "" && t.Key != taint.Key {
+		return false
+	}
+	switch t.Operator {
+	case TolerationOpExists:
+		return true
+	case TolerationOpEqual, "":
+		return t.Key != "" && t.Value == taint.Value
+	default:
+		return false
+	}
+}
+
+// FindUntoleratedTaint returns the first taint with one of the given effects
+// that is not tolerated by any of the tolerations.
+func FindUntoleratedTaint(taints []Taint, tolerations []Toleration, effects ...TaintEffect) (Taint, bool) {
+	for _, taint := range taints {
+		if !hasEffect(taint.Effect, effects) {
+			continue
+		}
+		tolerated := false
+		for _, t := range tolerations {
+			if t.Tolerates(taint) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			return taint, true
+		}
+	}
+	return Taint{}, false
+}
+
+func hasEffect(effect TaintEffect, effects []TaintEffect) bool {
+	if len(effects) == 0 {
+		return true
+	}
+	for _, e := range effects {
+		if e == effect {
+			return true
+		}
+	}
+	return false
+}
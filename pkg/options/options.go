@@ -0,0 +1,279 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package options provides flags to choose and configure the cluster
+// provider of a multi-cluster binary at startup.
+package options
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	flag "github.com/spf13/pflag"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/config"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	dnsprovider "sigs.k8s.io/multicluster-runtime/providers/dns"
+	nsprovider "sigs.k8s.io/multicluster-runtime/providers/namespace"
+	"sigs.k8s.io/multicluster-runtime/providers/registration"
+	singleprovider "sigs.k8s.io/multicluster-runtime/providers/single"
+)
+
+// Provider is a multicluster provider that is run against a manager.
+type Provider interface {
+	multicluster.Provider
+
+	// Run starts the provider and blocks.
+	Run(ctx context.Context, mgr mcmanager.Manager) error
+}
+
+// ProviderFactory constructs a provider from the completed configuration.
+// localMgr is the controller-runtime manager of the host cluster.
+type ProviderFactory func(cfg *config.FleetConfiguration, localMgr manager.Manager) (Provider, error)
+
+// Options holds the provider flags. Use NewOptions to create it, register
+// additional providers with Register, add the flags with AddFlags, and call
+// Complete, Validate and NewProvider after parsing.
+type Options struct {
+	// Provider is the name of the provider.
+	Provider string
+
+	// RequireLeadership runs the provider only on the replica elected
+	// leader.
+	RequireLeadership bool
+
+	// ConfigFile is the path of a FleetConfiguration file. Flags override
+	// the values of the file.
+	ConfigFile string
+
+	// CacheSyncPeriod overrides the cache sync period of the clusters.
+	CacheSyncPeriod time.Duration
+
+	// CacheNamespaces overrides the namespaces cached in the clusters.
+	CacheNamespaces []string
+
+	// CacheDisabled engages the clusters without caches, reading directly
+	// from their API servers.
+	CacheDisabled bool
+
+	// CacheKeepManagedFields keeps the managed fields and last applied
+	// configurations of cached objects.
+	CacheKeepManagedFields bool
+
+	// EngagementParallelism overrides the number of clusters whose caches
+	// sync at the same time.
+	EngagementParallelism int
+
+	// EngagementStagger overrides the minimum time between engagements.
+	EngagementStagger time.Duration
+
+	// KindPrefix overrides the name prefix of the engaged kind clusters.
+	// Its flag is only added if the kind provider is registered.
+	KindPrefix string
+
+	// ClusterAPINamespaces overrides the namespaces of the Cluster-API
+	// clusters. Its flag is only added if the Cluster-API provider is
+	// registered.
+	ClusterAPINamespaces []string
+
+	// ExecAllowList overrides the exec credential plugins that kubeconfigs
+	// read from secrets may run.
+	ExecAllowList []string
+
+	// Config is the completed configuration, set by Complete.
+	Config *config.FleetConfiguration
+
+	factories map[string]ProviderFactory
+}
+
+// NewOptions returns options with the providers of this module registered:
+// "namespace", which represents each namespace of the host as a cluster,
+// "single", which engages the host cluster as the only cluster,
+// "cluster-registration", which engages the clusters registered with
+// ClusterRegistration objects, and "dns", which engages the clusters
+// advertised by DNS SRV records. "cluster-registration" requires the
+// v1alpha1 types in the scheme of the local manager.
+//
+// The "kind" and "cluster-api" providers live in modules of their own.
+// Register their Factory under config.ProviderKind and
+// config.ProviderClusterAPI before calling AddFlags to use them.
+func NewOptions() *Options {
+	o := &Options{factories: map[string]ProviderFactory{}}
+	o.Register(config.ProviderNamespace, func(_ *config.FleetConfiguration, localMgr manager.Manager) (Provider, error) {
+		return nsprovider.New(localMgr), nil
+	})
+	o.Register(config.ProviderSingle, func(_ *config.FleetConfiguration, localMgr manager.Manager) (Provider, error) {
+		return singleprovider.New("local", localMgr), nil
+	})
+	o.Register(config.ProviderClusterRegistration, func(cfg *config.FleetConfiguration, localMgr manager.Manager) (Provider, error) {
+		p, err := registration.New(localMgr, registration.Options{
+			ClusterOptions: cfg.ClusterOptions(),
+			Kubeconfig:     cfg.KubeconfigOptions(),
+			Proxy:          cfg.ProxyFunc(),
+			Trust:          cfg.TrustFunc(),
+			Namespaces:     cfg.NamespacesFunc(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return p, nil
+	})
+	o.Register(config.ProviderDNS, func(cfg *config.FleetConfiguration, _ manager.Manager) (Provider, error) {
+		d := cfg.Provider.DNS
+		opts := dnsprovider.Options{
+			Catalog: &dnsprovider.SRVCatalog{Service: d.Service, Proto: d.Proto, Domain: d.Domain},
+			Credentials: dnsprovider.Credentials{
+				TokenFile: d.TokenFile,
+				CertDir:   d.CertDir,
+				CAFile:    d.CAFile,
+			},
+			ClusterOptions: cfg.ClusterOptions(),
+			Proxy:          cfg.ProxyFunc(),
+			Trust:          cfg.TrustFunc(),
+			Namespaces:     cfg.NamespacesFunc(),
+		}
+		if d.Interval != nil {
+			opts.Interval = d.Interval.Duration
+		}
+		return dnsprovider.New(opts)
+	})
+	return o
+}
+
+// Register registers a provider factory under the given name, replacing any
+// factory registered before.
+func (o *Options) Register(name string, factory ProviderFactory) {
+	o.factories[name] = factory
+}
+
+// Providers returns the sorted names of the registered providers.
+func (o *Options) Providers() []string {
+	names := make([]string, 0, len(o.factories))
+	for name := range o.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// AddFlags adds the provider flags to fs. The flags of the kind and
+// Cluster-API providers are only added if they are registered.
+func (o *Options) AddFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.Provider, "cluster-provider", o.Provider, fmt.Sprintf("The cluster provider to use, one of %v.", o.Providers()))
+	fs.BoolVar(&o.RequireLeadership, "cluster-provider-require-leadership", o.RequireLeadership, "Run the cluster provider only on the replica elected leader. Other replicas engage no clusters.")
+	fs.StringVar(&o.ConfigFile, "fleet-config", o.ConfigFile, "Path of a FleetConfiguration file. Flags override its values.")
+	fs.DurationVar(&o.CacheSyncPeriod, "cluster-cache-sync-period", o.CacheSyncPeriod, "The cache sync period of the engaged clusters.")
+	fs.StringSliceVar(&o.CacheNamespaces, "cluster-cache-namespaces", o.CacheNamespaces, "The namespaces cached in the engaged clusters. All namespaces if empty.")
+	fs.BoolVar(&o.CacheKeepManagedFields, "cluster-cache-keep-managed-fields", o.CacheKeepManagedFields, "Keep the managed fields and last applied configurations of cached objects instead of stripping them.")
+	fs.BoolVar(&o.CacheDisabled, "cluster-cache-disabled", o.CacheDisabled, "Engage the clusters without caches, with rate limited live reads. Clusters are not watched.")
+	fs.IntVar(&o.EngagementParallelism, "cluster-engagement-parallelism", o.EngagementParallelism, "The maximum number of clusters whose caches sync at the same time. Unlimited if zero.")
+	fs.DurationVar(&o.EngagementStagger, "cluster-engagement-stagger", o.EngagementStagger, "The minimum time between the engagement of two clusters.")
+	if _, ok := o.factories[config.ProviderKind]; ok {
+		fs.StringVar(&o.KindPrefix, "kind-cluster-prefix", o.KindPrefix, "The name prefix of the kind clusters to engage.")
+	}
+	if _, ok := o.factories[config.ProviderClusterAPI]; ok {
+		fs.StringSliceVar(&o.ClusterAPINamespaces, "cluster-api-namespaces", o.ClusterAPINamespaces, "The namespaces of the Cluster-API clusters to engage. All namespaces if empty.")
+	}
+	fs.StringSliceVar(&o.ExecAllowList, "cluster-exec-plugin-allowlist", o.ExecAllowList, "The exec credential plugin commands that kubeconfigs of clusters may run. Exec plugins are rejected if empty.")
+}
+
+// Complete loads the configuration file, if any, applies the flags on top
+// of it and completes the result.
+func (o *Options) Complete() error {
+	cfg := &config.FleetConfiguration{}
+	if o.ConfigFile != "" {
+		var err error
+		if cfg, err = config.Load(o.ConfigFile); err != nil {
+			return err
+		}
+	}
+
+	if o.Provider != "" {
+		cfg.Provider.Name = o.Provider
+	}
+	if o.RequireLeadership {
+		cfg.Provider.RequireLeadership = true
+	}
+	if o.CacheSyncPeriod != 0 {
+		cfg.Cache.SyncPeriod = &metav1.Duration{Duration: o.CacheSyncPeriod}
+	}
+	if len(o.CacheNamespaces) > 0 {
+		cfg.Cache.Namespaces = o.CacheNamespaces
+	}
+	if o.EngagementParallelism != 0 {
+		cfg.Engagement.Parallelism = o.EngagementParallelism
+	}
+	if o.EngagementStagger != 0 {
+		cfg.Engagement.Stagger = &metav1.Duration{Duration: o.EngagementStagger}
+	}
+	if o.CacheKeepManagedFields {
+		cfg.Cache.KeepManagedFields = true
+	}
+	if o.CacheDisabled && cfg.Cache.Live == nil {
+		cfg.Cache.Live = &config.LiveConfiguration{}
+	}
+	if o.KindPrefix != "" {
+		cfg.Provider.Kind = &config.KindConfiguration{Prefix: o.KindPrefix}
+	}
+	if len(o.ClusterAPINamespaces) > 0 {
+		if cfg.Provider.ClusterAPI == nil {
+			cfg.Provider.ClusterAPI = &config.ClusterAPIConfiguration{}
+		}
+		cfg.Provider.ClusterAPI.Namespaces = o.ClusterAPINamespaces
+	}
+	if len(o.ExecAllowList) > 0 {
+		cfg.Provider.Auth = &config.AuthConfiguration{ExecAllowList: o.ExecAllowList}
+	}
+
+	cfg.Complete()
+	o.Config = cfg
+	return nil
+}
+
+// Validate returns an error if the completed options are invalid.
+func (o *Options) Validate() error {
+	if o.Config == nil {
+		return fmt.Errorf("options are not completed")
+	}
+	if err := o.Config.Validate(); err != nil {
+		return err
+	}
+	if _, ok := o.factories[o.Config.Provider.Name]; !ok {
+		return fmt.Errorf("provider %q is not registered, expected one of %v", o.Config.Provider.Name, o.Providers())
+	}
+	return nil
+}
+
+// NewProvider constructs the chosen provider. localMgr is the
+// controller-runtime manager of the host cluster; wrap it with
+// mcmanager.WithMultiCluster and the returned provider afterwards.
+func (o *Options) NewProvider(localMgr manager.Manager) (Provider, error) {
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+	p, err := o.factories[o.Config.Provider.Name](o.Config, localMgr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create provider %q: %w", o.Config.Provider.Name, err)
+	}
+	return p, nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	flag "github.com/spf13/pflag"
+
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/config"
+	nop "sigs.k8s.io/multicluster-runtime/providers/nop"
+)
+
+var _ = Describe("Options", func() {
+	parse := func(o *Options, args ...string) {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		o.AddFlags(fs)
+		Expect(fs.Parse(args)).To(Succeed())
+		Expect(o.Complete()).To(Succeed())
+	}
+
+	It("constructs the chosen provider with the flag values", func() {
+		o := NewOptions()
+		var got *config.FleetConfiguration
+		o.Register("test", func(cfg *config.FleetConfiguration, _ manager.Manager) (Provider, error) {
+			got = cfg
+			return nop.New(), nil
+		})
+		parse(o, "--cluster-provider=test", "--cluster-cache-sync-period=1m", "--cluster-cache-namespaces=a,b",
+			"--cluster-engagement-parallelism=4", "--cluster-engagement-stagger=100ms", "--cluster-provider-require-leadership")
+
+		p, err := o.NewProvider(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p).NotTo(BeNil())
+		Expect(got.Cache.SyncPeriod.Duration).To(Equal(time.Minute))
+		Expect(got.Cache.Namespaces).To(Equal([]string{"a", "b"}))
+		Expect(got.Engagement.Parallelism).To(Equal(4))
+		Expect(got.Engagement.Stagger.Duration).To(Equal(100 * time.Millisecond))
+		Expect(got.ManagerOptions()).To(HaveLen(1))
+		Expect(got.ProviderOptions()).To(HaveLen(1))
+	})
+
+	It("rejects unregistered providers", func() {
+		o := NewOptions()
+		parse(o, "--cluster-provider=unknown")
+		Expect(o.Validate()).To(MatchError(ContainSubstring(`provider "unknown" is not registered`)))
+	})
+
+	It("adds the flags of the kind and Cluster-API providers only if they are registered", func() {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		NewOptions().AddFlags(fs)
+		Expect(fs.Lookup("kind-cluster-prefix")).To(BeNil())
+		Expect(fs.Lookup("cluster-api-namespaces")).To(BeNil())
+
+		o := NewOptions()
+		var got *config.FleetConfiguration
+		o.Register(config.ProviderClusterAPI, func(cfg *config.FleetConfiguration, _ manager.Manager) (Provider, error) {
+			got = cfg
+			return nop.New(), nil
+		})
+		parse(o, "--cluster-provider=cluster-api", "--cluster-api-namespaces=a,b")
+
+		_, err := o.NewProvider(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(got.Provider.ClusterAPI.Namespaces).To(Equal([]string{"a", "b"}))
+	})
+
+	It("requires a provider", func() {
+		o := NewOptions()
+		parse(o)
+		Expect(o.Validate()).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,83 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package placement
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// OCMPlacementLabel is the label of the PlacementDecisions of an Open
+// Cluster Management Placement, set to the name of the Placement.
+const OCMPlacementLabel = "cluster.open-cluster-management.io/placement"
+
+// OCMPlacementDecisionGVK is the kind of Open Cluster Management
+// PlacementDecisions.
+var OCMPlacementDecisionGVK = schema.GroupVersionKind{Group: "cluster.open-cluster-management.io", Version: "v1beta1", Kind: "PlacementDecision"}
+
+var _ Placement = &OCMPlacement{}
+
+// OCMPlacement is the decision of an Open Cluster Management Placement,
+// read from its PlacementDecisions in the hub cluster. The decisions are
+// read as unstructured objects, such that the Open Cluster Management API
+// is not a dependency.
+type OCMPlacement struct {
+	reader    client.Reader
+	placement types.NamespacedName
+}
+
+// NewOCMPlacement returns the decision of the Placement with the given key,
+// read with reader. Pass the cache of the host manager, i.e.
+// Manager.GetLocalManager().GetCache(), to read from an informer instead
+// of the API server.
+func NewOCMPlacement(reader client.Reader, placement types.NamespacedName) *OCMPlacement {
+	return &OCMPlacement{reader: reader, placement: placement}
+}
+
+// Clusters returns the sorted names of the clusters in the decisions of
+// the Placement.
+func (p *OCMPlacement) Clusters(ctx context.Context) ([]string, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(OCMPlacementDecisionGVK.GroupVersion().WithKind(OCMPlacementDecisionGVK.Kind + "List"))
+	if err := p.reader.List(ctx, list, client.InNamespace(p.placement.Namespace), client.MatchingLabels{OCMPlacementLabel: p.placement.Name}); err != nil {
+		return nil, fmt.Errorf("failed to list decisions of placement %s: %w", p.placement, err)
+	}
+	clusters := sets.New[string]()
+	for _, item := range list.Items {
+		decisions, _, err := unstructured.NestedSlice(item.Object, "status", "decisions")
+		if err != nil {
+			return nil, fmt.Errorf("invalid placement decision %s/%s: %w", item.GetNamespace(), item.GetName(), err)
+		}
+		for _, d := range decisions {
+			decision, ok := d.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if name, ok := decision["clusterName"].(string); ok && name != "" {
+				clusters.Insert(name)
+			}
+		}
+	}
+	return sets.List(clusters), nil
+}
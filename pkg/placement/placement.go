@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package placement restricts the fan-out of controllers to the clusters
+// chosen by an external placement engine, like the Placements of Open
+// Cluster Management, instead of reimplementing scheduling.
+package placement
+
+import (
+	"context"
+	"slices"
+
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	mcpredicate "sigs.k8s.io/multicluster-runtime/pkg/predicate"
+)
+
+var log = logf.Log.WithName("placement")
+
+// Placement is the decision of a placement engine.
+type Placement interface {
+	// Clusters returns the names of the clusters currently chosen.
+	Clusters(ctx context.Context) ([]string, error)
+}
+
+// Func adapts a function to a Placement.
+type Func func(ctx context.Context) ([]string, error)
+
+// Clusters implements Placement.
+func (f Func) Clusters(ctx context.Context) ([]string, error) {
+	return f(ctx)
+}
+
+// Selected returns whether p chose the cluster with the given name.
+func Selected(ctx context.Context, p Placement, clusterName string) (bool, error) {
+	clusters, err := p.Clusters(ctx)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(clusters, clusterName), nil
+}
+
+// Predicate returns a predicate processing the events of the clusters
+// currently chosen by p, e.g. for Builder.WithClusterEventFilter. The
+// placement is evaluated for every event, such that the controller follows
+// changed decisions. Objects of newly chosen clusters are only reconciled
+// on their next event, combine the predicate with a periodic resync to
+// converge without events. Events are dropped while the placement cannot
+// be evaluated.
+func Predicate(p Placement) mcpredicate.ClusterPredicate {
+	selected := func(clusterName string) bool {
+		ok, err := Selected(context.Background(), p, clusterName)
+		if err != nil {
+			log.Error(err, "Failed to evaluate placement, dropping event")
+			return false
+		}
+		return ok
+	}
+	return mcpredicate.Funcs{
+		CreateFunc:  func(clusterName string, _ event.CreateEvent) bool { return selected(clusterName) },
+		UpdateFunc:  func(clusterName string, _ event.UpdateEvent) bool { return selected(clusterName) },
+		DeleteFunc:  func(clusterName string, _ event.DeleteEvent) bool { return selected(clusterName) },
+		GenericFunc: func(clusterName string, _ event.GenericEvent) bool { return selected(clusterName) },
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package placement
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+var _ = Describe("OCMPlacement", func() {
+	decision := func(name, placement string, clusters ...string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(OCMPlacementDecisionGVK)
+		u.SetNamespace("apps")
+		u.SetName(name)
+		u.SetLabels(map[string]string{OCMPlacementLabel: placement})
+		var decisions []interface{}
+		for _, c := range clusters {
+			decisions = append(decisions, map[string]interface{}{"clusterName": c, "reason": ""})
+		}
+		Expect(unstructured.SetNestedSlice(u.Object, decisions, "status", "decisions")).To(Succeed())
+		return u
+	}
+
+	It("returns the clusters of all decisions of the placement", func(ctx context.Context) {
+		c := clientfake.NewClientBuilder().WithObjects(
+			decision("web-decision-1", "web", "spoke-1", "spoke-2"),
+			decision("web-decision-2", "web", "spoke-3", "spoke-1"),
+			decision("db-decision-1", "db", "spoke-4"),
+		).Build()
+
+		clusters, err := NewOCMPlacement(c, types.NamespacedName{Namespace: "apps", Name: "web"}).Clusters(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clusters).To(Equal([]string{"spoke-1", "spoke-2", "spoke-3"}))
+
+		clusters, err = NewOCMPlacement(c, types.NamespacedName{Namespace: "apps", Name: "missing"}).Clusters(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clusters).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Predicate", func() {
+	It("processes the events of the chosen clusters", func() {
+		chosen := []string{"spoke-1"}
+		var listErr error
+		p := Predicate(Func(func(context.Context) ([]string, error) { return chosen, listErr }))
+		evt := event.CreateEvent{Object: &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm"}}}
+
+		Expect(p.Create("spoke-1", evt)).To(BeTrue())
+		Expect(p.Create("spoke-2", evt)).To(BeFalse())
+
+		chosen = []string{"spoke-2"}
+		Expect(p.Create("spoke-1", evt)).To(BeFalse())
+		Expect(p.Delete("spoke-2", event.DeleteEvent{Object: evt.Object})).To(BeTrue())
+
+		listErr = errors.New("boom")
+		Expect(p.Generic("spoke-2", event.GenericEvent{Object: evt.Object})).To(BeFalse())
+	})
+})
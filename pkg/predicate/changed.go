@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predicate
+
+import (
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+var (
+	_ ClusterPredicate = GenerationChangedPredicate{}
+	_ ClusterPredicate = AnnotationChangedPredicate{}
+	_ ClusterPredicate = LabelChangedPredicate{}
+)
+
+// GenerationChangedPredicate is a GenerationChangedPredicate for
+// client.Objects.
+type GenerationChangedPredicate = TypedGenerationChangedPredicate[client.Object]
+
+// TypedGenerationChangedPredicate skips update events that do not change
+// the generation of the object, like predicate.GenerationChangedPredicate,
+// except in the excluded clusters.
+type TypedGenerationChangedPredicate[object metav1.Object] struct {
+	TypedFuncs[object]
+
+	// ExcludedClusters are the clusters whose update events are all
+	// processed, e.g. clusters with API servers not bumping the
+	// generation of a kind.
+	ExcludedClusters []string
+}
+
+// Update implements TypedClusterPredicate.
+func (p TypedGenerationChangedPredicate[object]) Update(clusterName string, e event.TypedUpdateEvent[object]) bool {
+	if slices.Contains(p.ExcludedClusters, clusterName) {
+		return true
+	}
+	return predicate.TypedGenerationChangedPredicate[object]{}.Update(e)
+}
+
+// AnnotationChangedPredicate is an AnnotationChangedPredicate for
+// client.Objects.
+type AnnotationChangedPredicate = TypedAnnotationChangedPredicate[client.Object]
+
+// TypedAnnotationChangedPredicate skips update events that do not change
+// the annotations of the object, like
+// predicate.AnnotationChangedPredicate, except in the excluded clusters.
+type TypedAnnotationChangedPredicate[object metav1.Object] struct {
+	TypedFuncs[object]
+
+	// ExcludedClusters are the clusters whose update events are all
+	// processed.
+	ExcludedClusters []string
+}
+
+// Update implements TypedClusterPredicate.
+func (p TypedAnnotationChangedPredicate[object]) Update(clusterName string, e event.TypedUpdateEvent[object]) bool {
+	if slices.Contains(p.ExcludedClusters, clusterName) {
+		return true
+	}
+	return predicate.TypedAnnotationChangedPredicate[object]{}.Update(e)
+}
+
+// LabelChangedPredicate is a LabelChangedPredicate for client.Objects.
+type LabelChangedPredicate = TypedLabelChangedPredicate[client.Object]
+
+// TypedLabelChangedPredicate skips update events that do not change the
+// labels of the object, like predicate.LabelChangedPredicate, except in
+// the excluded clusters.
+type TypedLabelChangedPredicate[object metav1.Object] struct {
+	TypedFuncs[object]
+
+	// ExcludedClusters are the clusters whose update events are all
+	// processed.
+	ExcludedClusters []string
+}
+
+// Update implements TypedClusterPredicate.
+func (p TypedLabelChangedPredicate[object]) Update(clusterName string, e event.TypedUpdateEvent[object]) bool {
+	if slices.Contains(p.ExcludedClusters, clusterName) {
+		return true
+	}
+	return predicate.TypedLabelChangedPredicate[object]{}.Update(e)
+}
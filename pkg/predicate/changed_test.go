@@ -0,0 +1,52 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predicate_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	mcpredicate "sigs.k8s.io/multicluster-runtime/pkg/predicate"
+)
+
+var _ = Describe("Changed predicates", func() {
+	update := func(oldMeta, newMeta metav1.ObjectMeta) event.UpdateEvent {
+		return event.UpdateEvent{ObjectOld: &corev1.ConfigMap{ObjectMeta: oldMeta}, ObjectNew: &corev1.ConfigMap{ObjectMeta: newMeta}}
+	}
+
+	It("skips unchanged updates except in excluded clusters", func() {
+		gen := mcpredicate.GenerationChangedPredicate{ExcludedClusters: []string{"legacy"}}
+		Expect(gen.Update("spoke", update(metav1.ObjectMeta{Generation: 1}, metav1.ObjectMeta{Generation: 1}))).To(BeFalse())
+		Expect(gen.Update("spoke", update(metav1.ObjectMeta{Generation: 1}, metav1.ObjectMeta{Generation: 2}))).To(BeTrue())
+		Expect(gen.Update("legacy", update(metav1.ObjectMeta{Generation: 1}, metav1.ObjectMeta{Generation: 1}))).To(BeTrue())
+		Expect(gen.Create("spoke", event.CreateEvent{Object: &corev1.ConfigMap{}})).To(BeTrue())
+
+		ann := mcpredicate.AnnotationChangedPredicate{ExcludedClusters: []string{"legacy"}}
+		Expect(ann.Update("spoke", update(metav1.ObjectMeta{}, metav1.ObjectMeta{Generation: 2}))).To(BeFalse())
+		Expect(ann.Update("spoke", update(metav1.ObjectMeta{}, metav1.ObjectMeta{Annotations: map[string]string{"a": "b"}}))).To(BeTrue())
+		Expect(ann.Update("legacy", update(metav1.ObjectMeta{}, metav1.ObjectMeta{}))).To(BeTrue())
+
+		lbl := mcpredicate.LabelChangedPredicate{}
+		Expect(lbl.Update("spoke", update(metav1.ObjectMeta{}, metav1.ObjectMeta{Annotations: map[string]string{"a": "b"}}))).To(BeFalse())
+		Expect(lbl.Update("spoke", update(metav1.ObjectMeta{}, metav1.ObjectMeta{Labels: map[string]string{"a": "b"}}))).To(BeTrue())
+	})
+})
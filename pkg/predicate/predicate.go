@@ -0,0 +1,278 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package predicate contains cluster-aware predicates, which filter events
+// depending on the cluster they were observed in.
+package predicate
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	mchandler "sigs.k8s.io/multicluster-runtime/pkg/handler"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+)
+
+// ClusterPredicate filters events of client.Objects by cluster.
+type ClusterPredicate = TypedClusterPredicate[client.Object]
+
+// TypedClusterPredicate filters events before they are passed to the event
+// handler, like predicate.TypedPredicate, additionally receiving the name
+// of the cluster the event was observed in.
+type TypedClusterPredicate[object any] interface {
+	// Create returns true if the Create event should be processed.
+	Create(clusterName string, e event.TypedCreateEvent[object]) bool
+
+	// Update returns true if the Update event should be processed.
+	Update(clusterName string, e event.TypedUpdateEvent[object]) bool
+
+	// Delete returns true if the Delete event should be processed.
+	Delete(clusterName string, e event.TypedDeleteEvent[object]) bool
+
+	// Generic returns true if the Generic event should be processed.
+	Generic(clusterName string, e event.TypedGenericEvent[object]) bool
+}
+
+var _ ClusterPredicate = Funcs{}
+
+// Funcs is a function that implements ClusterPredicate.
+type Funcs = TypedFuncs[client.Object]
+
+// TypedFuncs implements TypedClusterPredicate with functions. Nil
+// functions process the events.
+type TypedFuncs[object any] struct {
+	CreateFunc  func(clusterName string, e event.TypedCreateEvent[object]) bool
+	UpdateFunc  func(clusterName string, e event.TypedUpdateEvent[object]) bool
+	DeleteFunc  func(clusterName string, e event.TypedDeleteEvent[object]) bool
+	GenericFunc func(clusterName string, e event.TypedGenericEvent[object]) bool
+}
+
+// Create implements TypedClusterPredicate.
+func (p TypedFuncs[object]) Create(clusterName string, e event.TypedCreateEvent[object]) bool {
+	return p.CreateFunc == nil || p.CreateFunc(clusterName, e)
+}
+
+// Update implements TypedClusterPredicate.
+func (p TypedFuncs[object]) Update(clusterName string, e event.TypedUpdateEvent[object]) bool {
+	return p.UpdateFunc == nil || p.UpdateFunc(clusterName, e)
+}
+
+// Delete implements TypedClusterPredicate.
+func (p TypedFuncs[object]) Delete(clusterName string, e event.TypedDeleteEvent[object]) bool {
+	return p.DeleteFunc == nil || p.DeleteFunc(clusterName, e)
+}
+
+// Generic implements TypedClusterPredicate.
+func (p TypedFuncs[object]) Generic(clusterName string, e event.TypedGenericEvent[object]) bool {
+	return p.GenericFunc == nil || p.GenericFunc(clusterName, e)
+}
+
+// Lift turns a predicate without multi-cluster support into a
+// ClusterPredicate applying it to the events of all clusters.
+func Lift[object any](p predicate.TypedPredicate[object]) TypedClusterPredicate[object] {
+	return TypedFuncs[object]{
+		CreateFunc:  func(_ string, e event.TypedCreateEvent[object]) bool { return p.Create(e) },
+		UpdateFunc:  func(_ string, e event.TypedUpdateEvent[object]) bool { return p.Update(e) },
+		DeleteFunc:  func(_ string, e event.TypedDeleteEvent[object]) bool { return p.Delete(e) },
+		GenericFunc: func(_ string, e event.TypedGenericEvent[object]) bool { return p.Generic(e) },
+	}
+}
+
+// ForCluster binds a ClusterPredicate to one cluster, returning a
+// predicate without multi-cluster support.
+func ForCluster[object any](p TypedClusterPredicate[object], clusterName string) predicate.TypedPredicate[object] {
+	return predicate.TypedFuncs[object]{
+		CreateFunc:  func(e event.TypedCreateEvent[object]) bool { return p.Create(clusterName, e) },
+		UpdateFunc:  func(e event.TypedUpdateEvent[object]) bool { return p.Update(clusterName, e) },
+		DeleteFunc:  func(e event.TypedDeleteEvent[object]) bool { return p.Delete(clusterName, e) },
+		GenericFunc: func(e event.TypedGenericEvent[object]) bool { return p.Generic(clusterName, e) },
+	}
+}
+
+// InClusters returns a predicate processing the events of the clusters with
+// the given names only.
+func InClusters[object any](names ...string) TypedClusterPredicate[object] {
+	in := sets.New(names...)
+	return TypedFuncs[object]{
+		CreateFunc:  func(clusterName string, _ event.TypedCreateEvent[object]) bool { return in.Has(clusterName) },
+		UpdateFunc:  func(clusterName string, _ event.TypedUpdateEvent[object]) bool { return in.Has(clusterName) },
+		DeleteFunc:  func(clusterName string, _ event.TypedDeleteEvent[object]) bool { return in.Has(clusterName) },
+		GenericFunc: func(clusterName string, _ event.TypedGenericEvent[object]) bool { return in.Has(clusterName) },
+	}
+}
+
+// And returns a predicate processing events all the given predicates
+// process.
+func And[object any](predicates ...TypedClusterPredicate[object]) TypedClusterPredicate[object] {
+	return and[object](predicates)
+}
+
+type and[object any] []TypedClusterPredicate[object]
+
+func (a and[object]) Create(clusterName string, e event.TypedCreateEvent[object]) bool {
+	for _, p := range a {
+		if !p.Create(clusterName, e) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a and[object]) Update(clusterName string, e event.TypedUpdateEvent[object]) bool {
+	for _, p := range a {
+		if !p.Update(clusterName, e) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a and[object]) Delete(clusterName string, e event.TypedDeleteEvent[object]) bool {
+	for _, p := range a {
+		if !p.Delete(clusterName, e) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a and[object]) Generic(clusterName string, e event.TypedGenericEvent[object]) bool {
+	for _, p := range a {
+		if !p.Generic(clusterName, e) {
+			return false
+		}
+	}
+	return true
+}
+
+// Or returns a predicate processing events any of the given predicates
+// processes.
+func Or[object any](predicates ...TypedClusterPredicate[object]) TypedClusterPredicate[object] {
+	return or[object](predicates)
+}
+
+type or[object any] []TypedClusterPredicate[object]
+
+func (o or[object]) Create(clusterName string, e event.TypedCreateEvent[object]) bool {
+	for _, p := range o {
+		if p.Create(clusterName, e) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o or[object]) Update(clusterName string, e event.TypedUpdateEvent[object]) bool {
+	for _, p := range o {
+		if p.Update(clusterName, e) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o or[object]) Delete(clusterName string, e event.TypedDeleteEvent[object]) bool {
+	for _, p := range o {
+		if p.Delete(clusterName, e) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o or[object]) Generic(clusterName string, e event.TypedGenericEvent[object]) bool {
+	for _, p := range o {
+		if p.Generic(clusterName, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// Not returns a predicate processing the events p does not process.
+func Not[object any](p TypedClusterPredicate[object]) TypedClusterPredicate[object] {
+	return not[object]{p: p}
+}
+
+type not[object any] struct {
+	p TypedClusterPredicate[object]
+}
+
+func (n not[object]) Create(clusterName string, e event.TypedCreateEvent[object]) bool {
+	return !n.p.Create(clusterName, e)
+}
+
+func (n not[object]) Update(clusterName string, e event.TypedUpdateEvent[object]) bool {
+	return !n.p.Update(clusterName, e)
+}
+
+func (n not[object]) Delete(clusterName string, e event.TypedDeleteEvent[object]) bool {
+	return !n.p.Delete(clusterName, e)
+}
+
+func (n not[object]) Generic(clusterName string, e event.TypedGenericEvent[object]) bool {
+	return !n.p.Generic(clusterName, e)
+}
+
+// FilterHandler wraps an event handler constructor such that the handlers
+// only receive the events of their cluster that all predicates process.
+func FilterHandler[object client.Object, request mcreconcile.ClusterAware[request]](h mchandler.TypedEventHandlerFunc[object, request], predicates ...TypedClusterPredicate[object]) mchandler.TypedEventHandlerFunc[object, request] {
+	if len(predicates) == 0 {
+		return h
+	}
+	p := And(predicates...)
+	return func(clusterName string, cl cluster.Cluster) handler.TypedEventHandler[object, request] {
+		return &filteredHandler[object, request]{h: h(clusterName, cl), p: p, clusterName: clusterName}
+	}
+}
+
+type filteredHandler[object client.Object, request mcreconcile.ClusterAware[request]] struct {
+	h           handler.TypedEventHandler[object, request]
+	p           TypedClusterPredicate[object]
+	clusterName string
+}
+
+func (f *filteredHandler[object, request]) Create(ctx context.Context, e event.TypedCreateEvent[object], q workqueue.TypedRateLimitingInterface[request]) {
+	if f.p.Create(f.clusterName, e) {
+		f.h.Create(ctx, e, q)
+	}
+}
+
+func (f *filteredHandler[object, request]) Update(ctx context.Context, e event.TypedUpdateEvent[object], q workqueue.TypedRateLimitingInterface[request]) {
+	if f.p.Update(f.clusterName, e) {
+		f.h.Update(ctx, e, q)
+	}
+}
+
+func (f *filteredHandler[object, request]) Delete(ctx context.Context, e event.TypedDeleteEvent[object], q workqueue.TypedRateLimitingInterface[request]) {
+	if f.p.Delete(f.clusterName, e) {
+		f.h.Delete(ctx, e, q)
+	}
+}
+
+func (f *filteredHandler[object, request]) Generic(ctx context.Context, e event.TypedGenericEvent[object], q workqueue.TypedRateLimitingInterface[request]) {
+	if f.p.Generic(f.clusterName, e) {
+		f.h.Generic(ctx, e, q)
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predicate_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	mchandler "sigs.k8s.io/multicluster-runtime/pkg/handler"
+	mcpredicate "sigs.k8s.io/multicluster-runtime/pkg/predicate"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Cluster predicates", func() {
+	labeled := func(labels map[string]string) event.CreateEvent {
+		return event.CreateEvent{Object: &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "foo", Labels: labels}}}
+	}
+
+	It("combines predicates with And, Or and Not", func() {
+		prod := mcpredicate.Lift[client.Object](predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return obj.GetLabels()["env"] == "prod"
+		}))
+		p := mcpredicate.Or(mcpredicate.And(mcpredicate.InClusters[client.Object]("canary"), mcpredicate.Not(prod)), mcpredicate.InClusters[client.Object]("stable"))
+
+		Expect(p.Create("canary", labeled(nil))).To(BeTrue())
+		Expect(p.Create("canary", labeled(map[string]string{"env": "prod"}))).To(BeFalse())
+		Expect(p.Create("stable", labeled(map[string]string{"env": "prod"}))).To(BeTrue())
+		Expect(p.Create("other", labeled(nil))).To(BeFalse())
+		Expect(mcpredicate.And[client.Object]().Create("other", labeled(nil))).To(BeTrue())
+		Expect(mcpredicate.Or[client.Object]().Create("other", labeled(nil))).To(BeFalse())
+
+		bound := mcpredicate.ForCluster(p, "canary")
+		Expect(bound.Create(labeled(nil))).To(BeTrue())
+		cm := labeled(nil).Object
+		Expect(bound.Update(event.UpdateEvent{ObjectOld: cm, ObjectNew: cm})).To(BeTrue())
+	})
+
+	It("filters the events passed to handlers by cluster", func() {
+		h := mcpredicate.FilterHandler(mchandler.TypedLift[client.Object](&handler.EnqueueRequestForObject{}), mcpredicate.InClusters[client.Object]("a"))
+		q := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[mcreconcile.Request]())
+		defer q.ShutDown()
+
+		h("b", nil).Create(context.Background(), labeled(nil), q)
+		Expect(q.Len()).To(BeZero())
+		h("a", nil).Create(context.Background(), labeled(nil), q)
+		Expect(q.Len()).To(Equal(1))
+		item, _ := q.Get()
+		Expect(item.ClusterName).To(Equal("a"))
+	})
+})
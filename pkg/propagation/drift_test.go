@@ -0,0 +1,60 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagation
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("contains", func() {
+	live := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":            "foo",
+			"resourceVersion": "42",
+		},
+		"data": map[string]interface{}{
+			"a": "1",
+			"b": "2",
+		},
+		"list": []interface{}{int64(1), int64(2)},
+	}
+
+	It("should ignore fields only present in the live object", func() {
+		Expect(contains(live, map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "foo"},
+			"data":     map[string]interface{}{"a": "1"},
+		})).To(BeTrue())
+	})
+
+	It("should detect changed values", func() {
+		Expect(contains(live, map[string]interface{}{
+			"data": map[string]interface{}{"a": "2"},
+		})).To(BeFalse())
+	})
+
+	It("should detect missing fields", func() {
+		Expect(contains(live, map[string]interface{}{
+			"data": map[string]interface{}{"c": "3"},
+		})).To(BeFalse())
+	})
+
+	It("should compare lists element-wise", func() {
+		Expect(contains(live, map[string]interface{}{"list": []interface{}{int64(1), int64(2)}})).To(BeTrue())
+		Expect(contains(live, map[string]interface{}{"list": []interface{}{int64(1)}})).To(BeFalse())
+	})
+})
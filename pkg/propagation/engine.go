@@ -0,0 +1,592 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package propagation implements an engine that maintains desired objects in
+// all matching engaged clusters through server-side apply, watches them for
+// drift and reports per-cluster outcomes.
+package propagation
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/go-logr/logr"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/clustergroup"
+	mcerrors "sigs.k8s.io/multicluster-runtime/pkg/errors"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	"sigs.k8s.io/multicluster-runtime/pkg/selector"
+)
+
+// Status is the outcome of propagating an object to a cluster.
+type Status string
+
+const (
+	// StatusApplied means the object was applied successfully.
+	StatusApplied Status = "Applied"
+	// StatusFailed means the object could not be applied.
+	StatusFailed Status = "Failed"
+	// StatusDrifted means the object was changed in the cluster and diverged
+	// from the desired state. It is re-applied afterwards.
+	StatusDrifted Status = "Drifted"
+)
+
+// Result is the outcome of propagating an object to a cluster.
+type Result struct {
+	// Cluster is the name of the cluster.
+	Cluster string
+	// Object identifies the propagated object.
+	Object ObjectID
+	// Revision is the revision of the desired state of the object, counting
+	// its changes.
+	Revision int64
+	// Status is the outcome.
+	Status Status
+	// Err is the error for StatusFailed.
+	Err error
+}
+
+// ObjectID identifies a propagated object.
+type ObjectID struct {
+	schema.GroupVersionKind
+	types.NamespacedName
+}
+
+// String returns the string representation.
+func (id ObjectID) String() string {
+	return id.GroupVersionKind.String() + ", " + id.NamespacedName.String()
+}
+
+// Options are the options for the Engine.
+type Options struct {
+	// FieldManager is the field manager used for server-side apply. Required.
+	FieldManager string
+
+	// Selector decides whether an object is propagated to a cluster. If nil,
+	// objects are propagated to all engaged clusters. Objects are deleted
+	// from clusters that stop being selected; call Engine.Resync when the
+	// outcome of the selectors changes.
+	Selector func(clusterName string, cl cluster.Cluster) bool
+
+	// ClusterSelector selects the clusters objects are propagated to by
+	// their metadata. It is combined with Selector. If nil, objects are
+	// propagated to all engaged clusters.
+	ClusterSelector *selector.Selector
+
+	// ClusterGroups and ClusterGroup restrict the clusters objects are
+	// propagated to to the members of the named group if set. They are
+	// combined with the selectors.
+	ClusterGroups *clustergroup.Groups
+	ClusterGroup  string
+
+	// OnResult is called for every outcome of an apply or drift check.
+	OnResult func(ctx context.Context, result Result)
+
+	// MaxConcurrentApplies is the number of concurrent workers. Defaults to 1.
+	MaxConcurrentApplies int
+
+	// Rollout gates changes of propagated objects into canaries and waves
+	// if set. Otherwise changes are applied to all clusters at once.
+	Rollout *RolloutStrategy
+
+	// Journal records the state of every object in every cluster before a
+	// revision is applied if set, enabling Rollback.
+	Journal Journal
+
+	// Transforms customize the desired objects per cluster, in order.
+	Transforms []Transform
+}
+
+var _ mcmanager.Runnable = &Engine{}
+
+// Engine maintains desired objects in the engaged clusters. Add it to the
+// manager with Manager.Add.
+type Engine struct {
+	mgr    mcmanager.Manager
+	opts   Options
+	scheme *runtime.Scheme
+	log    logr.Logger
+	queue  workqueue.TypedRateLimitingInterface[item]
+
+	lock     sync.RWMutex
+	clusters map[string]cluster.Cluster
+	desired  map[ObjectID]*unstructured.Unstructured
+	watching map[string]sets.Set[schema.GroupVersionKind]
+	rollouts map[ObjectID]*rollout
+
+	revisions  map[ObjectID]int64
+	rolledBack map[item]int64
+
+	// applied are the UIDs of the objects applied to the clusters.
+	applied map[item]types.UID
+}
+
+// item is an object to apply to a cluster, or the rollout of an object to
+// advance if cluster is empty.
+type item struct {
+	cluster string
+	id      ObjectID
+}
+
+// New returns a new propagation Engine.
+func New(mgr mcmanager.Manager, opts Options) (*Engine, error) {
+	if opts.FieldManager == "" {
+		return nil, fmt.Errorf("field manager must be set")
+	}
+	if opts.MaxConcurrentApplies == 0 {
+		opts.MaxConcurrentApplies = 1
+	}
+	return &Engine{
+		mgr:      mgr,
+		opts:     opts,
+		scheme:   mgr.GetLocalManager().GetScheme(),
+		log:      log.Log.WithName("propagation").WithValues("fieldManager", opts.FieldManager),
+		queue:    workqueue.NewTypedRateLimitingQueueWithConfig(workqueue.DefaultTypedControllerRateLimiter[item](), workqueue.TypedRateLimitingQueueConfig[item]{Name: "propagation-" + opts.FieldManager}),
+		clusters: map[string]cluster.Cluster{},
+		desired:  map[ObjectID]*unstructured.Unstructured{},
+		watching: map[string]sets.Set[schema.GroupVersionKind]{},
+		rollouts: map[ObjectID]*rollout{},
+
+		revisions:  map[ObjectID]int64{},
+		rolledBack: map[item]int64{},
+		applied:    map[item]types.UID{},
+	}, nil
+}
+
+// Propagate sets the desired state of the given object and applies it to all
+// matching engaged clusters, current and future. With a rollout strategy, a
+// changed desired state starts a new rollout.
+func (e *Engine) Propagate(obj client.Object) (ObjectID, error) {
+	u, err := e.toUnstructured(obj)
+	if err != nil {
+		return ObjectID{}, err
+	}
+	id := idOf(u)
+
+	e.lock.Lock()
+	if prev, ok := e.desired[id]; !ok || !equality.Semantic.DeepEqual(prev.Object, u.Object) {
+		e.revisions[id]++
+		if e.opts.Rollout != nil {
+			e.rollouts[id] = newRollout(e.revisions[id])
+		}
+		for it := range e.rolledBack {
+			if it.id == id {
+				delete(e.rolledBack, it)
+			}
+		}
+	}
+	e.desired[id] = u
+	names := make([]string, 0, len(e.clusters))
+	for name := range e.clusters {
+		names = append(names, name)
+	}
+	e.lock.Unlock()
+
+	if e.opts.Rollout != nil {
+		e.queue.Add(item{id: id})
+	}
+	for _, name := range names {
+		e.queue.Add(item{cluster: name, id: id})
+	}
+	return id, nil
+}
+
+// Remove stops propagating the given object and deletes it from the
+// engaged clusters it was applied to or that are selected. Applies in
+// flight delete what they applied once they find the object removed.
+func (e *Engine) Remove(ctx context.Context, id ObjectID) error {
+	e.lock.Lock()
+	delete(e.desired, id)
+	delete(e.rollouts, id)
+	clusters := make(map[string]cluster.Cluster, len(e.clusters))
+	for name, cl := range e.clusters {
+		clusters[name] = cl
+	}
+	applied := map[string]types.UID{}
+	for it, uid := range e.applied {
+		if it.id == id {
+			applied[it.cluster] = uid
+			delete(e.applied, it)
+		}
+	}
+	e.lock.Unlock()
+
+	var errs []error
+	for name, cl := range clusters {
+		uid, ok := applied[name]
+		if !ok {
+			selected, err := e.selected(ctx, name, cl)
+			if err != nil {
+				errs = append(errs, mcerrors.New(name, "delete "+id.String(), err))
+				continue
+			}
+			if !selected {
+				continue
+			}
+		}
+		if err := e.delete(ctx, name, cl, id, uid); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return mcerrors.NewAggregate(errs...)
+}
+
+// Resync checks all desired objects in all engaged clusters again, e.g.
+// after the outcome of the selectors changed. Objects are applied to newly
+// selected clusters, and deleted from clusters no longer selected.
+func (e *Engine) Resync() {
+	e.lock.RLock()
+	var items []item
+	for id := range e.desired {
+		for name := range e.clusters {
+			items = append(items, item{cluster: name, id: id})
+		}
+	}
+	e.lock.RUnlock()
+	for _, it := range items {
+		e.queue.Add(it)
+	}
+	e.advanceRollouts()
+}
+
+// delete deletes the object with the given ID from the given cluster. If
+// uid is set, only the object with that UID is deleted, such that an
+// object re-created in the meantime is kept.
+func (e *Engine) delete(ctx context.Context, clusterName string, cl cluster.Cluster, id ObjectID, uid types.UID) error {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(id.GroupVersionKind)
+	obj.SetNamespace(id.Namespace)
+	obj.SetName(id.Name)
+	var opts []client.DeleteOption
+	if uid != "" {
+		opts = append(opts, client.Preconditions{UID: &uid})
+	}
+	err := cl.GetClient().Delete(ctx, obj, opts...)
+	if apierrors.IsNotFound(err) || (uid != "" && apierrors.IsConflict(err)) {
+		return nil
+	}
+	if err != nil {
+		return mcerrors.New(clusterName, "delete "+id.String(), err)
+	}
+	return nil
+}
+
+// Engage applies all desired objects to the given cluster and keeps them
+// there until ctx is done.
+func (e *Engine) Engage(ctx context.Context, name string, cl cluster.Cluster) error {
+	e.lock.Lock()
+	e.clusters[name] = cl
+	e.watching[name] = sets.New[schema.GroupVersionKind]()
+	ids := make([]ObjectID, 0, len(e.desired))
+	for id := range e.desired {
+		ids = append(ids, id)
+	}
+	e.lock.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		e.lock.Lock()
+		if e.clusters[name] == cl {
+			delete(e.clusters, name)
+			delete(e.watching, name)
+			for it := range e.applied {
+				if it.cluster == name {
+					delete(e.applied, it)
+				}
+			}
+		}
+		e.lock.Unlock()
+		e.advanceRollouts()
+	}()
+
+	for _, id := range ids {
+		e.queue.Add(item{cluster: name, id: id})
+	}
+	e.advanceRollouts()
+	return nil
+}
+
+// advanceRollouts queues all rollouts to be advanced, e.g. to include a
+// newly engaged cluster in the next wave.
+func (e *Engine) advanceRollouts() {
+	e.lock.RLock()
+	ids := make([]ObjectID, 0, len(e.rollouts))
+	for id := range e.rollouts {
+		ids = append(ids, id)
+	}
+	e.lock.RUnlock()
+	for _, id := range ids {
+		e.queue.Add(item{id: id})
+	}
+}
+
+// Start runs the engine workers until ctx is done.
+func (e *Engine) Start(ctx context.Context) error {
+	go func() {
+		<-ctx.Done()
+		e.queue.ShutDown()
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(e.opts.MaxConcurrentApplies)
+	for range e.opts.MaxConcurrentApplies {
+		go func() {
+			defer wg.Done()
+			for e.processNextItem(ctx) {
+			}
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+func (e *Engine) processNextItem(ctx context.Context) bool {
+	it, shutdown := e.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer e.queue.Done(it)
+
+	if err := e.process(ctx, it); err != nil {
+		e.log.Error(err, "failed to propagate object", "cluster", multicluster.EscapeClusterName(it.cluster), "object", it.id)
+		e.queue.AddRateLimited(it)
+		return true
+	}
+	e.queue.Forget(it)
+	return true
+}
+
+func (e *Engine) process(ctx context.Context, it item) error {
+	if it.cluster == "" {
+		return e.advance(ctx, it.id)
+	}
+
+	e.lock.RLock()
+	cl, engaged := e.clusters[it.cluster]
+	desired, ok := e.desired[it.id]
+	revision, admitted := e.admitted(it)
+	e.lock.RUnlock()
+	if !engaged || !ok {
+		return nil
+	}
+	selected, err := e.selected(ctx, it.cluster, cl)
+	if err != nil {
+		return err
+	}
+	if !selected {
+		return e.deselect(ctx, it, cl)
+	}
+	if !admitted {
+		return nil
+	}
+
+	if err := e.ensureWatch(ctx, it.cluster, cl, it.id.GroupVersionKind); err != nil {
+		return err
+	}
+	obj, err := e.transform(ctx, it, desired)
+	if err != nil {
+		e.report(ctx, it, revision, StatusFailed, err)
+		e.recordRollout(it, revision, err)
+		return err
+	}
+
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(it.id.GroupVersionKind)
+	err = cl.GetClient().Get(ctx, it.id.NamespacedName, live)
+	switch {
+	case apierrors.IsNotFound(err):
+		live = nil
+	case err != nil:
+		e.report(ctx, it, revision, StatusFailed, err)
+		e.recordRollout(it, revision, err)
+		return err
+	case !contains(live.Object, obj.Object):
+		e.report(ctx, it, revision, StatusDrifted, nil)
+	}
+
+	if e.opts.Journal != nil {
+		entry := JournalEntry{Cluster: it.cluster, Object: it.id, Revision: revision, Previous: live}
+		if err := e.opts.Journal.Record(ctx, entry); err != nil {
+			err = fmt.Errorf("failed to record journal entry: %w", err)
+			e.report(ctx, it, revision, StatusFailed, err)
+			return err
+		}
+	}
+
+	// the object may have been removed or changed in the meantime.
+	e.lock.RLock()
+	current := e.desired[it.id]
+	e.lock.RUnlock()
+	if current != desired {
+		return nil
+	}
+
+	if err := cl.GetClient().Patch(ctx, obj, client.Apply, client.FieldOwner(e.opts.FieldManager), client.ForceOwnership); err != nil {
+		e.report(ctx, it, revision, StatusFailed, err)
+		e.recordRollout(it, revision, err)
+		return err
+	}
+
+	// a Remove racing with the apply missed the applied object, delete it.
+	e.lock.Lock()
+	_, ok = e.desired[it.id]
+	if ok {
+		e.applied[it] = obj.GetUID()
+	}
+	e.lock.Unlock()
+	if !ok {
+		return e.delete(ctx, it.cluster, cl, it.id, obj.GetUID())
+	}
+	e.report(ctx, it, revision, StatusApplied, nil)
+	e.recordRollout(it, revision, nil)
+	return nil
+}
+
+// deselect deletes the item's object from its cluster if it was applied
+// there, as the cluster is no longer selected.
+func (e *Engine) deselect(ctx context.Context, it item, cl cluster.Cluster) error {
+	e.lock.RLock()
+	uid, ok := e.applied[it]
+	e.lock.RUnlock()
+	if !ok {
+		return nil
+	}
+	if err := e.delete(ctx, it.cluster, cl, it.id, uid); err != nil {
+		return err
+	}
+	e.lock.Lock()
+	if e.applied[it] == uid {
+		delete(e.applied, it)
+	}
+	e.lock.Unlock()
+	return nil
+}
+
+// selected returns whether objects are propagated to the given cluster.
+func (e *Engine) selected(ctx context.Context, clusterName string, cl cluster.Cluster) (bool, error) {
+	if e.opts.Selector != nil && !e.opts.Selector(clusterName, cl) {
+		return false, nil
+	}
+	if e.opts.ClusterGroups != nil {
+		if ok, err := e.opts.ClusterGroups.MatchesCluster(ctx, e.opts.ClusterGroup, clusterName); err != nil || !ok {
+			return false, err
+		}
+	}
+	return e.opts.ClusterSelector.MatchesCluster(ctx, e.mgr, clusterName)
+}
+
+func (e *Engine) report(ctx context.Context, it item, revision int64, status Status, err error) {
+	if e.opts.OnResult == nil {
+		return
+	}
+	e.opts.OnResult(ctx, Result{Cluster: it.cluster, Object: it.id, Revision: revision, Status: status, Err: err})
+}
+
+// ensureWatch makes sure changes to objects of the given kind in the given
+// cluster trigger a drift check.
+func (e *Engine) ensureWatch(ctx context.Context, clusterName string, cl cluster.Cluster, gvk schema.GroupVersionKind) error {
+	e.lock.RLock()
+	watching := e.watching[clusterName].Has(gvk)
+	e.lock.RUnlock()
+	if watching {
+		return nil
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	inf, err := cl.GetCache().GetInformer(ctx, obj)
+	if err != nil {
+		return fmt.Errorf("failed to get informer for %s: %w", gvk, err)
+	}
+	enqueue := func(o interface{}) {
+		if tombstone, ok := o.(toolscache.DeletedFinalStateUnknown); ok {
+			o = tombstone.Obj
+		}
+		obj, ok := o.(client.Object)
+		if !ok {
+			return
+		}
+		id := ObjectID{GroupVersionKind: gvk, NamespacedName: client.ObjectKeyFromObject(obj)}
+		e.lock.RLock()
+		_, ok = e.desired[id]
+		e.lock.RUnlock()
+		if ok {
+			e.queue.Add(item{cluster: clusterName, id: id})
+		}
+	}
+	if _, err := inf.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(_, o interface{}) { enqueue(o) },
+		DeleteFunc: enqueue,
+	}); err != nil {
+		return fmt.Errorf("failed to watch %s: %w", gvk, err)
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if w, ok := e.watching[clusterName]; ok {
+		w.Insert(gvk)
+	}
+	return nil
+}
+
+func (e *Engine) toUnstructured(obj client.Object) (*unstructured.Unstructured, error) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if ok {
+		u = u.DeepCopy()
+		if u.GroupVersionKind().Empty() {
+			return nil, fmt.Errorf("unstructured object %s has no GVK", client.ObjectKeyFromObject(u))
+		}
+	} else {
+		gvk, err := apiutil.GVKForObject(obj, e.scheme)
+		if err != nil {
+			return nil, err
+		}
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return nil, err
+		}
+		u = &unstructured.Unstructured{Object: content}
+		u.SetGroupVersionKind(gvk)
+	}
+	// drop fields that must not be applied.
+	u.SetResourceVersion("")
+	u.SetUID("")
+	u.SetManagedFields(nil)
+	unstructured.RemoveNestedField(u.Object, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(u.Object, "status")
+	return u, nil
+}
+
+func idOf(u *unstructured.Unstructured) ObjectID {
+	return ObjectID{GroupVersionKind: u.GroupVersionKind(), NamespacedName: client.ObjectKeyFromObject(u)}
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagation
+
+import (
+	"context"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/fake"
+)
+
+var _ = Describe("Engine", func() {
+	var (
+		ctx context.Context
+		mgr *fake.Manager
+		e   *Engine
+
+		lock     sync.Mutex
+		selected sets.Set[string]
+		results  []Result
+	)
+	clusters := []string{"a", "b", "c"}
+	key := client.ObjectKey{Namespace: "default", Name: "cm"}
+	cm := func(data string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name}, Data: map[string]string{"k": data}}
+	}
+	data := func(name string) func() string {
+		return func() string {
+			obj := &corev1.ConfigMap{}
+			if err := mgr.FakeCluster(name).GetClient().Get(ctx, key, obj); err != nil {
+				return "<" + string(apierrors.ReasonForError(err)) + ">"
+			}
+			return obj.Data["k"]
+		}
+	}
+	statuses := func() []Status {
+		lock.Lock()
+		defer lock.Unlock()
+		var s []Status
+		for _, r := range results {
+			s = append(s, r.Status)
+		}
+		return s
+	}
+
+	start := func(funcs map[string]interceptor.Funcs, objs map[string][]client.Object) {
+		ctx = context.Background()
+		lock.Lock()
+		results, selected = nil, sets.New("a", "b")
+		lock.Unlock()
+
+		b := fake.NewManagerBuilder()
+		for _, name := range clusters {
+			f, ok := funcs[name]
+			if !ok {
+				f = applyAsUpdate
+			}
+			b = b.WithCluster(name, objs[name]...).WithInterceptorFuncs(name, f)
+		}
+		mgr = b.Build()
+		var err error
+		e, err = New(mgr, Options{
+			FieldManager: "test",
+			Selector: func(name string, _ cluster.Cluster) bool {
+				lock.Lock()
+				defer lock.Unlock()
+				return selected.Has(name)
+			},
+			OnResult: func(_ context.Context, r Result) {
+				lock.Lock()
+				defer lock.Unlock()
+				results = append(results, r)
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		DeferCleanup(cancel)
+		for _, name := range clusters {
+			Expect(e.Engage(ctx, name, mgr.FakeCluster(name))).To(Succeed())
+		}
+		go func() { _ = e.Start(ctx) }()
+	}
+
+	It("applies objects to the selected clusters and removes them", func() {
+		start(nil, map[string][]client.Object{"c": {cm("foreign")}})
+
+		id, err := e.Propagate(cm("v1"))
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(data("a")).Should(Equal("v1"))
+		Eventually(data("b")).Should(Equal("v1"))
+		Consistently(data("c"), "100ms").Should(Equal("foreign"))
+		Expect(statuses()).To(ConsistOf(StatusApplied, StatusApplied))
+
+		Expect(e.Remove(ctx, id)).To(Succeed())
+		Expect(data("a")()).To(Equal("<NotFound>"))
+		Expect(data("b")()).To(Equal("<NotFound>"))
+		Expect(data("c")()).To(Equal("foreign"), "objects in clusters never selected are kept")
+	})
+
+	It("strips server-set fields from unstructured objects", func() {
+		start(nil, nil)
+
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("v1")
+		u.SetKind("ConfigMap")
+		u.SetNamespace(key.Namespace)
+		u.SetName(key.Name)
+		u.SetResourceVersion("42")
+		u.SetUID("stale")
+		u.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "other"}})
+		Expect(unstructured.SetNestedField(u.Object, "v1", "data", "k")).To(Succeed())
+		Expect(unstructured.SetNestedField(u.Object, "x", "status", "phase")).To(Succeed())
+
+		id, err := e.Propagate(u)
+		Expect(err).NotTo(HaveOccurred())
+		e.lock.RLock()
+		desired := e.desired[id]
+		e.lock.RUnlock()
+		Expect(desired.GetResourceVersion()).To(BeEmpty())
+		Expect(desired.GetUID()).To(BeEmpty())
+		Expect(desired.GetManagedFields()).To(BeEmpty())
+		Expect(desired.Object).NotTo(HaveKey("status"))
+		Expect(u.GetResourceVersion()).To(Equal("42"), "the input is not modified")
+		Eventually(data("a")).Should(Equal("v1"))
+	})
+
+	It("follows selector changes", func() {
+		start(nil, nil)
+
+		_, err := e.Propagate(cm("v1"))
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(data("b")).Should(Equal("v1"))
+
+		lock.Lock()
+		selected = sets.New("a", "c")
+		lock.Unlock()
+		e.Resync()
+		Eventually(data("b")).Should(Equal("<NotFound>"))
+		Eventually(data("c")).Should(Equal("v1"))
+		Expect(data("a")()).To(Equal("v1"))
+	})
+
+	It("corrects drift", func() {
+		start(nil, nil)
+
+		_, err := e.Propagate(cm("v1"))
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(data("a")).Should(Equal("v1"))
+		Eventually(data("b")).Should(Equal("v1"))
+
+		cl := mgr.FakeCluster("a")
+		live := &corev1.ConfigMap{}
+		Expect(cl.GetClient().Get(ctx, key, live)).To(Succeed())
+		changed := live.DeepCopy()
+		changed.Data["k"] = "changed"
+		Expect(cl.GetClient().Update(ctx, changed)).To(Succeed())
+
+		inf, err := cl.GetCache().GetInformer(ctx, &corev1.ConfigMap{})
+		Expect(err).NotTo(HaveOccurred())
+		inf.(*controllertest.FakeInformer).Update(live, changed)
+		Eventually(statuses).Should(ContainElement(StatusDrifted))
+		Eventually(data("a")).Should(Equal("v1"))
+	})
+
+	It("deletes objects applied while being removed", func() {
+		applying, release, applied := make(chan struct{}), make(chan struct{}), make(chan struct{})
+		blocking := interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				close(applying)
+				<-release
+				defer close(applied)
+				return applyAsUpdate.Patch(ctx, c, obj, patch, opts...)
+			},
+		}
+		start(map[string]interceptor.Funcs{"a": blocking}, nil)
+		lock.Lock()
+		selected = sets.New("a")
+		lock.Unlock()
+
+		id, err := e.Propagate(cm("v1"))
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(applying).Should(BeClosed())
+		Expect(e.Remove(ctx, id)).To(Succeed())
+		close(release)
+
+		Eventually(applied).Should(BeClosed())
+		Eventually(data("a")).Should(Equal("<NotFound>"))
+	})
+})
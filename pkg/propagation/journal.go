@@ -0,0 +1,197 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	mcerrors "sigs.k8s.io/multicluster-runtime/pkg/errors"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	"sigs.k8s.io/multicluster-runtime/pkg/selector"
+)
+
+// JournalEntry is the state of an object in a cluster before a revision of
+// its desired state was applied.
+type JournalEntry struct {
+	// Cluster is the name of the cluster.
+	Cluster string
+	// Object identifies the propagated object.
+	Object ObjectID
+	// Revision is the revision that was applied afterwards.
+	Revision int64
+	// Previous is the object before the revision was applied, nil if it did
+	// not exist.
+	Previous *unstructured.Unstructured
+}
+
+// Journal records the state of objects before revisions are applied.
+type Journal interface {
+	// Record stores the entry. Entries recorded again for the same cluster,
+	// object and revision must be ignored, such that re-applies after drift
+	// do not overwrite the state before the revision.
+	Record(ctx context.Context, entry JournalEntry) error
+
+	// Get returns the entry for the given cluster, object and revision, and
+	// false if there is none.
+	Get(ctx context.Context, cluster string, id ObjectID, revision int64) (JournalEntry, bool, error)
+}
+
+var _ Journal = &MemoryJournal{}
+
+// MemoryJournal is a Journal keeping the latest entries per cluster and
+// object in memory.
+type MemoryJournal struct {
+	limit int
+
+	lock    sync.Mutex
+	entries map[journalKey][]JournalEntry
+}
+
+type journalKey struct {
+	cluster string
+	id      ObjectID
+}
+
+// NewMemoryJournal returns a MemoryJournal keeping the last limit entries
+// per cluster and object. Zero defaults to 10.
+func NewMemoryJournal(limit int) *MemoryJournal {
+	if limit == 0 {
+		limit = 10
+	}
+	return &MemoryJournal{limit: limit, entries: map[journalKey][]JournalEntry{}}
+}
+
+// Record stores the entry unless there is one for its revision already.
+func (j *MemoryJournal) Record(_ context.Context, entry JournalEntry) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	key := journalKey{cluster: entry.Cluster, id: entry.Object}
+	entries := j.entries[key]
+	for _, e := range entries {
+		if e.Revision == entry.Revision {
+			return nil
+		}
+	}
+	if entry.Previous != nil {
+		entry.Previous = entry.Previous.DeepCopy()
+	}
+	entries = append(entries, entry)
+	if len(entries) > j.limit {
+		entries = entries[len(entries)-j.limit:]
+	}
+	j.entries[key] = entries
+	return nil
+}
+
+// Get returns the entry for the given cluster, object and revision.
+func (j *MemoryJournal) Get(_ context.Context, cluster string, id ObjectID, revision int64) (JournalEntry, bool, error) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	for _, e := range j.entries[journalKey{cluster: cluster, id: id}] {
+		if e.Revision == revision {
+			if e.Previous != nil {
+				e.Previous = e.Previous.DeepCopy()
+			}
+			return e, true, nil
+		}
+	}
+	return JournalEntry{}, false, nil
+}
+
+// Rollback restores the object with the given ID to its state before the
+// given revision was applied, in all engaged clusters matching sel that
+// the revision was applied to. A nil selector matches all clusters. Objects
+// that did not exist before are deleted. The rolled back clusters do not
+// receive the current revision again; they are updated with the next change
+// of the desired state.
+func (e *Engine) Rollback(ctx context.Context, sel *selector.Selector, id ObjectID, revision int64) error {
+	if e.opts.Journal == nil {
+		return errors.New("rollback requires a journal")
+	}
+
+	e.lock.RLock()
+	clusters := make(map[string]cluster.Cluster, len(e.clusters))
+	for name, cl := range e.clusters {
+		clusters[name] = cl
+	}
+	e.lock.RUnlock()
+	names := make([]string, 0, len(clusters))
+	for name := range clusters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		if selected, err := sel.MatchesCluster(ctx, e.mgr, name); err != nil || !selected {
+			errs = append(errs, mcerrors.New(name, "rollback "+id.String(), err))
+			continue
+		}
+		entry, ok, err := e.opts.Journal.Get(ctx, name, id, revision)
+		if err != nil || !ok {
+			errs = append(errs, mcerrors.New(name, "rollback "+id.String(), err))
+			continue
+		}
+
+		e.lock.Lock()
+		e.rolledBack[item{cluster: name, id: id}] = e.revisions[id]
+		e.lock.Unlock()
+
+		e.log.Info("Rolling back object", "cluster", multicluster.EscapeClusterName(name), "object", id, "revision", revision)
+		errs = append(errs, mcerrors.New(name, "rollback "+id.String(), restore(ctx, clusters[name], id, entry.Previous)))
+	}
+	return mcerrors.NewAggregate(errs...)
+}
+
+// restore replaces the object in the cluster with previous, or deletes it
+// if previous is nil.
+func restore(ctx context.Context, cl cluster.Cluster, id ObjectID, previous *unstructured.Unstructured) error {
+	if previous == nil {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(id.GroupVersionKind)
+		obj.SetNamespace(id.Namespace)
+		obj.SetName(id.Name)
+		return client.IgnoreNotFound(cl.GetClient().Delete(ctx, obj))
+	}
+
+	obj := previous.DeepCopy()
+	obj.SetManagedFields(nil)
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(id.GroupVersionKind)
+	err := cl.GetClient().Get(ctx, id.NamespacedName, live)
+	switch {
+	case apierrors.IsNotFound(err):
+		obj.SetResourceVersion("")
+		obj.SetUID("")
+		unstructured.RemoveNestedField(obj.Object, "metadata", "creationTimestamp")
+		return cl.GetClient().Create(ctx, obj)
+	case err != nil:
+		return fmt.Errorf("failed to get current object: %w", err)
+	}
+	obj.SetResourceVersion(live.GetResourceVersion())
+	return cl.GetClient().Update(ctx, obj)
+}
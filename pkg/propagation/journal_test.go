@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagation
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/fake"
+	"sigs.k8s.io/multicluster-runtime/pkg/selector"
+)
+
+// applyAsUpdate emulates server-side apply of whole objects, which the fake
+// client does not support.
+var applyAsUpdate = interceptor.Funcs{
+	Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+		if patch != client.Apply {
+			return c.Patch(ctx, obj, patch, opts...)
+		}
+		live := obj.DeepCopyObject().(client.Object)
+		if err := c.Get(ctx, client.ObjectKeyFromObject(obj), live); apierrors.IsNotFound(err) {
+			return c.Create(ctx, obj)
+		} else if err != nil {
+			return err
+		}
+		obj.SetResourceVersion(live.GetResourceVersion())
+		return c.Update(ctx, obj)
+	},
+}
+
+var _ = Describe("Rollback", func() {
+	cm := func(data string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cm"}, Data: map[string]string{"k": data}}
+	}
+
+	It("restores the state before a revision", func() {
+		mgr := fake.NewManagerBuilder().
+			WithCluster("a", cm("old")).WithInterceptorFuncs("a", applyAsUpdate).
+			WithCluster("b").WithInterceptorFuncs("b", applyAsUpdate).
+			WithCluster("c", cm("old")).WithInterceptorFuncs("c", applyAsUpdate).
+			Build()
+		e, err := New(mgr, Options{FieldManager: "test", Journal: NewMemoryJournal(0)})
+		Expect(err).NotTo(HaveOccurred())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+		for _, name := range []string{"a", "b", "c"} {
+			Expect(e.Engage(ctx, name, mgr.FakeCluster(name))).To(Succeed())
+		}
+		go func() { _ = e.Start(ctx) }()
+
+		data := func(name string) func() string {
+			return func() string {
+				obj := &corev1.ConfigMap{}
+				if err := mgr.FakeCluster(name).GetClient().Get(ctx, client.ObjectKey{Namespace: "default", Name: "cm"}, obj); err != nil {
+					return "<" + string(apierrors.ReasonForError(err)) + ">"
+				}
+				return obj.Data["k"]
+			}
+		}
+
+		id, err := e.Propagate(cm("new"))
+		Expect(err).NotTo(HaveOccurred())
+		for _, name := range []string{"a", "b", "c"} {
+			Eventually(data(name)).Should(Equal("new"))
+		}
+
+		sel, err := (&selector.ClusterSelector{Names: []string{"a", "b"}}).Compile()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(e.Rollback(ctx, sel, id, 1)).To(Succeed())
+		Expect(data("a")()).To(Equal("old"))
+		Expect(data("b")()).To(Equal("<NotFound>"))
+		Expect(data("c")()).To(Equal("new"))
+
+		By("not applying the rolled back revision again")
+		_, err = e.Propagate(cm("new"))
+		Expect(err).NotTo(HaveOccurred())
+		Consistently(data("a"), 200*time.Millisecond).Should(Equal("old"))
+
+		By("applying the next revision")
+		_, err = e.Propagate(cm("newer"))
+		Expect(err).NotTo(HaveOccurred())
+		for _, name := range []string{"a", "b", "c"} {
+			Eventually(data(name)).Should(Equal("newer"))
+		}
+		Expect(e.Rollback(ctx, nil, id, 2)).To(Succeed())
+		Expect(data("a")()).To(Equal("old"))
+		Expect(data("b")()).To(Equal("<NotFound>"))
+		Expect(data("c")()).To(Equal("new"))
+	})
+
+	It("requires a journal", func() {
+		e, err := New(fake.NewManagerBuilder().Build(), Options{FieldManager: "test"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(e.Rollback(context.Background(), nil, ObjectID{}, 1)).To(MatchError(ContainSubstring("journal")))
+	})
+})
@@ -0,0 +1,268 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagation
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+)
+
+// RolloutStrategy gates how changes of a propagated object reach the
+// clusters. A change is applied to the canary clusters first, and then to
+// waves of further clusters. Every wave has to be applied successfully and
+// soak before the next wave starts, and the rollout halts when too many
+// clusters fail.
+type RolloutStrategy struct {
+	// Canaries are the names of the clusters that receive a change first,
+	// as a wave of their own.
+	Canaries []string
+
+	// MaxUnavailable is the maximum number of clusters in a wave after the
+	// canaries, i.e. the number of clusters that may be broken by a bad
+	// change at the same time. Zero means all remaining clusters.
+	MaxUnavailable int
+
+	// SoakTime is the time a wave must have been applied before the next
+	// wave starts.
+	SoakTime time.Duration
+
+	// MaxFailures is the number of clusters that may fail to apply a change
+	// before the rollout halts. Zero halts on the first failure.
+	MaxFailures int
+}
+
+// RolloutStatus is the progress of the rollout of the current desired
+// state of an object.
+type RolloutStatus struct {
+	// Generation counts the changes of the desired state.
+	Generation int64
+
+	// Waves is the number of waves started.
+	Waves int
+
+	// Admitted are the clusters the change may be applied to.
+	Admitted []string
+
+	// Applied are the clusters the change was applied to.
+	Applied []string
+
+	// Failed are the clusters the change failed to apply to.
+	Failed []string
+
+	// Halted means no further waves are started until the rollout is
+	// resumed with ResumeRollout.
+	Halted bool
+}
+
+type rollout struct {
+	generation int64
+	waves      int
+	wave       sets.Set[string]
+	admitted   sets.Set[string]
+	applied    sets.Set[string]
+	failed     sets.Set[string]
+	soakUntil  time.Time
+	halted     bool
+}
+
+func newRollout(generation int64) *rollout {
+	return &rollout{
+		generation: generation,
+		wave:       sets.New[string](),
+		admitted:   sets.New[string](),
+		applied:    sets.New[string](),
+		failed:     sets.New[string](),
+	}
+}
+
+// RolloutStatus returns the rollout progress of the object with the given
+// ID, and false if the engine has no rollout strategy or the object is not
+// propagated.
+func (e *Engine) RolloutStatus(id ObjectID) (RolloutStatus, bool) {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+	r, ok := e.rollouts[id]
+	if !ok {
+		return RolloutStatus{}, false
+	}
+	return RolloutStatus{
+		Generation: r.generation,
+		Waves:      r.waves,
+		Admitted:   sets.List(r.admitted),
+		Applied:    sets.List(r.applied),
+		Failed:     sets.List(r.failed),
+		Halted:     r.halted,
+	}, true
+}
+
+// ResumeRollout resumes a halted rollout of the object with the given ID,
+// forgetting the failures so far. It returns false if there is no such
+// rollout.
+func (e *Engine) ResumeRollout(id ObjectID) bool {
+	e.lock.Lock()
+	r, ok := e.rollouts[id]
+	if ok {
+		r.halted = false
+		r.failed = sets.New[string]()
+	}
+	e.lock.Unlock()
+	if ok {
+		e.queue.Add(item{id: id})
+	}
+	return ok
+}
+
+// admitted returns the revision of the item's object, and whether the
+// item's cluster may receive it, i.e. it was admitted by the rollout and was
+// not rolled back from this revision. It must be called with the lock held.
+func (e *Engine) admitted(it item) (int64, bool) {
+	revision := e.revisions[it.id]
+	if rb, ok := e.rolledBack[it]; ok && rb == revision {
+		return revision, false
+	}
+	r, ok := e.rollouts[it.id]
+	if !ok {
+		return revision, true
+	}
+	return revision, r.admitted.Has(it.cluster)
+}
+
+// recordRollout records the outcome of applying the given generation of the
+// item's object, halting the rollout on too many failures.
+func (e *Engine) recordRollout(it item, generation int64, err error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	r, ok := e.rollouts[it.id]
+	if !ok || r.generation != generation {
+		return
+	}
+	if err != nil {
+		r.failed.Insert(it.cluster)
+		if !r.halted && r.failed.Len() > e.opts.Rollout.MaxFailures {
+			r.halted = true
+			e.log.Info("Halting rollout", "object", it.id, "generation", generation, "failed", sets.List(r.failed))
+			return
+		}
+	} else {
+		r.applied.Insert(it.cluster)
+	}
+	if !r.halted && r.settled() {
+		e.queue.Add(item{id: it.id})
+	}
+}
+
+// settled returns whether every cluster of the current wave was applied or
+// failed. Failures within MaxFailures do not hold up the rollout.
+func (r *rollout) settled() bool {
+	return r.applied.Union(r.failed).IsSuperset(r.wave)
+}
+
+// advance starts the next wave of the rollout of the given object once the
+// current wave is applied and has soaked.
+func (e *Engine) advance(ctx context.Context, id ObjectID) error {
+	candidates, err := e.candidates(ctx)
+	if err != nil {
+		return err
+	}
+
+	e.lock.Lock()
+	r, ok := e.rollouts[id]
+	if !ok || r.halted {
+		e.lock.Unlock()
+		return nil
+	}
+	// disengaged clusters do not hold up the wave.
+	r.wave = r.wave.Intersection(candidates)
+	if !r.settled() {
+		e.lock.Unlock()
+		return nil
+	}
+	if r.waves > 0 {
+		if r.soakUntil.IsZero() {
+			r.soakUntil = time.Now().Add(e.opts.Rollout.SoakTime)
+		}
+		if wait := time.Until(r.soakUntil); wait > 0 {
+			e.lock.Unlock()
+			e.queue.AddAfter(item{id: id}, wait)
+			return nil
+		}
+	}
+	next := e.nextWave(r, candidates)
+	if len(next) == 0 {
+		e.lock.Unlock()
+		return nil
+	}
+	r.waves++
+	r.wave = sets.New(next...)
+	r.admitted.Insert(next...)
+	r.soakUntil = time.Time{}
+	generation, wave := r.generation, r.waves
+	e.lock.Unlock()
+
+	e.log.V(1).Info("Starting rollout wave", "object", id, "generation", generation, "wave", wave, "clusters", next)
+	for _, name := range next {
+		e.queue.Add(item{cluster: name, id: id})
+	}
+	return nil
+}
+
+// nextWave returns the clusters of the next wave, the pending canaries
+// first.
+func (e *Engine) nextWave(r *rollout, candidates sets.Set[string]) []string {
+	var canaries []string
+	for _, name := range e.opts.Rollout.Canaries {
+		if candidates.Has(name) && !r.admitted.Has(name) {
+			canaries = append(canaries, name)
+		}
+	}
+	if len(canaries) > 0 {
+		sort.Strings(canaries)
+		return canaries
+	}
+	next := sets.List(candidates.Difference(r.admitted))
+	if n := e.opts.Rollout.MaxUnavailable; n > 0 && len(next) > n {
+		next = next[:n]
+	}
+	return next
+}
+
+// candidates returns the engaged clusters objects are propagated to.
+func (e *Engine) candidates(ctx context.Context) (sets.Set[string], error) {
+	e.lock.RLock()
+	clusters := make(map[string]cluster.Cluster, len(e.clusters))
+	for name, cl := range e.clusters {
+		clusters[name] = cl
+	}
+	e.lock.RUnlock()
+
+	candidates := sets.New[string]()
+	for name, cl := range clusters {
+		selected, err := e.selected(ctx, name, cl)
+		if err != nil {
+			return nil, err
+		}
+		if selected {
+			candidates.Insert(name)
+		}
+	}
+	return candidates, nil
+}
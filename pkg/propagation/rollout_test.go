@@ -0,0 +1,162 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagation
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/fake"
+)
+
+var _ = Describe("Rollout", func() {
+	clusters := []string{"a", "b", "c", "d"}
+
+	var (
+		lock    sync.Mutex
+		applied []string
+		failing map[string]bool
+	)
+
+	// apply records applies instead of sending them, failing for the
+	// clusters in failing.
+	apply := func(name string) interceptor.Funcs {
+		return interceptor.Funcs{
+			Patch: func(_ context.Context, _ client.WithWatch, _ client.Object, _ client.Patch, _ ...client.PatchOption) error {
+				lock.Lock()
+				defer lock.Unlock()
+				if failing[name] {
+					return errors.New("boom")
+				}
+				applied = append(applied, name)
+				return nil
+			},
+		}
+	}
+	appliedClusters := func() []string {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]string(nil), applied...)
+	}
+
+	start := func(strategy RolloutStrategy) (*Engine, ObjectID) {
+		lock.Lock()
+		applied = nil
+		lock.Unlock()
+
+		b := fake.NewManagerBuilder()
+		for _, name := range clusters {
+			b = b.WithCluster(name).WithInterceptorFuncs(name, apply(name))
+		}
+		mgr := b.Build()
+		e, err := New(mgr, Options{FieldManager: "test", Rollout: &strategy})
+		Expect(err).NotTo(HaveOccurred())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+		for _, name := range clusters {
+			Expect(e.Engage(ctx, name, mgr.FakeCluster(name))).To(Succeed())
+		}
+		go func() { _ = e.Start(ctx) }()
+
+		id, err := e.Propagate(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cm"}})
+		Expect(err).NotTo(HaveOccurred())
+		return e, id
+	}
+
+	BeforeEach(func() {
+		failing = map[string]bool{}
+	})
+
+	It("applies canaries first, then soaked waves", func() {
+		e, id := start(RolloutStrategy{Canaries: []string{"c"}, MaxUnavailable: 2, SoakTime: 200 * time.Millisecond})
+
+		Eventually(appliedClusters).Should(Equal([]string{"c"}))
+		Consistently(appliedClusters, 100*time.Millisecond).Should(HaveLen(1))
+		Eventually(appliedClusters).Should(HaveLen(3))
+		Expect(appliedClusters()[1:]).To(ConsistOf("a", "b"))
+		Eventually(appliedClusters).Should(HaveLen(4))
+		Expect(appliedClusters()[3]).To(Equal("d"))
+
+		status, ok := e.RolloutStatus(id)
+		Expect(ok).To(BeTrue())
+		Expect(status.Waves).To(Equal(3))
+		Expect(status.Applied).To(Equal(clusters))
+	})
+
+	It("starts a new rollout when the object changes", func() {
+		e, id := start(RolloutStrategy{Canaries: []string{"c"}})
+		Eventually(appliedClusters).Should(HaveLen(4))
+
+		_, err := e.Propagate(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cm"}})
+		Expect(err).NotTo(HaveOccurred())
+		status, _ := e.RolloutStatus(id)
+		Expect(status.Generation).To(Equal(int64(1)))
+
+		_, err = e.Propagate(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cm"}, Data: map[string]string{"k": "v"}})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(func() RolloutStatus {
+			status, _ := e.RolloutStatus(id)
+			return status
+		}).Should(And(
+			HaveField("Generation", int64(2)),
+			HaveField("Applied", clusters),
+		))
+		Expect(appliedClusters()[4]).To(Equal("c"))
+	})
+
+	It("halts on too many failures until resumed", func() {
+		failing["a"] = true
+		e, id := start(RolloutStrategy{MaxUnavailable: 2})
+
+		Eventually(func() bool {
+			status, _ := e.RolloutStatus(id)
+			return status.Halted
+		}).Should(BeTrue())
+		Eventually(appliedClusters).Should(Equal([]string{"b"}))
+		Consistently(appliedClusters, 200*time.Millisecond).Should(Equal([]string{"b"}))
+
+		lock.Lock()
+		failing["a"] = false
+		lock.Unlock()
+		Expect(e.ResumeRollout(id)).To(BeTrue())
+		Eventually(appliedClusters, 5*time.Second).Should(ConsistOf("a", "b", "c", "d"))
+	})
+
+	It("advances past failures within the budget", func() {
+		failing["a"] = true
+		e, id := start(RolloutStrategy{MaxUnavailable: 2, MaxFailures: 1})
+
+		Eventually(appliedClusters).Should(ConsistOf("b", "c", "d"))
+		status, ok := e.RolloutStatus(id)
+		Expect(ok).To(BeTrue())
+		Expect(status.Halted).To(BeFalse())
+		Expect(status.Waves).To(Equal(2))
+		Expect(status.Failed).To(Equal([]string{"a"}))
+	})
+})
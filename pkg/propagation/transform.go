@@ -0,0 +1,123 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagation
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+// Transform customizes the desired object for a cluster before it is
+// applied, e.g. substituting the region or sizing of the cluster. obj is a
+// copy of the desired object that may be modified in place, but must keep
+// its kind, namespace and name.
+type Transform func(ctx context.Context, clusterName string, md multicluster.Metadata, obj *unstructured.Unstructured) error
+
+// TemplateData is the data string values are rendered with by
+// TemplateTransform.
+type TemplateData struct {
+	// ClusterName is the name of the cluster.
+	ClusterName string
+	// Labels are the labels of the cluster.
+	Labels map[string]string
+	// Annotations are the annotations of the cluster.
+	Annotations map[string]string
+}
+
+// TemplateTransform returns a Transform that renders all string values of
+// the object containing "{{" as Go templates with TemplateData, e.g.
+// "{{ .ClusterName }}" or `{{ index .Labels "region" }}`. Referencing
+// missing labels or annotations fails the apply.
+func TemplateTransform() Transform {
+	return func(_ context.Context, clusterName string, md multicluster.Metadata, obj *unstructured.Unstructured) error {
+		data := TemplateData{ClusterName: clusterName, Labels: md.Labels, Annotations: md.Annotations}
+		rendered, err := render(obj.Object, data, "")
+		if err != nil {
+			return err
+		}
+		obj.Object = rendered.(map[string]interface{})
+		return nil
+	}
+}
+
+// render renders the string values of v, path being the field path for
+// errors.
+func render(v interface{}, data TemplateData, path string) (interface{}, error) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, fv := range v {
+			r, err := render(fv, data, path+"."+k)
+			if err != nil {
+				return nil, err
+			}
+			v[k] = r
+		}
+		return v, nil
+	case []interface{}:
+		for i, ev := range v {
+			r, err := render(ev, data, fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			v[i] = r
+		}
+		return v, nil
+	case string:
+		if !strings.Contains(v, "{{") {
+			return v, nil
+		}
+		tmpl, err := template.New(path).Option("missingkey=error").Parse(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse template of %s: %w", path, err)
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to render template of %s: %w", path, err)
+		}
+		return buf.String(), nil
+	default:
+		return v, nil
+	}
+}
+
+// transform returns the desired object for the given cluster.
+func (e *Engine) transform(ctx context.Context, it item, desired *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	obj := desired.DeepCopy()
+	if len(e.opts.Transforms) == 0 {
+		return obj, nil
+	}
+	md, err := e.mgr.GetClusterMetadata(ctx, it.cluster)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster metadata: %w", err)
+	}
+	for _, t := range e.opts.Transforms {
+		if err := t(ctx, it.cluster, md, obj); err != nil {
+			return nil, fmt.Errorf("failed to transform object: %w", err)
+		}
+	}
+	if idOf(obj) != it.id {
+		return nil, fmt.Errorf("transform changed the object to %s", idOf(obj))
+	}
+	return obj, nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagation
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/fake"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+var _ = Describe("TemplateTransform", func() {
+	ctx := context.Background()
+	md := multicluster.Metadata{Labels: map[string]string{"region": "eu-west"}}
+
+	It("renders string values", func() {
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "cm"},
+			"data": map[string]interface{}{
+				"cluster": "{{ .ClusterName }}",
+				"region":  `{{ index .Labels "region" }}`,
+				"plain":   "value",
+			},
+			"list": []interface{}{"{{ .ClusterName }}-0", int64(1)},
+		}}
+		Expect(TemplateTransform()(ctx, "one", md, obj)).To(Succeed())
+		Expect(obj.Object["data"]).To(Equal(map[string]interface{}{"cluster": "one", "region": "eu-west", "plain": "value"}))
+		Expect(obj.Object["list"]).To(Equal([]interface{}{"one-0", int64(1)}))
+	})
+
+	It("fails on missing keys", func() {
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{
+			"data": map[string]interface{}{"zone": "{{ .Labels.zone }}"},
+		}}
+		Expect(TemplateTransform()(ctx, "one", md, obj)).To(MatchError(ContainSubstring(".data.zone")))
+	})
+})
+
+var _ = Describe("Transforms", func() {
+	It("customizes the object per cluster", func() {
+		mgr := fake.NewManagerBuilder().
+			WithCluster("one").WithInterceptorFuncs("one", applyAsUpdate).
+			WithClusterMetadata("one", multicluster.Metadata{Labels: map[string]string{"size": "small"}}).
+			WithCluster("two").WithInterceptorFuncs("two", applyAsUpdate).
+			WithClusterMetadata("two", multicluster.Metadata{Labels: map[string]string{"size": "large"}}).
+			Build()
+		e, err := New(mgr, Options{
+			FieldManager: "test",
+			Transforms:   []Transform{TemplateTransform()},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+		Expect(e.Engage(ctx, "one", mgr.FakeCluster("one"))).To(Succeed())
+		Expect(e.Engage(ctx, "two", mgr.FakeCluster("two"))).To(Succeed())
+		go func() { _ = e.Start(ctx) }()
+
+		_, err = e.Propagate(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cm"},
+			Data:       map[string]string{"size": `{{ index .Labels "size" }}`},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		size := func(name string) func() string {
+			return func() string {
+				obj := &corev1.ConfigMap{}
+				_ = mgr.FakeCluster(name).GetClient().Get(ctx, client.ObjectKey{Namespace: "default", Name: "cm"}, obj)
+				return obj.Data["size"]
+			}
+		}
+		Eventually(size("one")).Should(Equal("small"))
+		Eventually(size("two")).Should(Equal("large"))
+	})
+
+	It("rejects transforms changing the object identity", func() {
+		e, err := New(fake.NewManagerBuilder().WithCluster("one").Build(), Options{
+			FieldManager: "test",
+			Transforms: []Transform{func(_ context.Context, _ string, _ multicluster.Metadata, obj *unstructured.Unstructured) error {
+				obj.SetName("other")
+				return nil
+			}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		desired := &unstructured.Unstructured{}
+		desired.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ConfigMap"))
+		desired.SetName("cm")
+		_, err = e.transform(context.Background(), item{cluster: "one", id: idOf(desired)}, desired)
+		Expect(err).To(MatchError(ContainSubstring("transform changed the object")))
+	})
+})
@@ -0,0 +1,186 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	mcerrors "sigs.k8s.io/multicluster-runtime/pkg/errors"
+)
+
+// FanOutOptions are the options of a FanOut.
+type FanOutOptions[request comparable] struct {
+	// Clusters returns the names of the clusters the request fans out to.
+	// Required.
+	Clusters func(ctx context.Context, req request) ([]string, error)
+
+	// Reconcile does the work of the request in one cluster. Required.
+	Reconcile func(ctx context.Context, req request, clusterName string) error
+
+	// Version returns the version of the desired state of the request, e.g.
+	// the generation of the hub object. When it changes, the request fans
+	// out to all clusters again even if retries are pending. If nil, the
+	// request fans out to all clusters only once all retries succeeded.
+	Version func(ctx context.Context, req request) (string, error)
+
+	// MaxConcurrency is the maximum number of clusters reconciled at the
+	// same time. Zero means unlimited.
+	MaxConcurrency int
+}
+
+// FanOut is a reconciler that does the work of a request in many clusters,
+// and remembers per request which clusters failed. When the request is
+// requeued after a partial failure, only the failed clusters are
+// reconciled again, instead of re-running the work against every cluster.
+// The errors of the failed clusters are returned as one aggregate, such
+// that the request is requeued with the rate limiter of the controller.
+type FanOut[request comparable] struct {
+	opts FanOutOptions[request]
+
+	lock    sync.Mutex
+	pending map[request]*pendingClusters
+}
+
+type pendingClusters struct {
+	version string
+	failed  map[string]struct{}
+}
+
+var _ reconcile.TypedReconciler[Request] = &FanOut[Request]{}
+
+// NewFanOut returns a new FanOut.
+func NewFanOut[request comparable](opts FanOutOptions[request]) (*FanOut[request], error) {
+	if opts.Clusters == nil {
+		return nil, errors.New("clusters function must be set")
+	}
+	if opts.Reconcile == nil {
+		return nil, errors.New("reconcile function must be set")
+	}
+	return &FanOut[request]{opts: opts, pending: map[request]*pendingClusters{}}, nil
+}
+
+// Reconcile reconciles the request in its pending failed clusters, or in
+// all its clusters if none are pending or its version changed.
+func (f *FanOut[request]) Reconcile(ctx context.Context, req request) (reconcile.Result, error) {
+	var version string
+	if f.opts.Version != nil {
+		v, err := f.opts.Version(ctx, req)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		version = v
+	}
+	clusters, err := f.opts.Clusters(ctx, req)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	f.lock.Lock()
+	p, ok := f.pending[req]
+	f.lock.Unlock()
+	if ok && p.version == version {
+		// clusters that are not targeted anymore are not retried.
+		retry := clusters[:0:0]
+		for _, name := range clusters {
+			if _, failed := p.failed[name]; failed {
+				retry = append(retry, name)
+			}
+		}
+		clusters = retry
+	}
+
+	errs := f.reconcile(ctx, req, clusters)
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if len(errs) == 0 {
+		delete(f.pending, req)
+		return reconcile.Result{}, nil
+	}
+	failed := make(map[string]struct{}, len(errs))
+	for name := range errs {
+		failed[name] = struct{}{}
+	}
+	f.pending[req] = &pendingClusters{version: version, failed: failed}
+
+	agg := make([]error, 0, len(errs))
+	for name, err := range errs {
+		agg = append(agg, mcerrors.New(name, "reconcile", err))
+	}
+	return reconcile.Result{}, mcerrors.NewAggregate(agg...)
+}
+
+// reconcile runs the work of the request in the given clusters and returns
+// the errors by cluster.
+func (f *FanOut[request]) reconcile(ctx context.Context, req request, clusters []string) map[string]error {
+	limit := f.opts.MaxConcurrency
+	if limit <= 0 || limit > len(clusters) {
+		limit = len(clusters)
+	}
+	slots := make(chan struct{}, limit)
+
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	errs := map[string]error{}
+	for _, name := range clusters {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			if err := f.opts.Reconcile(ctx, req, name); err != nil {
+				lock.Lock()
+				errs[name] = err
+				lock.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+// Pending returns the sorted names of the clusters the request failed in
+// and that are retried on the next reconcile.
+func (f *FanOut[request]) Pending(req request) []string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	p, ok := f.pending[req]
+	if !ok {
+		return nil
+	}
+	names := make([]string, 0, len(p.failed))
+	for name := range p.failed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Forget drops the pending retries of the request, such that it fans out
+// to all clusters again on the next reconcile.
+func (f *FanOut[request]) Forget(req request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	delete(f.pending, req)
+}
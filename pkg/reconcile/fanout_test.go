@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	mcerrors "sigs.k8s.io/multicluster-runtime/pkg/errors"
+)
+
+var _ = Describe("FanOut", func() {
+	ctx := context.Background()
+	req := Request{Request: reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "hub"}}}
+
+	var (
+		lock     sync.Mutex
+		calls    []string
+		failing  map[string]bool
+		clusters []string
+		version  string
+	)
+	reconciled := func() []string {
+		lock.Lock()
+		defer lock.Unlock()
+		sort.Strings(calls)
+		c := calls
+		calls = nil
+		return c
+	}
+
+	var f *FanOut[Request]
+	BeforeEach(func() {
+		calls, failing, clusters, version = nil, map[string]bool{}, []string{"a", "b", "c"}, "1"
+		var err error
+		f, err = NewFanOut(FanOutOptions[Request]{
+			Clusters: func(context.Context, Request) ([]string, error) { return clusters, nil },
+			Version:  func(context.Context, Request) (string, error) { return version, nil },
+			Reconcile: func(_ context.Context, _ Request, name string) error {
+				lock.Lock()
+				defer lock.Unlock()
+				calls = append(calls, name)
+				if failing[name] {
+					return errors.New("boom")
+				}
+				return nil
+			},
+			MaxConcurrency: 2,
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("retries only the failed clusters", func() {
+		failing["b"] = true
+		_, err := f.Reconcile(ctx, req)
+		var ce *mcerrors.ClusterError
+		Expect(errors.As(err, &ce)).To(BeTrue())
+		Expect(ce.Cluster).To(Equal("b"))
+		Expect(reconciled()).To(Equal([]string{"a", "b", "c"}))
+		Expect(f.Pending(req)).To(Equal([]string{"b"}))
+
+		_, err = f.Reconcile(ctx, req)
+		Expect(err).To(HaveOccurred())
+		Expect(reconciled()).To(Equal([]string{"b"}))
+
+		failing["b"] = false
+		_, err = f.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reconciled()).To(Equal([]string{"b"}))
+		Expect(f.Pending(req)).To(BeEmpty())
+
+		_, err = f.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reconciled()).To(Equal([]string{"a", "b", "c"}))
+	})
+
+	It("fans out to all clusters when the version changes", func() {
+		failing["b"] = true
+		_, err := f.Reconcile(ctx, req)
+		Expect(err).To(HaveOccurred())
+		reconciled()
+
+		version = "2"
+		_, err = f.Reconcile(ctx, req)
+		Expect(err).To(HaveOccurred())
+		Expect(reconciled()).To(Equal([]string{"a", "b", "c"}))
+	})
+
+	It("does not retry clusters that are not targeted anymore", func() {
+		failing["b"] = true
+		_, err := f.Reconcile(ctx, req)
+		Expect(err).To(HaveOccurred())
+		reconciled()
+
+		clusters = []string{"a", "c"}
+		_, err = f.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reconciled()).To(BeEmpty())
+		Expect(f.Pending(req)).To(BeEmpty())
+	})
+})
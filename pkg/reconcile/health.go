@@ -0,0 +1,244 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/utils/clock"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// CircuitState is the state of the circuit breaker of a cluster.
+type CircuitState string
+
+const (
+	// CircuitClosed means the cluster is healthy, or has failed fewer times
+	// than the failure threshold.
+	CircuitClosed CircuitState = "Closed"
+
+	// CircuitOpen means the cluster is considered down. Requests to it
+	// should be delayed until the circuit is half-open.
+	CircuitOpen CircuitState = "Open"
+
+	// CircuitHalfOpen means the cluster was down, and the next request
+	// probes whether it has recovered.
+	CircuitHalfOpen CircuitState = "HalfOpen"
+)
+
+// ClusterHealthOptions are the options of a ClusterHealth.
+type ClusterHealthOptions struct {
+	// FailureThreshold is the number of consecutive failures after which
+	// the circuit of a cluster opens. Defaults to 5.
+	FailureThreshold int
+
+	// OpenDuration is the time the circuit stays open before it becomes
+	// half-open. It doubles every time a probe fails, up to MaxDelay.
+	// Defaults to 30 seconds.
+	OpenDuration time.Duration
+
+	// MaxDelay caps the requeue delays. Defaults to 5 minutes.
+	MaxDelay time.Duration
+
+	// IsFailure decides which errors count as failures of the cluster.
+	// Defaults to IsClusterUnavailable.
+	IsFailure func(error) bool
+
+	// Clock is the clock used for the circuit. Defaults to the real clock.
+	Clock clock.PassiveClock
+}
+
+// ClusterHealth tracks the health of clusters with a circuit breaker per
+// cluster, fed by the outcomes of requests to the clusters.
+type ClusterHealth struct {
+	opts ClusterHealthOptions
+
+	lock     sync.Mutex
+	clusters map[string]*circuit
+}
+
+type circuit struct {
+	failures int
+	trips    int
+	openedAt time.Time
+}
+
+// DefaultClusterHealth is the ClusterHealth used by RequeueAfterForCluster.
+var DefaultClusterHealth = NewClusterHealth(ClusterHealthOptions{})
+
+// NewClusterHealth returns a new ClusterHealth.
+func NewClusterHealth(opts ClusterHealthOptions) *ClusterHealth {
+	if opts.FailureThreshold <= 0 {
+		opts.FailureThreshold = 5
+	}
+	if opts.OpenDuration <= 0 {
+		opts.OpenDuration = 30 * time.Second
+	}
+	if opts.MaxDelay <= 0 {
+		opts.MaxDelay = 5 * time.Minute
+	}
+	if opts.IsFailure == nil {
+		opts.IsFailure = IsClusterUnavailable
+	}
+	if opts.Clock == nil {
+		opts.Clock = clock.RealClock{}
+	}
+	return &ClusterHealth{opts: opts, clusters: map[string]*circuit{}}
+}
+
+// IsClusterUnavailable returns whether err indicates that a cluster is not
+// reachable or overloaded, as opposed to errors of the request itself.
+func IsClusterUnavailable(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		apierrors.IsServiceUnavailable(err) ||
+		apierrors.IsServerTimeout(err) ||
+		apierrors.IsTimeout(err) ||
+		apierrors.IsTooManyRequests(err)
+}
+
+// Observe records the outcome of a request to the cluster. Errors not
+// counting as failures are ignored.
+func (h *ClusterHealth) Observe(clusterName string, err error) {
+	if err == nil {
+		h.RecordSuccess(clusterName)
+	} else if h.opts.IsFailure(err) {
+		h.RecordFailure(clusterName)
+	}
+}
+
+// RecordSuccess closes the circuit of the cluster.
+func (h *ClusterHealth) RecordSuccess(clusterName string) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	delete(h.clusters, clusterName)
+}
+
+// RecordFailure records a failure of the cluster, opening its circuit once
+// the failure threshold is reached, or again if it was half-open.
+func (h *ClusterHealth) RecordFailure(clusterName string) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	c, ok := h.clusters[clusterName]
+	if !ok {
+		c = &circuit{}
+		h.clusters[clusterName] = c
+	}
+	state := h.state(c)
+	c.failures++
+	if state == CircuitHalfOpen || (state == CircuitClosed && c.failures >= h.opts.FailureThreshold) {
+		c.trips++
+		c.openedAt = h.opts.Clock.Now()
+	}
+}
+
+// Forget drops the state of the cluster, e.g. when it is disengaged.
+func (h *ClusterHealth) Forget(clusterName string) {
+	h.RecordSuccess(clusterName)
+}
+
+// State returns the state of the circuit of the cluster.
+func (h *ClusterHealth) State(clusterName string) CircuitState {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	c, ok := h.clusters[clusterName]
+	if !ok {
+		return CircuitClosed
+	}
+	return h.state(c)
+}
+
+// RequeueAfter scales the base delay by the health of the cluster: it grows
+// exponentially with the failures of a closed circuit, lasts until an open
+// circuit becomes half-open, and is the base delay for healthy clusters and
+// half-open circuits, so that recovered clusters are served quickly.
+func (h *ClusterHealth) RequeueAfter(clusterName string, base time.Duration) time.Duration {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	c, ok := h.clusters[clusterName]
+	if !ok {
+		return base
+	}
+	switch h.state(c) {
+	case CircuitOpen:
+		return max(base, h.openUntil(c).Sub(h.opts.Clock.Now()))
+	case CircuitHalfOpen:
+		return base
+	default:
+		return h.capped(base, c.failures)
+	}
+}
+
+// state returns the state of the circuit. The lock must be held.
+func (h *ClusterHealth) state(c *circuit) CircuitState {
+	switch {
+	case c.trips == 0:
+		return CircuitClosed
+	case h.opts.Clock.Now().Before(h.openUntil(c)):
+		return CircuitOpen
+	default:
+		return CircuitHalfOpen
+	}
+}
+
+func (h *ClusterHealth) openUntil(c *circuit) time.Time {
+	return c.openedAt.Add(h.capped(h.opts.OpenDuration, c.trips-1))
+}
+
+// capped returns d doubled n times, at most MaxDelay.
+func (h *ClusterHealth) capped(d time.Duration, n int) time.Duration {
+	for i := 0; i < n && d < h.opts.MaxDelay; i++ {
+		d *= 2
+	}
+	return min(d, h.opts.MaxDelay)
+}
+
+// RequeueAfterForCluster scales the base requeue delay by the health of the
+// cluster as tracked by DefaultClusterHealth. See ClusterHealth.RequeueAfter.
+func RequeueAfterForCluster(clusterName string, base time.Duration) time.Duration {
+	return DefaultClusterHealth.RequeueAfter(clusterName, base)
+}
+
+// ClusterHealthWrapper wraps an existing [reconcile.TypedReconciler] and
+// feeds the outcomes of its reconciliations into a ClusterHealth.
+type ClusterHealthWrapper[request ClusterAware[request]] struct {
+	wrapped reconcile.TypedReconciler[request]
+	health  *ClusterHealth
+}
+
+// NewClusterHealthWrapper creates a new [ClusterHealthWrapper]. If health
+// is nil, DefaultClusterHealth is used.
+func NewClusterHealthWrapper[request ClusterAware[request]](health *ClusterHealth, w reconcile.TypedReconciler[request]) reconcile.TypedReconciler[request] {
+	if health == nil {
+		health = DefaultClusterHealth
+	}
+	return &ClusterHealthWrapper[request]{wrapped: w, health: health}
+}
+
+// Reconcile implements [reconcile.TypedReconciler].
+func (r *ClusterHealthWrapper[request]) Reconcile(ctx context.Context, req request) (reconcile.Result, error) {
+	res, err := r.wrapped.Reconcile(ctx, req)
+	r.health.Observe(req.Cluster(), err)
+	return res, err
+}
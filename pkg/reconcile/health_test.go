@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("ClusterHealth", func() {
+	var (
+		clk *clocktesting.FakePassiveClock
+		h   *ClusterHealth
+	)
+	down := apierrors.NewServiceUnavailable("down")
+
+	BeforeEach(func() {
+		clk = clocktesting.NewFakePassiveClock(time.Now())
+		h = NewClusterHealth(ClusterHealthOptions{FailureThreshold: 2, OpenDuration: time.Minute, MaxDelay: 10 * time.Minute, Clock: clk})
+	})
+
+	It("backs off exponentially before the circuit opens", func() {
+		Expect(h.RequeueAfter("a", time.Second)).To(Equal(time.Second))
+		h.Observe("a", down)
+		Expect(h.State("a")).To(Equal(CircuitClosed))
+		Expect(h.RequeueAfter("a", time.Second)).To(Equal(2 * time.Second))
+		Expect(h.RequeueAfter("b", time.Second)).To(Equal(time.Second))
+	})
+
+	It("opens, half-opens and closes the circuit", func() {
+		h.Observe("a", down)
+		h.Observe("a", down)
+		Expect(h.State("a")).To(Equal(CircuitOpen))
+		Expect(h.RequeueAfter("a", time.Second)).To(Equal(time.Minute))
+
+		clk.SetTime(clk.Now().Add(time.Minute))
+		Expect(h.State("a")).To(Equal(CircuitHalfOpen))
+		Expect(h.RequeueAfter("a", time.Second)).To(Equal(time.Second))
+
+		// a failed probe opens the circuit for twice as long.
+		h.Observe("a", down)
+		Expect(h.State("a")).To(Equal(CircuitOpen))
+		Expect(h.RequeueAfter("a", time.Second)).To(Equal(2 * time.Minute))
+
+		clk.SetTime(clk.Now().Add(2 * time.Minute))
+		h.Observe("a", nil)
+		Expect(h.State("a")).To(Equal(CircuitClosed))
+		Expect(h.RequeueAfter("a", time.Second)).To(Equal(time.Second))
+	})
+
+	It("ignores errors of the request itself", func() {
+		for range 3 {
+			h.Observe("a", apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "x"))
+		}
+		Expect(h.State("a")).To(Equal(CircuitClosed))
+	})
+
+	It("is fed by the wrapper", func(ctx context.Context) {
+		r := NewClusterHealthWrapper(h, reconcile.TypedFunc[Request](func(context.Context, Request) (reconcile.Result, error) {
+			return reconcile.Result{}, errors.Join(errors.New("failed"), down)
+		}))
+		for range 2 {
+			_, err := r.Reconcile(ctx, Request{ClusterName: "a"})
+			Expect(err).To(HaveOccurred())
+		}
+		Expect(h.State("a")).To(Equal(CircuitOpen))
+	})
+})
@@ -0,0 +1,83 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcile
+
+import (
+	"context"
+	"reflect"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ClusterGetter returns clusters by name, like mcmanager.Manager.
+type ClusterGetter interface {
+	GetCluster(ctx context.Context, clusterName string) (cluster.Cluster, error)
+}
+
+// ObjectReconciler reconciles an object that was already fetched from its
+// cluster. It is turned into a Reconciler with AsReconciler.
+type ObjectReconciler[object client.Object] interface {
+	Reconcile(ctx context.Context, clusterName string, cl cluster.Cluster, obj object) (reconcile.Result, error)
+}
+
+// ObjectFunc is a function that implements ObjectReconciler.
+type ObjectFunc[object client.Object] func(ctx context.Context, clusterName string, cl cluster.Cluster, obj object) (reconcile.Result, error)
+
+// Reconcile implements ObjectReconciler.
+func (f ObjectFunc[object]) Reconcile(ctx context.Context, clusterName string, cl cluster.Cluster, obj object) (reconcile.Result, error) {
+	return f(ctx, clusterName, cl, obj)
+}
+
+// AsReconciler returns a Reconciler that gets the object of each request
+// from the client of its cluster and passes it to rec, together with the
+// cluster. Requests of objects that no longer exist are dropped, as with
+// the controller-runtime AsReconciler:
+//
+//	mcbuilder.ControllerManagedBy(mgr).
+//		For(&appsv1.Deployment{}).
+//		Complete(mcreconcile.AsReconciler(mgr, mcreconcile.ObjectFunc[*appsv1.Deployment](
+//			func(ctx context.Context, clusterName string, cl cluster.Cluster, d *appsv1.Deployment) (reconcile.Result, error) {
+//				...
+//			})))
+func AsReconciler[object client.Object](clusters ClusterGetter, rec ObjectReconciler[object]) Reconciler {
+	return &objectReconcilerAdapter[object]{clusters: clusters, rec: rec}
+}
+
+type objectReconcilerAdapter[object client.Object] struct {
+	clusters ClusterGetter
+	rec      ObjectReconciler[object]
+}
+
+// Reconcile implements Reconciler.
+func (a *objectReconcilerAdapter[object]) Reconcile(ctx context.Context, req Request) (reconcile.Result, error) {
+	cl, err := a.clusters.GetCluster(ctx, req.ClusterName)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	obj := reflect.New(reflect.TypeOf(*new(object)).Elem()).Interface().(object)
+	if err := cl.GetClient().Get(ctx, req.NamespacedName, obj); err != nil {
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+	return a.rec.Reconcile(ctx, req.ClusterName, cl, obj)
+}
+
+// String returns a string representation of the reconciler.
+func (a *objectReconcilerAdapter[object]) String() string {
+	return reflect.TypeOf(*new(object)).Elem().Name() + " object reconciler"
+}
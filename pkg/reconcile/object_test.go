@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcile
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+// clientCluster is a cluster serving only a client.
+type clientCluster struct {
+	cluster.Cluster
+	client client.Client
+}
+
+func (c *clientCluster) GetClient() client.Client { return c.client }
+
+type clusterMap map[string]cluster.Cluster
+
+func (m clusterMap) GetCluster(_ context.Context, name string) (cluster.Cluster, error) {
+	if cl, ok := m[name]; ok {
+		return cl, nil
+	}
+	return nil, multicluster.ErrClusterNotFound
+}
+
+var _ = Describe("AsReconciler", func() {
+	ctx := context.Background()
+	req := func(clusterName, name string) Request {
+		return Request{
+			Request:     reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: name}},
+			ClusterName: clusterName,
+		}
+	}
+
+	It("passes the object fetched from its cluster", func() {
+		clusters := clusterMap{
+			"one": &clientCluster{client: clientfake.NewClientBuilder().WithObjects(
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "a"}, Data: map[string]string{"from": "one"}},
+			).Build()},
+			"two": &clientCluster{client: clientfake.NewClientBuilder().WithObjects(
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "a"}, Data: map[string]string{"from": "two"}},
+			).Build()},
+		}
+
+		var seen []string
+		r := AsReconciler(clusters, ObjectFunc[*corev1.ConfigMap](func(_ context.Context, clusterName string, cl cluster.Cluster, cm *corev1.ConfigMap) (reconcile.Result, error) {
+			Expect(cl).To(BeIdenticalTo(clusters[clusterName]))
+			seen = append(seen, clusterName+"="+cm.Data["from"])
+			return reconcile.Result{Requeue: true}, nil
+		}))
+
+		res, err := r.Reconcile(ctx, req("two", "a"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.Requeue).To(BeTrue())
+		_, err = r.Reconcile(ctx, req("one", "a"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(seen).To(Equal([]string{"two=two", "one=one"}))
+	})
+
+	It("drops requests of missing objects and fails for missing clusters", func() {
+		clusters := clusterMap{"one": &clientCluster{client: clientfake.NewClientBuilder().Build()}}
+		called := false
+		r := AsReconciler(clusters, ObjectFunc[*corev1.ConfigMap](func(context.Context, string, cluster.Cluster, *corev1.ConfigMap) (reconcile.Result, error) {
+			called = true
+			return reconcile.Result{}, nil
+		}))
+
+		_, err := r.Reconcile(ctx, req("one", "a"))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = r.Reconcile(ctx, req("two", "a"))
+		Expect(err).To(MatchError(multicluster.ErrClusterNotFound))
+		Expect(called).To(BeFalse())
+	})
+})
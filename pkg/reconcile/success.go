@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcile
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+// SuccessTracker records the last successful reconcile per cluster. Its
+// Check method can be added as a cluster health check to the manager, e.g.
+//
+//	tracker := mcreconcile.NewSuccessTracker(10*time.Minute, nil)
+//	mgr.AddClusterHealthzCheck("reconciled", tracker.Check)
+type SuccessTracker struct {
+	maxAge time.Duration
+	clock  clock.PassiveClock
+
+	lock  sync.Mutex
+	since map[string]time.Time
+}
+
+// NewSuccessTracker returns a SuccessTracker whose check fails for clusters
+// without a successful reconcile within maxAge. The clock defaults to the
+// real clock.
+func NewSuccessTracker(maxAge time.Duration, clk clock.PassiveClock) *SuccessTracker {
+	if clk == nil {
+		clk = clock.RealClock{}
+	}
+	return &SuccessTracker{maxAge: maxAge, clock: clk, since: map[string]time.Time{}}
+}
+
+// RecordSuccess records a successful reconcile for the cluster.
+func (t *SuccessTracker) RecordSuccess(clusterName string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.since[clusterName] = t.clock.Now()
+}
+
+// Forget forgets the cluster, e.g. after it was disengaged.
+func (t *SuccessTracker) Forget(clusterName string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.since, clusterName)
+}
+
+// Check fails if the cluster was not reconciled successfully within the
+// maximum age. Clusters that were never reconciled get the maximum age
+// from the first check on.
+func (t *SuccessTracker) Check(_ *http.Request, clusterName string) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	now := t.clock.Now()
+	since, ok := t.since[clusterName]
+	if !ok {
+		t.since[clusterName] = now
+		return nil
+	}
+	if age := now.Sub(since); age > t.maxAge {
+		return fmt.Errorf("no successful reconcile for %s", age.Round(time.Second))
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcile
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+var _ = Describe("SuccessTracker", func() {
+	It("fails clusters without a recent successful reconcile", func() {
+		clk := clocktesting.NewFakePassiveClock(time.Now())
+		t := NewSuccessTracker(10*time.Minute, clk)
+
+		Expect(t.Check(nil, "a")).To(Succeed())
+		clk.SetTime(clk.Now().Add(5 * time.Minute))
+		t.RecordSuccess("b")
+		Expect(t.Check(nil, "a")).To(Succeed())
+
+		clk.SetTime(clk.Now().Add(6 * time.Minute))
+		Expect(t.Check(nil, "a")).To(MatchError("no successful reconcile for 11m0s"))
+		Expect(t.Check(nil, "b")).To(Succeed())
+
+		t.RecordSuccess("a")
+		Expect(t.Check(nil, "a")).To(Succeed())
+		t.Forget("b")
+		clk.SetTime(clk.Now().Add(time.Hour))
+		Expect(t.Check(nil, "b")).To(Succeed())
+	})
+})
@@ -0,0 +1,133 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package reference resolves references to objects in other clusters of the
+// fleet through a multi-cluster manager.
+package reference
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/apis/v1alpha1"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+)
+
+// Validate validates the reference.
+func Validate(ref v1alpha1.ClusterObjectReference, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if ref.APIVersion == "" {
+		errs = append(errs, field.Required(fldPath.Child("apiVersion"), ""))
+	} else if _, err := schema.ParseGroupVersion(ref.APIVersion); err != nil {
+		errs = append(errs, field.Invalid(fldPath.Child("apiVersion"), ref.APIVersion, err.Error()))
+	}
+	if ref.Kind == "" {
+		errs = append(errs, field.Required(fldPath.Child("kind"), ""))
+	}
+	if ref.Namespace != "" {
+		for _, msg := range validation.IsDNS1123Label(ref.Namespace) {
+			errs = append(errs, field.Invalid(fldPath.Child("namespace"), ref.Namespace, msg))
+		}
+	}
+	if ref.Name == "" {
+		errs = append(errs, field.Required(fldPath.Child("name"), ""))
+	} else {
+		for _, msg := range validation.IsDNS1123Subdomain(ref.Name) {
+			errs = append(errs, field.Invalid(fldPath.Child("name"), ref.Name, msg))
+		}
+	}
+	return errs
+}
+
+// ForObject returns a reference to obj in the given cluster.
+func ForObject(clusterName string, obj client.Object, scheme *runtime.Scheme) (v1alpha1.ClusterObjectReference, error) {
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return v1alpha1.ClusterObjectReference{}, err
+	}
+	apiVersion, kind := gvk.ToAPIVersionAndKind()
+	return v1alpha1.ClusterObjectReference{
+		Cluster:    clusterName,
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+	}, nil
+}
+
+// ToRequest returns a reconcile request for the referenced object.
+func ToRequest(ref v1alpha1.ClusterObjectReference) mcreconcile.Request {
+	return mcreconcile.Request{
+		Request:     reconcile.Request{NamespacedName: ref.NamespacedName()},
+		ClusterName: ref.Cluster,
+	}
+}
+
+// Resolver fetches referenced objects from the clusters of a manager.
+type Resolver struct {
+	mgr mcmanager.Manager
+}
+
+// NewResolver returns a Resolver for the clusters of mgr.
+func NewResolver(mgr mcmanager.Manager) *Resolver {
+	return &Resolver{mgr: mgr}
+}
+
+// Resolve fetches the referenced object as unstructured object.
+func (r *Resolver) Resolve(ctx context.Context, ref v1alpha1.ClusterObjectReference) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(ref.GroupVersionKind())
+	if err := r.get(ctx, ref, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// ResolveInto fetches the referenced object into obj, whose kind must match
+// the reference.
+func (r *Resolver) ResolveInto(ctx context.Context, ref v1alpha1.ClusterObjectReference, obj client.Object) error {
+	cl, err := r.mgr.GetCluster(ctx, ref.Cluster)
+	if err != nil {
+		return err
+	}
+	gvk, err := apiutil.GVKForObject(obj, cl.GetScheme())
+	if err != nil {
+		return err
+	}
+	if gvk != ref.GroupVersionKind() {
+		return fmt.Errorf("reference %s does not refer to a %s", ref, gvk)
+	}
+	return r.get(ctx, ref, obj)
+}
+
+func (r *Resolver) get(ctx context.Context, ref v1alpha1.ClusterObjectReference, obj client.Object) error {
+	cl, err := r.mgr.GetCluster(ctx, ref.Cluster)
+	if err != nil {
+		return fmt.Errorf("failed to get cluster of reference %s: %w", ref, err)
+	}
+	return cl.GetClient().Get(ctx, ref.NamespacedName(), obj)
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reference
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/apis/v1alpha1"
+	"sigs.k8s.io/multicluster-runtime/pkg/fake"
+)
+
+var _ = Describe("ClusterObjectReference", func() {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "config"},
+		Data:       map[string]string{"k": "v"},
+	}
+	ref := v1alpha1.ClusterObjectReference{Cluster: "spoke", APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "config"}
+
+	It("is created for objects", func() {
+		got, err := ForObject("spoke", cm, scheme.Scheme)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(got).To(Equal(ref))
+		Expect(got.String()).To(Equal("cluster://spoke/ConfigMap/default/config"))
+
+		req := ToRequest(got)
+		Expect(req.ClusterName).To(Equal("spoke"))
+		Expect(req.NamespacedName).To(Equal(types.NamespacedName{Namespace: "default", Name: "config"}))
+	})
+
+	It("is validated", func() {
+		Expect(Validate(ref, field.NewPath("ref"))).To(BeEmpty())
+
+		errs := Validate(v1alpha1.ClusterObjectReference{APIVersion: "a/b/c", Namespace: "Invalid"}, field.NewPath("ref"))
+		Expect(errs.ToAggregate()).To(MatchError(ContainSubstring("ref.apiVersion")))
+		Expect(errs.ToAggregate()).To(MatchError(ContainSubstring("ref.kind")))
+		Expect(errs.ToAggregate()).To(MatchError(ContainSubstring("ref.namespace")))
+		Expect(errs.ToAggregate()).To(MatchError(ContainSubstring("ref.name")))
+	})
+
+	It("is resolved through the manager", func(ctx context.Context) {
+		r := NewResolver(fake.NewManagerBuilder().WithCluster("spoke", cm.DeepCopy()).Build())
+
+		u, err := r.Resolve(ctx, ref)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(u.GetName()).To(Equal("config"))
+
+		got := &corev1.ConfigMap{}
+		Expect(r.ResolveInto(ctx, ref, got)).To(Succeed())
+		Expect(got.Data).To(HaveKeyWithValue("k", "v"))
+
+		Expect(r.ResolveInto(ctx, ref, &corev1.Secret{})).To(MatchError(ContainSubstring("does not refer to a /v1, Kind=Secret")))
+
+		missing := ref
+		missing.Name = "missing"
+		_, err = r.Resolve(ctx, missing)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+		missing = ref
+		missing.Cluster = "unknown"
+		_, err = r.Resolve(ctx, missing)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,239 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package replay records the fleet event feed of a live run to a file, and
+// replays it deterministically against a reconciler in tests or CI, to
+// reproduce race conditions found in production fleets.
+//
+// Record the feed of an eventstream.Stream with IncludeObjects set:
+//
+//	err := replay.Record(ctx, stream.Subscribe(ctx, nil), f)
+//
+// and replay it in a test:
+//
+//	events, err := replay.Load(f)
+//	h := replay.NewHarness(replay.Options{})
+//	steps, err := h.Replay(ctx, events, &MyReconciler{Manager: h.Manager()})
+package replay
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/eventstream"
+	"sigs.k8s.io/multicluster-runtime/pkg/fake"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+)
+
+// ErrDropped is returned by Record if the subscription ended before ctx was
+// done, e.g. because the recorder fell behind the stream.
+var ErrDropped = errors.New("event subscription dropped")
+
+// Record writes the events to w, one JSON document per line, until ctx is
+// done. Object events are only replayable if they carry the object, i.e.
+// if the stream was created with IncludeObjects.
+func Record(ctx context.Context, events <-chan eventstream.Event, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-events:
+			if !ok {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return ErrDropped
+			}
+			if err := enc.Encode(ev); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Load reads the events written by Record.
+func Load(r io.Reader) ([]eventstream.Event, error) {
+	var events []eventstream.Event
+	dec := json.NewDecoder(bufio.NewReader(r))
+	for {
+		var ev eventstream.Event
+		if err := dec.Decode(&ev); err != nil {
+			if errors.Is(err, io.EOF) {
+				return events, nil
+			}
+			return nil, fmt.Errorf("failed to decode event %d: %w", len(events)+1, err)
+		}
+		events = append(events, ev)
+	}
+}
+
+// Options are the options of a Harness.
+type Options struct {
+	// Builder configures the fake clusters. Clusters configured on it are
+	// not part of the fleet until engaged by an event, and start with the
+	// objects set for them. Defaults to fake.NewManagerBuilder().
+	Builder *fake.ManagerBuilder
+
+	// Requests maps an object event to the requests to reconcile. Defaults
+	// to the request of the object itself.
+	Requests func(eventstream.Event) []mcreconcile.Request
+
+	// MaxRequeues is the number of times a request asking to be requeued is
+	// reconciled again right away. By default, requeues are not followed.
+	MaxRequeues int
+}
+
+// Step is a reconciliation during a replay.
+type Step struct {
+	// Event is the index of the event in the replayed events.
+	Event int
+
+	// Request is the reconciled request.
+	Request mcreconcile.Request
+
+	// Result is the result of the reconciler.
+	Result reconcile.Result
+
+	// Err is the error of the reconciler.
+	Err error
+}
+
+// Harness replays recorded events against a fake fleet.
+type Harness struct {
+	opts    Options
+	mgr     *fake.Manager
+	cancels map[string]context.CancelFunc
+}
+
+// NewHarness returns a new Harness with an empty fleet.
+func NewHarness(opts Options) *Harness {
+	if opts.Builder == nil {
+		opts.Builder = fake.NewManagerBuilder()
+	}
+	if opts.Requests == nil {
+		opts.Requests = func(ev eventstream.Event) []mcreconcile.Request {
+			return []mcreconcile.Request{{
+				Request:     reconcile.Request{NamespacedName: types.NamespacedName{Namespace: ev.Namespace, Name: ev.Name}},
+				ClusterName: ev.Cluster,
+			}}
+		}
+	}
+	mgr := opts.Builder.Build()
+	for _, name := range mgr.FakeProvider().Names() {
+		mgr.FakeProvider().Remove(name)
+	}
+	return &Harness{opts: opts, mgr: mgr, cancels: map[string]context.CancelFunc{}}
+}
+
+// Manager returns the manager of the fleet, to construct the reconciler and
+// to inspect the clusters after a replay.
+func (h *Harness) Manager() *fake.Manager {
+	return h.mgr
+}
+
+// Replay applies the events to the fleet in order, and after every object
+// event reconciles the requests mapped from it synchronously. Engaged events
+// add a fresh cluster to the fleet and engage it with the manager,
+// Disengaged events remove it. Errors of the reconciler are recorded in the
+// returned steps, the replay stops on events that cannot be applied.
+func (h *Harness) Replay(ctx context.Context, events []eventstream.Event, r mcreconcile.Reconciler) ([]Step, error) {
+	var steps []Step
+	for i, ev := range events {
+		if err := h.apply(ctx, ev); err != nil {
+			return steps, fmt.Errorf("failed to apply event %d (%s %s): %w", i, ev.Type, ev.Cluster, err)
+		}
+		if ev.Kind == "" {
+			continue
+		}
+		for _, req := range h.opts.Requests(ev) {
+			for n := 0; ; n++ {
+				res, err := r.Reconcile(ctx, req)
+				steps = append(steps, Step{Event: i, Request: req, Result: res, Err: err})
+				if n >= h.opts.MaxRequeues || (err == nil && res.IsZero()) {
+					break
+				}
+			}
+		}
+	}
+	return steps, nil
+}
+
+func (h *Harness) apply(ctx context.Context, ev eventstream.Event) error {
+	switch ev.Type {
+	case eventstream.EventEngaged:
+		if cancel, ok := h.cancels[ev.Cluster]; ok {
+			cancel()
+		}
+		clusterCtx, cancel := context.WithCancel(ctx)
+		h.cancels[ev.Cluster] = cancel
+		h.mgr.FakeProvider().Add(ev.Cluster, h.opts.Builder.BuildCluster(ev.Cluster), multicluster.Metadata{})
+		return h.mgr.Engage(clusterCtx, ev.Cluster, h.mgr.FakeCluster(ev.Cluster))
+	case eventstream.EventDisengaged:
+		if cancel, ok := h.cancels[ev.Cluster]; ok {
+			cancel()
+			delete(h.cancels, ev.Cluster)
+		}
+		h.mgr.FakeProvider().Remove(ev.Cluster)
+		return nil
+	}
+
+	cl := h.mgr.FakeCluster(ev.Cluster)
+	if cl == nil {
+		return multicluster.ErrClusterNotFound
+	}
+	obj := &unstructured.Unstructured{}
+	if ev.Type == eventstream.EventDeleted {
+		obj.SetAPIVersion(ev.APIVersion)
+		obj.SetKind(ev.Kind)
+		obj.SetNamespace(ev.Namespace)
+		obj.SetName(ev.Name)
+		return client.IgnoreNotFound(cl.GetClient().Delete(ctx, obj))
+	}
+	if len(ev.Object) == 0 {
+		return errors.New("event has no object, record with IncludeObjects")
+	}
+	if err := json.Unmarshal(ev.Object, &obj.Object); err != nil {
+		return err
+	}
+	obj.SetAPIVersion(ev.APIVersion)
+	obj.SetKind(ev.Kind)
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(obj.GroupVersionKind())
+	err := cl.GetClient().Get(ctx, client.ObjectKeyFromObject(obj), existing)
+	switch {
+	case apierrors.IsNotFound(err):
+		obj.SetResourceVersion("")
+		return cl.GetClient().Create(ctx, obj)
+	case err != nil:
+		return err
+	}
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	return cl.GetClient().Update(ctx, obj)
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replay
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/eventstream"
+	"sigs.k8s.io/multicluster-runtime/pkg/fake"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+)
+
+// syncBuffer is a buffer safe to read while it is written.
+type syncBuffer struct {
+	lock sync.Mutex
+	buf  bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) Bytes() []byte {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return append([]byte(nil), b.buf.Bytes()...)
+}
+
+var _ = Describe("Record and Replay", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+	})
+
+	cm := func(name, value string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, ResourceVersion: "7"},
+			Data:       map[string]string{"k": value},
+		}
+	}
+
+	// observer records what the reconciler sees in the fleet.
+	observer := func(h *Harness, seen *[]string) mcreconcile.Func {
+		return func(ctx context.Context, req mcreconcile.Request) (reconcile.Result, error) {
+			cl, err := h.Manager().GetCluster(ctx, req.ClusterName)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			obj := &corev1.ConfigMap{}
+			if err := cl.GetClient().Get(ctx, req.NamespacedName, obj); err != nil {
+				if apierrors.IsNotFound(err) {
+					*seen = append(*seen, req.String()+"=gone")
+					return reconcile.Result{}, nil
+				}
+				return reconcile.Result{}, err
+			}
+			*seen = append(*seen, req.String()+"="+obj.Data["k"])
+			return reconcile.Result{}, nil
+		}
+	}
+
+	It("replays a recorded feed deterministically", func() {
+		live := fake.NewManagerBuilder().WithCluster("one").Build()
+		stream, err := eventstream.New(live, eventstream.Options{Kinds: []client.Object{&corev1.ConfigMap{}}, IncludeObjects: true})
+		Expect(err).NotTo(HaveOccurred())
+
+		recordCtx, stopRecording := context.WithCancel(ctx)
+		var buf syncBuffer
+		done := make(chan error)
+		events := stream.Subscribe(recordCtx, nil)
+		go func() { done <- Record(recordCtx, events, &buf) }()
+
+		clusterCtx, disengage := context.WithCancel(ctx)
+		Expect(stream.Engage(clusterCtx, "one", live.FakeCluster("one"))).To(Succeed())
+		inf, err := live.FakeCluster("one").GetCache().GetInformer(ctx, &corev1.ConfigMap{})
+		Expect(err).NotTo(HaveOccurred())
+		informer := inf.(*controllertest.FakeInformer)
+		informer.Add(cm("a", "1"))
+		informer.Update(cm("a", "1"), cm("a", "2"))
+		informer.Delete(cm("a", "2"))
+		disengage()
+		Eventually(func() int { return bytes.Count(buf.Bytes(), []byte("\n")) }).Should(Equal(5))
+		stopRecording()
+		Expect(<-done).To(Succeed())
+
+		recorded, err := Load(bytes.NewReader(buf.Bytes()))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(recorded).To(HaveLen(5))
+		Expect(recorded[0].Type).To(Equal(eventstream.EventEngaged))
+		Expect(recorded[4].Type).To(Equal(eventstream.EventDisengaged))
+
+		for range 2 {
+			h := NewHarness(Options{})
+			var seen []string
+			steps, err := h.Replay(ctx, recorded, observer(h, &seen))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(steps).To(HaveLen(3))
+			Expect(seen).To(Equal([]string{
+				"cluster://one/default/a=1",
+				"cluster://one/default/a=2",
+				"cluster://one/default/a=gone",
+			}))
+			_, err = h.Manager().GetCluster(ctx, "one")
+			Expect(err).To(HaveOccurred())
+		}
+	})
+
+	It("starts engaged clusters with the objects of the builder and maps events to requests", func() {
+		h := NewHarness(Options{
+			Builder: fake.NewManagerBuilder().WithCluster("one", cm("b", "initial")),
+			Requests: func(ev eventstream.Event) []mcreconcile.Request {
+				req := mcreconcile.Request{ClusterName: ev.Cluster}
+				req.Namespace = "default"
+				req.Name = "b"
+				return []mcreconcile.Request{req}
+			},
+		})
+		_, err := h.Manager().GetCluster(ctx, "one")
+		Expect(err).To(HaveOccurred())
+
+		var seen []string
+		_, err = h.Replay(ctx, []eventstream.Event{
+			{Type: eventstream.EventEngaged, Cluster: "one"},
+			{Type: eventstream.EventAdded, Cluster: "one", APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "a", Object: []byte(`{"metadata":{"namespace":"default","name":"a"}}`)},
+		}, observer(h, &seen))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(seen).To(Equal([]string{"cluster://one/default/b=initial"}))
+	})
+
+	It("follows requeues up to the limit and records errors", func() {
+		h := NewHarness(Options{MaxRequeues: 2})
+		calls := 0
+		steps, err := h.Replay(ctx, []eventstream.Event{
+			{Type: eventstream.EventEngaged, Cluster: "one"},
+			{Type: eventstream.EventDeleted, Cluster: "one", APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "a"},
+		}, mcreconcile.Func(func(context.Context, mcreconcile.Request) (reconcile.Result, error) {
+			calls++
+			return reconcile.Result{}, fmt.Errorf("attempt %d", calls)
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(steps).To(HaveLen(3))
+		Expect(steps[2].Event).To(Equal(1))
+		Expect(steps[2].Err).To(MatchError("attempt 3"))
+	})
+
+	It("stops on events that cannot be applied", func() {
+		h := NewHarness(Options{})
+		_, err := h.Replay(ctx, []eventstream.Event{
+			{Type: eventstream.EventEngaged, Cluster: "one"},
+			{Type: eventstream.EventAdded, Cluster: "one", APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "a"},
+		}, mcreconcile.Func(func(context.Context, mcreconcile.Request) (reconcile.Result, error) {
+			return reconcile.Result{}, errors.New("unexpected")
+		}))
+		Expect(err).To(MatchError(ContainSubstring("IncludeObjects")))
+	})
+
+	It("reports dropped subscriptions", func() {
+		events := make(chan eventstream.Event)
+		close(events)
+		Expect(Record(ctx, events, &bytes.Buffer{})).To(MatchError(ErrDropped))
+	})
+})
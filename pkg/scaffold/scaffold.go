@@ -0,0 +1,188 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package scaffold generates the skeleton of a hub-and-spoke controller
+// project: a main package wiring the chosen provider into a multicluster
+// manager, a reconciler that reads the objects of the spoke clusters and
+// records them in the hub cluster, and a unit test running the reconciler
+// against the fake manager of pkg/fake.
+package scaffold
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"golang.org/x/mod/module"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/config"
+)
+
+// DefaultVersion is the version of multicluster-runtime required by the
+// generated projects by default.
+const DefaultVersion = "v0.20.4-alpha.7"
+
+// Providers are the names of the providers the scaffolder can wire.
+var Providers = []string{config.ProviderKind, config.ProviderNamespace, config.ProviderClusterAPI}
+
+// Options configure the generated project.
+type Options struct {
+	// Module is the Go module path of the project, e.g.
+	// "example.com/fleet-controller". Required.
+	Module string
+
+	// Name is the name of the controller. Defaults to the last element of
+	// Module.
+	Name string
+
+	// Provider is the name of the provider discovering the spoke clusters,
+	// one of Providers. Defaults to config.ProviderKind.
+	Provider string
+
+	// HubNamespace is the namespace of the hub cluster the reconciler
+	// records the spoke objects in. Defaults to "default".
+	HubNamespace string
+
+	// Version is the version of multicluster-runtime, and of the provider
+	// modules, required by the project. Defaults to DefaultVersion.
+	Version string
+}
+
+// File is a generated file.
+type File struct {
+	// Path is the slash separated path of the file, relative to the root
+	// of the project.
+	Path string
+
+	// Content is the content of the file.
+	Content []byte
+}
+
+// complete defaults the unset options.
+func (o *Options) complete() {
+	if o.Name == "" {
+		o.Name = path.Base(o.Module)
+	}
+	if o.Provider == "" {
+		o.Provider = config.ProviderKind
+	}
+	if o.HubNamespace == "" {
+		o.HubNamespace = "default"
+	}
+	if o.Version == "" {
+		o.Version = DefaultVersion
+	}
+}
+
+// validate returns an error if the options are invalid.
+func (o *Options) validate() error {
+	var errs []error
+	if err := module.CheckPath(o.Module); err != nil {
+		errs = append(errs, fmt.Errorf("invalid module: %w", err))
+	}
+	for _, msg := range validation.IsDNS1123Label(o.Name) {
+		errs = append(errs, fmt.Errorf("invalid name %q: %s", o.Name, msg))
+	}
+	if !isProvider(o.Provider) {
+		errs = append(errs, fmt.Errorf("unsupported provider %q, must be one of %s", o.Provider, strings.Join(Providers, ", ")))
+	}
+	for _, msg := range validation.IsDNS1123Label(o.HubNamespace) {
+		errs = append(errs, fmt.Errorf("invalid hub namespace %q: %s", o.HubNamespace, msg))
+	}
+	if err := module.Check("sigs.k8s.io/multicluster-runtime", o.Version); err != nil {
+		errs = append(errs, fmt.Errorf("invalid version: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+func isProvider(name string) bool {
+	for _, p := range Providers {
+		if p == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Generate returns the files of a project for opts. The Go sources are
+// formatted. The project has no go.sum; run "go mod tidy" in it first.
+func Generate(opts Options) ([]File, error) {
+	opts.complete()
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	files := make([]File, 0, len(templates))
+	for _, t := range templates {
+		var buf bytes.Buffer
+		if err := t.tmpl.Execute(&buf, opts); err != nil {
+			return nil, fmt.Errorf("failed to generate %s: %w", t.path, err)
+		}
+		content := buf.Bytes()
+		if strings.HasSuffix(t.path, ".go") {
+			var err error
+			if content, err = format.Source(content); err != nil {
+				return nil, fmt.Errorf("failed to format %s: %w", t.path, err)
+			}
+		}
+		files = append(files, File{Path: t.path, Content: content})
+	}
+	return files, nil
+}
+
+// Write generates the project for opts into dir, creating it if needed.
+// It fails without writing anything if one of the files already exists.
+func Write(dir string, opts Options) error {
+	files, err := Generate(opts)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(f.Path))); err == nil {
+			return fmt.Errorf("refusing to overwrite %s", f.Path)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	for _, f := range files {
+		p := filepath.Join(dir, filepath.FromSlash(f.Path))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(p, f.Content, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// file is the template of a generated file.
+type file struct {
+	path string
+	tmpl *template.Template
+}
+
+func newFile(path, text string) file {
+	return file{path: path, tmpl: template.Must(template.New(path).Parse(text))}
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffold
+
+import (
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"golang.org/x/mod/modfile"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/config"
+)
+
+var _ = Describe("Generate", func() {
+	byPath := func(files []File) map[string][]byte {
+		m := map[string][]byte{}
+		for _, f := range files {
+			m[f.Path] = f.Content
+		}
+		return m
+	}
+
+	for _, provider := range Providers {
+		It("generates a formatted project for the "+provider+" provider", func() {
+			files, err := Generate(Options{Module: "example.com/fleet/configmap-recorder", Provider: provider})
+			Expect(err).NotTo(HaveOccurred())
+			m := byPath(files)
+			Expect(m).To(HaveKey("README.md"))
+
+			imports := map[string]bool{}
+			for path, content := range m {
+				if !strings.HasSuffix(path, ".go") {
+					continue
+				}
+				formatted, err := format.Source(content)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(content)).To(Equal(string(formatted)), path)
+
+				f, err := parser.ParseFile(token.NewFileSet(), path, content, parser.ImportsOnly)
+				Expect(err).NotTo(HaveOccurred(), path)
+				for _, imp := range f.Imports {
+					p, err := strconv.Unquote(imp.Path.Value)
+					Expect(err).NotTo(HaveOccurred())
+					imports[p] = true
+				}
+			}
+			Expect(imports).To(HaveKey("example.com/fleet/configmap-recorder/internal/controller"))
+			Expect(imports).To(HaveKey("sigs.k8s.io/multicluster-runtime/pkg/fake"))
+			Expect(string(m["internal/controller/controller.go"])).To(ContainSubstring(`Named("configmap-recorder")`))
+
+			mod, err := modfile.Parse("go.mod", m["go.mod"], nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mod.Module.Mod.Path).To(Equal("example.com/fleet/configmap-recorder"))
+			required := map[string]string{}
+			for _, r := range mod.Require {
+				required[r.Mod.Path] = r.Mod.Version
+			}
+			Expect(required).To(HaveKeyWithValue("sigs.k8s.io/multicluster-runtime", DefaultVersion))
+
+			// every imported module is required.
+			for imp := range imports {
+				if !strings.Contains(strings.Split(imp, "/")[0], ".") || strings.HasPrefix(imp, "example.com/") {
+					continue
+				}
+				Expect(hasModule(required, imp)).To(BeTrue(), imp)
+			}
+			switch provider {
+			case config.ProviderKind:
+				Expect(imports).To(HaveKey("sigs.k8s.io/multicluster-runtime/providers/kind"))
+			case config.ProviderNamespace:
+				Expect(imports).To(HaveKey("sigs.k8s.io/multicluster-runtime/providers/namespace"))
+			case config.ProviderClusterAPI:
+				Expect(imports).To(HaveKey("sigs.k8s.io/multicluster-runtime/providers/cluster-api"))
+				Expect(required).To(HaveKey("sigs.k8s.io/cluster-api"))
+			}
+		})
+	}
+
+	It("rejects invalid options", func() {
+		_, err := Generate(Options{})
+		Expect(err).To(MatchError(ContainSubstring("invalid module")))
+
+		_, err = Generate(Options{Module: "example.com/Fleet_Controller"})
+		Expect(err).To(MatchError(ContainSubstring("invalid name")))
+
+		_, err = Generate(Options{Module: "example.com/fleet", Provider: "dns"})
+		Expect(err).To(MatchError(ContainSubstring("unsupported provider")))
+
+		_, err = Generate(Options{Module: "example.com/fleet", HubNamespace: "Hub"})
+		Expect(err).To(MatchError(ContainSubstring("invalid hub namespace")))
+
+		_, err = Generate(Options{Module: "example.com/fleet", Version: "latest"})
+		Expect(err).To(MatchError(ContainSubstring("invalid version")))
+	})
+
+	It("writes the project, but never overwrites", func() {
+		dir := GinkgoT().TempDir()
+		opts := Options{Module: "example.com/fleet", Provider: config.ProviderNamespace, HubNamespace: "fleet-system"}
+		Expect(Write(dir, opts)).To(Succeed())
+
+		test, err := os.ReadFile(filepath.Join(dir, "internal", "controller", "controller_test.go"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(test)).To(ContainSubstring(`HubNamespace: "fleet-system"`))
+
+		dir = GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0o644)).To(Succeed())
+		Expect(Write(dir, opts)).To(MatchError(ContainSubstring("refusing to overwrite main.go")))
+		main, err := os.ReadFile(filepath.Join(dir, "main.go"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(main)).To(Equal("package main\n"))
+		_, err = os.Stat(filepath.Join(dir, "go.mod"))
+		Expect(os.IsNotExist(err)).To(BeTrue(), "nothing is written")
+	})
+})
+
+// hasModule returns whether the package path belongs to one of the
+// required modules.
+func hasModule(required map[string]string, pkg string) bool {
+	for p := pkg; p != "."; p = filepath.Dir(p) {
+		if _, ok := required[p]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,357 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffold
+
+// templates are the files of a generated project.
+var templates = []file{
+	newFile("go.mod", goModTemplate),
+	newFile("main.go", mainTemplate),
+	newFile("internal/controller/controller.go", controllerTemplate),
+	newFile("internal/controller/controller_test.go", controllerTestTemplate),
+	newFile("README.md", readmeTemplate),
+}
+
+const goModTemplate = `module {{.Module}}
+
+go 1.23.0
+
+require (
+	golang.org/x/sync v0.8.0
+	k8s.io/api v0.32.1
+	k8s.io/apimachinery v0.32.1
+	k8s.io/client-go v0.32.1
+{{- if eq .Provider "cluster-api"}}
+	sigs.k8s.io/cluster-api v1.9.4
+{{- end}}
+	sigs.k8s.io/controller-runtime v0.20.4
+	sigs.k8s.io/multicluster-runtime {{.Version}}
+{{- if eq .Provider "kind"}}
+	sigs.k8s.io/multicluster-runtime/providers/kind {{.Version}}
+{{- else if eq .Provider "cluster-api"}}
+	sigs.k8s.io/multicluster-runtime/providers/cluster-api {{.Version}}
+{{- end}}
+)
+`
+
+const mainTemplate = `package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+
+	"golang.org/x/sync/errgroup"
+{{- if eq .Provider "cluster-api"}}
+	capiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+{{- end}}
+
+{{if eq .Provider "cluster-api"}}	"k8s.io/apimachinery/pkg/util/runtime"
+{{end}}	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+{{- if eq .Provider "namespace"}}
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+{{- end}}
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+{{- if eq .Provider "cluster-api"}}
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+{{- end}}
+
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+{{- if eq .Provider "kind"}}
+	"sigs.k8s.io/multicluster-runtime/providers/kind"
+{{- else if eq .Provider "namespace"}}
+	"sigs.k8s.io/multicluster-runtime/providers/namespace"
+{{- else if eq .Provider "cluster-api"}}
+	capi "sigs.k8s.io/multicluster-runtime/providers/cluster-api"
+{{- end}}
+
+	"{{.Module}}/internal/controller"
+)
+{{- if eq .Provider "cluster-api"}}
+
+func init() {
+	runtime.Must(capiv1beta1.AddToScheme(clientgoscheme.Scheme))
+}
+{{- end}}
+
+func main() {
+	hubNamespace := flag.String("hub-namespace", "{{.HubNamespace}}", "namespace of the hub cluster the spoke objects are recorded in")
+	opts := zap.Options{}
+	opts.BindFlags(flag.CommandLine)
+	flag.Parse()
+
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	log := ctrl.Log.WithName("{{.Name}}")
+	ctx := ctrl.SetupSignalHandler()
+
+	if err := run(ctx, *hubNamespace); err != nil {
+		log.Error(err, "failed to run")
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, hubNamespace string) error {
+	cfg, err := ctrl.GetConfig()
+	if err != nil {
+		return err
+	}
+	g, ctx := errgroup.WithContext(ctx)
+{{if eq .Provider "kind"}}
+	// The kind provider engages the kind clusters of this machine.
+	provider := kind.New()
+{{- else if eq .Provider "namespace"}}
+	// The namespace provider engages every namespace of the hub cluster as
+	// a cluster of its own.
+	hub, err := cluster.New(cfg)
+	if err != nil {
+		return err
+	}
+	provider := namespace.New(hub)
+	g.Go(func() error {
+		return ignoreCanceled(hub.Start(ctx))
+	})
+{{- else if eq .Provider "cluster-api"}}
+	// The Cluster API provider engages the clusters of the Cluster objects
+	// in the hub cluster, watched by a manager of its own.
+	capiMgr, err := manager.New(cfg, manager.Options{
+		Scheme:  clientgoscheme.Scheme,
+		Metrics: metricsserver.Options{BindAddress: "0"},
+	})
+	if err != nil {
+		return err
+	}
+	provider, err := capi.New(capiMgr, capi.Options{})
+	if err != nil {
+		return err
+	}
+	g.Go(func() error {
+		return ignoreCanceled(capiMgr.Start(ctx))
+	})
+{{- end}}
+
+	mgr, err := mcmanager.New(cfg, provider, manager.Options{Scheme: clientgoscheme.Scheme})
+	if err != nil {
+		return err
+	}
+	if err := (&controller.Reconciler{Manager: mgr, HubNamespace: hubNamespace}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	g.Go(func() error {
+		return ignoreCanceled(provider.Run(ctx, mgr))
+	})
+	g.Go(func() error {
+		return ignoreCanceled(mgr.Start(ctx))
+	})
+	return g.Wait()
+}
+
+func ignoreCanceled(err error) error {
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
+`
+
+const controllerTemplate = `// Package controller implements the {{.Name}} controller.
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	mcbuilder "sigs.k8s.io/multicluster-runtime/pkg/builder"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+)
+
+// Reconciler watches the ConfigMaps of the spoke clusters, and records
+// them in one ConfigMap per spoke cluster in the hub cluster. Replace the
+// ConfigMaps with the types of your controller.
+type Reconciler struct {
+	// Manager is the multicluster manager. Its local cluster is the hub.
+	Manager mcmanager.Manager
+
+	// HubNamespace is the namespace of the records in the hub cluster.
+	HubNamespace string
+}
+
+// SetupWithManager adds the reconciler to mgr.
+func (r *Reconciler) SetupWithManager(mgr mcmanager.Manager) error {
+	return mcbuilder.ControllerManagedBy(mgr).
+		Named("{{.Name}}").
+		For(&corev1.ConfigMap{}).
+		Complete(r)
+}
+
+// Reconcile records the ConfigMap of req in the hub cluster, or removes
+// its record if it has been deleted.
+func (r *Reconciler) Reconcile(ctx context.Context, req mcreconcile.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	spoke, err := r.Manager.GetCluster(ctx, req.ClusterName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	cm := &corev1.ConfigMap{}
+	exists := true
+	if err := spoke.GetClient().Get(ctx, req.NamespacedName, cm); apierrors.IsNotFound(err) {
+		exists = false
+	} else if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	record := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Namespace: r.HubNamespace,
+		Name:      RecordName(req.ClusterName),
+	}}
+	key := RecordKey(req.Namespace, req.Name)
+	hub := r.Manager.GetLocalManager().GetClient()
+	op, err := controllerutil.CreateOrUpdate(ctx, hub, record, func() error {
+		if record.Data == nil {
+			record.Data = map[string]string{}
+		}
+		if exists {
+			record.Data[key] = cm.ResourceVersion
+		} else {
+			delete(record.Data, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	log.V(1).Info("Recorded ConfigMap", "operation", op, "exists", exists)
+	return ctrl.Result{}, nil
+}
+
+// RecordName returns the name of the record of a spoke cluster in the hub
+// cluster. Cluster names whose escaped form is no valid object name, e.g.
+// because it contains uppercase escapes or is too long, are hashed.
+func RecordName(clusterName string) string {
+	if name := "spoke-" + multicluster.EscapeClusterName(clusterName); len(validation.IsDNS1123Subdomain(name)) == 0 {
+		return name
+	}
+	sum := sha256.Sum256([]byte(clusterName))
+	return "spoke." + hex.EncodeToString(sum[:8])
+}
+
+// RecordKey returns the key of a ConfigMap in the record of its cluster.
+func RecordKey(namespace, name string) string {
+	return namespace + "." + name
+}
+`
+
+const controllerTestTemplate = `package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/fake"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+)
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+	mgr := fake.NewManagerBuilder().
+		WithCluster("spoke", &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "app"}}).
+		Build()
+	r := &Reconciler{Manager: mgr, HubNamespace: "{{.HubNamespace}}"}
+
+	req := mcreconcile.Request{
+		ClusterName: "spoke",
+		Request:     reconcile.Request{NamespacedName: client.ObjectKey{Namespace: "default", Name: "app"}},
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("failed to reconcile: %v", err)
+	}
+
+	hub := mgr.GetLocalManager().GetClient()
+	record := &corev1.ConfigMap{}
+	if err := hub.Get(ctx, client.ObjectKey{Namespace: "{{.HubNamespace}}", Name: RecordName("spoke")}, record); err != nil {
+		t.Fatalf("failed to get record: %v", err)
+	}
+	if _, ok := record.Data[RecordKey("default", "app")]; !ok {
+		t.Fatalf("ConfigMap not recorded: %v", record.Data)
+	}
+
+	spoke := mgr.FakeCluster("spoke").GetClient()
+	if err := spoke.Delete(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "app"}}); err != nil {
+		t.Fatalf("failed to delete ConfigMap: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("failed to reconcile: %v", err)
+	}
+	if err := hub.Get(ctx, client.ObjectKeyFromObject(record), record); err != nil {
+		t.Fatalf("failed to get record: %v", err)
+	}
+	if _, ok := record.Data[RecordKey("default", "app")]; ok {
+		t.Fatalf("deleted ConfigMap still recorded: %v", record.Data)
+	}
+}
+
+func TestRecordName(t *testing.T) {
+	if got := RecordName("spoke"); got != "spoke-spoke" {
+		t.Errorf("RecordName(%q) = %q, want %q", "spoke", got, "spoke-spoke")
+	}
+	for _, clusterName := range []string{"Prod/EU-1", strings.Repeat("edge", 63)} {
+		if errs := validation.IsDNS1123Subdomain(RecordName(clusterName)); len(errs) > 0 {
+			t.Errorf("RecordName(%q) is no valid name: %v", clusterName, errs)
+		}
+	}
+}
+`
+
+const readmeTemplate = `# {{.Name}}
+
+A hub-and-spoke controller built with
+[multicluster-runtime](https://sigs.k8s.io/multicluster-runtime). It
+watches the ConfigMaps of the spoke clusters discovered by the
+{{.Provider}} provider, and records them in the {{.HubNamespace}} namespace
+of the hub cluster, the cluster of the current kubeconfig.
+
+    go mod tidy
+    go test ./...
+    go run . --hub-namespace={{.HubNamespace}}
+
+Start with internal/controller/controller.go: replace the ConfigMaps with
+the types of your controller, and the records with what the hub should
+learn about the spokes.
+`
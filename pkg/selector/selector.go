@@ -0,0 +1,216 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package selector provides a ClusterSelector type to express which clusters
+// are targeted, consistently across the builder, the fleet helpers and the
+// propagation engine.
+package selector
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+// ClusterSelector selects clusters by their name and by the metadata
+// supplied by their provider. A cluster is selected if it matches all of
+// the set fields. The empty ClusterSelector selects all clusters.
+//
+// ClusterSelector can be embedded into custom resources.
+type ClusterSelector struct {
+	// Names restricts the selection to the clusters with the given names.
+	// +optional
+	Names []string `json:"names,omitempty"`
+
+	// LabelSelector selects clusters by their labels.
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+
+	// Expression selects clusters by their labels using the string syntax
+	// of label selectors.
+	//
+	// Example: region in (eu-west, eu-central),!canary
+	// +optional
+	Expression string `json:"expression,omitempty"`
+
+	// AnnotationExpression selects clusters by their annotations using the
+	// string syntax of label selectors.
+	// +optional
+	AnnotationExpression string `json:"annotationExpression,omitempty"`
+
+	// CEL selects clusters with a CEL expression evaluating to a bool. The
+	// variables name, labels and annotations hold the name and metadata of
+	// the cluster, and taints its taints as maps with the keys key, value
+	// and effect.
+	//
+	// Example: labels.env == 'prod' && !taints.exists(t, t.effect == 'NoSchedule')
+	// +optional
+	CEL string `json:"cel,omitempty"`
+}
+
+// CELCostLimit is the limit of the cost of evaluating the CEL expression of
+// a selector once.
+const CELCostLimit = 100000
+
+// celEnv returns the environment of the CEL expressions of selectors.
+var celEnv = sync.OnceValues(func() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("name", cel.StringType),
+		cel.Variable("labels", cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable("annotations", cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable("taints", cel.ListType(cel.MapType(cel.StringType, cel.StringType))),
+	)
+})
+
+// Compile validates the selector and compiles it for evaluation.
+func (s *ClusterSelector) Compile() (*Selector, error) {
+	sel := &Selector{labels: labels.Everything(), annotations: labels.Everything()}
+	if s == nil {
+		return sel, nil
+	}
+
+	if len(s.Names) > 0 {
+		sel.names = sets.New(s.Names...)
+	}
+	if s.LabelSelector != nil {
+		ls, err := metav1.LabelSelectorAsSelector(s.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label selector: %w", err)
+		}
+		sel.labels = ls
+	}
+	if s.Expression != "" {
+		expr, err := labels.Parse(s.Expression)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cluster selector expression: %w", err)
+		}
+		reqs, _ := expr.Requirements()
+		sel.labels = sel.labels.Add(reqs...)
+	}
+	if s.AnnotationExpression != "" {
+		expr, err := labels.Parse(s.AnnotationExpression)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cluster selector annotation expression: %w", err)
+		}
+		sel.annotations = expr
+	}
+	if s.CEL != "" {
+		program, err := compileCEL(s.CEL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cluster selector CEL expression: %w", err)
+		}
+		sel.cel = program
+	}
+
+	return sel, nil
+}
+
+func compileCEL(expression string) (cel.Program, error) {
+	env, err := celEnv()
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := env.Compile(expression)
+	if issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if !ast.OutputType().IsExactType(types.BoolType) {
+		return nil, fmt.Errorf("expression must evaluate to a bool, not %s", ast.OutputType())
+	}
+	return env.Program(ast, cel.CostLimit(CELCostLimit))
+}
+
+// Selector is a compiled ClusterSelector. A nil Selector selects all
+// clusters. It is safe for concurrent use.
+type Selector struct {
+	names       sets.Set[string]
+	labels      labels.Selector
+	annotations labels.Selector
+	cel         cel.Program
+}
+
+// Everything returns a Selector that selects all clusters.
+func Everything() *Selector {
+	return nil
+}
+
+// Matches returns true if the cluster with the given name and metadata is
+// selected. Clusters for which the CEL expression fails to evaluate, e.g.
+// because it exceeds the cost limit, are not selected.
+func (s *Selector) Matches(clusterName string, md multicluster.Metadata) bool {
+	if s == nil {
+		return true
+	}
+	if s.names != nil && !s.names.Has(clusterName) {
+		return false
+	}
+	if !s.labels.Matches(labels.Set(md.Labels)) || !s.annotations.Matches(labels.Set(md.Annotations)) {
+		return false
+	}
+	return s.cel == nil || s.matchesCEL(clusterName, md)
+}
+
+func (s *Selector) matchesCEL(clusterName string, md multicluster.Metadata) bool {
+	taints := make([]map[string]string, 0, len(md.Taints))
+	for _, t := range md.Taints {
+		taints = append(taints, map[string]string{"key": t.Key, "value": t.Value, "effect": string(t.Effect)})
+	}
+	out, _, err := s.cel.Eval(map[string]any{
+		"name":        clusterName,
+		"labels":      stringMap(md.Labels),
+		"annotations": stringMap(md.Annotations),
+		"taints":      taints,
+	})
+	if err != nil {
+		return false
+	}
+	b, ok := out.Value().(bool)
+	return ok && b
+}
+
+// stringMap returns m, or an empty map if m is nil.
+func stringMap(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+	return m
+}
+
+// MatchesCluster returns true if the cluster with the given name is
+// selected, looking up its metadata through the manager.
+func (s *Selector) MatchesCluster(ctx context.Context, mgr mcmanager.Manager, clusterName string) (bool, error) {
+	if s == nil {
+		return true, nil
+	}
+	if s.names != nil && !s.names.Has(clusterName) {
+		return false, nil
+	}
+	md, err := mgr.GetClusterMetadata(ctx, clusterName)
+	if err != nil {
+		return false, err
+	}
+	return s.Matches(clusterName, md), nil
+}
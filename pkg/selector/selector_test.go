@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selector
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+var _ = Describe("ClusterSelector", func() {
+	prod := multicluster.Metadata{
+		Labels:      map[string]string{"env": "prod", "region": "eu-west"},
+		Annotations: map[string]string{"owner": "team-a"},
+	}
+	dev := multicluster.Metadata{
+		Labels: map[string]string{"env": "dev", "region": "us-east", "canary": "true"},
+	}
+
+	compile := func(s *ClusterSelector) *Selector {
+		sel, err := s.Compile()
+		Expect(err).NotTo(HaveOccurred())
+		return sel
+	}
+
+	It("selects everything when empty", func() {
+		Expect(compile(nil).Matches("a", dev)).To(BeTrue())
+		Expect(compile(&ClusterSelector{}).Matches("a", prod)).To(BeTrue())
+		Expect(Everything().Matches("a", prod)).To(BeTrue())
+	})
+
+	It("combines all fields", func() {
+		sel := compile(&ClusterSelector{
+			Names:         []string{"a", "b"},
+			LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+			Expression:    "region in (eu-west, eu-central),!canary",
+		})
+		Expect(sel.Matches("a", prod)).To(BeTrue())
+		Expect(sel.Matches("c", prod)).To(BeFalse())
+		Expect(sel.Matches("a", dev)).To(BeFalse())
+	})
+
+	It("selects by annotations", func() {
+		sel := compile(&ClusterSelector{AnnotationExpression: "owner=team-a"})
+		Expect(sel.Matches("a", prod)).To(BeTrue())
+		Expect(sel.Matches("a", dev)).To(BeFalse())
+	})
+
+	It("selects by CEL expression", func() {
+		sel := compile(&ClusterSelector{CEL: "labels.env == 'prod' && annotations.owner.startsWith('team-') && name != 'b'"})
+		Expect(sel.Matches("a", prod)).To(BeTrue())
+		Expect(sel.Matches("b", prod)).To(BeFalse())
+		Expect(sel.Matches("a", dev)).To(BeFalse())
+
+		sel = compile(&ClusterSelector{CEL: "!taints.exists(t, t.effect == 'NoSchedule')"})
+		Expect(sel.Matches("a", prod)).To(BeTrue())
+		tainted := multicluster.Metadata{Taints: []multicluster.Taint{{Key: "maintenance", Effect: multicluster.TaintEffectNoSchedule}}}
+		Expect(sel.Matches("a", tainted)).To(BeFalse())
+
+		By("not selecting clusters the expression fails for")
+		sel = compile(&ClusterSelector{CEL: "labels.missing == 'x'"})
+		Expect(sel.Matches("a", prod)).To(BeFalse())
+	})
+
+	It("rejects invalid expressions", func() {
+		_, err := (&ClusterSelector{Expression: "env in ("}).Compile()
+		Expect(err).To(HaveOccurred())
+		_, err = (&ClusterSelector{CEL: "labels.env"}).Compile()
+		Expect(err).To(MatchError(ContainSubstring("must evaluate to a bool")))
+		_, err = (&ClusterSelector{CEL: "labels.env =="}).Compile()
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+// Strategy assigns clusters to shards.
+type Strategy interface {
+	// Assign returns the shard in [0, shards) the cluster is assigned to.
+	Assign(clusterName string, md multicluster.Metadata, shards int) int
+}
+
+// StrategyFunc implements Strategy with a function.
+type StrategyFunc func(clusterName string, md multicluster.Metadata, shards int) int
+
+// Assign calls f.
+func (f StrategyFunc) Assign(clusterName string, md multicluster.Metadata, shards int) int {
+	return f(clusterName, md, shards)
+}
+
+// Hash assigns clusters by the hash of their name. It is the default
+// strategy, and spreads clusters evenly, but splits related clusters.
+var Hash Strategy = StrategyFunc(func(clusterName string, _ multicluster.Metadata, shards int) int {
+	return hash(clusterName, shards)
+})
+
+// LabelAffinity assigns clusters by the hash of the value of the given
+// label, e.g. a region label, so that clusters with the same value are
+// assigned to the same shard. Clusters without the label are assigned by
+// the hash of their name.
+func LabelAffinity(key string) Strategy {
+	return StrategyFunc(func(clusterName string, md multicluster.Metadata, shards int) int {
+		if value, ok := md.Labels[key]; ok {
+			return hash(key+"="+value, shards)
+		}
+		return hash(clusterName, shards)
+	})
+}
+
+func hash(s string, shards int) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(s))
+	return int(h.Sum32() % uint32(shards))
+}
+
+// Config is the shard of a controller replica.
+type Config struct {
+	// Shard is the shard of this replica, in [0, Shards).
+	Shard int
+
+	// Shards is the total number of shards. Sharding is disabled if it is
+	// zero or one.
+	Shards int
+
+	// Strategy assigns clusters to shards. Defaults to Hash.
+	Strategy Strategy
+}
+
+// Enabled returns whether sharding is enabled.
+func (c Config) Enabled() bool {
+	return c.Shards > 1
+}
+
+// Validate returns an error if the shard is out of range.
+func (c Config) Validate() error {
+	if c.Shards < 0 {
+		return fmt.Errorf("number of shards %d must not be negative", c.Shards)
+	}
+	if c.Enabled() && (c.Shard < 0 || c.Shard >= c.Shards) {
+		return fmt.Errorf("shard %d out of range [0, %d)", c.Shard, c.Shards)
+	}
+	return nil
+}
+
+// Assign returns the shard the cluster is assigned to, or 0 if sharding is
+// disabled.
+func (c Config) Assign(clusterName string, md multicluster.Metadata) int {
+	if !c.Enabled() {
+		return 0
+	}
+	strategy := c.Strategy
+	if strategy == nil {
+		strategy = Hash
+	}
+	return strategy.Assign(clusterName, md, c.Shards)
+}
+
+// Owns returns whether the cluster is assigned to the shard of this
+// replica. All clusters are owned if sharding is disabled.
+func (c Config) Owns(clusterName string, md multicluster.Metadata) bool {
+	return c.Assign(clusterName, md) == c.Shard || !c.Enabled()
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+var _ = Describe("Config", func() {
+	It("owns all clusters when disabled", func() {
+		c := Config{}
+		Expect(c.Validate()).To(Succeed())
+		Expect(c.Owns("one", multicluster.Metadata{})).To(BeTrue())
+	})
+
+	It("assigns every cluster to exactly one shard", func() {
+		owners := map[string]int{}
+		for shard := 0; shard < 3; shard++ {
+			c := Config{Shard: shard, Shards: 3}
+			Expect(c.Validate()).To(Succeed())
+			for i := 0; i < 30; i++ {
+				name := fmt.Sprintf("cluster-%d", i)
+				if c.Owns(name, multicluster.Metadata{}) {
+					owners[name]++
+				}
+			}
+		}
+		Expect(owners).To(HaveLen(30))
+		for _, n := range owners {
+			Expect(n).To(Equal(1))
+		}
+	})
+
+	It("keeps clusters with the same label value on the same shard", func() {
+		c := Config{Shards: 8, Strategy: LabelAffinity("region")}
+		eu := multicluster.Metadata{Labels: map[string]string{"region": "eu"}}
+		shard := c.Assign("cluster-0", eu)
+		for i := 1; i < 20; i++ {
+			Expect(c.Assign(fmt.Sprintf("cluster-%d", i), eu)).To(Equal(shard))
+		}
+		Expect(c.Assign("cluster-0", multicluster.Metadata{})).To(Equal(Hash.Assign("cluster-0", multicluster.Metadata{}, 8)))
+	})
+
+	It("rejects shards out of range", func() {
+		Expect(Config{Shard: 2, Shards: 2}.Validate()).To(MatchError(ContainSubstring("out of range")))
+		Expect(Config{Shards: -1}.Validate()).To(MatchError(ContainSubstring("negative")))
+	})
+})
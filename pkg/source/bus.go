@@ -0,0 +1,146 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+)
+
+// BusResubscribeInterval is the time to wait before subscribing to an event
+// bus again after the subscription failed.
+var BusResubscribeInterval = 5 * time.Second
+
+// Message is a message of an external event bus referring to an object in
+// a cluster.
+type Message struct {
+	// Cluster is the name of the cluster.
+	Cluster string
+
+	// Key is the key of the object to reconcile.
+	Key types.NamespacedName
+}
+
+// Subscriber is a subscription to an external event bus like NATS or
+// Kafka, decoding its messages into Messages.
+type Subscriber interface {
+	// Subscribe calls handle for every message until ctx is done or the
+	// subscription fails. handle must not be called after Subscribe
+	// returned.
+	Subscribe(ctx context.Context, handle func(Message)) error
+}
+
+// SubscriberFunc is a function implementing Subscriber.
+type SubscriberFunc func(ctx context.Context, handle func(Message)) error
+
+// Subscribe calls f.
+func (f SubscriberFunc) Subscribe(ctx context.Context, handle func(Message)) error {
+	return f(ctx, handle)
+}
+
+// Bus returns a source turning the messages of an external event bus into
+// requests for the cluster and object named by the message. One
+// subscription is shared by all clusters and controllers while at least one
+// cluster is engaged, and resubscribed after failures. Messages for clusters
+// that are not engaged are dropped.
+func Bus(sub Subscriber) Source {
+	return &busSource{sub: sub, queues: map[string]map[busQueue]struct{}{}}
+}
+
+type busQueue = workqueue.TypedRateLimitingInterface[mcreconcile.Request]
+
+type busSource struct {
+	sub Subscriber
+
+	lock   sync.Mutex
+	queues map[string]map[busQueue]struct{}
+	cancel context.CancelFunc
+}
+
+func (s *busSource) ForCluster(name string, _ cluster.Cluster) (source.TypedSource[mcreconcile.Request], error) {
+	return source.TypedFunc[mcreconcile.Request](func(ctx context.Context, q busQueue) error {
+		s.register(ctx, name, q)
+		return nil
+	}), nil
+}
+
+// register dispatches the messages of the cluster to q until ctx is done,
+// subscribing to the bus for the first cluster and unsubscribing after the
+// last.
+func (s *busSource) register(ctx context.Context, name string, q busQueue) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.queues[name] == nil {
+		s.queues[name] = map[busQueue]struct{}{}
+	}
+	s.queues[name][q] = struct{}{}
+	if s.cancel == nil {
+		var subCtx context.Context
+		subCtx, s.cancel = context.WithCancel(context.Background())
+		go s.run(subCtx)
+	}
+
+	go func() {
+		<-ctx.Done()
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		delete(s.queues[name], q)
+		if len(s.queues[name]) == 0 {
+			delete(s.queues, name)
+		}
+		if len(s.queues) == 0 && s.cancel != nil {
+			s.cancel()
+			s.cancel = nil
+		}
+	}()
+}
+
+func (s *busSource) run(ctx context.Context) {
+	log := log.Log.WithName("bus-source")
+	for {
+		err := s.sub.Subscribe(ctx, s.dispatch)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Error(err, "Subscription to event bus failed, resubscribing", "after", BusResubscribeInterval)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(BusResubscribeInterval):
+		}
+	}
+}
+
+func (s *busSource) dispatch(m Message) {
+	req := mcreconcile.Request{Request: reconcile.Request{NamespacedName: m.Key}, ClusterName: m.Cluster}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for q := range s.queues[m.Cluster] {
+		q.Add(req)
+	}
+}
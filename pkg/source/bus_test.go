@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Bus", func() {
+	key := types.NamespacedName{Namespace: "default", Name: "obj"}
+
+	newQueue := func() busQueue {
+		q := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[mcreconcile.Request]())
+		DeferCleanup(q.ShutDown)
+		return q
+	}
+	next := func(q busQueue) mcreconcile.Request {
+		req, _ := q.Get()
+		q.Done(req)
+		return req
+	}
+
+	It("turns messages of engaged clusters into requests", func() {
+		messages := make(chan Message)
+		var subscriptions atomic.Int32
+		src := Bus(SubscriberFunc(func(ctx context.Context, handle func(Message)) error {
+			subscriptions.Add(1)
+			defer subscriptions.Add(-1)
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case m := <-messages:
+					handle(m)
+				}
+			}
+		}))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		oneCtx, disengageOne := context.WithCancel(ctx)
+		one, err := src.ForCluster("one", nil)
+		Expect(err).NotTo(HaveOccurred())
+		qOne := newQueue()
+		Expect(one.Start(oneCtx, qOne)).To(Succeed())
+		two, err := src.ForCluster("two", nil)
+		Expect(err).NotTo(HaveOccurred())
+		qTwo := newQueue()
+		Expect(two.Start(ctx, qTwo)).To(Succeed())
+		Eventually(subscriptions.Load).Should(Equal(int32(1)))
+
+		messages <- Message{Cluster: "three", Key: key}
+		messages <- Message{Cluster: "two", Key: key}
+		Expect(next(qTwo)).To(Equal(mcreconcile.Request{Request: reconcile.Request{NamespacedName: key}, ClusterName: "two"}))
+		messages <- Message{Cluster: "one", Key: key}
+		Expect(next(qOne).ClusterName).To(Equal("one"))
+		Expect(qTwo.Len()).To(BeZero())
+
+		disengageOne()
+		Consistently(subscriptions.Load).Should(Equal(int32(1)))
+		cancel()
+		Eventually(subscriptions.Load).Should(BeZero())
+	})
+
+	It("resubscribes after failures", func() {
+		defer func(d time.Duration) { BusResubscribeInterval = d }(BusResubscribeInterval)
+		BusResubscribeInterval = 10 * time.Millisecond
+
+		var attempts atomic.Int32
+		src := Bus(SubscriberFunc(func(ctx context.Context, handle func(Message)) error {
+			if attempts.Add(1) < 3 {
+				return errors.New("connection refused")
+			}
+			handle(Message{Cluster: "one", Key: key})
+			<-ctx.Done()
+			return nil
+		}))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		one, err := src.ForCluster("one", nil)
+		Expect(err).NotTo(HaveOccurred())
+		q := newQueue()
+		Expect(one.Start(ctx, q)).To(Succeed())
+		Expect(next(q).ClusterName).To(Equal("one"))
+		Expect(attempts.Load()).To(Equal(int32(3)))
+	})
+})
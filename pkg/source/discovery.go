@@ -0,0 +1,220 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	mccluster "sigs.k8s.io/multicluster-runtime/pkg/cluster"
+	mcmetrics "sigs.k8s.io/multicluster-runtime/pkg/metrics"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+)
+
+// MissingKindPolicy decides what happens when a watched kind is not served
+// by an engaged cluster.
+type MissingKindPolicy string
+
+const (
+	// MissingKindFail fails the engagement of the cluster. This is the
+	// default.
+	MissingKindFail MissingKindPolicy = "Fail"
+
+	// MissingKindSkip does not watch the kind in the cluster.
+	MissingKindSkip MissingKindPolicy = "Skip"
+
+	// MissingKindDefer starts the watch once the kind is served by the
+	// cluster, e.g. as soon as its CRD has been established.
+	MissingKindDefer MissingKindPolicy = "Defer"
+)
+
+// DeferredDiscoveryInterval is the interval in which clusters are checked
+// for deferred kinds that are not backed by a CRD, e.g. kinds of aggregated
+// API servers.
+var DeferredDiscoveryInterval = 10 * time.Second
+
+// WithDiscoveryGate wraps a source of the given object such that clusters
+// not serving the kind of the object are handled according to policy,
+// instead of failing the engagement with a "no matches for kind" error.
+func WithDiscoveryGate[object client.Object, request mcreconcile.ClusterAware[request]](src TypedSource[object, request], obj object, policy MissingKindPolicy) TypedSource[object, request] {
+	if policy == "" || policy == MissingKindFail {
+		return src
+	}
+	return &discoveryGatedSource[object, request]{TypedSource: src, obj: obj, policy: policy}
+}
+
+type discoveryGatedSource[object client.Object, request mcreconcile.ClusterAware[request]] struct {
+	TypedSource[object, request]
+	obj    object
+	policy MissingKindPolicy
+}
+
+func (s *discoveryGatedSource[object, request]) ForCluster(name string, cl cluster.Cluster) (source.TypedSource[request], error) {
+	if mccluster.IsLive(cl) {
+		// nothing is watched on live clusters.
+		return s.TypedSource.ForCluster(name, cl)
+	}
+	served, err := servesKind(cl, s.obj)
+	if err != nil {
+		return nil, err
+	}
+	if served {
+		return s.TypedSource.ForCluster(name, cl)
+	}
+
+	log := log.Log.WithName("discovery-gate").WithValues("cluster", multicluster.EscapeClusterName(name), "type", fmt.Sprintf("%T", s.obj))
+	if s.policy == MissingKindSkip {
+		log.Info("Kind is not served by cluster, skipping watch")
+		return source.TypedFunc[request](func(context.Context, workqueue.TypedRateLimitingInterface[request]) error {
+			return nil
+		}), nil
+	}
+
+	log.Info("Kind is not served by cluster, deferring watch")
+	return source.TypedFunc[request](func(ctx context.Context, q workqueue.TypedRateLimitingInterface[request]) error {
+		go s.startDeferred(ctx, log, name, cl, q)
+		return nil
+	}), nil
+}
+
+// startDeferred waits until the kind is served by the cluster and starts the
+// watch. It is triggered by the establishment of a matching CRD, and falls
+// back to polling discovery for kinds served by aggregated API servers.
+func (s *discoveryGatedSource[object, request]) startDeferred(ctx context.Context, log logr.Logger, name string, cl cluster.Cluster, q workqueue.TypedRateLimitingInterface[request]) {
+	gvk, err := apiutil.GVKForObject(s.obj, cl.GetScheme())
+	if err != nil {
+		log.Error(err, "Failed to determine kind of deferred watch")
+		return
+	}
+
+	established := make(chan client.Object, 1)
+	crd := &unstructured.Unstructured{}
+	crd.SetGroupVersionKind(apiextensionsv1.SchemeGroupVersion.WithKind("CustomResourceDefinition"))
+	if inf, err := cl.GetCache().GetInformer(ctx, crd); err != nil {
+		log.Info("Failed to watch CRDs, polling discovery instead", "error", err.Error())
+	} else {
+		notify := func(obj interface{}) {
+			if u, ok := obj.(*unstructured.Unstructured); ok && isEstablishedCRDFor(u, gvk.GroupKind()) {
+				select {
+				case established <- u:
+				default:
+				}
+			}
+		}
+		reg, err := inf.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+			AddFunc:    notify,
+			UpdateFunc: func(_, obj interface{}) { notify(obj) },
+		})
+		if err != nil {
+			log.Info("Failed to watch CRDs, polling discovery instead", "error", err.Error())
+		} else {
+			defer func() { _ = inf.RemoveEventHandler(reg) }()
+		}
+	}
+
+	ticker := time.NewTicker(DeferredDiscoveryInterval)
+	defer ticker.Stop()
+	for {
+		var crd client.Object
+		select {
+		case <-ctx.Done():
+			return
+		case crd = <-established:
+		case <-ticker.C:
+		}
+
+		if rm, ok := cl.GetRESTMapper().(meta.ResettableRESTMapper); ok {
+			rm.Reset()
+		}
+		served, err := servesKind(cl, s.obj)
+		if err != nil {
+			log.Error(err, "Failed to check discovery for deferred watch")
+			continue
+		}
+		if !served {
+			continue
+		}
+
+		src, err := s.TypedSource.ForCluster(name, cl)
+		if err != nil {
+			log.Error(err, "Failed to create deferred watch")
+			return
+		}
+		if err := src.Start(ctx, q); err != nil {
+			log.Error(err, "Failed to start deferred watch")
+			return
+		}
+
+		log.Info("Started deferred watch")
+		mcmetrics.DeferredWatchesStarted.WithLabelValues(mcmetrics.ClusterLabel(name), gvk.GroupKind().String()).Inc()
+		if crd != nil {
+			cl.GetEventRecorderFor("multicluster-runtime").Eventf(crd, corev1.EventTypeNormal, "DeferredWatchStarted",
+				"Started deferred watch for %s after the CRD was established", gvk.GroupKind())
+		}
+		return
+	}
+}
+
+// isEstablishedCRDFor returns whether crd defines gk and is established.
+func isEstablishedCRDFor(crd *unstructured.Unstructured, gk schema.GroupKind) bool {
+	group, _, _ := unstructured.NestedString(crd.Object, "spec", "group")
+	kind, _, _ := unstructured.NestedString(crd.Object, "spec", "names", "kind")
+	if group != gk.Group || kind != gk.Kind {
+		return false
+	}
+	conditions, _, _ := unstructured.NestedSlice(crd.Object, "status", "conditions")
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if ok && cond["type"] == string(apiextensionsv1.Established) && cond["status"] == string(apiextensionsv1.ConditionTrue) {
+			return true
+		}
+	}
+	return false
+}
+
+// servesKind returns whether the cluster serves the kind of obj.
+func servesKind(cl cluster.Cluster, obj client.Object) (bool, error) {
+	gvk, err := apiutil.GVKForObject(obj, cl.GetScheme())
+	if err != nil {
+		return false, err
+	}
+	if _, err := cl.GetRESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+		if meta.IsNoMatchError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
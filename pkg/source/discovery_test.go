@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	mccluster "sigs.k8s.io/multicluster-runtime/pkg/cluster"
+	"sigs.k8s.io/multicluster-runtime/pkg/fake"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+)
+
+type recordingSource struct {
+	clusters []string
+}
+
+func (s *recordingSource) ForCluster(name string, _ cluster.Cluster) (source.TypedSource[mcreconcile.Request], error) {
+	s.clusters = append(s.clusters, name)
+	return source.TypedFunc[mcreconcile.Request](nil), nil
+}
+
+var _ = Describe("WithDiscoveryGate", func() {
+	cl := fake.NewManagerBuilder().WithCluster("one").Build().FakeCluster("one")
+
+	missing := &unstructured.Unstructured{}
+	missing.SetGroupVersionKind(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Missing"})
+
+	It("watches served kinds", func() {
+		inner := &recordingSource{}
+		src := WithDiscoveryGate[client.Object, mcreconcile.Request](inner, &corev1.ConfigMap{}, MissingKindSkip)
+		_, err := src.ForCluster("one", cl)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(inner.clusters).To(Equal([]string{"one"}))
+	})
+
+	It("skips kinds that are not served", func() {
+		inner := &recordingSource{}
+		src := WithDiscoveryGate[client.Object, mcreconcile.Request](inner, missing, MissingKindSkip)
+		s, err := src.ForCluster("one", cl)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s).NotTo(BeNil())
+		Expect(inner.clusters).To(BeEmpty())
+	})
+
+	It("does not gate live clusters", func() {
+		live, err := mccluster.NewLive(&rest.Config{Host: "https://127.0.0.1:1"}, mccluster.LiveOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		inner := &recordingSource{}
+		src := WithDiscoveryGate[client.Object, mcreconcile.Request](inner, missing, MissingKindDefer)
+		_, err = src.ForCluster("live", live)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(inner.clusters).To(Equal([]string{"live"}))
+	})
+
+	It("does not wrap with the fail policy", func() {
+		inner := &recordingSource{}
+		Expect(WithDiscoveryGate[client.Object, mcreconcile.Request](inner, missing, MissingKindFail)).To(BeIdenticalTo(inner))
+	})
+})
+
+var _ = Describe("isEstablishedCRDFor", func() {
+	crd := func(established string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"group": "example.com",
+				"names": map[string]interface{}{"kind": "Foo"},
+			},
+			"status": map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Established", "status": established},
+				},
+			},
+		}}
+	}
+
+	It("matches established CRDs of the kind", func() {
+		gk := schema.GroupKind{Group: "example.com", Kind: "Foo"}
+		Expect(isEstablishedCRDFor(crd("True"), gk)).To(BeTrue())
+		Expect(isEstablishedCRDFor(crd("False"), gk)).To(BeFalse())
+		Expect(isEstablishedCRDFor(crd("True"), schema.GroupKind{Group: "example.com", Kind: "Bar"})).To(BeFalse())
+	})
+})
@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+)
+
+// ClusterFilterFunc decides whether a source should produce events for the
+// given cluster. ctx is the context of the engagement of the cluster.
+type ClusterFilterFunc func(ctx context.Context, clusterName string, cl cluster.Cluster) (bool, error)
+
+// ContextualSource is implemented by sources that need the context of the
+// engagement of a cluster to create its source, e.g. to look up the
+// metadata of the cluster.
+type ContextualSource[request mcreconcile.ClusterAware[request]] interface {
+	// ForClusterWithContext returns the source of the cluster engaged with
+	// ctx.
+	ForClusterWithContext(ctx context.Context, name string, cl cluster.Cluster) (source.TypedSource[request], error)
+}
+
+// ForClusterWithContext returns the source of src for the cluster engaged
+// with ctx, passing ctx on if src is a ContextualSource.
+func ForClusterWithContext[object client.Object, request mcreconcile.ClusterAware[request]](ctx context.Context, src TypedSource[object, request], name string, cl cluster.Cluster) (source.TypedSource[request], error) {
+	if cs, ok := src.(ContextualSource[request]); ok {
+		return cs.ForClusterWithContext(ctx, name, cl)
+	}
+	return src.ForCluster(name, cl)
+}
+
+// WithClusterFilter wraps a source such that it only produces events for
+// clusters accepted by filter. For other clusters, a source is returned that
+// does nothing.
+func WithClusterFilter[object client.Object, request mcreconcile.ClusterAware[request]](src TypedSource[object, request], filter ClusterFilterFunc) TypedSource[object, request] {
+	return &filteredSource[object, request]{TypedSource: src, filter: filter}
+}
+
+type filteredSource[object client.Object, request mcreconcile.ClusterAware[request]] struct {
+	TypedSource[object, request]
+	filter ClusterFilterFunc
+}
+
+func (s *filteredSource[object, request]) ForCluster(name string, cl cluster.Cluster) (source.TypedSource[request], error) {
+	return s.ForClusterWithContext(context.Background(), name, cl)
+}
+
+func (s *filteredSource[object, request]) ForClusterWithContext(ctx context.Context, name string, cl cluster.Cluster) (source.TypedSource[request], error) {
+	ok, err := s.filter(ctx, name, cl)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return source.TypedFunc[request](func(context.Context, workqueue.TypedRateLimitingInterface[request]) error {
+			return nil
+		}), nil
+	}
+	return ForClusterWithContext[object, request](ctx, s.TypedSource, name, cl)
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/fake"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+)
+
+type ctxKey struct{}
+
+var _ = Describe("WithClusterFilter", func() {
+	cl := fake.NewManagerBuilder().WithCluster("one").Build().FakeCluster("one")
+
+	It("filters clusters with the context of their engagement", func() {
+		inner := &recordingSource{}
+		var got context.Context
+		src := WithClusterFilter[client.Object, mcreconcile.Request](inner, func(ctx context.Context, name string, _ cluster.Cluster) (bool, error) {
+			got = ctx
+			return name == "one", nil
+		})
+
+		ctx := context.WithValue(context.Background(), ctxKey{}, "engagement")
+		_, err := ForClusterWithContext[client.Object, mcreconcile.Request](ctx, src, "one", cl)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(got.Value(ctxKey{})).To(Equal("engagement"))
+		Expect(inner.clusters).To(Equal([]string{"one"}))
+
+		skipped, err := ForClusterWithContext[client.Object, mcreconcile.Request](ctx, src, "two", cl)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(skipped.Start(ctx, nil)).To(Succeed())
+		Expect(inner.clusters).To(Equal([]string{"one"}))
+	})
+
+	It("fails the engagement if the filter fails", func() {
+		src := WithClusterFilter[client.Object, mcreconcile.Request](&recordingSource{}, func(context.Context, string, cluster.Cluster) (bool, error) {
+			return false, errors.New("no metadata")
+		})
+		_, err := src.ForCluster("one", cl)
+		Expect(err).To(MatchError("no metadata"))
+	})
+})
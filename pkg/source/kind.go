@@ -17,12 +17,20 @@ limitations under the License.
 package source
 
 import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/util/workqueue"
+
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	mccluster "sigs.k8s.io/multicluster-runtime/pkg/cluster"
 	mchandler "sigs.k8s.io/multicluster-runtime/pkg/handler"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
 	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
 )
 
@@ -67,6 +75,9 @@ func (k *kind[object, request]) WithProjection(project func(cluster.Cluster, obj
 }
 
 func (k *kind[object, request]) ForCluster(name string, cl cluster.Cluster) (source.TypedSource[request], error) {
+	if mccluster.IsLive(cl) {
+		return k.skipLive(name), nil
+	}
 	obj, err := k.project(cl, k.obj)
 	if err != nil {
 		return nil, err
@@ -77,6 +88,9 @@ func (k *kind[object, request]) ForCluster(name string, cl cluster.Cluster) (sou
 }
 
 func (k *kind[object, request]) SyncingForCluster(name string, cl cluster.Cluster) (source.TypedSyncingSource[request], error) {
+	if mccluster.IsLive(cl) {
+		return k.skipLive(name), nil
+	}
 	obj, err := k.project(cl, k.obj)
 	if err != nil {
 		return nil, err
@@ -85,3 +99,19 @@ func (k *kind[object, request]) SyncingForCluster(name string, cl cluster.Cluste
 		TypedSyncingSource: source.TypedKind(cl.GetCache(), obj, k.handler(name, cl), k.predicates...),
 	}, nil
 }
+
+// skipLive returns a source that does nothing, for clusters without caches.
+func (k *kind[object, request]) skipLive(name string) source.TypedSyncingSource[request] {
+	log.Log.WithName("kind-source").Info("Cluster has no cache, skipping watch", "cluster", multicluster.EscapeClusterName(name), "type", fmt.Sprintf("%T", k.obj))
+	return noopSyncingSource[request]{}
+}
+
+type noopSyncingSource[request comparable] struct{}
+
+func (noopSyncingSource[request]) Start(context.Context, workqueue.TypedRateLimitingInterface[request]) error {
+	return nil
+}
+
+func (noopSyncingSource[request]) WaitForSync(context.Context) error {
+	return nil
+}
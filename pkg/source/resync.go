@@ -0,0 +1,167 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+)
+
+// Schedule decides when resyncs are due. It is satisfied by the schedules
+// of common cron libraries, so resyncs can follow a cron expression.
+type Schedule interface {
+	// Next returns the next time a resync is due after t.
+	Next(t time.Time) time.Time
+}
+
+// Every returns a Schedule recurring at the given interval.
+func Every(interval time.Duration) Schedule {
+	return every(interval)
+}
+
+type every time.Duration
+
+func (e every) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(e))
+}
+
+// ResyncOptions are the options of a Resync source.
+type ResyncOptions struct {
+	// Schedule decides when the objects of a cluster are resynced.
+	// Required.
+	Schedule Schedule
+
+	// Jitter is the maximum random delay added to every resync of a
+	// cluster, so that the clusters of a fleet are not resynced all at
+	// once.
+	Jitter time.Duration
+}
+
+// Resync returns a source enqueuing requests for all objects of the kind
+// of obj in a cluster whenever a resync is due according to the schedule,
+// independent of the resync of the informers. It guarantees a periodic
+// convergence of controllers even without events. The objects are listed
+// from the cache of the cluster.
+func Resync(obj client.Object, opts ResyncOptions) Source {
+	return &resyncSource{obj: obj, opts: opts}
+}
+
+type resyncSource struct {
+	obj  client.Object
+	opts ResyncOptions
+}
+
+func (s *resyncSource) ForCluster(name string, cl cluster.Cluster) (source.TypedSource[mcreconcile.Request], error) {
+	if s.opts.Schedule == nil {
+		return nil, fmt.Errorf("resync of %T must have a schedule", s.obj)
+	}
+	list, err := newListFor(s.obj, cl.GetScheme())
+	if err != nil {
+		return nil, err
+	}
+	return source.TypedFunc[mcreconcile.Request](func(ctx context.Context, q busQueue) error {
+		go s.run(ctx, name, cl, list, q)
+		return nil
+	}), nil
+}
+
+// run enqueues the objects of the cluster when a resync is due until ctx
+// is done.
+func (s *resyncSource) run(ctx context.Context, name string, cl cluster.Cluster, list client.ObjectList, q busQueue) {
+	log := log.Log.WithName("resync-source").WithValues("cluster", multicluster.EscapeClusterName(name), "type", fmt.Sprintf("%T", s.obj))
+	for {
+		now := time.Now()
+		delay := s.opts.Schedule.Next(now).Sub(now)
+		if s.opts.Jitter > 0 {
+			delay += rand.N(s.opts.Jitter)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		l, ok := list.DeepCopyObject().(client.ObjectList)
+		if !ok {
+			log.Error(nil, "Cannot copy list", "list", fmt.Sprintf("%T", list))
+			return
+		}
+		if err := cl.GetCache().List(ctx, l); err != nil {
+			log.Error(err, "Failed to list objects to resync")
+			continue
+		}
+		if err := meta.EachListItem(l, func(o runtime.Object) error {
+			mo, err := meta.Accessor(o)
+			if err != nil {
+				return err
+			}
+			q.Add(mcreconcile.Request{
+				Request:     reconcile.Request{NamespacedName: types.NamespacedName{Namespace: mo.GetNamespace(), Name: mo.GetName()}},
+				ClusterName: name,
+			})
+			return nil
+		}); err != nil {
+			log.Error(err, "Failed to enqueue objects to resync")
+		}
+	}
+}
+
+// newListFor returns an empty list of the kind of obj.
+func newListFor(obj client.Object, scheme *runtime.Scheme) (client.ObjectList, error) {
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return nil, err
+	}
+	gvk.Kind += "List"
+	if _, ok := obj.(*metav1.PartialObjectMetadata); ok {
+		l := &metav1.PartialObjectMetadataList{}
+		l.SetGroupVersionKind(gvk)
+		return l, nil
+	}
+	if _, ok := obj.(runtime.Unstructured); ok {
+		l := &unstructured.UnstructuredList{}
+		l.SetGroupVersionKind(gvk)
+		return l, nil
+	}
+	o, err := scheme.New(gvk)
+	if err != nil {
+		return nil, err
+	}
+	l, ok := o.(client.ObjectList)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a list", gvk)
+	}
+	return l, nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/fake"
+	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Resync", func() {
+	It("enqueues all objects of the cluster when a resync is due", func() {
+		mgr := fake.NewManagerBuilder().WithCluster("one",
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "a"}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "b"}},
+		).Build()
+		src := Resync(&corev1.ConfigMap{}, ResyncOptions{Schedule: Every(20 * time.Millisecond), Jitter: 10 * time.Millisecond})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		s, err := src.ForCluster("one", mgr.FakeCluster("one"))
+		Expect(err).NotTo(HaveOccurred())
+		q := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[mcreconcile.Request]())
+		defer q.ShutDown()
+		Expect(s.Start(ctx, q)).To(Succeed())
+
+		seen := map[string]int{}
+		for len(seen) < 2 || seen["default/a"] < 2 {
+			req, _ := q.Get()
+			Expect(req.ClusterName).To(Equal("one"))
+			seen[req.NamespacedName.String()]++
+			q.Done(req)
+		}
+		Expect(seen).To(HaveKey("other/b"))
+
+		cancel()
+		time.Sleep(50 * time.Millisecond)
+		for q.Len() > 0 {
+			req, _ := q.Get()
+			q.Done(req)
+		}
+		Consistently(q.Len, 100*time.Millisecond).Should(BeZero())
+	})
+
+	It("requires a schedule", func() {
+		_, err := Resync(&corev1.ConfigMap{}, ResyncOptions{}).ForCluster("one", fake.NewManagerBuilder().BuildCluster("one"))
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,237 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package status provides helpers to aggregate per-cluster reconcile results
+// onto the status of a hub object.
+package status
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// ClusterResult is the result of reconciling a hub object in one cluster.
+type ClusterResult struct {
+	// ObservedGeneration is the generation of the hub object that was
+	// reconciled.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions are the conditions reported for the cluster.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Message is a human readable message, usually an error.
+	Message string `json:"message,omitempty"`
+}
+
+// AggregatedStatus is a compact aggregate of cluster results. It can be
+// embedded into the status of a hub custom resource.
+type AggregatedStatus struct {
+	// Total is the number of clusters with a result.
+	Total int `json:"total"`
+
+	// Ready is the number of clusters whose condition is True.
+	Ready int `json:"ready"`
+
+	// NotReady is the number of clusters whose condition is False.
+	NotReady int `json:"notReady"`
+
+	// Unknown is the number of clusters whose condition is Unknown or missing.
+	Unknown int `json:"unknown"`
+
+	// WorstCondition is the condition of the worst cluster, if any.
+	WorstCondition *metav1.Condition `json:"worstCondition,omitempty"`
+
+	// Clusters holds the results per cluster. If more than the configured
+	// maximum clusters have results, only the worst clusters are kept, i.e.
+	// those that are not ready before those that are, and Truncated is set.
+	Clusters map[string]ClusterResult `json:"clusters,omitempty"`
+
+	// Truncated is true if Clusters does not hold all results.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// Options are the options for the Aggregator.
+type Options struct {
+	// ConditionType is the condition type that decides whether a cluster is
+	// ready. Defaults to "Ready".
+	ConditionType string
+
+	// MaxClusters is the maximum number of clusters in the per-cluster map.
+	// Defaults to 20. Negative values disable truncation.
+	MaxClusters int
+
+	// StatusField is the field under .status the aggregate is written to.
+	// Defaults to "clusters".
+	StatusField string
+
+	// FieldManager is the field manager used for server-side apply. Defaults
+	// to "multicluster-status-aggregator".
+	FieldManager string
+}
+
+// Aggregator collects per-cluster results for hub objects, and writes the
+// aggregate onto the hub objects' status. It is safe for concurrent use.
+type Aggregator struct {
+	opts Options
+
+	lock    sync.RWMutex
+	results map[client.ObjectKey]map[string]ClusterResult
+}
+
+// NewAggregator returns a new Aggregator.
+func NewAggregator(opts Options) *Aggregator {
+	if opts.ConditionType == "" {
+		opts.ConditionType = "Ready"
+	}
+	if opts.MaxClusters == 0 {
+		opts.MaxClusters = 20
+	}
+	if opts.StatusField == "" {
+		opts.StatusField = "clusters"
+	}
+	if opts.FieldManager == "" {
+		opts.FieldManager = "multicluster-status-aggregator"
+	}
+	return &Aggregator{
+		opts:    opts,
+		results: map[client.ObjectKey]map[string]ClusterResult{},
+	}
+}
+
+// Report records the result for the hub object with the given key in the
+// given cluster, replacing an earlier result.
+func (a *Aggregator) Report(hub client.ObjectKey, clusterName string, result ClusterResult) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if _, ok := a.results[hub]; !ok {
+		a.results[hub] = map[string]ClusterResult{}
+	}
+	a.results[hub][clusterName] = result
+}
+
+// Forget removes the result for the hub object in the given cluster, e.g.
+// when the cluster is not targeted anymore. If clusterName is empty, all
+// results for the hub object are removed.
+func (a *Aggregator) Forget(hub client.ObjectKey, clusterName string) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if clusterName == "" {
+		delete(a.results, hub)
+		return
+	}
+	delete(a.results[hub], clusterName)
+}
+
+// Aggregate computes the aggregate of all results for the hub object.
+func (a *Aggregator) Aggregate(hub client.ObjectKey) AggregatedStatus {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	results := a.results[hub]
+	agg := AggregatedStatus{Total: len(results)}
+
+	names := make([]string, 0, len(results))
+	for name := range results {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		ri, rj := a.rank(results[names[i]]), a.rank(results[names[j]])
+		if ri != rj {
+			return ri > rj
+		}
+		return names[i] < names[j]
+	})
+
+	worst := -1
+	for _, name := range names {
+		res := results[name]
+		cond := meta.FindStatusCondition(res.Conditions, a.opts.ConditionType)
+		switch {
+		case cond == nil || cond.Status == metav1.ConditionUnknown:
+			agg.Unknown++
+		case cond.Status == metav1.ConditionTrue:
+			agg.Ready++
+		default:
+			agg.NotReady++
+		}
+		if r := a.rank(res); cond != nil && r > worst {
+			worst = r
+			agg.WorstCondition = cond.DeepCopy()
+		}
+	}
+
+	if len(names) > 0 {
+		agg.Clusters = map[string]ClusterResult{}
+	}
+	for i, name := range names {
+		if a.opts.MaxClusters >= 0 && i >= a.opts.MaxClusters {
+			agg.Truncated = true
+			break
+		}
+		agg.Clusters[name] = results[name]
+	}
+
+	return agg
+}
+
+// rank orders results from good to bad.
+func (a *Aggregator) rank(res ClusterResult) int {
+	cond := meta.FindStatusCondition(res.Conditions, a.opts.ConditionType)
+	switch {
+	case cond == nil:
+		return 1
+	case cond.Status == metav1.ConditionTrue:
+		return 0
+	case cond.Status == metav1.ConditionUnknown:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// Write applies the aggregate for hub onto its status with server-side
+// apply, forcing ownership of the status field. The hub object's type must have a status
+// subresource with the configured status field.
+func (a *Aggregator) Write(ctx context.Context, c client.Client, hub client.Object) error {
+	gvk, err := apiutil.GVKForObject(hub, c.Scheme())
+	if err != nil {
+		return err
+	}
+	agg := a.Aggregate(client.ObjectKeyFromObject(hub))
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&agg)
+	if err != nil {
+		return fmt.Errorf("failed to convert aggregated status: %w", err)
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetNamespace(hub.GetNamespace())
+	obj.SetName(hub.GetName())
+	if err := unstructured.SetNestedField(obj.Object, content, "status", a.opts.StatusField); err != nil {
+		return err
+	}
+	return c.Status().Patch(ctx, obj, client.Apply, client.FieldOwner(a.opts.FieldManager), client.ForceOwnership)
+}
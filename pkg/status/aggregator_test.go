@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/status"
+)
+
+func result(s metav1.ConditionStatus, reason string) status.ClusterResult {
+	return status.ClusterResult{Conditions: []metav1.Condition{{Type: "Ready", Status: s, Reason: reason}}}
+}
+
+var _ = Describe("Aggregator", func() {
+	key := client.ObjectKey{Namespace: "default", Name: "hub"}
+
+	It("counts the clusters by their condition", func() {
+		agg := status.NewAggregator(status.Options{})
+		agg.Report(key, "a", result(metav1.ConditionTrue, "Applied"))
+		agg.Report(key, "b", result(metav1.ConditionUnknown, "Pending"))
+		agg.Report(key, "c", result(metav1.ConditionFalse, "Failed"))
+		agg.Report(key, "d", status.ClusterResult{Message: "no conditions"})
+
+		res := agg.Aggregate(key)
+		Expect(res.Total).To(Equal(4))
+		Expect(res.Ready).To(Equal(1))
+		Expect(res.NotReady).To(Equal(1))
+		Expect(res.Unknown).To(Equal(2))
+		Expect(res.WorstCondition).NotTo(BeNil())
+		Expect(res.WorstCondition.Reason).To(Equal("Failed"))
+		Expect(res.Clusters).To(HaveLen(4))
+		Expect(res.Truncated).To(BeFalse())
+	})
+
+	It("keeps the worst clusters when truncating", func() {
+		agg := status.NewAggregator(status.Options{MaxClusters: 2})
+		agg.Report(key, "a", result(metav1.ConditionTrue, "Applied"))
+		agg.Report(key, "b", result(metav1.ConditionTrue, "Applied"))
+		agg.Report(key, "c", result(metav1.ConditionUnknown, "Pending"))
+		agg.Report(key, "d", result(metav1.ConditionFalse, "Failed"))
+
+		res := agg.Aggregate(key)
+		Expect(res.Total).To(Equal(4))
+		Expect(res.Truncated).To(BeTrue())
+		Expect(res.Clusters).To(HaveLen(2))
+		Expect(res.Clusters).To(HaveKey("c"))
+		Expect(res.Clusters).To(HaveKey("d"))
+	})
+
+	It("forgets results of single clusters and of whole hub objects", func() {
+		agg := status.NewAggregator(status.Options{})
+		agg.Report(key, "a", result(metav1.ConditionTrue, "Applied"))
+		agg.Report(key, "b", result(metav1.ConditionFalse, "Failed"))
+
+		agg.Forget(key, "b")
+		res := agg.Aggregate(key)
+		Expect(res.Total).To(Equal(1))
+		Expect(res.WorstCondition.Reason).To(Equal("Applied"))
+
+		agg.Forget(key, "")
+		Expect(agg.Aggregate(key)).To(Equal(status.AggregatedStatus{}))
+	})
+
+	It("applies the aggregate onto the status field of the hub object", func() {
+		var applied *unstructured.Unstructured
+		var opts client.SubResourcePatchOptions
+		c := fake.NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithInterceptorFuncs(interceptor.Funcs{
+				SubResourcePatch: func(_ context.Context, _ client.Client, subResource string, obj client.Object, patch client.Patch, o ...client.SubResourcePatchOption) error {
+					Expect(subResource).To(Equal("status"))
+					Expect(patch).To(Equal(client.Apply))
+					applied = obj.(*unstructured.Unstructured).DeepCopy()
+					opts.ApplyOptions(o)
+					return nil
+				},
+			}).
+			Build()
+
+		agg := status.NewAggregator(status.Options{StatusField: "fleet"})
+		agg.Report(key, "a", result(metav1.ConditionTrue, "Applied"))
+		agg.Report(key, "b", result(metav1.ConditionFalse, "Failed"))
+
+		hub := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name}}
+		Expect(agg.Write(context.Background(), c, hub)).To(Succeed())
+
+		Expect(applied).NotTo(BeNil())
+		Expect(applied.GetKind()).To(Equal("ConfigMap"))
+		Expect(applied.GetNamespace()).To(Equal(key.Namespace))
+		Expect(applied.GetName()).To(Equal(key.Name))
+		total, _, err := unstructured.NestedInt64(applied.Object, "status", "fleet", "total")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(total).To(BeEquivalentTo(2))
+		_, found, err := unstructured.NestedMap(applied.Object, "status", "fleet", "clusters", "b")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		notReady, _, err := unstructured.NestedInt64(applied.Object, "status", "fleet", "notReady")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(notReady).To(BeEquivalentTo(1))
+
+		Expect(opts.FieldManager).To(Equal("multicluster-status-aggregator"))
+		Expect(opts.Force).NotTo(BeNil())
+		Expect(*opts.Force).To(BeTrue())
+	})
+})
@@ -0,0 +1,142 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"errors"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mcmetrics "sigs.k8s.io/multicluster-runtime/pkg/metrics"
+)
+
+// Outcome is the outcome of reconciling a hub object in a cluster.
+type Outcome string
+
+const (
+	// OutcomeSuccess means the cluster was reconciled successfully.
+	OutcomeSuccess Outcome = "Success"
+
+	// OutcomeWarning means the cluster was reconciled, but with a problem
+	// worth surfacing.
+	OutcomeWarning Outcome = "Warning"
+
+	// OutcomeFailed means reconciling the cluster failed.
+	OutcomeFailed Outcome = "Failed"
+)
+
+// Reasons of the conditions written by a Reporter.
+const (
+	ReasonSucceeded       = "Succeeded"
+	ReasonReconcileFailed = "ReconcileFailed"
+)
+
+// ReasonError is an error carrying the reason of the condition reported by
+// Reporter.Failed.
+type ReasonError struct {
+	Reason string
+	Err    error
+}
+
+// Error implements error.
+func (e *ReasonError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *ReasonError) Unwrap() error {
+	return e.Err
+}
+
+// WithReason wraps err such that Reporter.Failed reports the given reason.
+func WithReason(reason string, err error) error {
+	return &ReasonError{Reason: reason, Err: err}
+}
+
+// Reporter records the per-cluster outcomes of reconciling a hub object.
+// Every outcome sets the condition of the Aggregator for the cluster and is
+// counted in the multicluster_cluster_reconcile_outcomes_total metric.
+type Reporter struct {
+	agg        *Aggregator
+	controller string
+	hub        client.ObjectKey
+	generation int64
+}
+
+// Reporter returns a Reporter for the outcomes of the given controller
+// reconciling the hub object at its current generation.
+func (a *Aggregator) Reporter(controller string, hub client.Object) *Reporter {
+	return &Reporter{
+		agg:        a,
+		controller: controller,
+		hub:        client.ObjectKeyFromObject(hub),
+		generation: hub.GetGeneration(),
+	}
+}
+
+// Success records that the cluster was reconciled successfully.
+func (r *Reporter) Success(clusterName string) {
+	r.report(clusterName, OutcomeSuccess, metav1.ConditionTrue, ReasonSucceeded, "")
+}
+
+// Warning records that the cluster was reconciled, but with a problem
+// described by reason and message. The cluster counts as ready.
+func (r *Reporter) Warning(clusterName, reason, message string) {
+	r.report(clusterName, OutcomeWarning, metav1.ConditionTrue, reason, message)
+}
+
+// Failed records that reconciling the cluster failed with err. The reason
+// of the condition is taken from a ReasonError in the chain of err, and
+// defaults to ReasonReconcileFailed.
+func (r *Reporter) Failed(clusterName string, err error) {
+	reason := ReasonReconcileFailed
+	var re *ReasonError
+	if errors.As(err, &re) && re.Reason != "" {
+		reason = re.Reason
+	}
+	var message string
+	if err != nil {
+		message = err.Error()
+	}
+	r.report(clusterName, OutcomeFailed, metav1.ConditionFalse, reason, message)
+}
+
+func (r *Reporter) report(clusterName string, outcome Outcome, status metav1.ConditionStatus, reason, message string) {
+	mcmetrics.ClusterReconcileOutcomes.WithLabelValues(r.controller, mcmetrics.ClusterLabel(clusterName), string(outcome)).Inc()
+
+	r.agg.lock.Lock()
+	defer r.agg.lock.Unlock()
+	if _, ok := r.agg.results[r.hub]; !ok {
+		r.agg.results[r.hub] = map[string]ClusterResult{}
+	}
+	// keep the transition time of unchanged conditions.
+	res := r.agg.results[r.hub][clusterName]
+	res.Conditions = append([]metav1.Condition(nil), res.Conditions...)
+	meta.SetStatusCondition(&res.Conditions, metav1.Condition{
+		Type:               r.agg.opts.ConditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: r.generation,
+	})
+	res.ObservedGeneration = r.generation
+	res.Message = message
+	r.agg.results[r.hub][clusterName] = res
+}
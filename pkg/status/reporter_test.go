@@ -0,0 +1,69 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mcmetrics "sigs.k8s.io/multicluster-runtime/pkg/metrics"
+	"sigs.k8s.io/multicluster-runtime/pkg/status"
+)
+
+var _ = Describe("Reporter", func() {
+	hub := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "hub", Generation: 3}}
+	key := client.ObjectKeyFromObject(hub)
+
+	It("feeds the aggregate and the metrics", func() {
+		agg := status.NewAggregator(status.Options{})
+		r := agg.Reporter("test", hub)
+		r.Success("a")
+		r.Warning("b", "Deprecated", "uses a deprecated API")
+		r.Failed("c", status.WithReason("QuotaExceeded", errors.New("quota exceeded")))
+		r.Failed("d", errors.New("boom"))
+
+		result := agg.Aggregate(key)
+		Expect(result.Total).To(Equal(4))
+		Expect(result.Ready).To(Equal(2))
+		Expect(result.NotReady).To(Equal(2))
+		Expect(result.Clusters["b"].Conditions[0].Reason).To(Equal("Deprecated"))
+		Expect(result.Clusters["c"].Conditions[0].Reason).To(Equal("QuotaExceeded"))
+		Expect(result.Clusters["d"].Conditions[0].Reason).To(Equal(status.ReasonReconcileFailed))
+		Expect(result.Clusters["d"].Message).To(Equal("boom"))
+		Expect(result.Clusters["a"].ObservedGeneration).To(BeEquivalentTo(3))
+
+		Expect(testutil.ToFloat64(mcmetrics.ClusterReconcileOutcomes.WithLabelValues("test", "c", string(status.OutcomeFailed)))).To(BeEquivalentTo(1))
+	})
+
+	It("keeps the transition time of unchanged conditions", func() {
+		agg := status.NewAggregator(status.Options{})
+		r := agg.Reporter("test", hub)
+		r.Success("a")
+		before := agg.Aggregate(key).Clusters["a"].Conditions[0].LastTransitionTime
+		r.Success("a")
+		Expect(agg.Aggregate(key).Clusters["a"].Conditions[0].LastTransitionTime).To(Equal(before))
+	})
+})
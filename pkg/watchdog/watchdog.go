@@ -0,0 +1,284 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package watchdog detects engaged clusters whose watches silently stopped
+// delivering events while their API server is still reachable, e.g.
+// because a middlebox dropped a long-lived connection without closing it.
+package watchdog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/utils/clock"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	mccluster "sigs.k8s.io/multicluster-runtime/pkg/cluster"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	mcmetrics "sigs.k8s.io/multicluster-runtime/pkg/metrics"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+// ErrStaleWatch is returned by Check for clusters with stale watches.
+var ErrStaleWatch = errors.New("watches stopped delivering events")
+
+// Options are the options of a Watchdog.
+type Options struct {
+	// Kinds are the kinds whose watches are observed. They should change
+	// regularly in every cluster, otherwise an idle kind is mistaken for a
+	// stale watch. Defaults to Leases, which are renewed by the node
+	// heartbeats every few seconds.
+	Kinds []client.Object
+
+	// Threshold is the time without events after which the watch of a kind
+	// is considered stale. Defaults to 5 minutes.
+	Threshold time.Duration
+
+	// Interval is the interval in which the watches are checked. Defaults
+	// to a quarter of Threshold.
+	Interval time.Duration
+
+	// Probe checks whether the API server of a cluster is reachable. Silent
+	// watches of unreachable clusters are not reported as stale, as their
+	// failure is surfaced elsewhere. Defaults to requesting the server
+	// version.
+	Probe func(ctx context.Context, cl cluster.Cluster) error
+
+	// Clock is the clock of the Watchdog. Defaults to the real clock.
+	Clock clock.Clock
+}
+
+var _ mcmanager.Runnable = &Watchdog{}
+
+// Watchdog tracks the time of the last event of the watches of the engaged
+// clusters per kind, and reports the watches that went silent beyond the
+// threshold in metrics and, when added with AddClusterHealthzCheck, in
+// the health checks. Add it to the manager with Manager.Add.
+type Watchdog struct {
+	opts Options
+	log  logr.Logger
+
+	lock     sync.Mutex
+	clusters map[string]*watched
+}
+
+type watched struct {
+	cluster   cluster.Cluster
+	lastEvent map[schema.GroupVersionKind]time.Time
+	stale     map[schema.GroupVersionKind]bool
+}
+
+// New returns a new Watchdog.
+func New(opts Options) *Watchdog {
+	if len(opts.Kinds) == 0 {
+		opts.Kinds = []client.Object{&coordinationv1.Lease{}}
+	}
+	if opts.Threshold <= 0 {
+		opts.Threshold = 5 * time.Minute
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = opts.Threshold / 4
+	}
+	if opts.Probe == nil {
+		opts.Probe = func(_ context.Context, cl cluster.Cluster) error {
+			_, err := mccluster.ServerVersion(cl)
+			return err
+		}
+	}
+	if opts.Clock == nil {
+		opts.Clock = clock.RealClock{}
+	}
+	return &Watchdog{
+		opts:     opts,
+		log:      log.Log.WithName("watchdog"),
+		clusters: map[string]*watched{},
+	}
+}
+
+// Engage observes the watches of the kinds in the cluster until ctx is
+// done.
+func (w *Watchdog) Engage(ctx context.Context, name string, cl cluster.Cluster) error {
+	now := w.opts.Clock.Now()
+	wt := &watched{cluster: cl, lastEvent: map[schema.GroupVersionKind]time.Time{}, stale: map[schema.GroupVersionKind]bool{}}
+	type registration struct {
+		informer cache.Informer
+		handle   toolscache.ResourceEventHandlerRegistration
+	}
+	var registrations []registration
+	for _, obj := range w.opts.Kinds {
+		gvk, err := apiutil.GVKForObject(obj, cl.GetScheme())
+		if err != nil {
+			return err
+		}
+		informer, err := cl.GetCache().GetInformer(ctx, obj, cache.BlockUntilSynced(false))
+		if err != nil {
+			return fmt.Errorf("failed to get informer for %s: %w", gvk, err)
+		}
+		observe := func() { w.observe(name, wt, gvk) }
+		handle, err := informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+			AddFunc:    func(interface{}) { observe() },
+			UpdateFunc: func(interface{}, interface{}) { observe() },
+			DeleteFunc: func(interface{}) { observe() },
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add event handler for %s: %w", gvk, err)
+		}
+		registrations = append(registrations, registration{informer: informer, handle: handle})
+		wt.lastEvent[gvk] = now
+	}
+
+	w.lock.Lock()
+	w.clusters[name] = wt
+	w.lock.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		for _, r := range registrations {
+			if err := r.informer.RemoveEventHandler(r.handle); err != nil {
+				w.log.Error(err, "Failed to remove event handler", "cluster", multicluster.EscapeClusterName(name))
+			}
+		}
+		w.lock.Lock()
+		defer w.lock.Unlock()
+		if w.clusters[name] == wt {
+			delete(w.clusters, name)
+			label := mcmetrics.ClusterLabel(name)
+			mcmetrics.ClusterWatchLastEvent.DeletePartialMatch(prometheus.Labels{"cluster": label})
+			mcmetrics.ClusterWatchStale.DeletePartialMatch(prometheus.Labels{"cluster": label})
+		}
+	}()
+	return nil
+}
+
+// observe records an event of the kind in the cluster.
+func (w *Watchdog) observe(name string, wt *watched, gvk schema.GroupVersionKind) {
+	now := w.opts.Clock.Now()
+	w.lock.Lock()
+	wt.lastEvent[gvk] = now
+	wasStale := wt.stale[gvk]
+	wt.stale[gvk] = false
+	w.lock.Unlock()
+	if wasStale {
+		w.log.Info("Watch delivers events again", "cluster", multicluster.EscapeClusterName(name), "kind", gvk)
+	}
+}
+
+// Start checks the watches in the interval until ctx is done.
+func (w *Watchdog) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, w.check, w.opts.Interval)
+	return nil
+}
+
+// check updates the staleness of the watches of all engaged clusters,
+// probing the API servers of clusters with silent watches.
+func (w *Watchdog) check(ctx context.Context) {
+	now := w.opts.Clock.Now()
+	type silent struct {
+		name string
+		wt   *watched
+		gvks []schema.GroupVersionKind
+	}
+	var silents []silent
+	w.lock.Lock()
+	for name, wt := range w.clusters {
+		s := silent{name: name, wt: wt}
+		label := mcmetrics.ClusterLabel(name)
+		for gvk, last := range wt.lastEvent {
+			mcmetrics.ClusterWatchLastEvent.WithLabelValues(label, gvk.String()).Set(float64(last.Unix()))
+			if now.Sub(last) > w.opts.Threshold {
+				s.gvks = append(s.gvks, gvk)
+			} else {
+				mcmetrics.ClusterWatchStale.WithLabelValues(label, gvk.String()).Set(0)
+			}
+		}
+		if len(s.gvks) > 0 {
+			silents = append(silents, s)
+		}
+	}
+	w.lock.Unlock()
+
+	for _, s := range silents {
+		reachable := w.opts.Probe(ctx, s.wt.cluster) == nil
+		label := mcmetrics.ClusterLabel(s.name)
+		w.lock.Lock()
+		for _, gvk := range s.gvks {
+			// an event might have arrived while probing.
+			stale := reachable && now.Sub(s.wt.lastEvent[gvk]) > w.opts.Threshold
+			if stale && !s.wt.stale[gvk] {
+				w.log.Info("Watch stopped delivering events while the API server is reachable", "cluster", multicluster.EscapeClusterName(s.name), "kind", gvk, "lastEvent", s.wt.lastEvent[gvk])
+			}
+			s.wt.stale[gvk] = stale
+			v := 0.0
+			if stale {
+				v = 1
+			}
+			mcmetrics.ClusterWatchStale.WithLabelValues(label, gvk.String()).Set(v)
+		}
+		w.lock.Unlock()
+	}
+}
+
+// Stale returns the kinds whose watches in the engaged cluster are stale,
+// sorted by their string representation.
+func (w *Watchdog) Stale(clusterName string) []schema.GroupVersionKind {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	wt, ok := w.clusters[clusterName]
+	if !ok {
+		return nil
+	}
+	var gvks []schema.GroupVersionKind
+	for gvk, stale := range wt.stale {
+		if stale {
+			gvks = append(gvks, gvk)
+		}
+	}
+	sort.Slice(gvks, func(i, j int) bool { return gvks[i].String() < gvks[j].String() })
+	return gvks
+}
+
+// Check is a mcmanager.ClusterChecker failing for clusters with stale
+// watches. Add it with AddClusterHealthzCheck to fail the health checks
+// on stale watches.
+func (w *Watchdog) Check(_ *http.Request, clusterName string) error {
+	gvks := w.Stale(clusterName)
+	if len(gvks) == 0 {
+		return nil
+	}
+	kinds := make([]string, 0, len(gvks))
+	for _, gvk := range gvks {
+		kinds = append(kinds, gvk.String())
+	}
+	return fmt.Errorf("%w: %s", ErrStaleWatch, strings.Join(kinds, ", "))
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watchdog
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/fake"
+	mcmetrics "sigs.k8s.io/multicluster-runtime/pkg/metrics"
+)
+
+var _ = Describe("Watchdog", func() {
+	leases := coordinationv1.SchemeGroupVersion.WithKind("Lease")
+
+	var (
+		clk       *clocktesting.FakeClock
+		reachable bool
+		w         *Watchdog
+		cl        *fake.Cluster
+	)
+
+	BeforeEach(func() {
+		clk = clocktesting.NewFakeClock(time.Now())
+		reachable = true
+		w = New(Options{Threshold: time.Minute, Clock: clk, Probe: func(context.Context, cluster.Cluster) error {
+			if !reachable {
+				return errors.New("unreachable")
+			}
+			return nil
+		}})
+		cl = fake.NewManagerBuilder().WithCluster("one").Build().FakeCluster("one")
+	})
+
+	event := func(ctx context.Context) {
+		inf, err := cl.GetCache().GetInformer(ctx, &coordinationv1.Lease{}, cache.BlockUntilSynced(false))
+		Expect(err).NotTo(HaveOccurred())
+		inf.(*controllertest.FakeInformer).Update(&coordinationv1.Lease{}, &coordinationv1.Lease{})
+	}
+	stale := func() float64 {
+		return testutil.ToFloat64(mcmetrics.ClusterWatchStale.WithLabelValues("one", leases.String()))
+	}
+
+	It("reports watches that went silent while the API server is reachable", func(ctx SpecContext) {
+		Expect(w.Engage(ctx, "one", cl)).To(Succeed())
+		w.check(ctx)
+		Expect(w.Stale("one")).To(BeEmpty())
+		Expect(stale()).To(BeZero())
+
+		clk.Step(30 * time.Second)
+		event(ctx)
+		clk.Step(45 * time.Second)
+		w.check(ctx)
+		Expect(w.Stale("one")).To(BeEmpty())
+
+		clk.Step(30 * time.Second)
+		w.check(ctx)
+		Expect(w.Stale("one")).To(Equal([]schema.GroupVersionKind{leases}))
+		Expect(stale()).To(Equal(1.0))
+		Expect(w.Check(nil, "one")).To(MatchError(ErrStaleWatch))
+
+		event(ctx)
+		w.check(ctx)
+		Expect(w.Stale("one")).To(BeEmpty())
+		Expect(stale()).To(BeZero())
+		Expect(w.Check(nil, "one")).To(Succeed())
+	})
+
+	It("does not report silent watches of unreachable clusters", func(ctx SpecContext) {
+		Expect(w.Engage(ctx, "one", cl)).To(Succeed())
+		reachable = false
+		clk.Step(2 * time.Minute)
+		w.check(ctx)
+		Expect(w.Stale("one")).To(BeEmpty())
+		Expect(stale()).To(BeZero())
+	})
+
+	It("forgets disengaged clusters", func(ctx SpecContext) {
+		clusterCtx, cancel := context.WithCancel(ctx)
+		Expect(w.Engage(clusterCtx, "one", cl)).To(Succeed())
+		clk.Step(2 * time.Minute)
+		w.check(ctx)
+		Expect(w.Stale("one")).NotTo(BeEmpty())
+
+		cancel()
+		Eventually(func() []schema.GroupVersionKind { return w.Stale("one") }).Should(BeEmpty())
+		Expect(testutil.CollectAndCount(mcmetrics.ClusterWatchStale)).To(BeZero())
+	})
+})
@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// AuditRecord is a sanitized record of an admission decision. It holds no
+// object contents.
+type AuditRecord struct {
+	Time      time.Time               `json:"time"`
+	Webhook   string                  `json:"webhook"`
+	UID       string                  `json:"uid"`
+	Operation string                  `json:"operation"`
+	Kind      metav1.GroupVersionKind `json:"kind"`
+	Namespace string                  `json:"namespace,omitempty"`
+	Name      string                  `json:"name,omitempty"`
+	User      string                  `json:"user"`
+	DryRun    bool                    `json:"dryRun,omitempty"`
+	Allowed   bool                    `json:"allowed"`
+	Code      int32                   `json:"code,omitempty"`
+	Reason    string                  `json:"reason,omitempty"`
+	Message   string                  `json:"message,omitempty"`
+
+	// Patches summarizes the patch of a mutating webhook as operation and
+	// path, e.g. "add /metadata/labels/team", without values.
+	Patches []string `json:"patches,omitempty"`
+}
+
+// AuditSink records admission decisions.
+type AuditSink interface {
+	Record(ctx context.Context, record AuditRecord)
+}
+
+// AuditSinkFunc adapts a function to an AuditSink.
+type AuditSinkFunc func(ctx context.Context, record AuditRecord)
+
+// Record implements AuditSink.
+func (f AuditSinkFunc) Record(ctx context.Context, record AuditRecord) {
+	f(ctx, record)
+}
+
+// NewWriterAuditSink returns an AuditSink writing the records to w as JSON
+// lines. Write errors are ignored.
+func NewWriterAuditSink(w io.Writer) AuditSink {
+	s := &writerSink{enc: json.NewEncoder(w)}
+	return AuditSinkFunc(func(_ context.Context, record AuditRecord) {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		_ = s.enc.Encode(record)
+	})
+}
+
+type writerSink struct {
+	lock sync.Mutex
+	enc  *json.Encoder
+}
+
+// WithAudit records the decisions of the webhook with the given name into
+// sink. It returns the webhook for chaining.
+func WithAudit(w *admission.Webhook, name string, sink AuditSink) *admission.Webhook {
+	w.Handler = &auditHandler{wrapped: w.Handler, name: name, sink: sink}
+	return w
+}
+
+type auditHandler struct {
+	wrapped admission.Handler
+	name    string
+	sink    AuditSink
+}
+
+// Handle implements admission.Handler.
+func (h *auditHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	resp := h.wrapped.Handle(ctx, req)
+
+	record := AuditRecord{
+		Time:      time.Now(),
+		Webhook:   h.name,
+		UID:       string(req.UID),
+		Operation: string(req.Operation),
+		Kind:      req.Kind,
+		Namespace: req.Namespace,
+		Name:      req.Name,
+		User:      req.UserInfo.Username,
+		DryRun:    req.DryRun != nil && *req.DryRun,
+		Allowed:   resp.Allowed,
+	}
+	if res := resp.Result; res != nil {
+		record.Code = res.Code
+		record.Reason = string(res.Reason)
+		record.Message = res.Message
+	}
+	for _, p := range resp.Patches {
+		record.Patches = append(record.Patches, p.Operation+" "+p.Path)
+	}
+	h.sink.Record(ctx, record)
+
+	return resp
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"gomodules.xyz/jsonpatch/v2"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var _ = Describe("WithAudit", func() {
+	req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		UID:       "abc",
+		Operation: admissionv1.Create,
+		Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+		Namespace: "default",
+		Name:      "cm",
+		UserInfo:  authenticationv1.UserInfo{Username: "alice"},
+	}}
+
+	It("records sanitized decisions", func(ctx context.Context) {
+		var buf bytes.Buffer
+		w := WithAudit(&admission.Webhook{Handler: admission.HandlerFunc(func(context.Context, admission.Request) admission.Response {
+			return admission.Patched("defaulted", jsonpatch.NewOperation("add", "/metadata/labels/team", "secret-value"))
+		})}, "/mutate", NewWriterAuditSink(&buf))
+
+		Expect(w.Handle(ctx, req).Allowed).To(BeTrue())
+
+		var record AuditRecord
+		Expect(json.Unmarshal(buf.Bytes(), &record)).To(Succeed())
+		Expect(record.Webhook).To(Equal("/mutate"))
+		Expect(record.Operation).To(Equal("CREATE"))
+		Expect(record.Kind.Kind).To(Equal("ConfigMap"))
+		Expect(record.User).To(Equal("alice"))
+		Expect(record.Allowed).To(BeTrue())
+		Expect(record.Patches).To(Equal([]string{"add /metadata/labels/team"}))
+		Expect(buf.String()).NotTo(ContainSubstring("secret-value"))
+	})
+
+	It("records denials", func(ctx context.Context) {
+		var records []AuditRecord
+		w := WithAudit(&admission.Webhook{Handler: admission.HandlerFunc(func(context.Context, admission.Request) admission.Response {
+			return admission.Denied("not allowed")
+		})}, "/validate", AuditSinkFunc(func(_ context.Context, r AuditRecord) { records = append(records, r) }))
+
+		Expect(w.Handle(ctx, req).Allowed).To(BeFalse())
+		Expect(records).To(HaveLen(1))
+		Expect(records[0].Allowed).To(BeFalse())
+		Expect(records[0].Message).To(Equal("not allowed"))
+	})
+})
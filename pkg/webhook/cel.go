@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+)
+
+// DefaultCELCostLimit is the default limit of the cost of evaluating a CEL
+// expression once, the same as the per-call limit of the Kubernetes API
+// server.
+const DefaultCELCostLimit = 1000000
+
+// CELRuleCompiler compiles the expressions of validation rules with CEL.
+// The object, oldObject and request variables are declared as dynamic, and
+// expressions must evaluate to a bool.
+type CELRuleCompiler struct {
+	env       *cel.Env
+	costLimit uint64
+}
+
+// NewCELRuleCompiler returns a new CELRuleCompiler. The options extend the
+// environment of the expressions, e.g. with ext.Strings().
+func NewCELRuleCompiler(opts ...cel.EnvOption) (*CELRuleCompiler, error) {
+	env, err := cel.NewEnv(append([]cel.EnvOption{
+		cel.Variable(RuleVariableObject, cel.DynType),
+		cel.Variable(RuleVariableOldObject, cel.DynType),
+		cel.Variable(RuleVariableRequest, cel.DynType),
+	}, opts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+	return &CELRuleCompiler{env: env, costLimit: DefaultCELCostLimit}, nil
+}
+
+// Compile implements RuleCompiler. It returns the parse and type errors of
+// the expression.
+func (c *CELRuleCompiler) Compile(expression string) (Program, error) {
+	ast, issues := c.env.Compile(expression)
+	if issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if t := ast.OutputType(); !t.IsExactType(types.BoolType) && !t.IsExactType(types.DynType) {
+		return nil, fmt.Errorf("expression must evaluate to a bool, not %s", t)
+	}
+	program, err := c.env.Program(ast, cel.CostLimit(c.costLimit))
+	if err != nil {
+		return nil, err
+	}
+	return ProgramFunc(func(vars map[string]interface{}) (bool, error) {
+		out, _, err := program.Eval(vars)
+		if err != nil {
+			return false, err
+		}
+		b, ok := out.Value().(bool)
+		if !ok {
+			return false, errors.New("expression did not evaluate to a bool")
+		}
+		return b, nil
+	}), nil
+}
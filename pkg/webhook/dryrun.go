@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// IsDryRun returns whether the admission request in ctx is a dry-run, e.g.
+// from kubectl --dry-run=server. Dry-run requests must not change state
+// outside of the admitted object. The context passed to a CustomDefaulter
+// or CustomValidator holds the request.
+func IsDryRun(ctx context.Context) bool {
+	req, err := admission.RequestFromContext(ctx)
+	return err == nil && req.DryRun != nil && *req.DryRun
+}
+
+// SideEffect is called after an admission request was allowed, e.g. to
+// record the admitted object elsewhere. It is not called for dry-run
+// requests.
+type SideEffect func(ctx context.Context, req admission.Request, resp admission.Response) error
+
+// WithSideEffects calls the side effects after the webhook allowed a
+// request that is not a dry-run. Errors of side effects are logged, they
+// do not change the admission decision. It returns the webhook for
+// chaining.
+func WithSideEffects(w *admission.Webhook, effects ...SideEffect) *admission.Webhook {
+	if len(effects) > 0 {
+		w.Handler = &sideEffectHandler{wrapped: w.Handler, effects: effects}
+	}
+	return w
+}
+
+type sideEffectHandler struct {
+	wrapped admission.Handler
+	effects []SideEffect
+}
+
+// Handle implements admission.Handler.
+func (h *sideEffectHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	resp := h.wrapped.Handle(ctx, req)
+	if !resp.Allowed || (req.DryRun != nil && *req.DryRun) {
+		return resp
+	}
+	for _, effect := range h.effects {
+		if err := effect(ctx, req, resp); err != nil {
+			log.FromContext(ctx).Error(err, "Side effect of admission failed", "uid", req.UID)
+		}
+	}
+	return resp
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var _ = Describe("WithSideEffects", func() {
+	var calls int
+	var dryRunSeen []bool
+
+	webhook := func(allowed bool) *admission.Webhook {
+		return WithSideEffects(&admission.Webhook{Handler: admission.HandlerFunc(func(ctx context.Context, req admission.Request) admission.Response {
+			dryRunSeen = append(dryRunSeen, IsDryRun(admission.NewContextWithRequest(ctx, req)))
+			return admission.ValidationResponse(allowed, "")
+		})}, func(context.Context, admission.Request, admission.Response) error {
+			calls++
+			return nil
+		})
+	}
+
+	BeforeEach(func() {
+		calls, dryRunSeen = 0, nil
+	})
+
+	It("calls side effects of allowed requests only", func(ctx context.Context) {
+		webhook(true).Handle(ctx, admission.Request{})
+		Expect(calls).To(Equal(1))
+		webhook(false).Handle(ctx, admission.Request{})
+		Expect(calls).To(Equal(1))
+	})
+
+	It("skips side effects of dry-run requests", func(ctx context.Context) {
+		webhook(true).Handle(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{DryRun: ptr.To(true)}})
+		Expect(calls).To(BeZero())
+		Expect(dryRunSeen).To(Equal([]bool{true}))
+	})
+})
@@ -0,0 +1,232 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook provides helpers for serving admission webhooks at fleet
+// scale.
+package webhook
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	"k8s.io/apimachinery/pkg/runtime/serializer/protobuf"
+)
+
+// DefaultMinCompressSize is the default minimum size of responses that are
+// compressed.
+const DefaultMinCompressSize = 8 * 1024
+
+// maxRequestSize is the maximum size of decoded request bodies, the same
+// as the limit of admission.Webhook for JSON requests. It bounds the
+// memory a small compressed body can expand to.
+const maxRequestSize = 7 * 1024 * 1024
+
+var errRequestTooLarge = fmt.Errorf("request body exceeds %d bytes", maxRequestSize)
+
+var (
+	scheme = runtime.NewScheme()
+	codecs = serializer.NewCodecFactory(scheme)
+
+	protobufSerializer = protobuf.NewSerializer(scheme, scheme)
+	jsonSerializer     = json.NewSerializerWithOptions(json.DefaultMetaFactory, scheme, scheme, json.SerializerOptions{})
+)
+
+func init() {
+	_ = admissionv1.AddToScheme(scheme)
+	_ = admissionv1beta1.AddToScheme(scheme)
+}
+
+// EncodingOptions configure the encodings a webhook handler negotiates in
+// addition to JSON.
+type EncodingOptions struct {
+	// Protobuf accepts AdmissionReview requests with the content type
+	// application/vnd.kubernetes.protobuf, and responds with protobuf if
+	// the Accept header of the request asks for it.
+	Protobuf bool
+
+	// Compression accepts gzip encoded requests, and gzips responses of at
+	// least MinCompressSize bytes if the Accept-Encoding header of the
+	// request allows it.
+	Compression bool
+
+	// MinCompressSize is the minimum size of compressed responses. Defaults
+	// to DefaultMinCompressSize.
+	MinCompressSize int
+}
+
+// WithEncodings wraps an admission webhook handler serving JSON, e.g. an
+// admission.Webhook, to negotiate protobuf and gzip as configured by opts.
+// Requests are translated to JSON for the wrapped handler, and its
+// responses are translated to the negotiated encoding.
+func WithEncodings(h http.Handler, opts EncodingOptions) http.Handler {
+	if !opts.Protobuf && !opts.Compression {
+		return h
+	}
+	if opts.MinCompressSize <= 0 {
+		opts.MinCompressSize = DefaultMinCompressSize
+	}
+	return &encodingHandler{wrapped: h, opts: opts}
+}
+
+type encodingHandler struct {
+	wrapped http.Handler
+	opts    EncodingOptions
+}
+
+func (h *encodingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Body != nil && h.opts.Compression && strings.EqualFold(r.Header.Get("Content-Encoding"), "gzip") {
+		body, err := gzip.NewReader(r.Body)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid gzip body: %v", err), http.StatusBadRequest)
+			return
+		}
+		defer body.Close()
+		r = r.Clone(r.Context())
+		r.Body = body
+		r.Header.Del("Content-Encoding")
+		r.ContentLength = -1
+	}
+
+	if h.opts.Protobuf && isProtobuf(r.Header.Get("Content-Type")) {
+		var err error
+		if r, err = protobufToJSON(r); errors.Is(err, errRequestTooLarge) {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		} else if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	rec := &bufferedResponse{header: w.Header(), code: http.StatusOK}
+	h.wrapped.ServeHTTP(rec, r)
+	body := rec.body.Bytes()
+	if w.Header().Get("Content-Type") == "" && rec.code == http.StatusOK {
+		w.Header().Set("Content-Type", runtime.ContentTypeJSON)
+	}
+
+	if h.opts.Protobuf && accepts(r.Header.Get("Accept"), runtime.ContentTypeProtobuf) && rec.code == http.StatusOK {
+		if encoded, err := jsonToProtobuf(body); err == nil {
+			body = encoded
+			w.Header().Set("Content-Type", runtime.ContentTypeProtobuf)
+		}
+	}
+
+	if h.opts.Compression && len(body) >= h.opts.MinCompressSize && accepts(r.Header.Get("Accept-Encoding"), "gzip") {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(body); err == nil && zw.Close() == nil {
+			body = buf.Bytes()
+			w.Header().Set("Content-Encoding", "gzip")
+			w.Header().Add("Vary", "Accept-Encoding")
+		}
+	}
+
+	w.Header().Del("Content-Length")
+	w.WriteHeader(rec.code)
+	_, _ = w.Write(body)
+}
+
+// bufferedResponse buffers the body of a response to translate it.
+type bufferedResponse struct {
+	header http.Header
+	code   int
+	body   bytes.Buffer
+}
+
+func (r *bufferedResponse) Header() http.Header {
+	return r.header
+}
+
+func (r *bufferedResponse) WriteHeader(code int) {
+	r.code = code
+}
+
+func (r *bufferedResponse) Write(data []byte) (int, error) {
+	return r.body.Write(data)
+}
+
+// protobufToJSON returns a copy of the request with its protobuf encoded
+// AdmissionReview re-encoded as JSON. It fails with errRequestTooLarge
+// if the body exceeds maxRequestSize.
+func protobufToJSON(r *http.Request) (*http.Request, error) {
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxRequestSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body: %w", err)
+	}
+	if len(data) > maxRequestSize {
+		return nil, errRequestTooLarge
+	}
+	obj, gvk, err := protobufSerializer.Decode(data, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode protobuf AdmissionReview: %w", err)
+	}
+	obj.GetObjectKind().SetGroupVersionKind(*gvk)
+	var buf bytes.Buffer
+	if err := jsonSerializer.Encode(obj, &buf); err != nil {
+		return nil, err
+	}
+	r = r.Clone(r.Context())
+	r.Body = io.NopCloser(&buf)
+	r.ContentLength = int64(buf.Len())
+	r.Header.Set("Content-Type", runtime.ContentTypeJSON)
+	return r, nil
+}
+
+// jsonToProtobuf re-encodes a JSON AdmissionReview as protobuf.
+func jsonToProtobuf(data []byte) ([]byte, error) {
+	obj, _, err := codecs.UniversalDeserializer().Decode(data, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := protobufSerializer.Encode(obj, &buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func isProtobuf(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == runtime.ContentTypeProtobuf
+}
+
+// accepts returns whether the comma separated header values contain value
+// without a zero quality.
+func accepts(header, value string) bool {
+	for _, part := range strings.Split(header, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil || !strings.EqualFold(mediaType, value) {
+			continue
+		}
+		if q, ok := params["q"]; ok && strings.TrimLeft(q, "0.") == "" {
+			continue
+		}
+		return true
+	}
+	return false
+}
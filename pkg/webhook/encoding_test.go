@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var _ = Describe("WithEncodings", func() {
+	var h http.Handler
+
+	review := &admissionv1.AdmissionReview{
+		Request: &admissionv1.AdmissionRequest{UID: types.UID("abc"), Name: "large"},
+	}
+	review.SetGroupVersionKind(admissionv1.SchemeGroupVersion.WithKind("AdmissionReview"))
+
+	BeforeEach(func() {
+		h = WithEncodings(&admission.Webhook{
+			Handler: admission.HandlerFunc(func(_ context.Context, req admission.Request) admission.Response {
+				return admission.Allowed("admitted " + req.Name)
+			}),
+		}, EncodingOptions{Protobuf: true, Compression: true, MinCompressSize: 1})
+	})
+
+	post := func(body []byte, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/validate", bytes.NewReader(body))
+		req.Header = header
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	It("serves protobuf requests and gzips responses", func() {
+		var body bytes.Buffer
+		Expect(protobufSerializer.Encode(review, &body)).To(Succeed())
+
+		rec := post(body.Bytes(), http.Header{
+			"Content-Type":    {runtime.ContentTypeProtobuf},
+			"Accept":          {runtime.ContentTypeProtobuf + ", application/json;q=0.9"},
+			"Accept-Encoding": {"gzip"},
+		})
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Header().Get("Content-Type")).To(Equal(runtime.ContentTypeProtobuf))
+		Expect(rec.Header().Get("Content-Encoding")).To(Equal("gzip"))
+
+		zr, err := gzip.NewReader(rec.Body)
+		Expect(err).NotTo(HaveOccurred())
+		data, err := io.ReadAll(zr)
+		Expect(err).NotTo(HaveOccurred())
+		obj, _, err := protobufSerializer.Decode(data, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		resp := obj.(*admissionv1.AdmissionReview).Response
+		Expect(resp.UID).To(Equal(types.UID("abc")))
+		Expect(resp.Allowed).To(BeTrue())
+		Expect(resp.Result.Message).To(Equal("admitted large"))
+	})
+
+	It("keeps serving plain JSON", func() {
+		var body bytes.Buffer
+		Expect(jsonSerializer.Encode(review, &body)).To(Succeed())
+
+		rec := post(body.Bytes(), http.Header{"Content-Type": {runtime.ContentTypeJSON}})
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Header().Get("Content-Type")).To(Equal(runtime.ContentTypeJSON))
+		Expect(rec.Header().Get("Content-Encoding")).To(BeEmpty())
+		Expect(rec.Body.String()).To(ContainSubstring(`"uid":"abc"`))
+	})
+
+	It("rejects protobuf requests decompressing beyond the size limit", func() {
+		var body bytes.Buffer
+		zw := gzip.NewWriter(&body)
+		_, err := zw.Write(make([]byte, maxRequestSize+1))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(zw.Close()).To(Succeed())
+		Expect(body.Len()).To(BeNumerically("<", 64*1024))
+
+		rec := post(body.Bytes(), http.Header{
+			"Content-Type":     {runtime.ContentTypeProtobuf},
+			"Content-Encoding": {"gzip"},
+		})
+		Expect(rec.Code).To(Equal(http.StatusRequestEntityTooLarge))
+	})
+})
@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// ReasonAdmissionDenied is the reason of the events emitted for denied
+// admission requests.
+const ReasonAdmissionDenied = "AdmissionDenied"
+
+// WithDenialEvents emits a Warning event when the webhook denies a request
+// that is not a dry-run. The event is attached to the involved object, or
+// to its namespace if the object has no name yet, e.g. when it is created
+// with generateName. It returns the webhook for chaining.
+func WithDenialEvents(w *admission.Webhook, recorder record.EventRecorder) *admission.Webhook {
+	w.Handler = &denialEventHandler{wrapped: w.Handler, recorder: recorder}
+	return w
+}
+
+type denialEventHandler struct {
+	wrapped  admission.Handler
+	recorder record.EventRecorder
+}
+
+// Handle implements admission.Handler.
+func (h *denialEventHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	resp := h.wrapped.Handle(ctx, req)
+	if resp.Allowed || (req.DryRun != nil && *req.DryRun) {
+		return resp
+	}
+	ref := involvedObject(req)
+	if ref == nil {
+		return resp
+	}
+	msg := "admission request denied"
+	if resp.Result != nil && resp.Result.Message != "" {
+		msg = resp.Result.Message
+	}
+	h.recorder.Eventf(ref, corev1.EventTypeWarning, ReasonAdmissionDenied, "%s of %s denied: %s", req.Operation, req.Kind.Kind, msg)
+	return resp
+}
+
+// involvedObject returns a reference to the object of the request, its
+// namespace, or nil for unnamed cluster-scoped objects.
+func involvedObject(req admission.Request) *corev1.ObjectReference {
+	name, namespace := req.Name, req.Namespace
+	var uid types.UID
+	raw := req.Object.Raw
+	if len(raw) == 0 {
+		raw = req.OldObject.Raw
+	}
+	var obj metav1.PartialObjectMetadata
+	if len(raw) > 0 && json.Unmarshal(raw, &obj) == nil {
+		uid = obj.UID
+		if name == "" {
+			name = obj.Name
+		}
+		if namespace == "" {
+			namespace = obj.Namespace
+		}
+	}
+
+	if name != "" {
+		gv := schema.GroupVersion{Group: req.Kind.Group, Version: req.Kind.Version}
+		return &corev1.ObjectReference{
+			APIVersion: gv.String(),
+			Kind:       req.Kind.Kind,
+			Namespace:  namespace,
+			Name:       name,
+			UID:        uid,
+		}
+	}
+	if namespace != "" {
+		return &corev1.ObjectReference{APIVersion: "v1", Kind: "Namespace", Name: namespace}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// objectRecorder records the involved objects of events.
+type objectRecorder struct {
+	*record.FakeRecorder
+	objects []runtime.Object
+}
+
+func (r *objectRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.objects = append(r.objects, object)
+	r.FakeRecorder.Eventf(object, eventtype, reason, messageFmt, args...)
+}
+
+var _ = Describe("WithDenialEvents", func() {
+	var recorder *objectRecorder
+
+	webhook := func(allowed bool) *admission.Webhook {
+		return WithDenialEvents(&admission.Webhook{Handler: admission.HandlerFunc(func(context.Context, admission.Request) admission.Response {
+			return admission.ValidationResponse(allowed, "replicas must be positive")
+		})}, recorder)
+	}
+
+	request := func(namespace, object string) admission.Request {
+		return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			Kind:      metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+			Namespace: namespace,
+			Object:    runtime.RawExtension{Raw: []byte(object)},
+		}}
+	}
+
+	BeforeEach(func() {
+		recorder = &objectRecorder{FakeRecorder: record.NewFakeRecorder(10)}
+	})
+
+	It("emits a warning on the denied object", func(ctx context.Context) {
+		webhook(false).Handle(ctx, request("default", `{"metadata":{"name":"foo","uid":"1234"}}`))
+		Expect(recorder.Events).To(Receive(Equal("Warning AdmissionDenied CREATE of Deployment denied: replicas must be positive")))
+		Expect(recorder.objects).To(Equal([]runtime.Object{&corev1.ObjectReference{
+			APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "foo", UID: "1234",
+		}}))
+	})
+
+	It("emits a warning on the namespace of unnamed objects", func(ctx context.Context) {
+		webhook(false).Handle(ctx, request("default", `{"metadata":{"generateName":"foo-"}}`))
+		Expect(recorder.objects).To(Equal([]runtime.Object{&corev1.ObjectReference{APIVersion: "v1", Kind: "Namespace", Name: "default"}}))
+	})
+
+	It("emits no events for allowed and dry-run requests", func(ctx context.Context) {
+		webhook(true).Handle(ctx, request("default", `{"metadata":{"name":"foo"}}`))
+		dryRun := request("default", `{"metadata":{"name":"foo"}}`)
+		dryRun.DryRun = ptr.To(true)
+		webhook(false).Handle(ctx, dryRun)
+		Expect(recorder.Events).To(BeEmpty())
+	})
+})
@@ -0,0 +1,187 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// LimitOptions limit the resources a webhook handler may use.
+type LimitOptions struct {
+	// Timeout is the deadline of a request in the handler, independent of
+	// the timeoutSeconds of the webhook configuration. Requests exceeding
+	// it are rejected with code 503 Service Unavailable, and their context
+	// is cancelled. Zero means no deadline.
+	Timeout time.Duration
+
+	// MaxConcurrent is the maximum number of requests served at the same
+	// time. Further requests are rejected with code 429 Too Many Requests
+	// instead of queueing up. A request that timed out keeps its slot
+	// until the handler returns. Zero means unlimited.
+	MaxConcurrent int
+
+	// Deny answers rejected requests with an AdmissionReview denying them,
+	// regardless of the failurePolicy of the webhook configuration. By
+	// default, they are answered with an HTTP error, which the API server
+	// handles according to the failurePolicy.
+	Deny bool
+}
+
+// WithLimits wraps a webhook handler to enforce the limits of opts.
+// If opts.Deny is set, rejected requests are answered with an
+// AdmissionReview denying them, so the wrapped handler must serve JSON
+// AdmissionReviews. Wrap the result with WithEncodings to negotiate other
+// encodings.
+func WithLimits(h http.Handler, opts LimitOptions) http.Handler {
+	if opts.Timeout <= 0 && opts.MaxConcurrent <= 0 {
+		return h
+	}
+	l := &limitHandler{wrapped: h, timeout: opts.Timeout, deny: opts.Deny}
+	if opts.MaxConcurrent > 0 {
+		l.slots = make(chan struct{}, opts.MaxConcurrent)
+	}
+	return l
+}
+
+type limitHandler struct {
+	wrapped http.Handler
+	timeout time.Duration
+	slots   chan struct{}
+	deny    bool
+}
+
+func (l *limitHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// the body is read upfront to answer rejected requests by their uid.
+	var data []byte
+	if r.Body != nil {
+		var err error
+		if data, err = io.ReadAll(io.LimitReader(r.Body, maxRequestSize+1)); err != nil {
+			http.Error(w, fmt.Sprintf("failed to read body: %v", err), http.StatusBadRequest)
+			return
+		}
+		if len(data) > maxRequestSize {
+			http.Error(w, errRequestTooLarge.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		r = r.Clone(r.Context())
+		r.Body = io.NopCloser(bytes.NewReader(data))
+	}
+
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		default:
+			w.Header().Set("Retry-After", "1")
+			l.reject(w, data, http.StatusTooManyRequests, "too many concurrent webhook requests")
+			return
+		}
+	}
+	release := func() {
+		if l.slots != nil {
+			<-l.slots
+		}
+	}
+
+	if l.timeout <= 0 {
+		defer release()
+		l.wrapped.ServeHTTP(w, r)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), l.timeout)
+	defer cancel()
+	rec := &bufferedResponse{header: http.Header{}, code: http.StatusOK}
+	done, panicked := make(chan struct{}), make(chan any, 1)
+	go func() {
+		// the slot is held until the handler returns, even after the
+		// timeout, so that MaxConcurrent bounds the work in flight.
+		defer release()
+		defer func() {
+			if p := recover(); p != nil {
+				panicked <- p
+				return
+			}
+			close(done)
+		}()
+		l.wrapped.ServeHTTP(rec, r.WithContext(ctx))
+	}()
+
+	select {
+	case p := <-panicked:
+		panic(p)
+	case <-done:
+		for k, v := range rec.header {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(rec.code)
+		_, _ = w.Write(rec.body.Bytes())
+	case <-ctx.Done():
+		l.reject(w, data, http.StatusServiceUnavailable, fmt.Sprintf("webhook did not respond within %s", l.timeout))
+	}
+}
+
+// reject answers a request rejected by the limits, with an HTTP error
+// unless l.deny is set.
+func (l *limitHandler) reject(w http.ResponseWriter, request []byte, code int32, message string) {
+	if l.deny {
+		deny(w, request, code, message)
+		return
+	}
+	http.Error(w, message, int(code))
+}
+
+// deny answers the AdmissionReview request with a response denying it
+// with the given code, in the version of the request. If the request
+// cannot be decoded, the response has no uid and is rejected by the API
+// server.
+func deny(w http.ResponseWriter, request []byte, code int32, message string) {
+	review := &admissionv1.AdmissionReview{
+		Response: &admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result:  &metav1.Status{Status: metav1.StatusFailure, Code: code, Message: message},
+		},
+	}
+	review.SetGroupVersionKind(admissionv1.SchemeGroupVersion.WithKind("AdmissionReview"))
+	if obj, _, err := codecs.UniversalDeserializer().Decode(request, nil, nil); err == nil {
+		switch req := obj.(type) {
+		case *admissionv1.AdmissionReview:
+			if req.Request != nil {
+				review.Response.UID = req.Request.UID
+			}
+		case *admissionv1beta1.AdmissionReview:
+			if req.Request != nil {
+				review.Response.UID = req.Request.UID
+			}
+			review.SetGroupVersionKind(admissionv1beta1.SchemeGroupVersion.WithKind("AdmissionReview"))
+		}
+	}
+
+	w.Header().Set("Content-Type", runtime.ContentTypeJSON)
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(review)
+}
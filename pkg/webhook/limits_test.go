@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("WithLimits", func() {
+	serveVersion := func(h http.Handler, apiVersion string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(map[string]any{
+			"apiVersion": apiVersion,
+			"kind":       "AdmissionReview",
+			"request":    map[string]any{"uid": "abc"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/validate", bytes.NewReader(body)))
+		return rec
+	}
+	serve := func(h http.Handler) *httptest.ResponseRecorder {
+		return serveVersion(h, admissionv1.SchemeGroupVersion.String())
+	}
+	denied := func(rec *httptest.ResponseRecorder) *admissionv1.AdmissionResponse {
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		review := &admissionv1.AdmissionReview{}
+		Expect(json.Unmarshal(rec.Body.Bytes(), review)).To(Succeed())
+		Expect(review.Response).NotTo(BeNil())
+		Expect(review.Response.Allowed).To(BeFalse())
+		Expect(review.Response.UID).To(Equal(types.UID("abc")))
+		return review.Response
+	}
+
+	It("enforces the timeout", func() {
+		h := WithLimits(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}), LimitOptions{Timeout: 10 * time.Millisecond})
+		rec := serve(h)
+		Expect(rec.Code).To(Equal(http.StatusServiceUnavailable), "the API server applies the failure policy")
+		Expect(rec.Body.String()).To(ContainSubstring("did not respond within 10ms"))
+	})
+
+	It("denies requests exceeding the timeout if configured", func() {
+		h := WithLimits(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}), LimitOptions{Timeout: 10 * time.Millisecond, Deny: true})
+		rec := serve(h)
+		Expect(rec.Header().Get("Content-Type")).To(Equal("application/json"))
+		Expect(denied(rec).Result.Code).To(BeEquivalentTo(http.StatusServiceUnavailable))
+	})
+
+	It("passes the response of handlers within the timeout", func() {
+		h := WithLimits(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Test", "yes")
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte("done"))
+		}), LimitOptions{Timeout: time.Minute})
+		rec := serve(h)
+		Expect(rec.Code).To(Equal(http.StatusAccepted))
+		Expect(rec.Header().Get("X-Test")).To(Equal("yes"))
+		Expect(rec.Body.String()).To(Equal("done"))
+	})
+
+	It("answers in the version of the request", func() {
+		h := WithLimits(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}), LimitOptions{Timeout: 10 * time.Millisecond, Deny: true})
+		rec := serveVersion(h, admissionv1beta1.SchemeGroupVersion.String())
+		review := &admissionv1beta1.AdmissionReview{}
+		Expect(json.Unmarshal(rec.Body.Bytes(), review)).To(Succeed())
+		Expect(review.APIVersion).To(Equal(admissionv1beta1.SchemeGroupVersion.String()))
+		Expect(review.Response.UID).To(Equal(types.UID("abc")))
+	})
+
+	It("rejects requests above the concurrency limit", func() {
+		started, release := make(chan struct{}), make(chan struct{})
+		h := WithLimits(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-release
+		}), LimitOptions{MaxConcurrent: 1})
+
+		done := make(chan int)
+		go func() { done <- serve(h).Code }()
+		<-started
+		rejected := serve(h)
+		Expect(rejected.Code).To(Equal(http.StatusTooManyRequests))
+		Expect(rejected.Header().Get("Retry-After")).To(Equal("1"))
+
+		close(release)
+		Expect(<-done).To(Equal(http.StatusOK))
+	})
+
+	It("keeps the slot of timed out requests until the handler returns", func() {
+		var once sync.Once
+		release, returned := make(chan struct{}), make(chan struct{})
+		h := WithLimits(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+			once.Do(func() { close(returned) })
+		}), LimitOptions{Timeout: 10 * time.Millisecond, MaxConcurrent: 1, Deny: true})
+
+		Expect(denied(serve(h)).Result.Code).To(BeEquivalentTo(http.StatusServiceUnavailable))
+		Expect(denied(serve(h)).Result.Code).To(BeEquivalentTo(http.StatusTooManyRequests))
+
+		close(release)
+		<-returned
+		Eventually(func() int { return serve(h).Code }).Should(Equal(http.StatusOK))
+	})
+})
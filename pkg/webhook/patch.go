@@ -0,0 +1,189 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	jsonpatchapply "github.com/evanphx/json-patch/v5"
+	"gomodules.xyz/jsonpatch/v2"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// DefaultMaxPatchBytes is the default maximum size of the patch of a
+// mutating webhook. It leaves headroom below the 3 MiB request limit of
+// the API server.
+const DefaultMaxPatchBytes = 1024 * 1024
+
+// PatchFallback is what happens when the patch of a mutating webhook
+// exceeds the maximum size.
+type PatchFallback string
+
+const (
+	// PatchFallbackReplaceFields replaces the changed top-level fields of
+	// the object as a whole, and denies the request if that is still too
+	// large. This is the default.
+	PatchFallbackReplaceFields PatchFallback = "ReplaceFields"
+
+	// PatchFallbackReject denies the request.
+	PatchFallbackReject PatchFallback = "Reject"
+)
+
+// PatchOptions limit the patches of a mutating webhook.
+type PatchOptions struct {
+	// MaxPatchBytes is the maximum size of the JSON encoded patch.
+	// Defaults to DefaultMaxPatchBytes.
+	MaxPatchBytes int
+
+	// Fallback is what happens when the patch is larger. Defaults to
+	// PatchFallbackReplaceFields.
+	Fallback PatchFallback
+}
+
+// WithPatchLimit checks the patches of the mutating webhook: patches with
+// invalid JSON pointer paths are rejected, and patches larger than the
+// maximum size are handled as configured by opts, with a clear error
+// instead of failing opaquely in the API server. It returns the webhook
+// for chaining.
+func WithPatchLimit(w *admission.Webhook, opts PatchOptions) *admission.Webhook {
+	if opts.MaxPatchBytes <= 0 {
+		opts.MaxPatchBytes = DefaultMaxPatchBytes
+	}
+	if opts.Fallback == "" {
+		opts.Fallback = PatchFallbackReplaceFields
+	}
+	w.Handler = &patchLimitHandler{wrapped: w.Handler, opts: opts}
+	return w
+}
+
+type patchLimitHandler struct {
+	wrapped admission.Handler
+	opts    PatchOptions
+}
+
+// Handle implements admission.Handler.
+func (h *patchLimitHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	resp := h.wrapped.Handle(ctx, req)
+	if !resp.Allowed || len(resp.Patches) == 0 {
+		return resp
+	}
+	for _, op := range resp.Patches {
+		if err := validatePointer(op.Path); err != nil {
+			return admission.Errored(http.StatusInternalServerError, fmt.Errorf("webhook generated an invalid patch: %w", err))
+		}
+	}
+
+	size, err := patchSize(resp.Patches)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if size <= h.opts.MaxPatchBytes {
+		return resp
+	}
+	tooLarge := fmt.Errorf("patch of %d bytes exceeds the limit of %d bytes", size, h.opts.MaxPatchBytes)
+	if h.opts.Fallback == PatchFallbackReject {
+		return admission.Errored(http.StatusRequestEntityTooLarge, tooLarge)
+	}
+
+	ops, err := replaceFields(req.Object.Raw, resp.Patches)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, fmt.Errorf("%w, and failed to replace fields instead: %w", tooLarge, err))
+	}
+	if size, err = patchSize(ops); err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if size > h.opts.MaxPatchBytes {
+		return admission.Errored(http.StatusRequestEntityTooLarge, fmt.Errorf("%w, also when replacing the changed fields (%d bytes)", tooLarge, size))
+	}
+	resp.Patches = ops
+	return resp
+}
+
+func patchSize(ops []jsonpatch.Operation) (int, error) {
+	data, err := json.Marshal(ops)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode patch: %w", err)
+	}
+	return len(data), nil
+}
+
+// validatePointer returns an error if path is not a JSON pointer with
+// properly escaped "~" and "/" characters.
+func validatePointer(path string) error {
+	if path != "" && !strings.HasPrefix(path, "/") {
+		return fmt.Errorf("path %q does not start with /", path)
+	}
+	for i := 0; i < len(path); i++ {
+		if path[i] == '~' && (i+1 == len(path) || (path[i+1] != '0' && path[i+1] != '1')) {
+			return fmt.Errorf("path %q has an unescaped ~", path)
+		}
+	}
+	return nil
+}
+
+// replaceFields applies the patch to the original object, and returns a
+// patch replacing the changed top-level fields as a whole.
+func replaceFields(original []byte, ops []jsonpatch.Operation) ([]jsonpatch.Operation, error) {
+	data, err := json.Marshal(ops)
+	if err != nil {
+		return nil, err
+	}
+	patch, err := jsonpatchapply.DecodePatch(data)
+	if err != nil {
+		return nil, err
+	}
+	patched, err := patch.Apply(original)
+	if err != nil {
+		return nil, err
+	}
+
+	var before, after map[string]interface{}
+	if err := json.Unmarshal(original, &before); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(patched, &after); err != nil {
+		return nil, err
+	}
+	var replaced []jsonpatch.Operation
+	for key, value := range after {
+		old, ok := before[key]
+		switch {
+		case !ok:
+			replaced = append(replaced, jsonpatch.NewOperation("add", "/"+escapePointer(key), value))
+		case !reflect.DeepEqual(old, value):
+			replaced = append(replaced, jsonpatch.NewOperation("replace", "/"+escapePointer(key), value))
+		}
+	}
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			replaced = append(replaced, jsonpatch.NewOperation("remove", "/"+escapePointer(key), nil))
+		}
+	}
+	sort.Slice(replaced, func(i, j int) bool { return replaced[i].Path < replaced[j].Path })
+	return replaced, nil
+}
+
+func escapePointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
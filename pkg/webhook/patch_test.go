@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gomodules.xyz/jsonpatch/v2"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var _ = Describe("WithPatchLimit", func() {
+	original := []byte(`{"metadata":{"name":"foo"},"data":{"a":"1"},"stale":true}`)
+	request := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Object: runtime.RawExtension{Raw: original}}}
+
+	webhook := func(opts PatchOptions, ops ...jsonpatch.Operation) *admission.Webhook {
+		return WithPatchLimit(&admission.Webhook{Handler: admission.HandlerFunc(func(context.Context, admission.Request) admission.Response {
+			return admission.Patched("", ops...)
+		})}, opts)
+	}
+
+	It("passes small patches through", func(ctx context.Context) {
+		op := jsonpatch.NewOperation("add", "/data/b", "2")
+		resp := webhook(PatchOptions{}, op).Handle(ctx, request)
+		Expect(resp.Allowed).To(BeTrue())
+		Expect(resp.Patches).To(Equal([]jsonpatch.Operation{op}))
+	})
+
+	It("rejects patches with unescaped paths", func(ctx context.Context) {
+		resp := webhook(PatchOptions{}, jsonpatch.NewOperation("add", "/metadata/annotations/a~b", "x")).Handle(ctx, request)
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Message).To(ContainSubstring("unescaped ~"))
+	})
+
+	It("replaces the changed top-level fields of large patches", func(ctx context.Context) {
+		ops := []jsonpatch.Operation{jsonpatch.NewOperation("remove", "/stale", nil)}
+		for _, key := range []string{"b", "c", "d", "e", "f", "g"} {
+			ops = append(ops, jsonpatch.NewOperation("add", "/data/"+key, key))
+		}
+		resp := webhook(PatchOptions{MaxPatchBytes: 200}, ops...).Handle(ctx, request)
+		Expect(resp.Allowed).To(BeTrue())
+		Expect(resp.Patches).To(Equal([]jsonpatch.Operation{
+			jsonpatch.NewOperation("replace", "/data", map[string]interface{}{"a": "1", "b": "b", "c": "c", "d": "d", "e": "e", "f": "f", "g": "g"}),
+			jsonpatch.NewOperation("remove", "/stale", nil),
+		}))
+	})
+
+	It("rejects large patches with a clear error", func(ctx context.Context) {
+		op := jsonpatch.NewOperation("add", "/data/b", strings.Repeat("x", 100))
+		resp := webhook(PatchOptions{MaxPatchBytes: 50, Fallback: PatchFallbackReject}, op).Handle(ctx, request)
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Message).To(MatchRegexp(`patch of \d+ bytes exceeds the limit of 50 bytes`))
+
+		resp = webhook(PatchOptions{MaxPatchBytes: 50}, op).Handle(ctx, request)
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Message).To(ContainSubstring("also when replacing the changed fields"))
+	})
+})
@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	mccontext "sigs.k8s.io/multicluster-runtime/pkg/context"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+// ClusterPath returns the path below prefix that serves webhook requests
+// of the given cluster, with the cluster name in its escaped form.
+func ClusterPath(prefix, clusterName string) string {
+	return strings.TrimSuffix(prefix, "/") + "/" + multicluster.EscapeClusterName(clusterName)
+}
+
+// WithClusterRouting wraps a webhook handler served below prefix for
+// requests to ClusterPath(prefix, clusterName), optionally followed by
+// further path segments. The cluster name is unescaped from the path and
+// passed to h in the request context, see mccontext.ClusterFrom, with the
+// cluster segment removed from the path. Requests not below prefix are
+// answered with 404 Not Found, requests with a malformed or invalid
+// cluster segment with 400 Bad Request.
+func WithClusterRouting(prefix string, h http.Handler) http.Handler {
+	prefix = strings.TrimSuffix(prefix, "/")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rest, ok := strings.CutPrefix(r.URL.Path, prefix+"/")
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		segment, rest, _ := strings.Cut(rest, "/")
+		clusterName, err := multicluster.UnescapeClusterName(segment)
+		if err == nil {
+			err = multicluster.ValidateClusterName(clusterName)
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		r2 := r.WithContext(mccontext.WithCluster(r.Context(), clusterName))
+		r2.URL = new(url.URL)
+		*r2.URL = *r.URL
+		r2.URL.Path = prefix + "/" + rest
+		r2.URL.RawPath = ""
+		h.ServeHTTP(w, r2)
+	})
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	mccontext "sigs.k8s.io/multicluster-runtime/pkg/context"
+)
+
+var _ = Describe("WithClusterRouting", func() {
+	var (
+		cluster string
+		path    string
+		h       http.Handler
+	)
+
+	BeforeEach(func() {
+		cluster, path = "", ""
+		h = WithClusterRouting("/clusters/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cluster, _ = mccontext.ClusterFrom(r.Context())
+			path = r.URL.Path
+		}))
+	})
+
+	serve := func(p string) int {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, p, nil))
+		return rec.Code
+	}
+
+	It("routes requests to the cluster of the path", func() {
+		p := ClusterPath("/clusters", "Team/Prod")
+		Expect(p).To(Equal("/clusters/X54eamX2FX50rod"))
+		Expect(serve(p + "/validate-v1-pod")).To(Equal(http.StatusOK))
+		Expect(cluster).To(Equal("Team/Prod"))
+		Expect(path).To(Equal("/clusters/validate-v1-pod"))
+	})
+
+	It("rejects malformed cluster segments", func() {
+		Expect(serve("/clusters/Prod/validate")).To(Equal(http.StatusBadRequest))
+		Expect(serve("/clusters//validate")).To(Equal(http.StatusBadRequest))
+		Expect(cluster).To(BeEmpty())
+	})
+
+	It("does not serve paths outside of the prefix", func() {
+		Expect(serve("/validate")).To(Equal(http.StatusNotFound))
+	})
+})
@@ -0,0 +1,196 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// Variables available to the expressions of validation rules.
+const (
+	// RuleVariableObject is the object of the request, decoded as JSON. It
+	// is nil for DELETE requests.
+	RuleVariableObject = "object"
+
+	// RuleVariableOldObject is the old object of the request, decoded as
+	// JSON. It is nil for CREATE and CONNECT requests.
+	RuleVariableOldObject = "oldObject"
+
+	// RuleVariableRequest is the admission request without the objects,
+	// decoded as JSON, e.g. request.operation or request.userInfo.username.
+	RuleVariableRequest = "request"
+)
+
+// ValidationRule is an expression that must evaluate to true for a
+// request to be allowed, like the x-kubernetes-validations rules of CRDs.
+type ValidationRule struct {
+	// Expression is evaluated with the object, oldObject and request
+	// variables.
+	Expression string
+
+	// Message is returned when the rule fails. Defaults to a message
+	// containing the expression.
+	Message string
+}
+
+// Program is a compiled expression.
+type Program interface {
+	// Eval evaluates the expression with the given variables.
+	Eval(vars map[string]interface{}) (bool, error)
+}
+
+// RuleCompiler compiles the expressions of validation rules, declaring the
+// object, oldObject and request variables. CELRuleCompiler compiles CEL
+// expressions.
+type RuleCompiler interface {
+	Compile(expression string) (Program, error)
+}
+
+// RuleCompilerFunc implements RuleCompiler with a function.
+type RuleCompilerFunc func(expression string) (Program, error)
+
+// Compile implements RuleCompiler.
+func (f RuleCompilerFunc) Compile(expression string) (Program, error) {
+	return f(expression)
+}
+
+// ProgramFunc implements Program with a function.
+type ProgramFunc func(vars map[string]interface{}) (bool, error)
+
+// Eval implements Program.
+func (f ProgramFunc) Eval(vars map[string]interface{}) (bool, error) {
+	return f(vars)
+}
+
+// CompiledRules are validation rules compiled ahead of serving requests.
+// It implements admission.Handler.
+type CompiledRules struct {
+	rules    []ValidationRule
+	programs []Program
+}
+
+// CompileRules compiles the rules, returning the errors of all rules that
+// fail to compile. A nil compiler compiles the rules as CEL expressions
+// with a default CELRuleCompiler.
+func CompileRules(compiler RuleCompiler, rules ...ValidationRule) (*CompiledRules, error) {
+	if compiler == nil {
+		c, err := NewCELRuleCompiler()
+		if err != nil {
+			return nil, err
+		}
+		compiler = c
+	}
+	compiled := &CompiledRules{rules: rules, programs: make([]Program, len(rules))}
+	var errs []error
+	for i, rule := range rules {
+		program, err := compiler.Compile(rule.Expression)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("rule %d %q: %w", i, rule.Expression, err))
+			continue
+		}
+		compiled.programs[i] = program
+	}
+	if len(errs) > 0 {
+		return nil, kerrors.NewAggregate(errs)
+	}
+	return compiled, nil
+}
+
+// Handle implements admission.Handler. It denies the request with the
+// messages of all failed rules.
+func (r *CompiledRules) Handle(_ context.Context, req admission.Request) admission.Response {
+	vars, err := ruleVariables(req)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	var failed []string
+	for i, program := range r.programs {
+		ok, err := program.Eval(vars)
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, fmt.Errorf("failed to evaluate rule %q: %w", r.rules[i].Expression, err))
+		}
+		if !ok {
+			msg := r.rules[i].Message
+			if msg == "" {
+				msg = fmt.Sprintf("failed rule: %s", r.rules[i].Expression)
+			}
+			failed = append(failed, msg)
+		}
+	}
+	if len(failed) > 0 {
+		return admission.Denied(strings.Join(failed, "; "))
+	}
+	return admission.Allowed("")
+}
+
+func ruleVariables(req admission.Request) (map[string]interface{}, error) {
+	vars := map[string]interface{}{
+		RuleVariableObject:    nil,
+		RuleVariableOldObject: nil,
+	}
+	for name, raw := range map[string][]byte{RuleVariableObject: req.Object.Raw, RuleVariableOldObject: req.OldObject.Raw} {
+		if len(raw) == 0 {
+			continue
+		}
+		var obj map[string]interface{}
+		if err := json.Unmarshal(raw, &obj); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", name, err)
+		}
+		vars[name] = obj
+	}
+
+	request := req.AdmissionRequest
+	request.Object.Raw, request.OldObject.Raw = nil, nil
+	request.Object.Object, request.OldObject.Object = nil, nil
+	data, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	var r map[string]interface{}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, err
+	}
+	delete(r, "object")
+	delete(r, "oldObject")
+	vars[RuleVariableRequest] = r
+	return vars, nil
+}
+
+// WithValidationRules evaluates the compiled rules before the validating
+// webhook w, which is only called if all rules pass. If w is nil, a webhook
+// evaluating only the rules is returned.
+func WithValidationRules(w *admission.Webhook, rules *CompiledRules) *admission.Webhook {
+	if w == nil {
+		return &admission.Webhook{Handler: rules}
+	}
+	wrapped := w.Handler
+	w.Handler = admission.HandlerFunc(func(ctx context.Context, req admission.Request) admission.Response {
+		if resp := rules.Handle(ctx, req); !resp.Allowed {
+			return resp
+		}
+		return wrapped.Handle(ctx, req)
+	})
+	return w
+}
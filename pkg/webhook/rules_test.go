@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// fieldCompiler compiles expressions naming a variable that must be set.
+var fieldCompiler = RuleCompilerFunc(func(expression string) (Program, error) {
+	if expression == "" {
+		return nil, errors.New("empty expression")
+	}
+	return ProgramFunc(func(vars map[string]interface{}) (bool, error) {
+		return vars[expression] != nil, nil
+	}), nil
+})
+
+var _ = Describe("Validation rules", func() {
+	request := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		Operation: admissionv1.Create,
+		UserInfo:  authenticationv1.UserInfo{Username: "alice"},
+		Object:    runtime.RawExtension{Raw: []byte(`{"metadata":{"name":"foo"}}`)},
+	}}
+
+	It("reports all rules failing to compile", func() {
+		_, err := CompileRules(fieldCompiler, ValidationRule{}, ValidationRule{Expression: "object"}, ValidationRule{})
+		Expect(err).To(MatchError(ContainSubstring("rule 0")))
+		Expect(err).To(MatchError(ContainSubstring("rule 2")))
+
+	})
+
+	It("evaluates rules with the object, oldObject and request variables", func(ctx context.Context) {
+		var vars map[string]interface{}
+		rules, err := CompileRules(RuleCompilerFunc(func(string) (Program, error) {
+			return ProgramFunc(func(v map[string]interface{}) (bool, error) {
+				vars = v
+				return true, nil
+			}), nil
+		}), ValidationRule{Expression: "true"})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(rules.Handle(ctx, request).Allowed).To(BeTrue())
+		Expect(vars).To(HaveKeyWithValue(RuleVariableObject, map[string]interface{}{"metadata": map[string]interface{}{"name": "foo"}}))
+		Expect(vars).To(HaveKeyWithValue(RuleVariableOldObject, BeNil()))
+		Expect(vars[RuleVariableRequest]).To(HaveKeyWithValue("operation", "CREATE"))
+		Expect(vars[RuleVariableRequest]).To(HaveKeyWithValue("userInfo", HaveKeyWithValue("username", "alice")))
+		Expect(vars[RuleVariableRequest]).NotTo(HaveKey("object"))
+	})
+
+	It("denies with the messages of failed rules before calling the validator", func(ctx context.Context) {
+		rules, err := CompileRules(fieldCompiler,
+			ValidationRule{Expression: "object"},
+			ValidationRule{Expression: "oldObject", Message: "updates only"},
+			ValidationRule{Expression: "missing"},
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		var called bool
+		w := WithValidationRules(&admission.Webhook{Handler: admission.HandlerFunc(func(context.Context, admission.Request) admission.Response {
+			called = true
+			return admission.Allowed("")
+		})}, rules)
+
+		resp := w.Handle(ctx, request)
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Message).To(Equal("updates only; failed rule: missing"))
+		Expect(called).To(BeFalse())
+
+		update := request
+		update.OldObject = request.Object
+		rules, err = CompileRules(fieldCompiler, ValidationRule{Expression: "oldObject"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(WithValidationRules(nil, rules).Handle(ctx, update).Allowed).To(BeTrue())
+	})
+
+	It("compiles CEL expressions", func(ctx context.Context) {
+		_, err := CompileRules(nil,
+			ValidationRule{Expression: "object.metadata.name =="},
+			ValidationRule{Expression: "object.metadata.name"},
+			ValidationRule{Expression: "unknown == 1"},
+			ValidationRule{Expression: "'a' + 1 == 'a1'"},
+		)
+		Expect(err).To(MatchError(ContainSubstring("rule 0")))
+		Expect(err).To(MatchError(ContainSubstring("rule 2")))
+		Expect(err).To(MatchError(ContainSubstring("rule 3")))
+		Expect(err).NotTo(MatchError(ContainSubstring("rule 1")), "dynamic results are checked on evaluation")
+
+		rules, err := CompileRules(nil,
+			ValidationRule{Expression: "object.metadata.name.startsWith('f')"},
+			ValidationRule{Expression: "request.operation == 'CREATE' || oldObject != null"},
+			ValidationRule{Expression: "request.userInfo.username != 'alice'", Message: "alice is read-only"},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		resp := rules.Handle(ctx, request)
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Message).To(Equal("alice is read-only"))
+
+		rules, err = CompileRules(nil, ValidationRule{Expression: "object.metadata.name"})
+		Expect(err).NotTo(HaveOccurred())
+		resp = rules.Handle(ctx, request)
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Code).To(Equal(int32(http.StatusInternalServerError)))
+
+		compiler, err := NewCELRuleCompiler()
+		Expect(err).NotTo(HaveOccurred())
+		rules, err = CompileRules(compiler, ValidationRule{Expression: "!has(object.spec) || object.spec.replicas <= 3"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rules.Handle(ctx, request).Allowed).To(BeTrue())
+	})
+})
@@ -0,0 +1,179 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	mccontext "sigs.k8s.io/multicluster-runtime/pkg/context"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	"sigs.k8s.io/multicluster-runtime/pkg/selector"
+)
+
+// ClusterSelectorValidatorOptions configure a ClusterSelectorValidator.
+type ClusterSelectorValidatorOptions struct {
+	// Paths are the dot separated paths of the cluster selectors in the
+	// validated objects, e.g. "spec.clusterSelector". Absent selectors are
+	// not validated.
+	Paths []string
+
+	// DenyUnmatched denies requests with selectors that match no engaged
+	// cluster. By default, they are allowed with a warning.
+	DenyUnmatched bool
+}
+
+var _ mcmanager.Runnable = &ClusterSelectorValidator{}
+var _ admission.Handler = &ClusterSelectorValidator{}
+
+// ClusterSelectorValidator is a validating admission handler for hub
+// objects declaring cluster selectors. It denies invalid selectors, and
+// warns about or denies selectors that match none of the engaged clusters,
+// e.g. because of a typo in a label, which would otherwise silently select
+// nothing.
+//
+// Add it to the manager with Manager.Add, such that it learns the engaged
+// clusters, and serve it with &admission.Webhook{Handler: v}.
+type ClusterSelectorValidator struct {
+	metadata mccontext.MetadataFunc
+	opts     ClusterSelectorValidatorOptions
+
+	lock     sync.RWMutex
+	clusters map[string]cluster.Cluster
+}
+
+// NewClusterSelectorValidator returns a new ClusterSelectorValidator
+// looking up the metadata of the clusters with metadata, e.g.
+// Manager.GetClusterMetadata.
+func NewClusterSelectorValidator(metadata mccontext.MetadataFunc, opts ClusterSelectorValidatorOptions) *ClusterSelectorValidator {
+	return &ClusterSelectorValidator{metadata: metadata, opts: opts, clusters: map[string]cluster.Cluster{}}
+}
+
+// Engage remembers the cluster until ctx is done.
+func (v *ClusterSelectorValidator) Engage(ctx context.Context, name string, cl cluster.Cluster) error {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	v.clusters[name] = cl
+	go func() {
+		<-ctx.Done()
+		v.lock.Lock()
+		defer v.lock.Unlock()
+		if v.clusters[name] == cl {
+			delete(v.clusters, name)
+		}
+	}()
+	return nil
+}
+
+// Start blocks until ctx is done.
+func (v *ClusterSelectorValidator) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+// Handle implements admission.Handler.
+func (v *ClusterSelectorValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation == admissionv1.Delete || len(req.Object.Raw) == 0 {
+		return admission.Allowed("")
+	}
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(req.Object.Raw, &obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	var denied, warnings []string
+	for _, path := range v.opts.Paths {
+		field, ok, err := unstructured.NestedFieldNoCopy(obj, strings.Split(path, ".")...)
+		if err != nil || !ok || field == nil {
+			continue
+		}
+		sel, err := compileSelector(field)
+		if err != nil {
+			denied = append(denied, fmt.Sprintf("%s: %v", path, err))
+			continue
+		}
+		matched, err := v.matchesAny(ctx, sel)
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		if matched {
+			continue
+		}
+		msg := fmt.Sprintf("%s matches no engaged cluster", path)
+		if v.opts.DenyUnmatched {
+			denied = append(denied, msg)
+		} else {
+			warnings = append(warnings, msg)
+		}
+	}
+	if len(denied) > 0 {
+		return admission.Denied(strings.Join(denied, "; ")).WithWarnings(warnings...)
+	}
+	return admission.Allowed("").WithWarnings(warnings...)
+}
+
+// matchesAny returns whether sel matches one of the engaged clusters.
+func (v *ClusterSelectorValidator) matchesAny(ctx context.Context, sel *selector.Selector) (bool, error) {
+	v.lock.RLock()
+	names := make([]string, 0, len(v.clusters))
+	for name := range v.clusters {
+		names = append(names, name)
+	}
+	v.lock.RUnlock()
+	sort.Strings(names)
+
+	for _, name := range names {
+		md, err := v.metadata(ctx, name)
+		if errors.Is(err, multicluster.ErrClusterNotFound) {
+			// disengaged in the meantime.
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to get metadata of cluster %q: %w", name, err)
+		}
+		if sel.Matches(name, md) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// compileSelector decodes and compiles the selector in field.
+func compileSelector(field interface{}) (*selector.Selector, error) {
+	data, err := json.Marshal(field)
+	if err != nil {
+		return nil, err
+	}
+	var cs selector.ClusterSelector
+	if err := json.Unmarshal(data, &cs); err != nil {
+		return nil, fmt.Errorf("invalid cluster selector: %w", err)
+	}
+	return cs.Compile()
+}
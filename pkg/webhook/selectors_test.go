@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+var _ = Describe("ClusterSelectorValidator", func() {
+	metadata := func(_ context.Context, name string) (multicluster.Metadata, error) {
+		return multicluster.Metadata{Labels: map[string]string{"region": name}}, nil
+	}
+	request := func(obj string) admission.Request {
+		return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: []byte(obj)},
+		}}
+	}
+
+	It("warns about selectors matching no engaged cluster", func(ctx context.Context) {
+		v := NewClusterSelectorValidator(metadata, ClusterSelectorValidatorOptions{Paths: []string{"spec.clusterSelector"}})
+		Expect(v.Engage(ctx, "eu-west", nil)).To(Succeed())
+
+		resp := v.Handle(ctx, request(`{"spec":{"clusterSelector":{"expression":"region=eu-west"}}}`))
+		Expect(resp.Allowed).To(BeTrue())
+		Expect(resp.Warnings).To(BeEmpty())
+
+		resp = v.Handle(ctx, request(`{"spec":{"clusterSelector":{"expression":"region=eu-wset"}}}`))
+		Expect(resp.Allowed).To(BeTrue())
+		Expect(resp.Warnings).To(ConsistOf("spec.clusterSelector matches no engaged cluster"))
+
+		resp = v.Handle(ctx, request(`{"spec":{}}`))
+		Expect(resp.Allowed).To(BeTrue())
+		Expect(resp.Warnings).To(BeEmpty())
+	})
+
+	It("denies invalid and, if configured, unmatched selectors", func(ctx context.Context) {
+		v := NewClusterSelectorValidator(metadata, ClusterSelectorValidatorOptions{Paths: []string{"spec.clusterSelector"}, DenyUnmatched: true})
+		engageCtx, cancel := context.WithCancel(ctx)
+		Expect(v.Engage(engageCtx, "eu-west", nil)).To(Succeed())
+
+		resp := v.Handle(ctx, request(`{"spec":{"clusterSelector":{"expression":"region in ("}}}`))
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Message).To(ContainSubstring("invalid cluster selector expression"))
+
+		resp = v.Handle(ctx, request(`{"spec":{"clusterSelector":{"names":["eu-west"]}}}`))
+		Expect(resp.Allowed).To(BeTrue())
+
+		cancel()
+		Eventually(func() bool {
+			return v.Handle(ctx, request(`{"spec":{"clusterSelector":{"names":["eu-west"]}}}`)).Allowed
+		}).Should(BeFalse(), "disengaged clusters are not matched")
+	})
+})
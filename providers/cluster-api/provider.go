@@ -19,6 +19,8 @@ package capi
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -27,6 +29,8 @@ import (
 	utilkubeconfig "sigs.k8s.io/cluster-api/util/kubeconfig"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -39,11 +43,16 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	mccluster "sigs.k8s.io/multicluster-runtime/pkg/cluster"
+	"sigs.k8s.io/multicluster-runtime/pkg/config"
 	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
 	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	"sigs.k8s.io/multicluster-runtime/pkg/options"
 )
 
 var _ multicluster.Provider = &Provider{}
+var _ multicluster.MetadataProvider = &Provider{}
+var _ multicluster.ReEngager = &Provider{}
 
 // Options are the options for the Cluster-API cluster Provider.
 type Options struct {
@@ -53,8 +62,30 @@ type Options struct {
 	// GetSecret is a function that returns the kubeconfig secret for a cluster.
 	GetSecret func(ctx context.Context, ccl *capiv1beta1.Cluster) (*rest.Config, error)
 	// NewCluster is a function that creates a new cluster from a rest.Config.
-	// The cluster will be started by the provider.
+	// The cluster will be started by the provider. Defaults to the
+	// constructor configured with mcmanager.WithNewCluster, or cluster.New.
 	NewCluster func(ctx context.Context, ccl *capiv1beta1.Cluster, cfg *rest.Config, opts ...cluster.Option) (cluster.Cluster, error)
+
+	// Proxy returns the proxy through which a cluster is reached, by the
+	// name "<namespace>/<name>". Optional.
+	Proxy mccluster.ProxyFunc
+
+	// Trust overrides the CAs and pins the certificates of a cluster, by
+	// the name "<namespace>/<name>". Optional.
+	Trust mccluster.TrustFunc
+
+	// Namespaces restricts the cache of a cluster to the namespaces returned
+	// for its name "<namespace>/<name>" and the labels and annotations of
+	// its Cluster object when it is engaged. Optional.
+	Namespaces mccluster.NamespacesFunc
+
+	// ClusterNamespaces restricts the engaged clusters to the Cluster
+	// objects in these namespaces. All namespaces if empty.
+	ClusterNamespaces []string
+
+	// ClusterSelector restricts the engaged clusters to the Cluster objects
+	// matching it. Optional.
+	ClusterSelector labels.Selector
 }
 
 func setDefaults(opts *Options, cli client.Client) {
@@ -67,11 +98,6 @@ func setDefaults(opts *Options, cli client.Client) {
 			return clientcmd.RESTConfigFromKubeConfig(bs)
 		}
 	}
-	if opts.NewCluster == nil {
-		opts.NewCluster = func(ctx context.Context, ccl *capiv1beta1.Cluster, cfg *rest.Config, opts ...cluster.Option) (cluster.Cluster, error) {
-			return cluster.New(cfg, opts...)
-		}
-	}
 }
 
 // New creates a new Cluster-API cluster Provider.
@@ -81,6 +107,7 @@ func New(localMgr manager.Manager, opts Options) (*Provider, error) {
 		log:       log.Log.WithName("cluster-api-cluster-provider"),
 		client:    localMgr.GetClient(),
 		clusters:  map[string]cluster.Cluster{},
+		metadata:  map[string]multicluster.Metadata{},
 		cancelFns: map[string]context.CancelFunc{},
 	}
 
@@ -96,6 +123,32 @@ func New(localMgr manager.Manager, opts Options) (*Provider, error) {
 	return p, nil
 }
 
+// Factory constructs a Cluster-API provider from a fleet configuration.
+// Register it with options.Options.Register under config.ProviderClusterAPI.
+func Factory(cfg *config.FleetConfiguration, localMgr manager.Manager) (options.Provider, error) {
+	opts := Options{
+		ClusterOptions: cfg.ClusterOptions(),
+		Proxy:          cfg.ProxyFunc(),
+		Trust:          cfg.TrustFunc(),
+		Namespaces:     cfg.NamespacesFunc(),
+	}
+	if c := cfg.Provider.ClusterAPI; c != nil {
+		opts.ClusterNamespaces = c.Namespaces
+		if c.Selector != nil {
+			sel, err := metav1.LabelSelectorAsSelector(c.Selector)
+			if err != nil {
+				return nil, fmt.Errorf("invalid cluster selector: %w", err)
+			}
+			opts.ClusterSelector = sel
+		}
+	}
+	p, err := New(localMgr, opts)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
 type index struct {
 	object       client.Object
 	field        string
@@ -108,9 +161,14 @@ type Provider struct {
 	log    logr.Logger
 	client client.Client
 
+	// reconcileLock serializes reconciles and ReEngage.
+	reconcileLock sync.Mutex
+
 	lock      sync.Mutex
+	runCtx    context.Context
 	mcMgr     mcmanager.Manager
 	clusters  map[string]cluster.Cluster
+	metadata  map[string]multicluster.Metadata
 	cancelFns map[string]context.CancelFunc
 	indexers  []index
 }
@@ -126,12 +184,24 @@ func (p *Provider) Get(_ context.Context, clusterName string) (cluster.Cluster,
 	return nil, multicluster.ErrClusterNotFound
 }
 
+// GetMetadata returns the labels and annotations of the Cluster-API Cluster
+// object backing the cluster, as of its last reconciliation.
+func (p *Provider) GetMetadata(_ context.Context, clusterName string) (multicluster.Metadata, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	md, ok := p.metadata[clusterName]
+	if !ok {
+		return multicluster.Metadata{}, multicluster.ErrClusterNotFound
+	}
+	return md, nil
+}
+
 // Run starts the provider and blocks.
 func (p *Provider) Run(ctx context.Context, mgr mcmanager.Manager) error {
 	p.log.Info("Starting Cluster-API cluster provider")
 
 	p.lock.Lock()
-	p.mcMgr = mgr
+	p.runCtx, p.mcMgr = ctx, mgr
 	p.lock.Unlock()
 
 	<-ctx.Done()
@@ -139,44 +209,77 @@ func (p *Provider) Run(ctx context.Context, mgr mcmanager.Manager) error {
 	return ctx.Err()
 }
 
+// Reconcile engages the cluster of a selected and provisioned Cluster-API
+// Cluster, and disengages it when it is deleted or no longer selected.
 func (p *Provider) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
-	log := p.log.WithValues("cluster", req.Name)
-	log.Info("Reconciling Cluster")
+	p.reconcileLock.Lock()
+	defer p.reconcileLock.Unlock()
+	return p.reconcile(ctx, ctx, req)
+}
+
+// ReEngage disengages the cluster with the given name, and engages it
+// again with a new client and cache.
+func (p *Provider) ReEngage(ctx context.Context, clusterName string) error {
+	namespace, name, _ := strings.Cut(clusterName, "/")
+	p.reconcileLock.Lock()
+	defer p.reconcileLock.Unlock()
+
+	p.lock.Lock()
+	_, ok := p.clusters[clusterName]
+	runCtx := p.runCtx
+	p.lock.Unlock()
+	if !ok {
+		return multicluster.ErrClusterNotFound
+	}
+	p.disengage(clusterName)
+	_, err := p.reconcile(ctx, runCtx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}})
+	return err
+}
 
+// reconcile reconciles the Cluster of req. Engaged clusters run until
+// engageCtx is done. The reconcile lock must be held.
+func (p *Provider) reconcile(ctx, engageCtx context.Context, req reconcile.Request) (reconcile.Result, error) {
 	key := req.NamespacedName.String()
+	log := p.log.WithValues("cluster", multicluster.EscapeClusterName(key))
+	log.Info("Reconciling Cluster")
 
 	// get the cluster
 	ccl := &capiv1beta1.Cluster{}
 	if err := p.client.Get(ctx, req.NamespacedName, ccl); err != nil {
 		if apierrors.IsNotFound(err) {
 			log.Error(err, "failed to get cluster")
-
-			p.lock.Lock()
-			defer p.lock.Unlock()
-
-			delete(p.clusters, key)
-			if cancel, ok := p.cancelFns[key]; ok {
-				cancel()
-			}
-
+			p.disengage(key)
 			return reconcile.Result{}, nil
 		}
 
 		return reconcile.Result{}, fmt.Errorf("failed to get cluster: %w", err)
 	}
 
-	p.lock.Lock()
-	defer p.lock.Unlock()
+	// not selected (anymore)?
+	if !p.selected(ccl) {
+		p.disengage(key)
+		return reconcile.Result{}, nil
+	}
 
-	// TODO(sttts): do tighter logging.
+	// the lock is not held while the cluster is synced and engaged: the
+	// manager calls back into the provider while engaging. Reconciles do not
+	// run concurrently.
+	p.lock.Lock()
+	mcMgr := p.mcMgr
+	_, ok := p.clusters[key]
+	if ok {
+		p.metadata[key] = metadataOf(ccl)
+	}
+	indexers := slices.Clone(p.indexers)
+	p.lock.Unlock()
 
 	// provider already started?
-	if p.mcMgr == nil {
+	if mcMgr == nil {
 		return reconcile.Result{RequeueAfter: time.Second * 2}, nil
 	}
 
 	// already engaged?
-	if _, ok := p.clusters[key]; ok {
+	if ok {
 		log.Info("Cluster already engaged")
 		return reconcile.Result{}, nil
 	}
@@ -192,18 +295,32 @@ func (p *Provider) Reconcile(ctx context.Context, req reconcile.Request) (reconc
 	if err != nil {
 		return reconcile.Result{}, fmt.Errorf("failed to get kubeconfig: %w", err)
 	}
+	cfg = mccluster.WrapConfigForProxy(cfg, key, p.opts.Proxy)
+	if cfg, err = mccluster.WrapConfigForTrust(cfg, key, p.opts.Trust); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to apply cluster trust: %w", err)
+	}
 
 	// create cluster.
-	cl, err := p.opts.NewCluster(ctx, ccl, cfg, p.opts.ClusterOptions...)
+	clusterOpts := p.opts.ClusterOptions
+	if p.opts.Namespaces != nil {
+		namespaces := p.opts.Namespaces(key, metadataOf(ccl))
+		clusterOpts = append(slices.Clip(clusterOpts), mccluster.WithCacheNamespaces(namespaces...))
+	}
+	var cl cluster.Cluster
+	if p.opts.NewCluster != nil {
+		cl, err = p.opts.NewCluster(ctx, ccl, cfg, clusterOpts...)
+	} else {
+		cl, err = mcMgr.NewClusterOr(mcmanager.PlainNewCluster, key, cfg, clusterOpts...)
+	}
 	if err != nil {
 		return reconcile.Result{}, fmt.Errorf("failed to create cluster: %w", err)
 	}
-	for _, idx := range p.indexers {
+	for _, idx := range indexers {
 		if err := cl.GetCache().IndexField(ctx, idx.object, idx.field, idx.extractValue); err != nil {
 			return reconcile.Result{}, fmt.Errorf("failed to index field %q: %w", idx.field, err)
 		}
 	}
-	clusterCtx, cancel := context.WithCancel(ctx)
+	clusterCtx, cancel := context.WithCancel(engageCtx)
 	go func() {
 		if err := cl.Start(clusterCtx); err != nil {
 			log.Error(err, "failed to start cluster")
@@ -215,23 +332,59 @@ func (p *Provider) Reconcile(ctx context.Context, req reconcile.Request) (reconc
 		return reconcile.Result{}, fmt.Errorf("failed to sync cache")
 	}
 
-	// remember.
+	// remember, with the indexers added while the cache synced.
+	p.lock.Lock()
+	for _, idx := range p.indexers[len(indexers):] {
+		if err := cl.GetCache().IndexField(ctx, idx.object, idx.field, idx.extractValue); err != nil {
+			p.lock.Unlock()
+			cancel()
+			return reconcile.Result{}, fmt.Errorf("failed to index field %q: %w", idx.field, err)
+		}
+	}
 	p.clusters[key] = cl
+	p.metadata[key] = metadataOf(ccl)
 	p.cancelFns[key] = cancel
+	p.lock.Unlock()
 
-	p.log.Info("Added new cluster")
+	log.Info("Added new cluster")
 
 	// engage manager.
-	if err := p.mcMgr.Engage(clusterCtx, key, cl); err != nil {
+	if err := mcMgr.Engage(clusterCtx, key, cl); err != nil {
 		log.Error(err, "failed to engage manager")
-		delete(p.clusters, key)
-		delete(p.cancelFns, key)
+		p.disengage(key)
 		return reconcile.Result{}, err
 	}
 
 	return reconcile.Result{}, nil
 }
 
+// metadataOf returns the labels and annotations of the Cluster object.
+func metadataOf(ccl *capiv1beta1.Cluster) multicluster.Metadata {
+	return multicluster.Metadata{Labels: ccl.Labels, Annotations: ccl.Annotations}
+}
+
+// selected returns whether the Cluster object matches the namespaces and
+// the selector of the options.
+func (p *Provider) selected(ccl *capiv1beta1.Cluster) bool {
+	if len(p.opts.ClusterNamespaces) > 0 && !slices.Contains(p.opts.ClusterNamespaces, ccl.Namespace) {
+		return false
+	}
+	return p.opts.ClusterSelector == nil || p.opts.ClusterSelector.Matches(labels.Set(ccl.Labels))
+}
+
+// disengage stops the cluster of the given name, if it is engaged.
+func (p *Provider) disengage(key string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	delete(p.clusters, key)
+	delete(p.metadata, key)
+	if cancel, ok := p.cancelFns[key]; ok {
+		cancel()
+	}
+	delete(p.cancelFns, key)
+}
+
 // IndexField indexes a field on all clusters, existing and future.
 func (p *Provider) IndexField(ctx context.Context, obj client.Object, field string, extractValue client.IndexerFunc) error {
 	p.lock.Lock()
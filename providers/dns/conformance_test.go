@@ -0,0 +1,88 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/rest"
+
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/conformance"
+	"sigs.k8s.io/multicluster-runtime/pkg/fake"
+)
+
+var _ = func() bool {
+	var (
+		lock  sync.Mutex
+		names map[string]bool
+	)
+	catalog := CatalogFunc(func(context.Context) ([]Endpoint, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		endpoints := make([]Endpoint, 0, len(names))
+		for name := range names {
+			endpoints = append(endpoints, Endpoint{Name: name, Host: "https://" + name + ":6443"})
+		}
+		sort.Slice(endpoints, func(i, j int) bool { return endpoints[i].Name < endpoints[j].Name })
+		return endpoints, nil
+	})
+	set := func(name string, listed bool) {
+		lock.Lock()
+		defer lock.Unlock()
+		if listed {
+			names[name] = true
+		} else {
+			delete(names, name)
+		}
+	}
+	return conformance.DescribeProvider("dns", conformance.Harness{
+		New: func(context.Context) (conformance.Provider, error) {
+			lock.Lock()
+			names = map[string]bool{}
+			lock.Unlock()
+			return New(Options{
+				Catalog:  catalog,
+				Interval: 10 * time.Millisecond,
+				NewCluster: func(context.Context, Endpoint, *rest.Config, ...cluster.Option) (cluster.Cluster, error) {
+					return fake.NewCluster(clientfake.NewClientBuilder().Build()), nil
+				},
+			})
+		},
+		AddCluster: func(_ context.Context, name string) error {
+			set(name, true)
+			return nil
+		},
+		RemoveCluster: func(_ context.Context, name string) error {
+			set(name, false)
+			return nil
+		},
+		RenameCluster: func(_ context.Context, oldName, newName string) error {
+			lock.Lock()
+			defer lock.Unlock()
+			delete(names, oldName)
+			names[newName] = true
+			return nil
+		},
+		Timeout: 2 * time.Second,
+	})
+}()
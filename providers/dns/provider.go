@@ -0,0 +1,546 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dns provides a cluster provider for fleets without a Kubernetes
+// native registry, e.g. at the edge. The API endpoints of the clusters are
+// discovered from DNS SRV records or any other Catalog, like Consul or
+// etcd, and paired with credentials found in files.
+package dns
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	mccluster "sigs.k8s.io/multicluster-runtime/pkg/cluster"
+	mcerrors "sigs.k8s.io/multicluster-runtime/pkg/errors"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+var _ multicluster.Provider = &Provider{}
+var _ multicluster.MetadataProvider = &Provider{}
+var _ multicluster.ReEngager = &Provider{}
+
+// Endpoint is the API endpoint of a cluster.
+type Endpoint struct {
+	// Name is the name of the cluster.
+	Name string
+
+	// Host is the URL of the API server, e.g. "https://edge-1.example.com:6443".
+	Host string
+
+	// Labels are the labels of the cluster, returned as its metadata.
+	Labels map[string]string
+}
+
+// Catalog lists the endpoints of the clusters of the fleet.
+type Catalog interface {
+	Endpoints(ctx context.Context) ([]Endpoint, error)
+}
+
+// CatalogFunc adapts a function to a Catalog, e.g. to list the endpoints
+// registered in Consul or etcd.
+type CatalogFunc func(ctx context.Context) ([]Endpoint, error)
+
+// Endpoints implements Catalog.
+func (f CatalogFunc) Endpoints(ctx context.Context) ([]Endpoint, error) {
+	return f(ctx)
+}
+
+// SRVCatalog discovers the endpoints from the SRV records of
+// _<Service>._<Proto>.<Domain>. Each target is a cluster named after the
+// target host without the domain.
+type SRVCatalog struct {
+	// Service is the service of the records. Defaults to "kubernetes".
+	Service string
+
+	// Proto is the protocol of the records. Defaults to "tcp".
+	Proto string
+
+	// Domain is the domain of the records.
+	Domain string
+
+	// Resolver resolves the records. Defaults to net.DefaultResolver.
+	Resolver *net.Resolver
+}
+
+// Endpoints implements Catalog.
+func (c *SRVCatalog) Endpoints(ctx context.Context) ([]Endpoint, error) {
+	service, proto, resolver := c.Service, c.Proto, c.Resolver
+	if service == "" {
+		service = "kubernetes"
+	}
+	if proto == "" {
+		proto = "tcp"
+	}
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	_, records, err := resolver.LookupSRV(ctx, service, proto, c.Domain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up SRV records of %q: %w", c.Domain, err)
+	}
+	return endpointsFromSRV(records, c.Domain), nil
+}
+
+// endpointsFromSRV returns an endpoint per target of the records, using
+// the target with the highest priority if it is listed multiple times.
+func endpointsFromSRV(records []*net.SRV, domain string) []Endpoint {
+	seen := map[string]bool{}
+	var endpoints []Endpoint
+	for _, r := range records {
+		target := strings.TrimSuffix(r.Target, ".")
+		if target == "" || seen[target] {
+			continue
+		}
+		seen[target] = true
+		name := strings.TrimSuffix(strings.TrimSuffix(target, strings.TrimSuffix(domain, ".")), ".")
+		if name == "" {
+			name = target
+		}
+		endpoints = append(endpoints, Endpoint{
+			Name: name,
+			Host: (&url.URL{Scheme: "https", Host: net.JoinHostPort(target, strconv.Itoa(int(r.Port)))}).String(),
+		})
+	}
+	return endpoints
+}
+
+// Credentials are templates of the paths of the credentials of a cluster.
+// The templates are expanded with the Endpoint, e.g.
+// "/var/run/fleet/{{.Name}}/token".
+type Credentials struct {
+	// TokenFile is the path of a bearer token file, read again when it
+	// changes.
+	TokenFile string
+
+	// CertDir is the path of a directory containing the client certificate
+	// tls.crt and key tls.key, and optionally the CA bundle ca.crt.
+	CertDir string
+
+	// CAFile is the path of the CA bundle of the API server. It overrides
+	// ca.crt of CertDir.
+	CAFile string
+}
+
+// restConfig returns the config of the cluster at the endpoint.
+func (c Credentials) restConfig(ep Endpoint) (*rest.Config, error) {
+	cfg := &rest.Config{Host: ep.Host}
+	var err error
+	if cfg.BearerTokenFile, err = expand(c.TokenFile, ep); err != nil {
+		return nil, err
+	}
+	certDir, err := expand(c.CertDir, ep)
+	if err != nil {
+		return nil, err
+	}
+	if certDir != "" {
+		cfg.CertFile = filepath.Join(certDir, "tls.crt")
+		cfg.KeyFile = filepath.Join(certDir, "tls.key")
+		cfg.CAFile = filepath.Join(certDir, "ca.crt")
+	}
+	if c.CAFile != "" {
+		if cfg.CAFile, err = expand(c.CAFile, ep); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+func expand(tmpl string, ep Endpoint) (string, error) {
+	if tmpl == "" {
+		return "", nil
+	}
+	t, err := template.New("path").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("invalid credential template %q: %w", tmpl, err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, ep); err != nil {
+		return "", fmt.Errorf("failed to expand credential template %q: %w", tmpl, err)
+	}
+	return buf.String(), nil
+}
+
+// Options are the options for the DNS Provider.
+type Options struct {
+	// Catalog lists the endpoints of the clusters.
+	Catalog Catalog
+
+	// Credentials are the templates of the credential paths.
+	Credentials Credentials
+
+	// Interval is the interval in which the catalog is listed. Defaults to
+	// 30 seconds.
+	Interval time.Duration
+
+	// CacheSyncTimeout is the maximum time the cache of a cluster may take
+	// to sync before its engagement fails. It is retried with the next
+	// sync. Defaults to two minutes.
+	CacheSyncTimeout time.Duration
+
+	// ClusterOptions are the options passed to the cluster constructor.
+	ClusterOptions []cluster.Option
+
+	// Proxy returns the proxy through which a cluster is reached. Optional.
+	Proxy mccluster.ProxyFunc
+
+	// Trust overrides the CAs and pins the certificates of a cluster.
+	// Optional.
+	Trust mccluster.TrustFunc
+
+	// NewCluster is a function that creates a new cluster from a rest.Config.
+	// The cluster will be started by the provider. Defaults to the
+	// NewCluster of the manager. Clusters created with
+	// mccluster.NewUpdatable, the default of the manager, keep their watches
+	// when their endpoint changes.
+	NewCluster func(ctx context.Context, ep Endpoint, cfg *rest.Config, opts ...cluster.Option) (cluster.Cluster, error)
+
+	// Namespaces restricts the cache of a cluster to the namespaces returned
+	// for its name and the labels of its endpoint. Clusters are re-engaged
+	// when the namespaces change. Optional.
+	Namespaces mccluster.NamespacesFunc
+}
+
+type index struct {
+	object       client.Object
+	field        string
+	extractValue client.IndexerFunc
+}
+
+type engaged struct {
+	cluster.Cluster
+	endpoint   Endpoint
+	namespaces []string
+	cancel     context.CancelFunc
+}
+
+// Provider is a cluster Provider that engages the clusters listed by a
+// Catalog, re-engages them when their endpoint changes and disengages them
+// when they are no longer listed.
+type Provider struct {
+	opts Options
+	log  logr.Logger
+
+	lock     sync.Mutex
+	clusters map[string]engaged
+	indexers []index
+
+	// runCtx and mgr are the context and manager of the last sync, which
+	// clusters re-engaged on demand are engaged with.
+	runCtx context.Context
+	mgr    mcmanager.Manager
+}
+
+// New creates a new DNS Provider.
+func New(opts Options) (*Provider, error) {
+	if opts.Catalog == nil {
+		return nil, fmt.Errorf("catalog is required")
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = 30 * time.Second
+	}
+	if opts.CacheSyncTimeout <= 0 {
+		opts.CacheSyncTimeout = 2 * time.Minute
+	}
+	return &Provider{
+		opts:     opts,
+		log:      log.Log.WithName("dns-provider"),
+		clusters: map[string]engaged{},
+	}, nil
+}
+
+// Get returns the cluster with the given name, if it is known.
+func (p *Provider) Get(_ context.Context, clusterName string) (cluster.Cluster, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if cl, ok := p.clusters[clusterName]; ok {
+		return cl.Cluster, nil
+	}
+	return nil, multicluster.ErrClusterNotFound
+}
+
+// GetMetadata returns the labels of the endpoint of the cluster.
+func (p *Provider) GetMetadata(_ context.Context, clusterName string) (multicluster.Metadata, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	cl, ok := p.clusters[clusterName]
+	if !ok {
+		return multicluster.Metadata{}, multicluster.ErrClusterNotFound
+	}
+	return multicluster.Metadata{Labels: cl.endpoint.Labels}, nil
+}
+
+// Run lists the catalog periodically, engages the listed clusters with the
+// manager, and blocks.
+func (p *Provider) Run(ctx context.Context, mgr mcmanager.Manager) error {
+	p.log.Info("Starting DNS provider")
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := p.sync(ctx, mgr); err != nil {
+			p.log.Error(err, "Failed to sync clusters from catalog")
+		}
+	}, p.opts.Interval)
+	return ctx.Err()
+}
+
+// sync engages the clusters listed by the catalog and disengages the others.
+// If the catalog cannot be listed, the engaged clusters are kept. The
+// clusters to engage are collected under the lock and engaged in parallel
+// without it, so that a cluster whose cache does not sync holds up neither
+// the others nor Get and GetMetadata.
+func (p *Provider) sync(ctx context.Context, mgr mcmanager.Manager) error {
+	endpoints, err := p.opts.Catalog.Endpoints(ctx)
+	if err != nil {
+		return err
+	}
+
+	p.lock.Lock()
+	p.runCtx, p.mgr = ctx, mgr
+	listed := map[string]bool{}
+	var updates, engages []Endpoint
+	for _, ep := range endpoints {
+		listed[ep.Name] = true
+		if cl, ok := p.clusters[ep.Name]; ok {
+			sameNamespaces := slices.Equal(cl.namespaces, p.namespaces(ep))
+			if cl.endpoint.Host == ep.Host && sameNamespaces {
+				cl.endpoint.Labels = ep.Labels
+				p.clusters[ep.Name] = cl
+				continue
+			}
+			if sameNamespaces {
+				updates = append(updates, ep)
+				continue
+			}
+			p.log.Info("Re-engaging cluster with changed namespaces", "cluster", multicluster.EscapeClusterName(ep.Name))
+			p.disengage(ep.Name)
+		}
+		engages = append(engages, ep)
+	}
+	for name := range p.clusters {
+		if !listed[name] {
+			p.log.Info("Cluster removed", "cluster", multicluster.EscapeClusterName(name))
+			p.disengage(name)
+		}
+	}
+	p.lock.Unlock()
+
+	for _, ep := range updates {
+		err := p.update(ctx, mgr, ep)
+		if err == nil {
+			p.log.Info("Updated endpoint of cluster", "cluster", multicluster.EscapeClusterName(ep.Name), "host", ep.Host)
+			continue
+		}
+		if !errors.Is(err, mccluster.ErrUpdateNotSupported) {
+			p.log.Error(err, "Failed to update cluster, re-engaging", "cluster", multicluster.EscapeClusterName(ep.Name))
+		}
+		p.log.Info("Re-engaging cluster with changed endpoint", "cluster", multicluster.EscapeClusterName(ep.Name), "host", ep.Host)
+		p.lock.Lock()
+		p.disengage(ep.Name)
+		p.lock.Unlock()
+		engages = append(engages, ep)
+	}
+
+	var (
+		wg      sync.WaitGroup
+		errLock sync.Mutex
+		errs    []error
+	)
+	for _, ep := range engages {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := p.engage(ctx, mgr, ep); err != nil {
+				errLock.Lock()
+				defer errLock.Unlock()
+				errs = append(errs, mcerrors.New(ep.Name, "engage", err))
+			}
+		}()
+	}
+	wg.Wait()
+	return mcerrors.NewAggregate(errs...)
+}
+
+// engage creates, starts and engages the cluster at the endpoint. The lock
+// must not be held: the cache sync can take up to CacheSyncTimeout, and the
+// manager calls back into the provider while engaging.
+func (p *Provider) engage(ctx context.Context, mgr mcmanager.Manager, ep Endpoint) error {
+	cfg, err := p.opts.Credentials.restConfig(ep)
+	if err != nil {
+		return err
+	}
+	cfg = mccluster.WrapConfigForProxy(cfg, ep.Name, p.opts.Proxy)
+	if cfg, err = mccluster.WrapConfigForTrust(cfg, ep.Name, p.opts.Trust); err != nil {
+		return err
+	}
+	namespaces := p.namespaces(ep)
+	clusterOpts := append(slices.Clip(p.opts.ClusterOptions), mccluster.WithCacheNamespaces(namespaces...))
+	var cl cluster.Cluster
+	if p.opts.NewCluster != nil {
+		cl, err = p.opts.NewCluster(ctx, ep, cfg, clusterOpts...)
+	} else {
+		cl, err = mgr.NewCluster(ep.Name, cfg, clusterOpts...)
+	}
+	if err != nil {
+		return err
+	}
+	p.lock.Lock()
+	indexers := slices.Clone(p.indexers)
+	p.lock.Unlock()
+	for _, idx := range indexers {
+		if err := cl.GetCache().IndexField(ctx, idx.object, idx.field, idx.extractValue); err != nil {
+			return fmt.Errorf("failed to index field %q: %w", idx.field, err)
+		}
+	}
+
+	clusterCtx, cancel := context.WithCancel(ctx)
+	go func() {
+		if err := cl.Start(clusterCtx); err != nil {
+			p.log.Error(err, "failed to start cluster", "cluster", multicluster.EscapeClusterName(ep.Name))
+		}
+	}()
+	syncCtx, syncCancel := context.WithTimeout(ctx, p.opts.CacheSyncTimeout)
+	defer syncCancel()
+	if !cl.GetCache().WaitForCacheSync(syncCtx) {
+		cancel()
+		return fmt.Errorf("failed to sync cache within %s", p.opts.CacheSyncTimeout)
+	}
+
+	p.lock.Lock()
+	if _, ok := p.clusters[ep.Name]; ok {
+		// engaged concurrently, e.g. by ReEngage.
+		p.lock.Unlock()
+		cancel()
+		return nil
+	}
+	// indexers added while the cache synced.
+	for _, idx := range p.indexers[len(indexers):] {
+		if err := cl.GetCache().IndexField(ctx, idx.object, idx.field, idx.extractValue); err != nil {
+			p.lock.Unlock()
+			cancel()
+			return fmt.Errorf("failed to index field %q: %w", idx.field, err)
+		}
+	}
+	ep.Labels = maps.Clone(ep.Labels)
+	p.clusters[ep.Name] = engaged{Cluster: cl, endpoint: ep, namespaces: namespaces, cancel: cancel}
+	p.lock.Unlock()
+	p.log.Info("Added new cluster", "cluster", multicluster.EscapeClusterName(ep.Name), "host", ep.Host)
+
+	if err := mgr.Engage(clusterCtx, ep.Name, cl); err != nil {
+		p.lock.Lock()
+		if p.clusters[ep.Name].Cluster == cl {
+			delete(p.clusters, ep.Name)
+		}
+		p.lock.Unlock()
+		cancel()
+		return err
+	}
+	return nil
+}
+
+// ReEngage disengages the cluster with the given name and engages it again
+// with a new client and cache, blocking until the new cache synced.
+func (p *Provider) ReEngage(_ context.Context, clusterName string) error {
+	p.lock.Lock()
+	cl, ok := p.clusters[clusterName]
+	if !ok || p.mgr == nil {
+		p.lock.Unlock()
+		return multicluster.ErrClusterNotFound
+	}
+	ctx, mgr := p.runCtx, p.mgr
+	p.log.Info("Re-engaging cluster on demand", "cluster", multicluster.EscapeClusterName(clusterName))
+	p.disengage(clusterName)
+	p.lock.Unlock()
+	return p.engage(ctx, mgr, cl.endpoint)
+}
+
+// update switches the engaged cluster to the changed endpoint, keeping its
+// watches. The lock must not be held, the manager looks up the cluster.
+func (p *Provider) update(ctx context.Context, mgr mcmanager.Manager, ep Endpoint) error {
+	cfg, err := p.opts.Credentials.restConfig(ep)
+	if err != nil {
+		return err
+	}
+	if err := mgr.UpdateCluster(ctx, ep.Name, cfg); err != nil {
+		return err
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if cl, ok := p.clusters[ep.Name]; ok {
+		cl.endpoint = Endpoint{Name: ep.Name, Host: ep.Host, Labels: maps.Clone(ep.Labels)}
+		p.clusters[ep.Name] = cl
+	}
+	return nil
+}
+
+// namespaces returns the namespaces the cache of the cluster at the
+// endpoint is restricted to.
+func (p *Provider) namespaces(ep Endpoint) []string {
+	if p.opts.Namespaces == nil {
+		return nil
+	}
+	return p.opts.Namespaces(ep.Name, multicluster.Metadata{Labels: ep.Labels})
+}
+
+// disengage stops the cluster with the given name. The lock must be held.
+func (p *Provider) disengage(name string) {
+	if cl, ok := p.clusters[name]; ok {
+		cl.cancel()
+	}
+	delete(p.clusters, name)
+}
+
+// IndexField indexes a field on all clusters, existing and future.
+func (p *Provider) IndexField(ctx context.Context, obj client.Object, field string, extractValue client.IndexerFunc) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	// save for future clusters.
+	p.indexers = append(p.indexers, index{
+		object:       obj,
+		field:        field,
+		extractValue: extractValue,
+	})
+
+	// apply to existing clusters.
+	for name, cl := range p.clusters {
+		if err := cl.GetCache().IndexField(ctx, obj, field, extractValue); err != nil {
+			return fmt.Errorf("failed to index field %q on cluster %q: %w", field, name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,314 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net"
+	"slices"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	mccluster "sigs.k8s.io/multicluster-runtime/pkg/cluster"
+	"sigs.k8s.io/multicluster-runtime/pkg/fake"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+var _ = Describe("SRV records", func() {
+	It("returns an endpoint per target", func() {
+		endpoints := endpointsFromSRV([]*net.SRV{
+			{Target: "edge-1.fleet.example.com.", Port: 6443},
+			{Target: "edge-2.fleet.example.com.", Port: 443},
+			{Target: "edge-1.fleet.example.com.", Port: 7443},
+		}, "fleet.example.com")
+		Expect(endpoints).To(Equal([]Endpoint{
+			{Name: "edge-1", Host: "https://edge-1.fleet.example.com:6443"},
+			{Name: "edge-2", Host: "https://edge-2.fleet.example.com:443"},
+		}))
+	})
+})
+
+var _ = Describe("Credentials", func() {
+	It("expands the templates with the endpoint", func() {
+		cfg, err := Credentials{
+			TokenFile: "/var/run/fleet/{{.Name}}/token",
+			CertDir:   "/etc/fleet/{{.Name}}",
+		}.restConfig(Endpoint{Name: "edge-1", Host: "https://edge-1:6443"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Host).To(Equal("https://edge-1:6443"))
+		Expect(cfg.BearerTokenFile).To(Equal("/var/run/fleet/edge-1/token"))
+		Expect(cfg.CertFile).To(Equal("/etc/fleet/edge-1/tls.crt"))
+		Expect(cfg.KeyFile).To(Equal("/etc/fleet/edge-1/tls.key"))
+		Expect(cfg.CAFile).To(Equal("/etc/fleet/edge-1/ca.crt"))
+
+		_, err = Credentials{TokenFile: "/{{.Cluster}}"}.restConfig(Endpoint{Name: "edge-1"})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Provider", func() {
+	var (
+		mgr       *fake.Manager
+		p         *Provider
+		endpoints []Endpoint
+		listErr   error
+		engaged   *recorder
+	)
+
+	BeforeEach(func() {
+		mgr = fake.NewManagerBuilder().Build()
+		engaged = &recorder{contexts: map[string]context.Context{}}
+		Expect(mgr.Add(engaged)).To(Succeed())
+		endpoints, listErr = nil, nil
+
+		var err error
+		p, err = New(Options{
+			Catalog: CatalogFunc(func(context.Context) ([]Endpoint, error) { return endpoints, listErr }),
+			NewCluster: func(context.Context, Endpoint, *rest.Config, ...cluster.Option) (cluster.Cluster, error) {
+				return fake.NewCluster(clientfake.NewClientBuilder().Build()), nil
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("engages listed clusters and disengages removed ones", func(ctx context.Context) {
+		endpoints = []Endpoint{
+			{Name: "edge-1", Host: "https://edge-1:6443", Labels: map[string]string{"site": "a"}},
+			{Name: "edge-2", Host: "https://edge-2:6443"},
+		}
+		Expect(p.sync(ctx, mgr)).To(Succeed())
+		Expect(engaged.names()).To(ConsistOf("edge-1", "edge-2"))
+		md, err := p.GetMetadata(ctx, "edge-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(md.Labels).To(HaveKeyWithValue("site", "a"))
+
+		endpoints = endpoints[:1]
+		Expect(p.sync(ctx, mgr)).To(Succeed())
+		Expect(engaged.get("edge-2").Done()).To(BeClosed())
+		_, err = p.Get(ctx, "edge-2")
+		Expect(err).To(MatchError(multicluster.ErrClusterNotFound))
+		Expect(engaged.get("edge-1").Err()).NotTo(HaveOccurred())
+	})
+
+	It("re-engages clusters whose endpoint changed", func(ctx context.Context) {
+		endpoints = []Endpoint{{Name: "edge-1", Host: "https://edge-1:6443"}}
+		Expect(p.sync(ctx, mgr)).To(Succeed())
+		first := engaged.get("edge-1")
+
+		endpoints = []Endpoint{{Name: "edge-1", Host: "https://edge-1.new:6443"}}
+		Expect(p.sync(ctx, mgr)).To(Succeed())
+		Expect(first.Done()).To(BeClosed())
+		Expect(engaged.get("edge-1").Err()).NotTo(HaveOccurred())
+	})
+
+	It("re-engages clusters on demand", func(ctx context.Context) {
+		Expect(p.ReEngage(ctx, "edge-1")).To(MatchError(multicluster.ErrClusterNotFound))
+
+		endpoints = []Endpoint{{Name: "edge-1", Host: "https://edge-1:6443", Labels: map[string]string{"site": "a"}}}
+		Expect(p.sync(ctx, mgr)).To(Succeed())
+		first := engaged.get("edge-1")
+		oldCluster, err := p.Get(ctx, "edge-1")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(p.ReEngage(ctx, "edge-1")).To(Succeed())
+		Expect(first.Done()).To(BeClosed())
+		Expect(engaged.get("edge-1").Err()).NotTo(HaveOccurred())
+		newCluster, err := p.Get(ctx, "edge-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(newCluster).NotTo(BeIdenticalTo(oldCluster))
+		md, err := p.GetMetadata(ctx, "edge-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(md.Labels).To(HaveKeyWithValue("site", "a"))
+	})
+
+	It("restricts the caches to the namespaces of each cluster", func(ctx context.Context) {
+		var namespaces []string
+		p.opts.Namespaces = mccluster.NamespacesFromMetadata("tenant")
+		p.opts.NewCluster = func(_ context.Context, _ Endpoint, _ *rest.Config, opts ...cluster.Option) (cluster.Cluster, error) {
+			o := &cluster.Options{}
+			for _, opt := range opts {
+				opt(o)
+			}
+			namespaces = slices.Sorted(maps.Keys(o.Cache.DefaultNamespaces))
+			return fake.NewCluster(clientfake.NewClientBuilder().Build()), nil
+		}
+
+		endpoints = []Endpoint{{Name: "edge-1", Host: "https://edge-1:6443", Labels: map[string]string{"tenant": "a"}}}
+		Expect(p.sync(ctx, mgr)).To(Succeed())
+		Expect(namespaces).To(Equal([]string{"a"}))
+		first := engaged.get("edge-1")
+
+		endpoints[0].Labels = map[string]string{"tenant": "a", "site": "x"}
+		Expect(p.sync(ctx, mgr)).To(Succeed())
+		Expect(first.Err()).NotTo(HaveOccurred())
+
+		endpoints[0].Labels = map[string]string{"tenant": "a,b"}
+		Expect(p.sync(ctx, mgr)).To(Succeed())
+		Expect(first.Done()).To(BeClosed())
+		Expect(namespaces).To(Equal([]string{"a", "b"}))
+		Expect(engaged.get("edge-1").Err()).NotTo(HaveOccurred())
+	})
+
+	It("engages the other clusters while a cache does not sync", func(ctx context.Context) {
+		p.opts.CacheSyncTimeout = 200 * time.Millisecond
+		p.opts.NewCluster = func(_ context.Context, ep Endpoint, _ *rest.Config, _ ...cluster.Option) (cluster.Cluster, error) {
+			cl := fake.NewCluster(clientfake.NewClientBuilder().Build())
+			if ep.Name == "unreachable" {
+				return &unsyncedCluster{Cluster: cl}, nil
+			}
+			return cl, nil
+		}
+		endpoints = []Endpoint{
+			{Name: "unreachable", Host: "https://unreachable:6443"},
+			{Name: "edge-1", Host: "https://edge-1:6443"},
+		}
+
+		done := make(chan error)
+		go func() { done <- p.sync(ctx, mgr) }()
+		Eventually(func() error {
+			_, err := p.Get(ctx, "edge-1")
+			return err
+		}).Should(Succeed())
+		Consistently(done, 50*time.Millisecond).ShouldNot(Receive())
+		Eventually(done).Should(Receive(MatchError(ContainSubstring("failed to sync cache"))))
+		Expect(engaged.names()).To(ConsistOf("edge-1"))
+		_, err := p.Get(ctx, "unreachable")
+		Expect(err).To(MatchError(multicluster.ErrClusterNotFound))
+	})
+
+	It("serves the metadata to runnables while they are engaged", func(ctx context.Context) {
+		Expect(mgr.Add(&metadataReader{p: p})).To(Succeed())
+		endpoints = []Endpoint{{Name: "edge-1", Host: "https://edge-1:6443", Labels: map[string]string{"site": "a"}}}
+
+		done := make(chan error)
+		go func() { done <- p.sync(ctx, mgr) }()
+		Eventually(done).Should(Receive(BeNil()))
+		Expect(engaged.names()).To(ConsistOf("edge-1"))
+	})
+
+	It("engages clusters through a multi-cluster manager", func(ctx context.Context) {
+		m, err := mcmanager.WithMultiCluster(mgr.GetLocalManager(), p)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(m.Add(&metadataReader{p: p})).To(Succeed())
+		endpoints = []Endpoint{{Name: "edge-1", Host: "https://edge-1:6443", Labels: map[string]string{"site": "a"}}}
+
+		done := make(chan error)
+		go func() { done <- p.sync(ctx, m) }()
+		Eventually(done).Should(Receive(BeNil()))
+		md, err := m.GetClusterMetadata(ctx, "edge-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(md.Labels).To(HaveKeyWithValue("site", "a"))
+		_, err = m.GetCluster(ctx, "edge-1")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("keeps the clusters if the catalog fails", func(ctx context.Context) {
+		endpoints = []Endpoint{{Name: "edge-1", Host: "https://edge-1:6443"}}
+		Expect(p.sync(ctx, mgr)).To(Succeed())
+
+		listErr = errors.New("catalog unavailable")
+		Expect(p.sync(ctx, mgr)).To(MatchError(listErr))
+		_, err := p.Get(ctx, "edge-1")
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
+
+type recorder struct {
+	lock     sync.Mutex
+	contexts map[string]context.Context
+}
+
+func (r *recorder) Engage(ctx context.Context, name string, _ cluster.Cluster) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.contexts[name] = ctx
+	return nil
+}
+
+func (r *recorder) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+func (r *recorder) get(name string) context.Context {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.contexts[name]
+}
+
+func (r *recorder) names() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var names []string
+	for name := range r.contexts {
+		names = append(names, name)
+	}
+	return names
+}
+
+// unsyncedCluster is a cluster whose cache never syncs, like that of an
+// unreachable API server.
+type unsyncedCluster struct {
+	*fake.Cluster
+}
+
+func (c *unsyncedCluster) GetCache() cache.Cache {
+	return unsyncedCache{Cache: c.Cluster.GetCache()}
+}
+
+type unsyncedCache struct {
+	cache.Cache
+}
+
+func (unsyncedCache) WaitForCacheSync(ctx context.Context) bool {
+	<-ctx.Done()
+	return false
+}
+
+// metadataReader reads the metadata of the clusters it is engaged with from
+// the provider, like the manager and the cluster filters of controllers.
+type metadataReader struct {
+	p *Provider
+}
+
+func (r *metadataReader) Engage(ctx context.Context, name string, _ cluster.Cluster) error {
+	md, err := r.p.GetMetadata(ctx, name)
+	if err != nil {
+		return err
+	}
+	if md.Labels["site"] == "" {
+		return errors.New("missing labels")
+	}
+	return nil
+}
+
+func (r *metadataReader) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
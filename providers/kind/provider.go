@@ -33,17 +33,22 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 
+	"sigs.k8s.io/multicluster-runtime/pkg/config"
 	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
 	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+	"sigs.k8s.io/multicluster-runtime/pkg/options"
 )
 
 var _ multicluster.Provider = &Provider{}
+var _ multicluster.ReEngager = &Provider{}
 
 // New creates a new kind cluster Provider.
 func New() *Provider {
 	return &Provider{
 		log:       log.Log.WithName("kind-cluster-provider"),
+		prefix:    "fleet-",
 		clusters:  map[string]cluster.Cluster{},
 		cancelFns: map[string]context.CancelFunc{},
 	}
@@ -58,6 +63,7 @@ type index struct {
 // Provider is a cluster Provider that works with a local Kind instance.
 type Provider struct {
 	opts      []cluster.Option
+	prefix    string
 	log       logr.Logger
 	lock      sync.RWMutex
 	clusters  map[string]cluster.Cluster
@@ -65,6 +71,17 @@ type Provider struct {
 	indexers  []index
 }
 
+// Factory constructs a kind provider from a fleet configuration. Register
+// it with options.Options.Register under config.ProviderKind.
+func Factory(cfg *config.FleetConfiguration, _ manager.Manager) (options.Provider, error) {
+	p := New()
+	p.opts = cfg.ClusterOptions()
+	if cfg.Provider.Kind != nil && cfg.Provider.Kind.Prefix != "" {
+		p.prefix = cfg.Provider.Kind.Prefix
+	}
+	return p, nil
+}
+
 // Get returns the cluster with the given name, if it is known.
 func (p *Provider) Get(ctx context.Context, clusterName string) (cluster.Cluster, error) {
 	p.lock.RLock()
@@ -96,10 +113,10 @@ func (p *Provider) Run(ctx context.Context, mgr mcmanager.Manager) error {
 
 		// start new clusters
 		for _, clusterName := range list {
-			log := p.log.WithValues("cluster", clusterName)
+			log := p.log.WithValues("cluster", multicluster.EscapeClusterName(clusterName))
 
 			// skip?
-			if !strings.HasPrefix(clusterName, "fleet-") {
+			if !strings.HasPrefix(clusterName, p.prefix) {
 				continue
 			}
 			p.lock.RLock()
@@ -120,7 +137,14 @@ func (p *Provider) Run(ctx context.Context, mgr mcmanager.Manager) error {
 				p.log.Info("failed to create rest config", "error", err)
 				return false, nil // keep going
 			}
-			cl, err := cluster.New(cfg, p.opts...)
+			// clusters are created with cluster.New, unless another
+			// constructor is configured with mcmanager.WithNewCluster.
+			var cl cluster.Cluster
+			if mgr != nil {
+				cl, err = mgr.NewClusterOr(mcmanager.PlainNewCluster, clusterName, cfg, p.opts...)
+			} else {
+				cl, err = mcmanager.PlainNewCluster(clusterName, cfg, p.opts...)
+			}
 			if err != nil {
 				p.log.Info("failed to create cluster", "error", err)
 				return false, nil // keep going
@@ -149,7 +173,7 @@ func (p *Provider) Run(ctx context.Context, mgr mcmanager.Manager) error {
 			p.cancelFns[clusterName] = cancel
 			p.lock.Unlock()
 
-			p.log.Info("Added new cluster", "cluster", clusterName)
+			p.log.Info("Added new cluster", "cluster", multicluster.EscapeClusterName(clusterName))
 
 			// engage manager
 			if mgr != nil {
@@ -181,7 +205,7 @@ func (p *Provider) Run(ctx context.Context, mgr mcmanager.Manager) error {
 				delete(p.cancelFns, name)
 				p.lock.Unlock()
 
-				p.log.Info("Cluster removed", "cluster", name)
+				p.log.Info("Cluster removed", "cluster", multicluster.EscapeClusterName(name))
 			}
 		}
 
@@ -189,6 +213,24 @@ func (p *Provider) Run(ctx context.Context, mgr mcmanager.Manager) error {
 	})
 }
 
+// ReEngage stops and forgets the cluster with the given name. Unlike the
+// other providers, the cluster is engaged again asynchronously, with a new
+// kubeconfig, on the next poll of the kind clusters.
+func (p *Provider) ReEngage(_ context.Context, clusterName string) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	cancel, ok := p.cancelFns[clusterName]
+	if !ok {
+		return multicluster.ErrClusterNotFound
+	}
+	cancel()
+	delete(p.clusters, clusterName)
+	delete(p.cancelFns, clusterName)
+
+	p.log.Info("Cluster forgotten to be re-engaged", "cluster", multicluster.EscapeClusterName(clusterName))
+	return nil
+}
+
 // IndexField indexes a field on all clusters, existing and future.
 func (p *Provider) IndexField(ctx context.Context, obj client.Object, field string, extractValue client.IndexerFunc) error {
 	p.lock.Lock()
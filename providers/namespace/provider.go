@@ -24,6 +24,7 @@ import (
 	"github.com/go-logr/logr"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	toolscache "k8s.io/client-go/tools/cache"
 
@@ -36,6 +37,8 @@ import (
 )
 
 var _ multicluster.Provider = &Provider{}
+var _ multicluster.MetadataProvider = &Provider{}
+var _ multicluster.ReEngager = &Provider{}
 
 // Provider is a cluster provider that represents each namespace
 // as a dedicated cluster with only a "default" namespace. It maps each namespace
@@ -46,6 +49,8 @@ type Provider struct {
 
 	log       logr.Logger
 	lock      sync.RWMutex
+	runCtx    context.Context
+	mgr       mcmanager.Manager
 	clusters  map[string]cluster.Cluster
 	cancelFns map[string]context.CancelFunc
 }
@@ -62,6 +67,10 @@ func New(cl cluster.Cluster) *Provider {
 
 // Run starts the provider and blocks.
 func (p *Provider) Run(ctx context.Context, mgr mcmanager.Manager) error {
+	p.lock.Lock()
+	p.runCtx, p.mgr = ctx, mgr
+	p.lock.Unlock()
+
 	nsInf, err := p.cluster.GetCache().GetInformer(ctx, &corev1.Namespace{})
 	if err != nil {
 		return err
@@ -80,22 +89,8 @@ func (p *Provider) Run(ctx context.Context, mgr mcmanager.Manager) error {
 				return
 			}
 
-			// create new cluster
-			p.lock.Lock()
-			clusterCtx, cancel := context.WithCancel(ctx)
-			cl := &NamespacedCluster{clusterName: ns.Name, Cluster: p.cluster}
-			p.clusters[ns.Name] = cl
-			p.cancelFns[ns.Name] = cancel
-			p.lock.Unlock()
-
-			if err := mgr.Engage(clusterCtx, ns.Name, cl); err != nil {
+			if err := p.engage(ctx, mgr, ns.Name); err != nil {
 				utilruntime.HandleError(fmt.Errorf("failed to engage manager with cluster %q: %w", ns.Name, err))
-
-				// cleanup
-				p.lock.Lock()
-				delete(p.clusters, ns.Name)
-				delete(p.cancelFns, ns.Name)
-				p.lock.Unlock()
 			}
 		},
 		DeleteFunc: func(obj interface{}) {
@@ -127,6 +122,48 @@ func (p *Provider) Run(ctx context.Context, mgr mcmanager.Manager) error {
 	return nil
 }
 
+// engage creates and engages the cluster of the namespace with the given
+// name until ctx is done.
+func (p *Provider) engage(ctx context.Context, mgr mcmanager.Manager, name string) error {
+	p.lock.Lock()
+	clusterCtx, cancel := context.WithCancel(ctx)
+	cl := &NamespacedCluster{clusterName: name, Cluster: p.cluster}
+	p.clusters[name] = cl
+	p.cancelFns[name] = cancel
+	p.lock.Unlock()
+
+	if err := mgr.Engage(clusterCtx, name, cl); err != nil {
+		p.lock.Lock()
+		if p.clusters[name] == cluster.Cluster(cl) {
+			delete(p.clusters, name)
+			delete(p.cancelFns, name)
+		}
+		p.lock.Unlock()
+		cancel()
+		return err
+	}
+	return nil
+}
+
+// ReEngage disengages the cluster of the namespace with the given name, and
+// engages it again. The clusters share the cache of the host cluster, which
+// is not recreated.
+func (p *Provider) ReEngage(_ context.Context, clusterName string) error {
+	p.lock.Lock()
+	cancel, ok := p.cancelFns[clusterName]
+	runCtx, mgr := p.runCtx, p.mgr
+	if ok {
+		cancel()
+		delete(p.clusters, clusterName)
+		delete(p.cancelFns, clusterName)
+	}
+	p.lock.Unlock()
+	if !ok {
+		return multicluster.ErrClusterNotFound
+	}
+	return p.engage(runCtx, mgr, clusterName)
+}
+
 // Get returns a cluster by name.
 func (p *Provider) Get(_ context.Context, clusterName string) (cluster.Cluster, error) {
 	p.lock.RLock()
@@ -138,6 +175,25 @@ func (p *Provider) Get(_ context.Context, clusterName string) (cluster.Cluster,
 	return nil, multicluster.ErrClusterNotFound
 }
 
+// GetMetadata returns the labels and annotations of the namespace backing
+// the cluster.
+func (p *Provider) GetMetadata(ctx context.Context, clusterName string) (multicluster.Metadata, error) {
+	if _, err := p.Get(ctx, clusterName); err != nil {
+		return multicluster.Metadata{}, err
+	}
+	ns := &corev1.Namespace{}
+	if err := p.cluster.GetCache().Get(ctx, client.ObjectKey{Name: clusterName}, ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return multicluster.Metadata{}, multicluster.ErrClusterNotFound
+		}
+		return multicluster.Metadata{}, err
+	}
+	return multicluster.Metadata{
+		Labels:      ns.Labels,
+		Annotations: ns.Annotations,
+	}, nil
+}
+
 // IndexField indexes a field on all clusters.
 func (p *Provider) IndexField(ctx context.Context, obj client.Object, field string, extractValue client.IndexerFunc) error {
 	return p.cluster.GetFieldIndexer().IndexField(ctx, obj, field, extractValue)
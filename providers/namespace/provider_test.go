@@ -39,6 +39,7 @@ import (
 
 	mcbuilder "sigs.k8s.io/multicluster-runtime/pkg/builder"
 	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
 	mcreconcile "sigs.k8s.io/multicluster-runtime/pkg/reconcile"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -246,6 +247,19 @@ var _ = Describe("Provider Namespace", Ordered, func() {
 		Expect(cms.Items[0].Namespace).To(Equal("island"))
 	})
 
+	It("re-engages a cluster on demand", func() {
+		err := mgr.ReEngage(ctx, "island")
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(func() error {
+			_, err := mgr.GetCluster(ctx, "island")
+			return err
+		}, "10s").Should(Succeed())
+
+		err = mgr.ReEngage(ctx, "atlantis")
+		Expect(err).To(MatchError(multicluster.ErrClusterNotFound))
+	})
+
 	AfterAll(func() {
 		By("Stopping the provider, cluster, manager, and controller", func() {
 			cancel()
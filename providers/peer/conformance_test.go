@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package peer
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/conformance"
+	"sigs.k8s.io/multicluster-runtime/pkg/fake"
+)
+
+var _ = func() bool {
+	key := types.NamespacedName{Namespace: "default", Name: "engaged-clusters"}
+	var (
+		lock      sync.Mutex
+		host      *fake.Cluster
+		published sets.Set[string]
+	)
+	// publish writes the published clusters as the Publisher of a primary
+	// manager would.
+	publish := func(ctx context.Context, change func(sets.Set[string])) error {
+		lock.Lock()
+		defer lock.Unlock()
+		change(published)
+		data, err := json.Marshal(sets.List(published))
+		if err != nil {
+			return err
+		}
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name}}
+		if err := host.GetClient().Get(ctx, key, cm); err != nil {
+			cm.Data = map[string]string{ClustersKey: string(data)}
+			return host.GetClient().Create(ctx, cm)
+		}
+		cm.Data = map[string]string{ClustersKey: string(data)}
+		return host.GetClient().Update(ctx, cm)
+	}
+	return conformance.DescribeProvider("peer", conformance.Harness{
+		New: func(context.Context) (conformance.Provider, error) {
+			lock.Lock()
+			host = fake.NewCluster(clientfake.NewClientBuilder().Build())
+			published = sets.New[string]()
+			lock.Unlock()
+			return New(host, Options{
+				ConfigMap: key,
+				Interval:  10 * time.Millisecond,
+				NewCluster: func(context.Context, string) (cluster.Cluster, error) {
+					return fake.NewCluster(clientfake.NewClientBuilder().Build()), nil
+				},
+			})
+		},
+		AddCluster: func(ctx context.Context, name string) error {
+			return publish(ctx, func(s sets.Set[string]) { s.Insert(name) })
+		},
+		RemoveCluster: func(ctx context.Context, name string) error {
+			return publish(ctx, func(s sets.Set[string]) { s.Delete(name) })
+		},
+		RenameCluster: func(ctx context.Context, oldName, newName string) error {
+			return publish(ctx, func(s sets.Set[string]) { s.Delete(oldName).Insert(newName) })
+		},
+		Timeout: 2 * time.Second,
+	})
+}()
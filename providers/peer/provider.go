@@ -0,0 +1,310 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package peer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	mcerrors "sigs.k8s.io/multicluster-runtime/pkg/errors"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+var _ multicluster.Provider = &Provider{}
+
+// ErrReadOnly is returned by the clients of clusters engaged read-only.
+var ErrReadOnly = errors.New("cluster is engaged read-only")
+
+// Options are the options for the peer Provider.
+type Options struct {
+	// ConfigMap is the ConfigMap in the host cluster written by the
+	// Publisher of the primary manager, or by its membership publishing
+	// configured with mcmanager.WithMembership.
+	ConfigMap types.NamespacedName
+
+	// NewCluster creates the cluster with the given name, e.g. from the
+	// kubeconfig secret the primary manager's provider reads. The cluster
+	// will be started by the provider.
+	NewCluster func(ctx context.Context, name string) (cluster.Cluster, error)
+
+	// ReadOnly makes the clients of the engaged clusters reject writes with
+	// ErrReadOnly, e.g. for analytics sidecars.
+	ReadOnly bool
+
+	// Interval is the interval in which the ConfigMap is read. Defaults to
+	// 10 seconds.
+	Interval time.Duration
+}
+
+type index struct {
+	object       client.Object
+	field        string
+	extractValue client.IndexerFunc
+}
+
+type engaged struct {
+	cluster.Cluster
+	cancel context.CancelFunc
+}
+
+// Provider is a cluster Provider that engages the same clusters as another
+// manager, as published by its Publisher.
+type Provider struct {
+	opts   Options
+	reader client.Reader
+	log    logr.Logger
+
+	lock     sync.Mutex
+	clusters map[string]engaged
+	indexers []index
+}
+
+// New creates a new peer Provider reading the ConfigMap from the host
+// cluster, usually the local manager.
+func New(host cluster.Cluster, opts Options) (*Provider, error) {
+	if opts.NewCluster == nil {
+		return nil, fmt.Errorf("NewCluster is required")
+	}
+	if opts.ConfigMap.Name == "" {
+		return nil, fmt.Errorf("ConfigMap is required")
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = 10 * time.Second
+	}
+	return &Provider{
+		opts:     opts,
+		reader:   host.GetAPIReader(),
+		log:      log.Log.WithName("peer-provider"),
+		clusters: map[string]engaged{},
+	}, nil
+}
+
+// Get returns the cluster with the given name, if it is engaged.
+func (p *Provider) Get(_ context.Context, clusterName string) (cluster.Cluster, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if cl, ok := p.clusters[clusterName]; ok {
+		return cl.Cluster, nil
+	}
+	return nil, multicluster.ErrClusterNotFound
+}
+
+// Run reads the published clusters periodically, engages them with the
+// manager, and blocks.
+func (p *Provider) Run(ctx context.Context, mgr mcmanager.Manager) error {
+	p.log.Info("Starting peer provider", "configMap", p.opts.ConfigMap)
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := p.sync(ctx, mgr); err != nil {
+			p.log.Error(err, "Failed to sync published clusters")
+		}
+	}, p.opts.Interval)
+	return ctx.Err()
+}
+
+// sync engages the published clusters and disengages the others. If the
+// ConfigMap cannot be read, the engaged clusters are kept.
+func (p *Provider) sync(ctx context.Context, mgr mcmanager.Manager) error {
+	cm := &corev1.ConfigMap{}
+	if err := p.reader.Get(ctx, p.opts.ConfigMap, cm); client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	names, err := parseClusters(cm)
+	if err != nil {
+		return fmt.Errorf("invalid ConfigMap %s: %w", p.opts.ConfigMap, err)
+	}
+
+	p.lock.Lock()
+	published := map[string]bool{}
+	var added []string
+	for _, name := range names {
+		published[name] = true
+		if _, ok := p.clusters[name]; !ok {
+			added = append(added, name)
+		}
+	}
+	for name := range p.clusters {
+		if !published[name] {
+			p.log.Info("Cluster no longer published", "cluster", multicluster.EscapeClusterName(name))
+			p.disengage(name)
+		}
+	}
+	p.lock.Unlock()
+
+	var errs []error
+	for _, name := range added {
+		if err := p.engage(ctx, mgr, name); err != nil {
+			errs = append(errs, mcerrors.New(name, "engage", err))
+		}
+	}
+	return mcerrors.NewAggregate(errs...)
+}
+
+// engage creates, starts and engages the cluster. The lock must not be
+// held, the manager calls back into the provider while engaging.
+func (p *Provider) engage(ctx context.Context, mgr mcmanager.Manager, name string) error {
+	cl, err := p.opts.NewCluster(ctx, name)
+	if err != nil {
+		return err
+	}
+	p.lock.Lock()
+	indexers := slices.Clone(p.indexers)
+	p.lock.Unlock()
+	for _, idx := range indexers {
+		if err := cl.GetCache().IndexField(ctx, idx.object, idx.field, idx.extractValue); err != nil {
+			return fmt.Errorf("failed to index field %q: %w", idx.field, err)
+		}
+	}
+	if p.opts.ReadOnly {
+		cl = &readOnlyCluster{Cluster: cl}
+	}
+
+	clusterCtx, cancel := context.WithCancel(ctx)
+	go func() {
+		if err := cl.Start(clusterCtx); err != nil {
+			p.log.Error(err, "failed to start cluster", "cluster", multicluster.EscapeClusterName(name))
+		}
+	}()
+	if !cl.GetCache().WaitForCacheSync(ctx) {
+		cancel()
+		return fmt.Errorf("failed to sync cache")
+	}
+
+	p.lock.Lock()
+	for _, idx := range p.indexers[len(indexers):] {
+		if err := cl.GetCache().IndexField(ctx, idx.object, idx.field, idx.extractValue); err != nil {
+			p.lock.Unlock()
+			cancel()
+			return fmt.Errorf("failed to index field %q: %w", idx.field, err)
+		}
+	}
+	p.clusters[name] = engaged{Cluster: cl, cancel: cancel}
+	p.lock.Unlock()
+	p.log.Info("Added new cluster", "cluster", multicluster.EscapeClusterName(name), "readOnly", p.opts.ReadOnly)
+
+	if err := mgr.Engage(clusterCtx, name, cl); err != nil {
+		p.lock.Lock()
+		if p.clusters[name].Cluster == cl {
+			delete(p.clusters, name)
+		}
+		p.lock.Unlock()
+		cancel()
+		return err
+	}
+	return nil
+}
+
+// disengage stops the cluster with the given name. The lock must be held.
+func (p *Provider) disengage(name string) {
+	if cl, ok := p.clusters[name]; ok {
+		cl.cancel()
+	}
+	delete(p.clusters, name)
+}
+
+// IndexField indexes a field on all clusters, existing and future.
+func (p *Provider) IndexField(ctx context.Context, obj client.Object, field string, extractValue client.IndexerFunc) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	// save for future clusters.
+	p.indexers = append(p.indexers, index{
+		object:       obj,
+		field:        field,
+		extractValue: extractValue,
+	})
+
+	// apply to existing clusters.
+	for name, cl := range p.clusters {
+		if err := cl.GetCache().IndexField(ctx, obj, field, extractValue); err != nil {
+			return fmt.Errorf("failed to index field %q on cluster %q: %w", field, name, err)
+		}
+	}
+
+	return nil
+}
+
+// readOnlyCluster is a cluster whose client rejects writes.
+type readOnlyCluster struct {
+	cluster.Cluster
+}
+
+func (c *readOnlyCluster) GetClient() client.Client {
+	return readOnlyClient{Client: c.Cluster.GetClient()}
+}
+
+type readOnlyClient struct {
+	client.Client
+}
+
+func (readOnlyClient) Create(context.Context, client.Object, ...client.CreateOption) error {
+	return ErrReadOnly
+}
+
+func (readOnlyClient) Delete(context.Context, client.Object, ...client.DeleteOption) error {
+	return ErrReadOnly
+}
+
+func (readOnlyClient) Update(context.Context, client.Object, ...client.UpdateOption) error {
+	return ErrReadOnly
+}
+
+func (readOnlyClient) Patch(context.Context, client.Object, client.Patch, ...client.PatchOption) error {
+	return ErrReadOnly
+}
+
+func (readOnlyClient) DeleteAllOf(context.Context, client.Object, ...client.DeleteAllOfOption) error {
+	return ErrReadOnly
+}
+
+func (c readOnlyClient) Status() client.SubResourceWriter {
+	return readOnlySubResource{SubResourceClient: c.Client.SubResource("status")}
+}
+
+func (c readOnlyClient) SubResource(subResource string) client.SubResourceClient {
+	return readOnlySubResource{SubResourceClient: c.Client.SubResource(subResource)}
+}
+
+type readOnlySubResource struct {
+	client.SubResourceClient
+}
+
+func (readOnlySubResource) Create(context.Context, client.Object, client.Object, ...client.SubResourceCreateOption) error {
+	return ErrReadOnly
+}
+
+func (readOnlySubResource) Update(context.Context, client.Object, ...client.SubResourceUpdateOption) error {
+	return ErrReadOnly
+}
+
+func (readOnlySubResource) Patch(context.Context, client.Object, client.Patch, ...client.SubResourcePatchOption) error {
+	return ErrReadOnly
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package peer
+
+import (
+	"context"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/fake"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+)
+
+var _ = Describe("Provider", func() {
+	key := types.NamespacedName{Namespace: "default", Name: "engaged-clusters"}
+
+	var (
+		primary, follower *fake.Manager
+		publisher         *Publisher
+		engaged           *recorder
+	)
+
+	newProvider := func(readOnly bool) *Provider {
+		// the follower shares the host cluster with the primary.
+		p, err := New(primary.FakeCluster(""), Options{
+			ConfigMap: key,
+			ReadOnly:  readOnly,
+			NewCluster: func(context.Context, string) (cluster.Cluster, error) {
+				return fake.NewCluster(clientfake.NewClientBuilder().Build()), nil
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return p
+	}
+
+	BeforeEach(func() {
+		primary = fake.NewManagerBuilder().Build()
+		publisher = NewPublisher(primary.FakeCluster(""), key)
+		follower = fake.NewManagerBuilder().Build()
+		engaged = &recorder{contexts: map[string]context.Context{}}
+		Expect(follower.Add(engaged)).To(Succeed())
+	})
+
+	It("engages the clusters published by the primary", func(ctx context.Context) {
+		p := newProvider(false)
+
+		By("following nothing before anything is published")
+		Expect(p.sync(ctx, follower)).To(Succeed())
+		Expect(engaged.names()).To(BeEmpty())
+
+		primaryCtx, cancel := context.WithCancel(ctx)
+		Expect(publisher.Engage(primaryCtx, "edge-1", nil)).To(Succeed())
+		Expect(publisher.Engage(ctx, "edge-2", nil)).To(Succeed())
+		Expect(publisher.publish(ctx)).To(Succeed())
+
+		cm := &corev1.ConfigMap{}
+		Expect(primary.FakeCluster("").GetClient().Get(ctx, key, cm)).To(Succeed())
+		Expect(cm.Data).To(HaveKeyWithValue(ClustersKey, `["edge-1","edge-2"]`))
+
+		Expect(p.sync(ctx, follower)).To(Succeed())
+		Expect(engaged.names()).To(ConsistOf("edge-1", "edge-2"))
+		_, err := p.Get(ctx, "edge-1")
+		Expect(err).NotTo(HaveOccurred())
+
+		By("disengaging clusters the primary disengaged")
+		cancel()
+		Eventually(func() string {
+			Expect(publisher.publish(ctx)).To(Succeed())
+			Expect(primary.FakeCluster("").GetClient().Get(ctx, key, cm)).To(Succeed())
+			return cm.Data[ClustersKey]
+		}).Should(Equal(`["edge-2"]`))
+		Expect(p.sync(ctx, follower)).To(Succeed())
+		Expect(engaged.get("edge-1").Err()).To(HaveOccurred())
+		Expect(engaged.get("edge-2").Err()).NotTo(HaveOccurred())
+		_, err = p.Get(ctx, "edge-1")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("engages clusters read-only", func(ctx context.Context) {
+		Expect(primary.FakeCluster("").GetClient().Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+			Data:       map[string]string{ClustersKey: `["edge-1"]`},
+		})).To(Succeed())
+
+		p := newProvider(true)
+		Expect(p.sync(ctx, follower)).To(Succeed())
+
+		cl, err := p.Get(ctx, "edge-1")
+		Expect(err).NotTo(HaveOccurred())
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo"}}
+		Expect(cl.GetClient().Create(ctx, cm)).To(MatchError(ErrReadOnly))
+		Expect(cl.GetClient().Status().Update(ctx, cm)).To(MatchError(ErrReadOnly))
+		Expect(cl.GetClient().Get(ctx, key, cm)).NotTo(Succeed())
+	})
+
+	It("engages clusters through a multi-cluster manager", func(ctx context.Context) {
+		Expect(primary.FakeCluster("").GetClient().Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+			Data:       map[string]string{ClustersKey: `["edge-1"]`},
+		})).To(Succeed())
+
+		p := newProvider(false)
+		m, err := mcmanager.WithMultiCluster(follower.GetLocalManager(), p)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(m.Add(&clusterReader{mgr: m})).To(Succeed())
+
+		done := make(chan error)
+		go func() { done <- p.sync(ctx, m) }()
+		Eventually(done).Should(Receive(BeNil()))
+		_, err = m.GetCluster(ctx, "edge-1")
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
+
+// clusterReader gets the clusters it is engaged with from the manager, like
+// controllers that look up clusters while they engage.
+type clusterReader struct {
+	mgr mcmanager.Manager
+}
+
+func (r *clusterReader) Engage(ctx context.Context, name string, _ cluster.Cluster) error {
+	_, err := r.mgr.GetCluster(ctx, name)
+	return err
+}
+
+func (r *clusterReader) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+type recorder struct {
+	lock     sync.Mutex
+	contexts map[string]context.Context
+}
+
+func (r *recorder) Engage(ctx context.Context, name string, _ cluster.Cluster) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.contexts[name] = ctx
+	return nil
+}
+
+func (r *recorder) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+func (r *recorder) get(name string) context.Context {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.contexts[name]
+}
+
+func (r *recorder) names() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var names []string
+	for name := range r.contexts {
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package peer
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+)
+
+// ClustersKey is the key of the ConfigMap data holding the JSON list of
+// engaged cluster names. Managers publishing their membership with
+// mcmanager.WithMembership use the same key.
+const ClustersKey = mcmanager.MembershipClustersKey
+
+var _ mcmanager.Runnable = &Publisher{}
+
+// Publisher publishes the names of the clusters engaged by a manager in a
+// ConfigMap of the host cluster, for Providers of other managers to follow.
+// Add it to the manager of the primary replica with mgr.Add.
+type Publisher struct {
+	client client.Client
+	reader client.Reader
+	key    types.NamespacedName
+	log    logr.Logger
+
+	lock     sync.Mutex
+	clusters sets.Set[string]
+	changed  chan struct{}
+}
+
+// NewPublisher returns a Publisher writing the ConfigMap key in the host
+// cluster, usually the local manager.
+func NewPublisher(host cluster.Cluster, key types.NamespacedName) *Publisher {
+	return &Publisher{
+		client:   host.GetClient(),
+		reader:   host.GetAPIReader(),
+		key:      key,
+		log:      log.Log.WithName("peer-publisher"),
+		clusters: sets.New[string](),
+		changed:  make(chan struct{}, 1),
+	}
+}
+
+// Engage adds the cluster to the published set until ctx is done.
+func (p *Publisher) Engage(ctx context.Context, name string, _ cluster.Cluster) error {
+	p.lock.Lock()
+	p.clusters.Insert(name)
+	p.lock.Unlock()
+	p.notify()
+	go func() {
+		<-ctx.Done()
+		p.lock.Lock()
+		p.clusters.Delete(name)
+		p.lock.Unlock()
+		p.notify()
+	}()
+	return nil
+}
+
+func (p *Publisher) notify() {
+	select {
+	case p.changed <- struct{}{}:
+	default:
+	}
+}
+
+// Start publishes the engaged clusters whenever they change, and blocks
+// until ctx is done. Failed writes are retried.
+func (p *Publisher) Start(ctx context.Context) error {
+	retry := time.NewTimer(0)
+	defer retry.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-p.changed:
+		case <-retry.C:
+		}
+		if err := p.publish(ctx); err != nil {
+			p.log.Error(err, "Failed to publish engaged clusters", "configMap", p.key)
+			retry.Reset(5 * time.Second)
+		}
+	}
+}
+
+// NeedLeaderElection returns true, only the leader publishes.
+func (p *Publisher) NeedLeaderElection() bool {
+	return true
+}
+
+func (p *Publisher) publish(ctx context.Context) error {
+	p.lock.Lock()
+	names := sets.List(p.clusters)
+	p.lock.Unlock()
+	data, err := json.Marshal(names)
+	if err != nil {
+		return err
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := p.reader.Get(ctx, p.key, cm); apierrors.IsNotFound(err) {
+		cm.Namespace, cm.Name = p.key.Namespace, p.key.Name
+		cm.Data = map[string]string{ClustersKey: string(data)}
+		return p.client.Create(ctx, cm)
+	} else if err != nil {
+		return err
+	}
+	if cm.Data[ClustersKey] == string(data) {
+		return nil
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[ClustersKey] = string(data)
+	return p.client.Update(ctx, cm)
+}
+
+// parseClusters returns the sorted cluster names published in cm.
+func parseClusters(cm *corev1.ConfigMap) ([]string, error) {
+	var names []string
+	if data, ok := cm.Data[ClustersKey]; ok {
+		if err := json.Unmarshal([]byte(data), &names); err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
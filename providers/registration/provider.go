@@ -0,0 +1,483 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/apis/v1alpha1"
+	mccluster "sigs.k8s.io/multicluster-runtime/pkg/cluster"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+var _ multicluster.Provider = &Provider{}
+var _ multicluster.MetadataProvider = &Provider{}
+var _ multicluster.ReEngager = &Provider{}
+
+// DefaultSecretKey is the default key of the kubeconfig in the secret
+// referenced by a ClusterRegistration.
+const DefaultSecretKey = "kubeconfig"
+
+// Options are the options for the ClusterRegistration Provider.
+type Options struct {
+	// ClusterOptions are the options passed to the cluster constructor.
+	ClusterOptions []cluster.Option
+
+	// NewCluster is a function that creates a new cluster from a rest.Config.
+	// The cluster will be started by the provider. Defaults to the
+	// NewCluster of the manager. Clusters created with
+	// mccluster.NewUpdatable, the default of the manager, keep their watches
+	// when only the endpoint or credentials change.
+	NewCluster func(ctx context.Context, reg *v1alpha1.ClusterRegistration, cfg *rest.Config, opts ...cluster.Option) (cluster.Cluster, error)
+
+	// Kubeconfig restricts the authentication of the kubeconfigs in the
+	// secrets, e.g. which exec credential plugins may run.
+	Kubeconfig mccluster.KubeconfigOptions
+
+	// Proxy returns the proxy through which a cluster is reached, by the
+	// name "<namespace>/<name>". Optional.
+	Proxy mccluster.ProxyFunc
+
+	// Trust overrides the CAs and pins the certificates of a cluster, by
+	// the name "<namespace>/<name>". Optional.
+	Trust mccluster.TrustFunc
+
+	// Namespaces restricts the cache of a cluster to the namespaces returned
+	// for its name "<namespace>/<name>" and the labels and annotations of
+	// its ClusterRegistration. Clusters are re-engaged when the namespaces
+	// change. Optional.
+	Namespaces mccluster.NamespacesFunc
+}
+
+// New creates a new ClusterRegistration Provider. It watches
+// ClusterRegistration objects through the local manager, whose scheme must
+// include the v1alpha1 types. Clusters are named "<namespace>/<name>".
+func New(localMgr manager.Manager, opts Options) (*Provider, error) {
+	p := &Provider{
+		opts:      opts,
+		log:       log.Log.WithName("cluster-registration-provider"),
+		client:    localMgr.GetClient(),
+		clusters:  map[string]engaged{},
+		cancelFns: map[string]context.CancelFunc{},
+	}
+
+	if err := builder.ControllerManagedBy(localMgr).
+		For(&v1alpha1.ClusterRegistration{}).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(p.registrationsForSecret)).
+		WithOptions(controller.Options{MaxConcurrentReconciles: 1}). // no parallelism.
+		Complete(p); err != nil {
+		return nil, fmt.Errorf("failed to create controller: %w", err)
+	}
+
+	return p, nil
+}
+
+type index struct {
+	object       client.Object
+	field        string
+	extractValue client.IndexerFunc
+}
+
+type engaged struct {
+	cluster.Cluster
+	generation    int64
+	secretVersion string
+	labels        map[string]string
+	annotations   map[string]string
+	taints        []multicluster.Taint
+	namespaces    []string
+}
+
+// Provider is a cluster Provider that engages the clusters registered with
+// ClusterRegistration objects, and writes the engagement status back to them.
+type Provider struct {
+	opts   Options
+	log    logr.Logger
+	client client.Client
+
+	// reconcileLock serializes reconciles and ReEngage.
+	reconcileLock sync.Mutex
+
+	lock      sync.Mutex
+	runCtx    context.Context
+	mcMgr     mcmanager.Manager
+	clusters  map[string]engaged
+	cancelFns map[string]context.CancelFunc
+	indexers  []index
+}
+
+// Get returns the cluster with the given name, if it is known.
+func (p *Provider) Get(_ context.Context, clusterName string) (cluster.Cluster, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if cl, ok := p.clusters[clusterName]; ok {
+		return cl.Cluster, nil
+	}
+
+	return nil, multicluster.ErrClusterNotFound
+}
+
+// GetMetadata returns the labels, annotations and taints of the
+// ClusterRegistration backing the cluster.
+func (p *Provider) GetMetadata(_ context.Context, clusterName string) (multicluster.Metadata, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	cl, ok := p.clusters[clusterName]
+	if !ok {
+		return multicluster.Metadata{}, multicluster.ErrClusterNotFound
+	}
+	return multicluster.Metadata{
+		Labels:      cl.labels,
+		Annotations: cl.annotations,
+		Taints:      cl.taints,
+	}, nil
+}
+
+// Run starts the provider and blocks.
+func (p *Provider) Run(ctx context.Context, mgr mcmanager.Manager) error {
+	p.log.Info("Starting cluster registration provider")
+
+	p.lock.Lock()
+	p.runCtx, p.mcMgr = ctx, mgr
+	p.lock.Unlock()
+
+	<-ctx.Done()
+
+	return ctx.Err()
+}
+
+// Reconcile engages the cluster of a ClusterRegistration, re-engages it if
+// its spec or kubeconfig changes, and disengages it when it is deleted.
+func (p *Provider) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	p.reconcileLock.Lock()
+	defer p.reconcileLock.Unlock()
+	return p.reconcile(ctx, ctx, req)
+}
+
+// ReEngage disengages the cluster with the given name, and engages it
+// again with a new client and cache.
+func (p *Provider) ReEngage(ctx context.Context, clusterName string) error {
+	namespace, name, _ := strings.Cut(clusterName, "/")
+	p.reconcileLock.Lock()
+	defer p.reconcileLock.Unlock()
+
+	p.lock.Lock()
+	_, ok := p.clusters[clusterName]
+	runCtx := p.runCtx
+	if ok {
+		p.disengage(clusterName)
+	}
+	p.lock.Unlock()
+	if !ok {
+		return multicluster.ErrClusterNotFound
+	}
+	_, err := p.reconcile(ctx, runCtx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}})
+	return err
+}
+
+// reconcile reconciles the ClusterRegistration of req. Engaged clusters
+// run until engageCtx is done. The reconcile lock must be held.
+func (p *Provider) reconcile(ctx, engageCtx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	key := req.NamespacedName.String()
+	log := p.log.WithValues("cluster", multicluster.EscapeClusterName(key))
+
+	reg := &v1alpha1.ClusterRegistration{}
+	if err := p.client.Get(ctx, req.NamespacedName, reg); err != nil {
+		if apierrors.IsNotFound(err) {
+			p.lock.Lock()
+			defer p.lock.Unlock()
+			p.disengage(key)
+			log.Info("Cluster removed")
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, fmt.Errorf("failed to get cluster registration: %w", err)
+	}
+	if !reg.DeletionTimestamp.IsZero() {
+		p.lock.Lock()
+		defer p.lock.Unlock()
+		p.disengage(key)
+		return reconcile.Result{}, nil
+	}
+
+	// the lock is not held while the cluster is updated, synced and
+	// engaged: the manager calls back into the provider while engaging.
+	// Reconciles do not run concurrently.
+	p.lock.Lock()
+	mcMgr := p.mcMgr
+	current, ok := p.clusters[key]
+	indexers := slices.Clone(p.indexers)
+	p.lock.Unlock()
+	disengage := func() {
+		p.lock.Lock()
+		defer p.lock.Unlock()
+		p.disengage(key)
+	}
+	remember := func(e engaged) {
+		p.lock.Lock()
+		defer p.lock.Unlock()
+		if _, ok := p.clusters[key]; ok {
+			p.clusters[key] = e
+		}
+	}
+
+	// provider already started?
+	if mcMgr == nil {
+		return reconcile.Result{RequeueAfter: time.Second * 2}, nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := p.client.Get(ctx, client.ObjectKey{Namespace: reg.Namespace, Name: reg.Spec.SecretRef.Name}, secret); err != nil {
+		disengage()
+		return reconcile.Result{}, p.setEngaged(ctx, reg, false, "SecretNotFound", err)
+	}
+
+	var namespaces []string
+	if p.opts.Namespaces != nil {
+		namespaces = p.opts.Namespaces(key, multicluster.Metadata{Labels: reg.Labels, Annotations: reg.Annotations, Taints: taintsOf(reg)})
+	}
+
+	// already engaged with the current spec, kubeconfig and namespaces?
+	sameNamespaces := slices.Equal(current.namespaces, namespaces)
+	if ok && current.generation == reg.Generation && current.secretVersion == secret.ResourceVersion && sameNamespaces {
+		current.labels, current.annotations = reg.Labels, reg.Annotations
+		remember(current)
+		return reconcile.Result{}, p.setEngaged(ctx, reg, true, "Engaged", nil)
+	}
+
+	cfg, err := restConfig(reg, secret, p.opts.Kubeconfig)
+	if err != nil {
+		disengage()
+		return reconcile.Result{}, p.setEngaged(ctx, reg, false, "InvalidKubeconfig", err)
+	}
+	cfg = mccluster.WrapConfigForProxy(cfg, key, p.opts.Proxy)
+	if cfg, err = mccluster.WrapConfigForTrust(cfg, key, p.opts.Trust); err != nil {
+		disengage()
+		return reconcile.Result{}, p.setEngaged(ctx, reg, false, "InvalidTrust", err)
+	}
+
+	if ok {
+		// only the endpoint or credentials changed? Then keep the watches.
+		if sameNamespaces && equality.Semantic.DeepEqual(taintsOf(reg), current.taints) {
+			err := mcMgr.UpdateCluster(ctx, key, cfg)
+			if err == nil {
+				log.Info("Updated endpoint and credentials of cluster")
+				current.generation, current.secretVersion = reg.Generation, secret.ResourceVersion
+				current.labels, current.annotations = reg.Labels, reg.Annotations
+				remember(current)
+				return reconcile.Result{}, p.setEngaged(ctx, reg, true, "Engaged", nil)
+			}
+			if !errors.Is(err, mccluster.ErrUpdateNotSupported) {
+				log.Error(err, "Failed to update cluster, re-engaging")
+			}
+		}
+		log.Info("Re-engaging changed cluster")
+		disengage()
+	}
+
+	clusterOpts := append(slices.Clip(p.opts.ClusterOptions), mccluster.WithCacheNamespaces(namespaces...))
+	var cl cluster.Cluster
+	if p.opts.NewCluster != nil {
+		cl, err = p.opts.NewCluster(ctx, reg, cfg, clusterOpts...)
+	} else {
+		cl, err = mcMgr.NewCluster(key, cfg, clusterOpts...)
+	}
+	if err != nil {
+		return reconcile.Result{}, p.setEngaged(ctx, reg, false, "ClusterCreationFailed", err)
+	}
+	for _, idx := range indexers {
+		if err := cl.GetCache().IndexField(ctx, idx.object, idx.field, idx.extractValue); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to index field %q: %w", idx.field, err)
+		}
+	}
+	clusterCtx, cancel := context.WithCancel(engageCtx)
+	go func() {
+		if err := cl.Start(clusterCtx); err != nil {
+			log.Error(err, "failed to start cluster")
+			return
+		}
+	}()
+	if !cl.GetCache().WaitForCacheSync(ctx) {
+		cancel()
+		return reconcile.Result{}, p.setEngaged(ctx, reg, false, "CacheSyncFailed", fmt.Errorf("failed to sync cache"))
+	}
+
+	// remember, with the indexers added while the cache synced.
+	p.lock.Lock()
+	for _, idx := range p.indexers[len(indexers):] {
+		if err := cl.GetCache().IndexField(ctx, idx.object, idx.field, idx.extractValue); err != nil {
+			p.lock.Unlock()
+			cancel()
+			return reconcile.Result{}, fmt.Errorf("failed to index field %q: %w", idx.field, err)
+		}
+	}
+	p.clusters[key] = engaged{
+		Cluster:       cl,
+		generation:    reg.Generation,
+		secretVersion: secret.ResourceVersion,
+		labels:        reg.Labels,
+		annotations:   reg.Annotations,
+		taints:        taintsOf(reg),
+		namespaces:    namespaces,
+	}
+	p.cancelFns[key] = cancel
+	p.lock.Unlock()
+
+	log.Info("Added new cluster")
+
+	// engage manager.
+	if err := mcMgr.Engage(clusterCtx, key, cl); err != nil {
+		log.Error(err, "failed to engage manager")
+		disengage()
+		return reconcile.Result{}, p.setEngaged(ctx, reg, false, "EngageFailed", err)
+	}
+
+	return reconcile.Result{}, p.setEngaged(ctx, reg, true, "Engaged", nil)
+}
+
+// disengage stops the cluster with the given key. The lock must be held.
+func (p *Provider) disengage(key string) {
+	if cancel, ok := p.cancelFns[key]; ok {
+		cancel()
+	}
+	delete(p.clusters, key)
+	delete(p.cancelFns, key)
+}
+
+// setEngaged writes the Engaged condition to the status of reg and returns
+// cause, so that failed engagements are retried.
+func (p *Provider) setEngaged(ctx context.Context, reg *v1alpha1.ClusterRegistration, engaged bool, reason string, cause error) error {
+	cond := metav1.Condition{
+		Type:               v1alpha1.ConditionTypeEngaged,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		ObservedGeneration: reg.Generation,
+	}
+	if !engaged {
+		cond.Status = metav1.ConditionFalse
+	}
+	if cause != nil {
+		cond.Message = cause.Error()
+	}
+
+	orig := reg.DeepCopy()
+	reg.Status.ObservedGeneration = reg.Generation
+	if !meta.SetStatusCondition(&reg.Status.Conditions, cond) && orig.Status.ObservedGeneration == reg.Generation {
+		return cause
+	}
+	if err := p.client.Status().Patch(ctx, reg, client.MergeFrom(orig)); err != nil && !apierrors.IsNotFound(err) {
+		if cause != nil {
+			return cause
+		}
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+	return cause
+}
+
+// registrationsForSecret maps a secret to the registrations referencing it.
+func (p *Provider) registrationsForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	regs := &v1alpha1.ClusterRegistrationList{}
+	if err := p.client.List(ctx, regs, client.InNamespace(obj.GetNamespace())); err != nil {
+		p.log.Error(err, "failed to list cluster registrations")
+		return nil
+	}
+	var reqs []reconcile.Request
+	for _, reg := range regs.Items {
+		if reg.Spec.SecretRef.Name == obj.GetName() {
+			reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: reg.Namespace, Name: reg.Name}})
+		}
+	}
+	return reqs
+}
+
+// restConfig returns the config of the registered cluster.
+func restConfig(reg *v1alpha1.ClusterRegistration, secret *corev1.Secret, opts mccluster.KubeconfigOptions) (*rest.Config, error) {
+	key := reg.Spec.SecretRef.Key
+	if key == "" {
+		key = DefaultSecretKey
+	}
+	data, ok := secret.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("secret %s/%s has no key %q", secret.Namespace, secret.Name, key)
+	}
+	cfg, err := mccluster.RESTConfigFromKubeconfig(data, opts)
+	if err != nil {
+		return nil, err
+	}
+	if reg.Spec.Endpoint != "" {
+		cfg.Host = reg.Spec.Endpoint
+	}
+	return cfg, nil
+}
+
+// IndexField indexes a field on all clusters, existing and future.
+func (p *Provider) IndexField(ctx context.Context, obj client.Object, field string, extractValue client.IndexerFunc) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	// save for future clusters.
+	p.indexers = append(p.indexers, index{
+		object:       obj,
+		field:        field,
+		extractValue: extractValue,
+	})
+
+	// apply to existing clusters.
+	for name, cl := range p.clusters {
+		if err := cl.GetCache().IndexField(ctx, obj, field, extractValue); err != nil {
+			return fmt.Errorf("failed to index field %q on cluster %q: %w", field, name, err)
+		}
+	}
+
+	return nil
+}
+
+func taintsOf(reg *v1alpha1.ClusterRegistration) []multicluster.Taint {
+	if len(reg.Spec.Taints) == 0 {
+		return nil
+	}
+	taints := make([]multicluster.Taint, 0, len(reg.Spec.Taints))
+	for _, t := range reg.Spec.Taints {
+		taints = append(taints, multicluster.Taint{Key: t.Key, Value: t.Value, Effect: multicluster.TaintEffect(t.Effect)})
+	}
+	return taints
+}
@@ -0,0 +1,345 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registration
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/apis/v1alpha1"
+	mccluster "sigs.k8s.io/multicluster-runtime/pkg/cluster"
+	"sigs.k8s.io/multicluster-runtime/pkg/fake"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+var _ = Describe("Provider", func() {
+	const key = "fleet/edge-1"
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "fleet", Name: "edge-1"}}
+
+	var (
+		local       client.Client
+		mgr         *fake.Manager
+		p           *Provider
+		engagements *recorder
+		updatable   bool
+		created     []*rest.Config
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+		local = clientfake.NewClientBuilder().
+			WithScheme(scheme).
+			WithStatusSubresource(&v1alpha1.ClusterRegistration{}).
+			WithObjects(
+				&v1alpha1.ClusterRegistration{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "fleet", Name: "edge-1", Generation: 1, Labels: map[string]string{"site": "a"}},
+					Spec:       v1alpha1.ClusterRegistrationSpec{SecretRef: v1alpha1.SecretKeyReference{Name: "edge-1-kubeconfig"}},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "fleet", Name: "edge-1-kubeconfig"},
+					Data:       map[string][]byte{DefaultSecretKey: kubeconfig("https://edge-1:6443", "first")},
+				},
+			).
+			Build()
+
+		mgr = fake.NewManagerBuilder().Build()
+		engagements = &recorder{contexts: map[string]context.Context{}}
+		Expect(mgr.Add(engagements)).To(Succeed())
+		updatable, created = false, nil
+
+		p = &Provider{
+			opts: Options{
+				NewCluster: func(_ context.Context, _ *v1alpha1.ClusterRegistration, cfg *rest.Config, _ ...cluster.Option) (cluster.Cluster, error) {
+					created = append(created, cfg)
+					cl := fake.NewCluster(clientfake.NewClientBuilder().Build())
+					if updatable {
+						return &updatableCluster{Cluster: cl, cfg: cfg}, nil
+					}
+					return cl, nil
+				},
+			},
+			client:    local,
+			log:       GinkgoLogr,
+			clusters:  map[string]engaged{},
+			cancelFns: map[string]context.CancelFunc{},
+		}
+		p.runCtx, p.mcMgr = context.Background(), &updatingManager{Manager: mgr, p: p}
+	})
+
+	engagedCondition := func(ctx context.Context) *metav1.Condition {
+		reg := &v1alpha1.ClusterRegistration{}
+		Expect(local.Get(ctx, req.NamespacedName, reg)).To(Succeed())
+		return meta.FindStatusCondition(reg.Status.Conditions, v1alpha1.ConditionTypeEngaged)
+	}
+
+	rotate := func(ctx context.Context, token string) {
+		secret := &corev1.Secret{}
+		Expect(local.Get(ctx, client.ObjectKey{Namespace: "fleet", Name: "edge-1-kubeconfig"}, secret)).To(Succeed())
+		secret.Data[DefaultSecretKey] = kubeconfig("https://edge-1:6443", token)
+		Expect(local.Update(ctx, secret)).To(Succeed())
+	}
+
+	It("engages registered clusters", func(ctx context.Context) {
+		_, err := p.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(engagements.get(key)).NotTo(BeNil())
+		Expect(engagements.get(key).Err()).NotTo(HaveOccurred())
+		_, err = p.Get(ctx, key)
+		Expect(err).NotTo(HaveOccurred())
+		md, err := p.GetMetadata(ctx, key)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(md.Labels).To(HaveKeyWithValue("site", "a"))
+		Expect(created).To(HaveLen(1))
+		Expect(created[0].Host).To(Equal("https://edge-1:6443"))
+		Expect(created[0].BearerToken).To(Equal("first"))
+
+		cond := engagedCondition(ctx)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+
+		// unchanged registrations are not engaged again.
+		_, err = p.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created).To(HaveLen(1))
+	})
+
+	It("re-engages clusters on demand", func(ctx context.Context) {
+		Expect(p.ReEngage(ctx, key)).To(MatchError(multicluster.ErrClusterNotFound))
+
+		_, err := p.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		first := engagements.get(key)
+		oldCluster, err := p.Get(ctx, key)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(p.ReEngage(ctx, key)).To(Succeed())
+		Expect(first.Done()).To(BeClosed())
+		Expect(engagements.get(key).Err()).NotTo(HaveOccurred())
+		newCluster, err := p.Get(ctx, key)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(newCluster).NotTo(BeIdenticalTo(oldCluster))
+		Expect(created).To(HaveLen(2))
+		md, err := p.GetMetadata(ctx, key)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(md.Labels).To(HaveKeyWithValue("site", "a"))
+	})
+
+	It("serves the metadata to runnables while they are engaged", func(ctx context.Context) {
+		var labels map[string]string
+		Expect(mgr.Add(&engageHook{fn: func(ctx context.Context, name string) error {
+			md, err := p.GetMetadata(ctx, name)
+			labels = md.Labels
+			return err
+		}})).To(Succeed())
+
+		done := make(chan error)
+		go func() {
+			_, err := p.Reconcile(ctx, req)
+			done <- err
+		}()
+		Eventually(done).Should(Receive(BeNil()))
+		Expect(labels).To(HaveKeyWithValue("site", "a"))
+	})
+
+	It("disengages deleted clusters", func(ctx context.Context) {
+		_, err := p.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		first := engagements.get(key)
+
+		Expect(local.Delete(ctx, &v1alpha1.ClusterRegistration{ObjectMeta: metav1.ObjectMeta{Namespace: "fleet", Name: "edge-1"}})).To(Succeed())
+		_, err = p.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(first.Done()).To(BeClosed())
+		_, err = p.Get(ctx, key)
+		Expect(err).To(MatchError(multicluster.ErrClusterNotFound))
+	})
+
+	It("does not engage clusters whose secret is missing", func(ctx context.Context) {
+		Expect(local.Delete(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "fleet", Name: "edge-1-kubeconfig"}})).To(Succeed())
+		_, err := p.Reconcile(ctx, req)
+		Expect(err).To(HaveOccurred())
+
+		Expect(engagements.get(key)).To(BeNil())
+		cond := engagedCondition(ctx)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal("SecretNotFound"))
+	})
+
+	It("maps kubeconfig secrets to the registrations referencing them", func(ctx context.Context) {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "fleet", Name: "edge-1-kubeconfig"}}
+		Expect(p.registrationsForSecret(ctx, secret)).To(ConsistOf(req))
+
+		secret.Name = "other"
+		Expect(p.registrationsForSecret(ctx, secret)).To(BeEmpty())
+	})
+
+	It("updates the credentials of updatable clusters when the secret rotates", func(ctx context.Context) {
+		updatable = true
+		_, err := p.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		first := engagements.get(key)
+		cl, err := p.Get(ctx, key)
+		Expect(err).NotTo(HaveOccurred())
+
+		rotate(ctx, "second")
+		_, err = p.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(created).To(HaveLen(1))
+		Expect(first.Err()).NotTo(HaveOccurred())
+		current, err := p.Get(ctx, key)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(current).To(BeIdenticalTo(cl))
+		Expect(current.(*updatableCluster).config().BearerToken).To(Equal("second"))
+	})
+
+	It("re-engages other clusters when the secret rotates", func(ctx context.Context) {
+		_, err := p.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		first := engagements.get(key)
+
+		rotate(ctx, "second")
+		_, err = p.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(first.Done()).To(BeClosed())
+		Expect(engagements.get(key).Err()).NotTo(HaveOccurred())
+		Expect(created).To(HaveLen(2))
+		Expect(created[1].BearerToken).To(Equal("second"))
+	})
+})
+
+func kubeconfig(host, token string) []byte {
+	return []byte(fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: edge
+  cluster:
+    server: %s
+users:
+- name: edge
+  user:
+    token: %s
+contexts:
+- name: edge
+  context:
+    cluster: edge
+    user: edge
+current-context: edge
+`, host, token))
+}
+
+// updatingManager updates the clusters of the provider, which the fake
+// manager does not know.
+type updatingManager struct {
+	*fake.Manager
+	p *Provider
+}
+
+func (m *updatingManager) UpdateCluster(ctx context.Context, clusterName string, cfg *rest.Config) error {
+	// like the manager, look the cluster up in the provider.
+	cl, err := m.p.Get(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+	return mccluster.UpdateConfig(cl, cfg)
+}
+
+var _ mcmanager.Manager = &updatingManager{}
+
+type updatableCluster struct {
+	*fake.Cluster
+
+	lock sync.Mutex
+	cfg  *rest.Config
+}
+
+var _ mccluster.Updatable = &updatableCluster{}
+
+func (c *updatableCluster) UpdateConfig(cfg *rest.Config) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.cfg = cfg
+	return nil
+}
+
+func (c *updatableCluster) config() *rest.Config {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.cfg
+}
+
+type recorder struct {
+	lock     sync.Mutex
+	contexts map[string]context.Context
+}
+
+func (r *recorder) Engage(ctx context.Context, name string, _ cluster.Cluster) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.contexts[name] = ctx
+	return nil
+}
+
+func (r *recorder) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+func (r *recorder) get(name string) context.Context {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.contexts[name]
+}
+
+// engageHook is a runnable calling fn when it is engaged, like the manager
+// and the cluster filters of controllers reading metadata.
+type engageHook struct {
+	fn func(ctx context.Context, name string) error
+}
+
+func (h *engageHook) Engage(ctx context.Context, name string, _ cluster.Cluster) error {
+	return h.fn(ctx, name)
+}
+
+func (h *engageHook) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
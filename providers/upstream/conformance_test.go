@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upstream
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/conformance"
+	"sigs.k8s.io/multicluster-runtime/pkg/fake"
+)
+
+var _ = func() bool {
+	var (
+		lock    sync.Mutex
+		fleet   *Fleet
+		removes map[string]context.CancelFunc
+	)
+	add := func(ctx context.Context, name string) error {
+		lock.Lock()
+		defer lock.Unlock()
+		ctx, cancel := context.WithCancel(ctx)
+		removes[name] = cancel
+		return fleet.Engage(ctx, name, fake.NewCluster(clientfake.NewClientBuilder().Build()))
+	}
+	remove := func(_ context.Context, name string) error {
+		lock.Lock()
+		defer lock.Unlock()
+		removes[name]()
+		delete(removes, name)
+		return nil
+	}
+	return conformance.DescribeProvider("upstream", conformance.Harness{
+		New: func(context.Context) (conformance.Provider, error) {
+			lock.Lock()
+			defer lock.Unlock()
+			fleet = NewFleet(0)
+			removes = map[string]context.CancelFunc{}
+			return New(fleet, Options{ResyncInterval: 10 * time.Millisecond}), nil
+		},
+		AddCluster:    add,
+		RemoveCluster: remove,
+		RenameCluster: func(ctx context.Context, oldName, newName string) error {
+			if err := remove(ctx, oldName); err != nil {
+				return err
+			}
+			return add(ctx, newName)
+		},
+		Timeout: 2 * time.Second,
+	})
+}()
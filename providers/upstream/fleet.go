@@ -0,0 +1,165 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upstream
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/watch"
+
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+var _ ClusterProvider = &Fleet{}
+var _ mcmanager.Runnable = &Fleet{}
+
+// Fleet exposes the clusters engaged with a multicluster manager as an
+// upstream ClusterProvider, such that consumers of the upstream interface
+// can use multicluster-runtime providers. Add it to the manager with
+// Manager.Add. The clusters are started by the manager, not the consumer.
+type Fleet struct {
+	bufferSize int
+
+	lock     sync.Mutex
+	clusters map[string]cluster.Cluster
+	watchers map[*fleetWatcher]struct{}
+}
+
+// NewFleet returns a new Fleet. Watchers falling behind by more than
+// bufferSize changes are stopped, such that they list the clusters again.
+func NewFleet(bufferSize int) *Fleet {
+	if bufferSize <= 0 {
+		bufferSize = 100
+	}
+	return &Fleet{
+		bufferSize: bufferSize,
+		clusters:   map[string]cluster.Cluster{},
+		watchers:   map[*fleetWatcher]struct{}{},
+	}
+}
+
+// Engage adds the cluster until ctx is done.
+func (f *Fleet) Engage(ctx context.Context, name string, cl cluster.Cluster) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	typ := watch.Added
+	if _, ok := f.clusters[name]; ok {
+		typ = watch.Modified
+	}
+	f.clusters[name] = cl
+	f.publish(WatchEvent{Type: typ, ClusterName: name})
+
+	go func() {
+		<-ctx.Done()
+		f.lock.Lock()
+		defer f.lock.Unlock()
+		if f.clusters[name] == cl {
+			delete(f.clusters, name)
+			f.publish(WatchEvent{Type: watch.Deleted, ClusterName: name})
+		}
+	}()
+	return nil
+}
+
+// Start stops all watchers when ctx is done.
+func (f *Fleet) Start(ctx context.Context) error {
+	<-ctx.Done()
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for w := range f.watchers {
+		f.stop(w)
+	}
+	return nil
+}
+
+// Get returns the engaged cluster with the given name.
+func (f *Fleet) Get(_ context.Context, clusterName string) (cluster.Cluster, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if cl, ok := f.clusters[clusterName]; ok {
+		return cl, nil
+	}
+	return nil, multicluster.ErrClusterNotFound
+}
+
+// List returns the sorted names of the engaged clusters.
+func (f *Fleet) List(context.Context) ([]string, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	names := make([]string, 0, len(f.clusters))
+	for name := range f.clusters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Watch returns the changes of the engaged clusters until ctx is done or
+// the watcher is stopped.
+func (f *Fleet) Watch(ctx context.Context) (Watcher, error) {
+	w := &fleetWatcher{fleet: f, events: make(chan WatchEvent, f.bufferSize)}
+	f.lock.Lock()
+	f.watchers[w] = struct{}{}
+	f.lock.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		w.Stop()
+	}()
+	return w, nil
+}
+
+// publish sends the event to all watchers, stopping those falling behind.
+// The lock must be held.
+func (f *Fleet) publish(ev WatchEvent) {
+	for w := range f.watchers {
+		select {
+		case w.events <- ev:
+		default:
+			f.stop(w)
+		}
+	}
+}
+
+// stop closes the channel of the watcher. The lock must be held.
+func (f *Fleet) stop(w *fleetWatcher) {
+	if _, ok := f.watchers[w]; !ok {
+		return
+	}
+	delete(f.watchers, w)
+	close(w.events)
+}
+
+type fleetWatcher struct {
+	fleet  *Fleet
+	events chan WatchEvent
+}
+
+func (w *fleetWatcher) Stop() {
+	w.fleet.lock.Lock()
+	defer w.fleet.lock.Unlock()
+	w.fleet.stop(w)
+}
+
+func (w *fleetWatcher) ResultChan() <-chan WatchEvent {
+	return w.events
+}
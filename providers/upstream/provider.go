@@ -0,0 +1,284 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upstream
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	mcerrors "sigs.k8s.io/multicluster-runtime/pkg/errors"
+	mcmanager "sigs.k8s.io/multicluster-runtime/pkg/manager"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+var _ multicluster.Provider = &Provider{}
+
+// Options are the options of the upstream Provider.
+type Options struct {
+	// ClustersStarted is set if the upstream provider starts the clusters it
+	// returns itself. By default, the Provider starts them.
+	ClustersStarted bool
+
+	// ResyncInterval is the interval in which the clusters are listed and
+	// watched again after the watch ended or failed. Defaults to 10 seconds.
+	ResyncInterval time.Duration
+}
+
+type index struct {
+	object       client.Object
+	field        string
+	extractValue client.IndexerFunc
+}
+
+type engaged struct {
+	cluster.Cluster
+	cancel context.CancelFunc
+}
+
+// Provider is a multicluster provider engaging the clusters of an upstream
+// ClusterProvider.
+type Provider struct {
+	upstream ClusterProvider
+	opts     Options
+	log      logr.Logger
+
+	lock     sync.Mutex
+	clusters map[string]engaged
+	indexers []index
+}
+
+// New returns a Provider engaging the clusters of the upstream provider.
+func New(upstream ClusterProvider, opts Options) *Provider {
+	if opts.ResyncInterval <= 0 {
+		opts.ResyncInterval = 10 * time.Second
+	}
+	return &Provider{
+		upstream: upstream,
+		opts:     opts,
+		log:      log.Log.WithName("upstream-provider"),
+		clusters: map[string]engaged{},
+	}
+}
+
+// Get returns the engaged cluster with the given name.
+func (p *Provider) Get(_ context.Context, clusterName string) (cluster.Cluster, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if cl, ok := p.clusters[clusterName]; ok {
+		return cl.Cluster, nil
+	}
+	return nil, multicluster.ErrClusterNotFound
+}
+
+// Run lists and watches the clusters of the upstream provider, engages them
+// with the manager, and blocks. When the watch ends, the clusters are listed
+// and watched again after the resync interval.
+func (p *Provider) Run(ctx context.Context, mgr mcmanager.Manager) error {
+	p.log.Info("Starting upstream provider")
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := p.sync(ctx, mgr); err != nil {
+			p.log.Error(err, "Failed to sync clusters")
+		}
+		if err := p.watch(ctx, mgr); err != nil {
+			p.log.Error(err, "Failed to watch clusters")
+		}
+	}, p.opts.ResyncInterval)
+	return ctx.Err()
+}
+
+// sync engages the listed clusters and disengages the others. If the
+// clusters cannot be listed, the engaged clusters are kept.
+func (p *Provider) sync(ctx context.Context, mgr mcmanager.Manager) error {
+	names, err := p.upstream.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	p.lock.Lock()
+	listed := map[string]bool{}
+	var added []string
+	for _, name := range names {
+		listed[name] = true
+		if _, ok := p.clusters[name]; !ok {
+			added = append(added, name)
+		}
+	}
+	for name := range p.clusters {
+		if !listed[name] {
+			p.log.Info("Cluster removed", "cluster", multicluster.EscapeClusterName(name))
+			p.disengage(name)
+		}
+	}
+	p.lock.Unlock()
+
+	var errs []error
+	for _, name := range added {
+		if err := p.engage(ctx, mgr, name); err != nil {
+			errs = append(errs, mcerrors.New(name, "engage", err))
+		}
+	}
+	return mcerrors.NewAggregate(errs...)
+}
+
+// watch applies the changes of the upstream provider until the watch ends.
+func (p *Provider) watch(ctx context.Context, mgr mcmanager.Manager) error {
+	w, err := p.upstream.Watch(ctx)
+	if err != nil {
+		return err
+	}
+	defer w.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-w.ResultChan():
+			if !ok {
+				return nil
+			}
+			if err := p.apply(ctx, mgr, ev); err != nil {
+				p.log.Error(err, "Failed to apply cluster change", "cluster", multicluster.EscapeClusterName(ev.ClusterName), "type", ev.Type)
+			}
+		}
+	}
+}
+
+// apply engages added clusters, re-engages modified clusters if the
+// upstream provider returns a different cluster, and disengages deleted
+// clusters.
+func (p *Provider) apply(ctx context.Context, mgr mcmanager.Manager, ev WatchEvent) error {
+	switch ev.Type {
+	case watch.Added, watch.Modified:
+		p.lock.Lock()
+		cl, ok := p.clusters[ev.ClusterName]
+		p.lock.Unlock()
+		if ok {
+			current, err := p.upstream.Get(ctx, ev.ClusterName)
+			if err != nil {
+				return err
+			}
+			if current == cl.Cluster {
+				return nil
+			}
+			p.log.Info("Re-engaging changed cluster", "cluster", multicluster.EscapeClusterName(ev.ClusterName))
+			p.lock.Lock()
+			p.disengage(ev.ClusterName)
+			p.lock.Unlock()
+		}
+		return p.engage(ctx, mgr, ev.ClusterName)
+	case watch.Deleted:
+		p.log.Info("Cluster removed", "cluster", multicluster.EscapeClusterName(ev.ClusterName))
+		p.lock.Lock()
+		p.disengage(ev.ClusterName)
+		p.lock.Unlock()
+	}
+	return nil
+}
+
+// engage gets, starts and engages the cluster. The lock must not be held,
+// the manager calls back into the provider while engaging.
+func (p *Provider) engage(ctx context.Context, mgr mcmanager.Manager, name string) error {
+	cl, err := p.upstream.Get(ctx, name)
+	if err != nil {
+		return err
+	}
+	p.lock.Lock()
+	indexers := slices.Clone(p.indexers)
+	p.lock.Unlock()
+	for _, idx := range indexers {
+		if err := cl.GetCache().IndexField(ctx, idx.object, idx.field, idx.extractValue); err != nil {
+			return fmt.Errorf("failed to index field %q: %w", idx.field, err)
+		}
+	}
+
+	clusterCtx, cancel := context.WithCancel(ctx)
+	if !p.opts.ClustersStarted {
+		go func() {
+			if err := cl.Start(clusterCtx); err != nil {
+				p.log.Error(err, "failed to start cluster", "cluster", multicluster.EscapeClusterName(name))
+			}
+		}()
+	}
+	if !cl.GetCache().WaitForCacheSync(ctx) {
+		cancel()
+		return fmt.Errorf("failed to sync cache")
+	}
+
+	p.lock.Lock()
+	for _, idx := range p.indexers[len(indexers):] {
+		if err := cl.GetCache().IndexField(ctx, idx.object, idx.field, idx.extractValue); err != nil {
+			p.lock.Unlock()
+			cancel()
+			return fmt.Errorf("failed to index field %q: %w", idx.field, err)
+		}
+	}
+	p.clusters[name] = engaged{Cluster: cl, cancel: cancel}
+	p.lock.Unlock()
+	p.log.Info("Added new cluster", "cluster", multicluster.EscapeClusterName(name))
+
+	if err := mgr.Engage(clusterCtx, name, cl); err != nil {
+		p.lock.Lock()
+		if p.clusters[name].Cluster == cl {
+			delete(p.clusters, name)
+		}
+		p.lock.Unlock()
+		cancel()
+		return err
+	}
+	return nil
+}
+
+// disengage stops the cluster with the given name. The lock must be held.
+func (p *Provider) disengage(name string) {
+	if cl, ok := p.clusters[name]; ok {
+		cl.cancel()
+	}
+	delete(p.clusters, name)
+}
+
+// IndexField indexes a field on all clusters, existing and future.
+func (p *Provider) IndexField(ctx context.Context, obj client.Object, field string, extractValue client.IndexerFunc) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	// save for future clusters.
+	p.indexers = append(p.indexers, index{
+		object:       obj,
+		field:        field,
+		extractValue: extractValue,
+	})
+
+	// apply to existing clusters.
+	for name, cl := range p.clusters {
+		if err := cl.GetCache().IndexField(ctx, obj, field, extractValue); err != nil {
+			return fmt.Errorf("failed to index field %q on cluster %q: %w", field, name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upstream
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/watch"
+
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	"sigs.k8s.io/multicluster-runtime/pkg/fake"
+	"sigs.k8s.io/multicluster-runtime/pkg/multicluster"
+)
+
+var _ = Describe("Fleet", func() {
+	It("lists and watches the engaged clusters", func(ctx context.Context) {
+		fleet := NewFleet(0)
+		w, err := fleet.Watch(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		clusterCtx, disengage := context.WithCancel(ctx)
+		one := fake.NewCluster(clientfake.NewClientBuilder().Build())
+		Expect(fleet.Engage(clusterCtx, "one", one)).To(Succeed())
+		Expect(fleet.Engage(ctx, "two", fake.NewCluster(clientfake.NewClientBuilder().Build()))).To(Succeed())
+		Expect(fleet.Engage(ctx, "two", fake.NewCluster(clientfake.NewClientBuilder().Build()))).To(Succeed())
+
+		Expect(fleet.List(ctx)).To(Equal([]string{"one", "two"}))
+		Expect(fleet.Get(ctx, "one")).To(BeIdenticalTo(one))
+
+		disengage()
+		Eventually(func() error { _, err := fleet.Get(ctx, "one"); return err }).Should(MatchError(multicluster.ErrClusterNotFound))
+
+		var events []WatchEvent
+		for range 4 {
+			events = append(events, <-w.ResultChan())
+		}
+		Expect(events).To(Equal([]WatchEvent{
+			{Type: watch.Added, ClusterName: "one"},
+			{Type: watch.Added, ClusterName: "two"},
+			{Type: watch.Modified, ClusterName: "two"},
+			{Type: watch.Deleted, ClusterName: "one"},
+		}))
+
+		w.Stop()
+		Eventually(w.ResultChan()).Should(BeClosed())
+	})
+
+	It("stops watchers falling behind", func(ctx context.Context) {
+		fleet := NewFleet(1)
+		w, err := fleet.Watch(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fleet.Engage(ctx, "one", fake.NewCluster(clientfake.NewClientBuilder().Build()))).To(Succeed())
+		Expect(fleet.Engage(ctx, "two", fake.NewCluster(clientfake.NewClientBuilder().Build()))).To(Succeed())
+		Expect(w.ResultChan()).To(Receive())
+		Expect(w.ResultChan()).To(BeClosed())
+	})
+})
+
+var _ = Describe("Provider", func() {
+	var (
+		fleet   *Fleet
+		mgr     *fake.Manager
+		p       *Provider
+		engaged *recorder
+	)
+
+	BeforeEach(func() {
+		fleet = NewFleet(0)
+		mgr = fake.NewManagerBuilder().Build()
+		engaged = &recorder{contexts: map[string]context.Context{}}
+		Expect(mgr.Add(engaged)).To(Succeed())
+		p = New(fleet, Options{ResyncInterval: 10 * time.Millisecond})
+	})
+
+	It("engages the clusters of the upstream provider as they come and go", func(ctx context.Context) {
+		one := fake.NewCluster(clientfake.NewClientBuilder().Build())
+		oneCtx, removeOne := context.WithCancel(ctx)
+		Expect(fleet.Engage(oneCtx, "one", one)).To(Succeed())
+
+		runCtx, stop := context.WithCancel(ctx)
+		defer stop()
+		go func() { _ = p.Run(runCtx, mgr) }()
+
+		Eventually(engaged.names).Should(ConsistOf("one"))
+		Expect(p.Get(ctx, "one")).To(BeIdenticalTo(one))
+
+		Expect(fleet.Engage(ctx, "two", fake.NewCluster(clientfake.NewClientBuilder().Build()))).To(Succeed())
+		Eventually(engaged.names).Should(ConsistOf("one", "two"))
+
+		first := engaged.get("two")
+		replaced := fake.NewCluster(clientfake.NewClientBuilder().Build())
+		Expect(fleet.Engage(ctx, "two", replaced)).To(Succeed())
+		Eventually(first.Done()).Should(BeClosed())
+		Eventually(func() (cluster.Cluster, error) { return p.Get(ctx, "two") }).Should(BeIdenticalTo(replaced))
+
+		removeOne()
+		Eventually(func() error { _, err := p.Get(ctx, "one"); return err }).Should(MatchError(multicluster.ErrClusterNotFound))
+		Expect(engaged.get("one").Done()).To(BeClosed())
+	})
+
+	It("resyncs the clusters when the watch ends", func(ctx context.Context) {
+		oneCtx, removeOne := context.WithCancel(ctx)
+		Expect(fleet.Engage(oneCtx, "one", fake.NewCluster(clientfake.NewClientBuilder().Build()))).To(Succeed())
+		Expect(p.sync(ctx, mgr)).To(Succeed())
+		Expect(engaged.names()).To(ConsistOf("one"))
+
+		removeOne()
+		Eventually(func() ([]string, error) { return fleet.List(ctx) }).Should(BeEmpty())
+		Expect(p.sync(ctx, mgr)).To(Succeed())
+		Expect(engaged.get("one").Done()).To(BeClosed())
+	})
+})
+
+type recorder struct {
+	lock     sync.Mutex
+	contexts map[string]context.Context
+}
+
+func (r *recorder) Engage(ctx context.Context, name string, _ cluster.Cluster) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.contexts[name] = ctx
+	return nil
+}
+
+func (r *recorder) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+func (r *recorder) get(name string) context.Context {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.contexts[name]
+}
+
+func (r *recorder) names() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var names []string
+	for name := range r.contexts {
+		names = append(names, name)
+	}
+	return names
+}
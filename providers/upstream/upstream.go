@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package upstream adapts between multicluster-runtime providers and the
+// experimental cluster provider interface proposed for controller-runtime,
+// to ease migrating providers in either direction while upstream support
+// evolves. The controller-runtime version used here does not ship the
+// interface yet, so ClusterProvider mirrors it, and upstream providers
+// implement it without depending on this package.
+package upstream
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/watch"
+
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+)
+
+// WatchEvent is a change of the clusters of a ClusterProvider.
+type WatchEvent struct {
+	// Type is watch.Added, watch.Modified or watch.Deleted.
+	Type watch.EventType
+
+	// ClusterName is the name of the changed cluster.
+	ClusterName string
+}
+
+// Watcher delivers the changes of the clusters of a ClusterProvider.
+type Watcher interface {
+	// Stop stops the watch and closes the result channel.
+	Stop()
+
+	// ResultChan returns the changes. It is closed when the watch ends.
+	ResultChan() <-chan WatchEvent
+}
+
+// ClusterProvider is the experimental upstream cluster provider interface.
+// Clusters returned by Get are started by the consumer, not the provider.
+type ClusterProvider interface {
+	// Get returns the cluster with the given name.
+	Get(ctx context.Context, clusterName string) (cluster.Cluster, error)
+
+	// List returns the names of the known clusters.
+	List(ctx context.Context) ([]string, error)
+
+	// Watch returns the changes of the known clusters.
+	Watch(ctx context.Context) (Watcher, error)
+}